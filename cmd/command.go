@@ -20,13 +20,16 @@
 package answercmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/apache/incubator-answer/internal/base/conf"
+	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/cli"
 	"github.com/apache/incubator-answer/internal/install"
+	"github.com/apache/incubator-answer/internal/maintenance"
 	"github.com/apache/incubator-answer/internal/migrations"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/segmentfault/pacman/log"
@@ -49,6 +52,10 @@ var (
 	upgradeVersion string
 	// The fields that need to be set to the default value
 	configFields []string
+	// maintenanceOptions the options of maintenance commands
+	maintenanceOptions = &maintenance.Options{}
+	// reputationApply applies the reputation corrections, otherwise only the drift is reported
+	reputationApply bool
 	// i18nSourcePath i18n from path
 	i18nSourcePath string
 	// i18nTargetPath i18n to path
@@ -76,9 +83,37 @@ func init() {
 
 	i18nCmd.Flags().StringVarP(&i18nTargetPath, "target", "t", "", "i18n target path, eg: -t ./i18n/target")
 
-	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd} {
+	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd, maintenanceCmd, reputationCmd} {
 		rootCmd.AddCommand(cmd)
 	}
+
+	maintenanceCmd.PersistentFlags().BoolVar(&maintenanceOptions.DryRun, "dry-run", false, "only print what would be changed")
+	maintenanceCmd.PersistentFlags().BoolVar(&maintenanceOptions.Resume, "resume", false, "resume from the checkpoint of the last interrupted run")
+	maintenanceCmd.PersistentFlags().IntVar(&maintenanceOptions.BatchSize, "batch-size", 100, "the number of rows handled in one batch")
+	maintenanceCmd.AddCommand(
+		newMaintenanceCmd("rerender", "render the stored content again",
+			`Render the stored markdown of questions, answers, comments and user bios again, and save the html`,
+			maintenance.Rerender),
+		newMaintenanceCmd("recount", "count the stored counters again",
+			`Count the answers of questions, the questions of tags and the questions and answers of users again`,
+			maintenance.Recount),
+		newMaintenanceCmd("reindex", "push all content to the search plugin",
+			`Push all questions and answers to the enabled search plugin`,
+			maintenance.Reindex),
+	)
+
+	reputationCmd.PersistentFlags().BoolVar(&reputationApply, "apply", false, "apply the corrections as adjustment activities")
+	reputationCmd.PersistentFlags().BoolVar(&maintenanceOptions.Resume, "resume", false, "resume from the checkpoint of the last interrupted run")
+	reputationCmd.PersistentFlags().IntVar(&maintenanceOptions.BatchSize, "batch-size", 100, "the number of users handled in one batch")
+	reputationCmd.AddCommand(
+		newMaintenanceCmd("recompute", "replay the activities to recompute the reputation",
+			`Replay the activities of all users under the current reputation rules and report the users whose reputation drifts.
+Use --apply to correct the reputation, every correction is recorded as an adjustment activity.`,
+			func(dbConf *data.Database, opts *maintenance.Options) error {
+				opts.DryRun = !reputationApply
+				return maintenance.RecomputeReputation(dbConf, opts)
+			}),
+	)
 }
 
 var (
@@ -207,6 +242,7 @@ To run answer, use:
 
 			if cli.CheckDBConnection(c.Data.Database) {
 				fmt.Println("db connection successfully [✔]")
+				checkPluginLifecycle(c.Data.Database)
 			} else {
 				fmt.Println("db connection failed [x]")
 			}
@@ -238,7 +274,8 @@ To run answer, use:
 	pluginCmd = &cobra.Command{
 		Use:   "plugin",
 		Short: "prints all plugins packed in the binary",
-		Long:  `prints all plugins packed in the binary`,
+		Long: `prints all plugins packed in the binary
+The commands of plugin can be used by 'answer plugin <slug_name> ...'`,
 		Run: func(_ *cobra.Command, _ []string) {
 			_ = plugin.CallBase(func(base plugin.Base) error {
 				info := base.Info()
@@ -285,6 +322,21 @@ To run answer, use:
 		},
 	}
 
+	// maintenanceCmd contains the maintenance commands for the stored data
+	maintenanceCmd = &cobra.Command{
+		Use:   "maintenance",
+		Short: "maintain the stored data",
+		Long: `Maintain the stored data, such as render the stored content again.
+The maintenance commands save the progress in the cache directory, use --resume to continue the interrupted one.`,
+	}
+
+	// reputationCmd contains the commands to audit the reputation of users
+	reputationCmd = &cobra.Command{
+		Use:   "reputation",
+		Short: "audit the reputation of users",
+		Long:  `Audit the reputation of users by replaying the activities under the current reputation rules.`,
+	}
+
 	// i18nCmd used to merge i18n files
 	i18nCmd = &cobra.Command{
 		Use:   "i18n",
@@ -311,8 +363,102 @@ To run answer, use:
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	registerPluginCommands()
 	err := rootCmd.Execute()
 	if err != nil {
 		os.Exit(1)
 	}
 }
+
+// registerPluginCommands registers the commands of plugins under `answer plugin <slug_name>`.
+// It should be called after all plugins are registered, so it can't be called in init function.
+func registerPluginCommands() {
+	_ = plugin.CallCommand(func(p plugin.Command) error {
+		info := p.Info()
+		var (
+			env     *plugin.Env
+			cleanup func()
+		)
+		cmd := &cobra.Command{
+			Use:   info.SlugName,
+			Short: fmt.Sprintf("commands of plugin %s", info.SlugName),
+			PersistentPostRun: func(_ *cobra.Command, _ []string) {
+				if cleanup != nil {
+					cleanup()
+				}
+			},
+		}
+		p.RegisterCommands(cmd, func() (*plugin.Env, error) {
+			if env != nil {
+				return env, nil
+			}
+			cli.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				return nil, err
+			}
+			e, envCleanup, err := cli.NewPluginEnv(c.Data.Database)
+			if err != nil {
+				return nil, err
+			}
+			// the plugin runs with the same status and config as in the application
+			if err = cli.LoadPluginStatus(e.DB); err == nil {
+				err = cli.LoadPluginConfig(e.DB)
+			}
+			if err != nil {
+				envCleanup()
+				return nil, err
+			}
+			env, cleanup = e, envCleanup
+			return env, nil
+		})
+		pluginCmd.AddCommand(cmd)
+		return nil
+	})
+}
+
+// checkPluginLifecycle checks all enabled lifecycle plugins by their OnCheck hooks,
+// so that checking won't run the side effects of starting or stopping them.
+func checkPluginLifecycle(dbConf *data.Database) {
+	env, cleanup, err := cli.NewPluginEnv(dbConf)
+	if err != nil {
+		fmt.Println("load plugin environment failed: ", err.Error())
+		return
+	}
+	defer cleanup()
+	if err = cli.LoadPluginStatus(env.DB); err != nil {
+		fmt.Println("load plugin status failed: ", err.Error())
+		return
+	}
+
+	for _, result := range cli.CheckLifecyclePlugins(context.Background(), env) {
+		if result.Err != nil {
+			fmt.Printf("plugin %s check failed: %v [x]\n", result.SlugName, result.Err)
+		} else {
+			fmt.Printf("plugin %s check successfully [✔]\n", result.SlugName)
+		}
+	}
+}
+
+// newMaintenanceCmd creates a maintenance sub command that runs the maintenance task
+func newMaintenanceCmd(use, short, long string, run func(dbConf *data.Database, opts *maintenance.Options) error) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long:  long,
+		Run: func(_ *cobra.Command, _ []string) {
+			cli.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			maintenanceOptions.CheckpointDir = cli.CacheDir
+			if err = run(c.Data.Database, maintenanceOptions); err != nil {
+				fmt.Printf("%s failed: %s\n", use, err.Error())
+				return
+			}
+			fmt.Printf("%s done\n", use)
+		},
+	}
+}
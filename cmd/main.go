@@ -28,13 +28,19 @@ import (
 	"github.com/apache/incubator-answer/internal/base/conf"
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/cron"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/metrics"
+	"github.com/apache/incubator-answer/internal/base/tracing"
 	"github.com/apache/incubator-answer/internal/cli"
 	"github.com/apache/incubator-answer/internal/schema"
+	mixinbotcommand "github.com/apache/incubator-answer/internal/service/mixinbot/command"
+	"github.com/apache/incubator-answer/plugin"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman"
 	"github.com/segmentfault/pacman/contrib/log/zap"
 	"github.com/segmentfault/pacman/contrib/server/http"
 	"github.com/segmentfault/pacman/log"
+	pacmanserver "github.com/segmentfault/pacman/server"
 )
 
 // go build -ldflags "-X github.com/apache/incubator-answer/cmd.Version=x.y.z"
@@ -71,6 +77,15 @@ func runApp() {
 	if err != nil {
 		panic(err)
 	}
+	shutdownTracing, err := tracing.Init(c.Tracing, Version)
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Error(err)
+		}
+	}()
 	app, cleanup, err := initApplication(
 		c.Debug, c.Server, c.Data.Database, c.Data.Cache, c.I18n, c.Swaggerui, c.ServiceConfig, c.UI, c.MixinBotConfig, c.StorageConfig, log.GetLogger())
 	if err != nil {
@@ -83,16 +98,51 @@ func runApp() {
 	fmt.Println("answer Version:", constant.Version, " Revision:", constant.Revision)
 
 	defer cleanup()
+
+	for _, result := range cli.StartPlugins(context.Background(), app.pluginEnv) {
+		if result.Err != nil {
+			log.Errorf("start plugin %s failed: %v", result.SlugName, result.Err)
+		}
+	}
+	defer func() {
+		for _, result := range cli.StopPlugins(context.Background()) {
+			if result.Err != nil {
+				log.Errorf("stop plugin %s failed: %v", result.SlugName, result.Err)
+			}
+		}
+	}()
+
 	if err := app.Run(context.Background()); err != nil {
 		panic(err)
 	}
 }
 
-func newApplication(serverConf *conf.Server, server *gin.Engine, manager *cron.ScheduledTaskManager) *pacman.Application {
+// application the answer application and the environment passed to the lifecycle hooks of plugins
+type application struct {
+	*pacman.Application
+	pluginEnv *plugin.Env
+}
+
+func newApplication(serverConf *conf.Server, server *gin.Engine, manager *cron.ScheduledTaskManager,
+	mixinBotCommandService *mixinbotcommand.MixinBotCommandService, dataSource *data.Data) (*application, error) {
+	pluginEnv, err := cli.NewPluginEnvWithDB(dataSource.DB)
+	if err != nil {
+		return nil, err
+	}
 	manager.Run()
-	return pacman.NewApp(
-		pacman.WithName(Name),
-		pacman.WithVersion(Version),
-		pacman.WithServer(http.NewServer(server, serverConf.HTTP.Addr)),
-	)
+	servers := []pacmanserver.Server{http.NewServer(server, serverConf.HTTP.Addr)}
+	if serverConf.Metrics != nil && len(serverConf.Metrics.Addr) > 0 {
+		servers = append(servers, http.NewServer(metrics.NewEngine(), serverConf.Metrics.Addr))
+	}
+	if mixinBotCommandService.Enabled() {
+		servers = append(servers, mixinBotCommandService)
+	}
+	return &application{
+		Application: pacman.NewApp(
+			pacman.WithName(Name),
+			pacman.WithVersion(Version),
+			pacman.WithServer(servers...),
+		),
+		pluginEnv: pluginEnv,
+	}, nil
 }
@@ -41,7 +41,6 @@ import (
 	"github.com/apache/incubator-answer/internal/service/service_config"
 	"github.com/apache/incubator-answer/internal/service/uploader"
 	"github.com/google/wire"
-	"github.com/segmentfault/pacman"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -57,7 +56,7 @@ func initApplication(
 	uiConf *server.UI,
 	mixinbotConf *mixinbot.MixinBotConfig,
 	storageConf *uploader.StorageConfig,
-	logConf log.Logger) (*pacman.Application, func(), error) {
+	logConf log.Logger) (*application, func(), error) {
 	panic(wire.Build(
 		server.ProviderSetServer,
 		router.ProviderSetRouter,
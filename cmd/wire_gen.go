@@ -18,6 +18,7 @@ import (
 	"github.com/apache/incubator-answer/internal/controller_admin"
 	"github.com/apache/incubator-answer/internal/repo/activity"
 	"github.com/apache/incubator-answer/internal/repo/activity_common"
+	"github.com/apache/incubator-answer/internal/repo/analytics"
 	"github.com/apache/incubator-answer/internal/repo/answer"
 	"github.com/apache/incubator-answer/internal/repo/auth"
 	"github.com/apache/incubator-answer/internal/repo/badge"
@@ -27,9 +28,11 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/collection"
 	"github.com/apache/incubator-answer/internal/repo/comment"
 	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/draft"
 	"github.com/apache/incubator-answer/internal/repo/export"
 	"github.com/apache/incubator-answer/internal/repo/limit"
 	"github.com/apache/incubator-answer/internal/repo/meta"
+	mixinbot2 "github.com/apache/incubator-answer/internal/repo/mixinbot"
 	notification2 "github.com/apache/incubator-answer/internal/repo/notification"
 	"github.com/apache/incubator-answer/internal/repo/plugin_config"
 	"github.com/apache/incubator-answer/internal/repo/question"
@@ -39,12 +42,15 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/review"
 	"github.com/apache/incubator-answer/internal/repo/revision"
 	"github.com/apache/incubator-answer/internal/repo/role"
+	"github.com/apache/incubator-answer/internal/repo/scim"
 	"github.com/apache/incubator-answer/internal/repo/search_common"
 	"github.com/apache/incubator-answer/internal/repo/site_info"
+	"github.com/apache/incubator-answer/internal/repo/space"
 	"github.com/apache/incubator-answer/internal/repo/tag"
 	"github.com/apache/incubator-answer/internal/repo/tag_common"
 	"github.com/apache/incubator-answer/internal/repo/unique"
 	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/repo/user_data"
 	"github.com/apache/incubator-answer/internal/repo/user_external_login"
 	"github.com/apache/incubator-answer/internal/repo/user_notification_config"
 	"github.com/apache/incubator-answer/internal/router"
@@ -52,6 +58,7 @@ import (
 	activity2 "github.com/apache/incubator-answer/internal/service/activity"
 	activity_common2 "github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	analytics2 "github.com/apache/incubator-answer/internal/service/analytics"
 	"github.com/apache/incubator-answer/internal/service/answer_common"
 	auth2 "github.com/apache/incubator-answer/internal/service/auth"
 	badge2 "github.com/apache/incubator-answer/internal/service/badge"
@@ -62,44 +69,54 @@ import (
 	config2 "github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/content"
 	"github.com/apache/incubator-answer/internal/service/dashboard"
+	draft2 "github.com/apache/incubator-answer/internal/service/draft"
 	"github.com/apache/incubator-answer/internal/service/event_queue"
 	export2 "github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/external_profile"
 	"github.com/apache/incubator-answer/internal/service/follow"
 	meta2 "github.com/apache/incubator-answer/internal/service/meta"
 	"github.com/apache/incubator-answer/internal/service/meta_common"
 	"github.com/apache/incubator-answer/internal/service/mixinbot"
+	"github.com/apache/incubator-answer/internal/service/mixinbot/command"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/notification"
+	"github.com/apache/incubator-answer/internal/service/notification_channel"
 	"github.com/apache/incubator-answer/internal/service/notification_common"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	"github.com/apache/incubator-answer/internal/service/plugin_common"
 	"github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/question_view"
 	rank2 "github.com/apache/incubator-answer/internal/service/rank"
 	reason2 "github.com/apache/incubator-answer/internal/service/reason"
 	report2 "github.com/apache/incubator-answer/internal/service/report"
 	"github.com/apache/incubator-answer/internal/service/report_handle"
+	"github.com/apache/incubator-answer/internal/service/reputation"
 	review2 "github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	role2 "github.com/apache/incubator-answer/internal/service/role"
+	scim2 "github.com/apache/incubator-answer/internal/service/scim"
 	"github.com/apache/incubator-answer/internal/service/search_parser"
 	"github.com/apache/incubator-answer/internal/service/service_config"
 	"github.com/apache/incubator-answer/internal/service/siteinfo"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	space2 "github.com/apache/incubator-answer/internal/service/space"
 	tag2 "github.com/apache/incubator-answer/internal/service/tag"
 	tag_common2 "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/internal/service/tag_moderator"
 	"github.com/apache/incubator-answer/internal/service/uploader"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
 	"github.com/apache/incubator-answer/internal/service/user_common"
+	user_data2 "github.com/apache/incubator-answer/internal/service/user_data"
 	user_external_login2 "github.com/apache/incubator-answer/internal/service/user_external_login"
 	user_notification_config2 "github.com/apache/incubator-answer/internal/service/user_notification_config"
-	"github.com/segmentfault/pacman"
+	"github.com/apache/incubator-answer/internal/service/user_suspension"
 	"github.com/segmentfault/pacman/log"
 )
 
 // Injectors from wire.go:
 
 // initApplication init application.
-func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database, cacheConf *data.CacheConf, i18nConf *translator.I18n, swaggerConf *router.SwaggerConfig, serviceConf *service_config.ServiceConfig, uiConf *server.UI, mixinbotConf *mixinbot.MixinBotConfig, storageConf *uploader.StorageConfig, logConf log.Logger) (*pacman.Application, func(), error) {
+func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database, cacheConf *data.CacheConf, i18nConf *translator.I18n, swaggerConf *router.SwaggerConfig, serviceConf *service_config.ServiceConfig, uiConf *server.UI, mixinbotConf *mixinbot.MixinBotConfig, storageConf *uploader.StorageConfig, logConf log.Logger) (*application, func(), error) {
 	staticRouter := router.NewStaticRouter(serviceConf)
 	i18nTranslator, err := translator.NewTranslator(i18nConf)
 	if err != nil {
@@ -140,7 +157,8 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	userExternalLoginRepo := user_external_login.NewUserExternalLoginRepo(dataData)
 	userNotificationConfigRepo := user_notification_config.NewUserNotificationConfigRepo(dataData)
 	userNotificationConfigService := user_notification_config2.NewUserNotificationConfigService(userRepo, userNotificationConfigRepo, dataData)
-	userExternalLoginService := user_external_login2.NewUserExternalLoginService(userRepo, userCommon, userExternalLoginRepo, emailService, siteInfoCommonService, userActiveActivityRepo, userNotificationConfigService)
+	eventQueueService := event_queue.NewEventQueueService()
+	userExternalLoginService := user_external_login2.NewUserExternalLoginService(userRepo, userCommon, userExternalLoginRepo, emailService, siteInfoCommonService, userActiveActivityRepo, userNotificationConfigService, eventQueueService)
 	questionRepo := question.NewQuestionRepo(dataData, uniqueIDRepo)
 	answerRepo := answer.NewAnswerRepo(dataData, uniqueIDRepo, userRankRepo, activityRepo)
 	voteRepo := activity_common.NewVoteRepo(dataData, activityRepo)
@@ -157,74 +175,92 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	answerCommon := answercommon.NewAnswerCommon(answerRepo)
 	metaRepo := meta.NewMetaRepo(dataData)
 	metaCommonService := metacommon.NewMetaCommonService(metaRepo)
-	questionCommon := questioncommon.NewQuestionCommon(questionRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, dataData)
-	eventQueueService := event_queue.NewEventQueueService()
-	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon, eventQueueService)
+	spaceRepo := space.NewSpaceRepo(dataData)
+	spaceService := space2.NewSpaceService(spaceRepo, userCommon, userRoleRelService, roleService)
+	externalProfileRepo := user_external_login.NewExternalProfileRepo(dataData)
+	externalProfileService := external_profile.NewExternalProfileService(externalProfileRepo)
+	questionCommon := questioncommon.NewQuestionCommon(questionRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, spaceService, dataData, externalProfileService)
+	userSuspensionRepo := user.NewUserSuspensionRepo(dataData)
+	userSuspensionService := user_suspension.NewUserSuspensionService(userSuspensionRepo, configService, userCommon)
+	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon, eventQueueService, externalProfileService, userSuspensionService)
 	captchaRepo := captcha.NewCaptchaRepo(dataData)
-	captchaService := action.NewCaptchaService(captchaRepo)
+	captchaService := action.NewCaptchaService(captchaRepo, siteInfoCommonService, userRepo)
 	userController := controller.NewUserController(authService, userService, captchaService, emailService, siteInfoCommonService, userNotificationConfigService)
 	commentRepo := comment.NewCommentRepo(dataData, uniqueIDRepo)
 	commentCommonRepo := comment.NewCommentCommonRepo(dataData, uniqueIDRepo)
 	objService := object_info.NewObjService(answerRepo, questionRepo, commentCommonRepo, tagCommonRepo, tagCommonService)
 	notificationQueueService := notice_queue.NewNotificationQueueService()
 	externalNotificationQueueService := notice_queue.NewNewQuestionNotificationQueueService()
-	commentService := comment2.NewCommentService(commentRepo, commentCommonRepo, userCommon, objService, voteRepo, emailService, userRepo, notificationQueueService, externalNotificationQueueService, activityQueueService, eventQueueService, dataData)
+	commentService := comment2.NewCommentService(commentRepo, commentCommonRepo, userCommon, objService, voteRepo, emailService, userRepo, notificationQueueService, externalNotificationQueueService, activityQueueService, eventQueueService, spaceService, externalProfileService)
 	rolePowerRelRepo := role.NewRolePowerRelRepo(dataData)
 	rolePowerRelService := role2.NewRolePowerRelService(rolePowerRelRepo, userRoleRelService)
-	rankService := rank2.NewRankService(userCommon, userRankRepo, objService, userRoleRelService, rolePowerRelService, configService)
+	tagModeratorRepo := tag.NewTagModeratorRepo(dataData)
+	tagModeratorService := tag_moderator.NewTagModeratorService(tagModeratorRepo, tagCommonService, userCommon)
+	rankService := rank2.NewRankService(userCommon, userRankRepo, objService, userRoleRelService, rolePowerRelService, spaceService, tagModeratorService, configService)
 	limitRepo := limit.NewRateLimitRepo(dataData)
 	rateLimitMiddleware := middleware.NewRateLimitMiddleware(limitRepo)
 	commentController := controller.NewCommentController(commentService, rankService, captchaService, rateLimitMiddleware)
 	reportRepo := report.NewReportRepo(dataData, uniqueIDRepo)
-	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, siteInfoCommonService, activityQueueService)
+	tagCategoryRepo := tag.NewTagCategoryRepo(dataData)
+	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, siteInfoCommonService, activityQueueService, tagCategoryRepo)
 	answerActivityRepo := activity.NewAnswerActivityRepo(dataData, activityRepo, userRankRepo, notificationQueueService)
 	answerActivityService := activity2.NewAnswerActivityService(answerActivityRepo, configService)
-	mixinBotService, err := mixinbot.NewMixinBotService(mixinbotConf)
+	mixinBotRepo := mixinbot2.NewMixinBotRepo(dataData)
+	mixinBotService, err := mixinbot.NewMixinBotService(mixinbotConf, mixinBotRepo)
 	if err != nil {
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	externalNotificationService := notification.NewExternalNotificationService(dataData, userNotificationConfigRepo, followRepo, emailService, userRepo, externalNotificationQueueService, userExternalLoginRepo, siteInfoCommonService, mixinBotService)
+	externalNotificationService := notification.NewExternalNotificationService(dataData, userNotificationConfigRepo, followRepo, emailService, userRepo, externalNotificationQueueService, userExternalLoginRepo, siteInfoCommonService, mixinBotService, spaceService, tagCommonService)
 	reviewRepo := review.NewReviewRepo(dataData)
-	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalNotificationQueueService, tagCommonService, questionCommon, notificationQueueService, siteInfoCommonService)
-	questionService := content.NewQuestionService(activityRepo, questionRepo, answerRepo, tagCommonService, tagService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService, dataData)
-	answerService := content.NewAnswerService(answerRepo, questionRepo, questionCommon, userCommon, collectionCommon, userRepo, revisionService, answerActivityService, answerCommon, voteRepo, emailService, userRoleRelService, notificationQueueService, externalNotificationQueueService, activityQueueService, reviewService, eventQueueService, dataData)
+	userAdminRepo := user.NewUserAdminRepo(dataData, authRepo)
+	userAdminService := user_admin.NewUserAdminService(userAdminRepo, userRoleRelService, authService, userCommon, userActiveActivityRepo, siteInfoCommonService, emailService, questionRepo, answerRepo, commentCommonRepo, eventQueueService, userSuspensionService)
+	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalNotificationQueueService, tagCommonService, questionCommon, notificationQueueService, siteInfoCommonService, tagModeratorService, eventQueueService, userSuspensionService, userAdminService)
+	questionViewRepo := question.NewQuestionViewRepo(dataData)
+	questionViewService := question_view.NewQuestionViewService(questionRepo, questionViewRepo)
+	questionService := content.NewQuestionService(activityRepo, questionRepo, answerRepo, tagCommonService, tagService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService, spaceService, questionViewService, externalProfileService)
+	answerService := content.NewAnswerService(answerRepo, questionRepo, questionCommon, userCommon, collectionCommon, userRepo, revisionService, answerActivityService, answerCommon, voteRepo, emailService, userRoleRelService, notificationQueueService, externalNotificationQueueService, activityQueueService, reviewService, eventQueueService, spaceService, externalProfileService)
 	reportHandle := report_handle.NewReportHandle(questionService, answerService, commentService)
 	reportService := report2.NewReportService(reportRepo, objService, userCommon, answerRepo, questionRepo, commentCommonRepo, reportHandle, configService, eventQueueService)
 	reportController := controller.NewReportController(reportService, rankService, captchaService)
 	contentVoteRepo := activity.NewVoteRepo(dataData, activityRepo, userRankRepo, notificationQueueService)
 	voteService := content.NewVoteService(contentVoteRepo, configService, questionRepo, answerRepo, commentCommonRepo, objService, eventQueueService)
 	voteController := controller.NewVoteController(voteService, rankService, captchaService)
-	tagController := controller.NewTagController(tagService, tagCommonService, rankService)
+	tagController := controller.NewTagController(tagService, tagCommonService, rankService, tagModeratorService)
 	followFollowRepo := activity.NewFollowRepo(dataData, uniqueIDRepo, activityRepo)
 	followService := follow.NewFollowService(followFollowRepo, followRepo, tagCommonRepo)
 	followController := controller.NewFollowController(followService)
 	collectionGroupRepo := collection.NewCollectionGroupRepo(dataData)
 	collectionService := collection2.NewCollectionService(collectionRepo, collectionGroupRepo, questionCommon)
 	collectionController := controller.NewCollectionController(collectionService)
-	questionController := controller.NewQuestionController(questionService, answerService, rankService, siteInfoCommonService, captchaService, rateLimitMiddleware)
-	answerController := controller.NewAnswerController(answerService, rankService, captchaService, siteInfoCommonService, rateLimitMiddleware)
+	draftRepo := draft.NewDraftRepo(dataData)
+	draftService := draft2.NewDraftService(draftRepo, revisionRepo, objService, spaceService, rankService)
+	draftController := controller.NewDraftController(draftService)
+	questionController := controller.NewQuestionController(questionService, answerService, rankService, siteInfoCommonService, captchaService, rateLimitMiddleware, questionViewService, draftService)
+	answerController := controller.NewAnswerController(answerService, rankService, captchaService, siteInfoCommonService, rateLimitMiddleware, draftService)
 	searchParser := search_parser.NewSearchParser(tagCommonService, userCommon)
 	searchRepo := search_common.NewSearchRepo(dataData, uniqueIDRepo, userCommon, tagCommonService)
 	searchService := content.NewSearchService(searchParser, searchRepo)
 	searchController := controller.NewSearchController(searchService, captchaService)
 	reviewActivityRepo := activity.NewReviewActivityRepo(dataData, activityRepo, userRankRepo, configService)
-	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, notificationQueueService, activityQueueService, reportRepo, reviewService, reviewActivityRepo)
-	revisionController := controller.NewRevisionController(contentRevisionService, rankService)
-	rankController := controller.NewRankController(rankService)
-	userAdminRepo := user.NewUserAdminRepo(dataData, authRepo)
-	userAdminService := user_admin.NewUserAdminService(userAdminRepo, userRoleRelService, authService, userCommon, userActiveActivityRepo, siteInfoCommonService, emailService, questionRepo, answerRepo, commentCommonRepo)
+	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, notificationQueueService, activityQueueService, reportRepo, reviewService, reviewActivityRepo, questionService, spaceService, tagModeratorService)
+	revisionController := controller.NewRevisionController(contentRevisionService, rankService, captchaService)
+	reputationRepo := rank.NewReputationRepo(dataData)
+	reputationService := reputation.NewReputationService(reputationRepo, userCommon, objService, spaceService)
+	rankController := controller.NewRankController(rankService, reputationService)
 	userAdminController := controller_admin.NewUserAdminController(userAdminService)
 	reasonRepo := reason.NewReasonRepo(configService)
 	reasonService := reason2.NewReasonService(reasonRepo)
 	reasonController := controller.NewReasonController(reasonService)
 	themeController := controller_admin.NewThemeController()
 	siteInfoService := siteinfo.NewSiteInfoService(siteInfoRepo, siteInfoCommonService, emailService, tagCommonService, configService, questionCommon)
-	siteInfoController := controller_admin.NewSiteInfoController(siteInfoService)
+	siteInfoController := controller_admin.NewSiteInfoController(siteInfoService, captchaService)
 	controllerSiteInfoController := controller.NewSiteInfoController(siteInfoCommonService)
 	notificationRepo := notification2.NewNotificationRepo(dataData)
-	notificationCommon := notificationcommon.NewNotificationCommon(dataData, notificationRepo, userCommon, activityRepo, followRepo, objService, notificationQueueService, userExternalLoginRepo, siteInfoCommonService, mixinBotService)
+	notificationDeliveryRepo := notification2.NewNotificationDeliveryRepo(dataData)
+	notificationChannelService := notification_channel.NewNotificationChannelService(notificationDeliveryRepo, userNotificationConfigService, userCommon, userExternalLoginRepo, userRepo, siteInfoCommonService, emailService, mixinBotService)
+	notificationCommon := notificationcommon.NewNotificationCommon(dataData, notificationRepo, userCommon, activityRepo, followRepo, objService, notificationQueueService, notificationChannelService, spaceService)
 	badgeRepo := badge.NewBadgeRepo(dataData, uniqueIDRepo)
 	notificationService := notification.NewNotificationService(dataData, notificationRepo, notificationCommon, revisionService, userRepo, reportRepo, reviewService, badgeRepo)
 	notificationController := controller.NewNotificationController(notificationService, rankService)
@@ -235,7 +271,7 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	activityActivityRepo := activity.NewActivityRepo(dataData, configService)
 	activityCommon := activity_common2.NewActivityCommon(activityRepo, activityQueueService)
 	commentCommonService := comment_common.NewCommentCommonService(commentCommonRepo)
-	activityService := activity2.NewActivityService(activityActivityRepo, userCommon, activityCommon, tagCommonService, objService, commentCommonService, revisionService, metaCommonService, configService)
+	activityService := activity2.NewActivityService(activityActivityRepo, userCommon, activityCommon, tagCommonService, objService, commentCommonService, revisionService, metaCommonService, configService, spaceService)
 	activityController := controller.NewActivityController(activityService)
 	roleController := controller_admin.NewRoleController(roleService)
 	pluginConfigRepo := plugin_config.NewPluginConfigRepo(dataData)
@@ -250,31 +286,54 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	badgeGroupRepo := badge_group.NewBadgeGroupRepo(dataData, uniqueIDRepo)
 	badgeAwardRepo := badge_award.NewBadgeAwardRepo(dataData, uniqueIDRepo)
 	eventRuleRepo := badge.NewEventRuleRepo(dataData)
-	badgeAwardService := badge2.NewBadgeAwardService(badgeAwardRepo, badgeRepo, userCommon, objService, notificationQueueService)
+	badgeAwardService := badge2.NewBadgeAwardService(badgeAwardRepo, badgeRepo, userCommon, objService, notificationQueueService, eventQueueService)
 	badgeEventService := badge2.NewBadgeEventService(dataData, eventQueueService, badgeRepo, eventRuleRepo, badgeAwardService)
 	badgeService := badge2.NewBadgeService(badgeRepo, badgeGroupRepo, badgeAwardRepo, badgeEventService, siteInfoCommonService)
 	badgeController := controller.NewBadgeController(badgeService, badgeAwardService)
 	controller_adminBadgeController := controller_admin.NewBadgeController(badgeService)
-	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController, badgeController, controller_adminBadgeController)
+	userDataRepo := user_data.NewUserDataRepo(dataData)
+	userDataService := user_data2.NewUserDataService(userDataRepo, userRepo, userCommon, userAdminRepo, userAdminService, userRoleRelService, authService, configService, serviceConf, siteInfoCommonService)
+	userDataController := controller.NewUserDataController(userDataService)
+	spaceController := controller.NewSpaceController(spaceService)
+	controller_adminSpaceController := controller_admin.NewSpaceController(spaceService)
+	tagModeratorController := controller_admin.NewTagModeratorController(tagModeratorService)
+	controller_adminTagController := controller_admin.NewTagController(tagService)
+	analyticsRepo := analytics.NewAnalyticsRepo(dataData)
+	analyticsService := analytics2.NewAnalyticsService(analyticsRepo, configService)
+	analyticsController := controller_admin.NewAnalyticsController(analyticsService)
+	reputationController := controller_admin.NewReputationController(reputationService)
+	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, followController, collectionController, draftController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController, badgeController, controller_adminBadgeController, userDataController, spaceController, controller_adminSpaceController, tagModeratorController, controller_adminTagController, analyticsController, reputationController)
 	swaggerRouter := router.NewSwaggerRouter(swaggerConf)
 	uiRouter := router.NewUIRouter(controllerSiteInfoController, siteInfoCommonService)
 	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService)
 	avatarMiddleware := middleware.NewAvatarMiddleware(serviceConf, uploaderService)
 	shortIDMiddleware := middleware.NewShortIDMiddleware(siteInfoCommonService)
+	spaceMiddleware := middleware.NewSpaceMiddleware(spaceService)
 	templateRenderController := templaterender.NewTemplateRenderController(questionService, userService, tagService, answerService, commentService, siteInfoCommonService, questionRepo)
-	templateController := controller.NewTemplateController(templateRenderController, siteInfoCommonService, eventQueueService, userService)
+	templateController := controller.NewTemplateController(templateRenderController, siteInfoCommonService, eventQueueService, userService, spaceService)
 	templateRouter := router.NewTemplateRouter(templateController, templateRenderController, siteInfoController, authUserMiddleware)
 	connectorController := controller.NewConnectorController(siteInfoCommonService, emailService, userExternalLoginService)
-	userCenterLoginService := user_external_login2.NewUserCenterLoginService(userRepo, userCommon, userExternalLoginRepo, userActiveActivityRepo, siteInfoCommonService)
+	userCenterLoginService := user_external_login2.NewUserCenterLoginService(userRepo, userCommon, userExternalLoginRepo, userActiveActivityRepo, siteInfoCommonService, eventQueueService)
 	userCenterController := controller.NewUserCenterController(userCenterLoginService, siteInfoCommonService)
 	captchaController := controller.NewCaptchaController()
 	embedController := controller.NewEmbedController()
 	renderController := controller.NewRenderController()
 	pluginAPIRouter := router.NewPluginAPIRouter(connectorController, userCenterController, captchaController, embedController, renderController)
-	ginEngine := server.NewHTTPServer(debug, staticRouter, answerAPIRouter, swaggerRouter, uiRouter, authUserMiddleware, avatarMiddleware, shortIDMiddleware, templateRouter, pluginAPIRouter, uiConf)
-	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService)
-	application := newApplication(serverConf, ginEngine, scheduledTaskManager)
-	return application, func() {
+	scimRepo := scim.NewScimRepo(dataData)
+	scimService := scim2.NewScimService(scimRepo, userAdminRepo, userAdminService, userCommon, userExternalLoginRepo, roleService, userRoleRelService, authService, siteInfoCommonService)
+	scimController := controller.NewScimController(scimService)
+	scimRouter := router.NewScimRouter(scimController)
+	healthRouter := router.NewHealthRouter(dataData)
+	ginEngine := server.NewHTTPServer(debug, staticRouter, answerAPIRouter, swaggerRouter, uiRouter, authUserMiddleware, avatarMiddleware, shortIDMiddleware, spaceMiddleware, templateRouter, pluginAPIRouter, scimRouter, healthRouter, uiConf)
+	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService, userDataService, questionViewService, analyticsService, notificationChannelService, userAdminService, draftService)
+	mixinBotCommandService := mixinbotcommand.NewMixinBotCommandService(mixinBotService, userExternalLoginRepo, userCommon, rankService, captchaService, answerService, commentService, followService, tagCommonService, questionRepo, searchService, notificationService, siteInfoCommonService, spaceService)
+	answercmdApplication, err := newApplication(serverConf, ginEngine, scheduledTaskManager, mixinBotCommandService, dataData)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	return answercmdApplication, func() {
 		cleanup2()
 		cleanup()
 	}, nil
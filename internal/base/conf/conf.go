@@ -26,6 +26,7 @@ import (
 
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/server"
+	"github.com/apache/incubator-answer/internal/base/tracing"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/cli"
 	"github.com/apache/incubator-answer/internal/router"
@@ -48,6 +49,7 @@ type AllConfig struct {
 	UI             *server.UI                    `json:"ui" mapstructure:"ui" yaml:"ui"`
 	MixinBotConfig *mixinbot.MixinBotConfig      `json:"mixinbot_config" mapstructure:"mixinbot_config" yaml:"mixinbot_config"`
 	StorageConfig  *uploader.StorageConfig       `json:"storage_config" mapstructure:"storage_config" yaml:"storage_config"`
+	Tracing        *tracing.Config               `json:"tracing,omitempty" mapstructure:"tracing" yaml:"tracing,omitempty"`
 }
 
 type envConfigOverrides struct {
@@ -70,7 +72,8 @@ type PathIgnore struct {
 
 // Server server config
 type Server struct {
-	HTTP *server.HTTP `json:"http" mapstructure:"http" yaml:"http"`
+	HTTP    *server.HTTP    `json:"http" mapstructure:"http" yaml:"http"`
+	Metrics *server.Metrics `json:"metrics,omitempty" mapstructure:"metrics" yaml:"metrics,omitempty"`
 }
 
 // Data data config
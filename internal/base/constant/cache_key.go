@@ -42,6 +42,12 @@ const (
 	ConfigCacheTime                            = 1 * time.Hour
 	ConnectorUserExternalInfoCacheKey          = "answer:connector:"
 	ConnectorUserExternalInfoCacheTime         = 10 * time.Minute
+	UserExternalProfileCacheKey                = "answer:user:external-profile:"
+	UserExternalProfileCacheTime               = 1 * time.Hour
+	MixinBotCardCacheKey                       = "answer:mixinbot:card:"
+	MixinBotCardCacheTime                      = 7 * 24 * time.Hour
+	MixinBotMessageCacheKey                    = "answer:mixinbot:message:"
+	MixinBotMessageCacheTime                   = 24 * time.Hour
 	SiteMapQuestionCacheKeyPrefix              = "answer:sitemap:question:%d"
 	SiteMapQuestionCacheTime                   = time.Hour
 	SitemapMaxSize                             = 50000
@@ -52,4 +58,13 @@ const (
 	RateLimitCacheTime                         = 5 * time.Minute
 	RedDotCacheKey                             = "answer:red-dot:%s:%s"
 	RedDotCacheTime                            = 30 * 24 * time.Hour
+	QuestionViewCacheKey                       = "answer:question-view:%s:%s"
+	QuestionViewCacheTime                      = 30 * time.Minute
+	QuestionUniqueViewCacheKey                 = "answer:question-unique-view:%s:%s:%s"
+	QuestionUniqueViewCacheTime                = 24 * time.Hour
+	QuestionPendingViewCacheKey                = "answer:question-pending-view:%s:%s"
+	QuestionPendingUniqueViewCacheKey          = "answer:question-pending-unique-view:%s:%s"
+	QuestionPendingViewCacheTime               = 24 * time.Hour
+	ReputationLedgerCacheKey                   = "answer:reputation-ledger:"
+	ReputationLedgerCacheTime                  = 10 * time.Minute
 )
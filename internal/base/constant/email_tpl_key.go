@@ -43,4 +43,7 @@ const (
 
 	EmailTplKeyNewQuestionTitle = "email_tpl.new_question.title"
 	EmailTplKeyNewQuestionBody  = "email_tpl.new_question.body"
+
+	EmailTplKeyNotificationTitle = "email_tpl.notification.title"
+	EmailTplKeyNotificationBody  = "email_tpl.notification.body"
 )
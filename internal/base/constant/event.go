@@ -28,6 +28,9 @@ const (
 	eventAnswer   = "answer"
 	eventComment  = "comment"
 	eventUser     = "user"
+	eventReview   = "review"
+	eventReport   = "report"
+	eventBadge    = "badge"
 )
 
 // event action
@@ -40,11 +43,23 @@ const (
 	eventShare  = "share"  // the object share link has been clicked
 	eventFlag   = "flag"
 	eventReact  = "react"
+
+	eventRegister = "register"
+	eventSuspend  = "suspend"
+	eventActivate = "activate" // the suspended or deleted user has been restored to normal
+	eventApprove  = "approve"
+	eventReject   = "reject"
+	eventHandle   = "handle"
+	eventAward    = "award"
 )
 
 const (
-	EventUserUpdate EventType = eventUser + "." + eventUpdate
-	EventUserShare  EventType = eventUser + "." + eventShare
+	EventUserUpdate   EventType = eventUser + "." + eventUpdate
+	EventUserShare    EventType = eventUser + "." + eventShare
+	EventUserRegister EventType = eventUser + "." + eventRegister
+	EventUserSuspend  EventType = eventUser + "." + eventSuspend
+	EventUserActivate EventType = eventUser + "." + eventActivate
+	EventUserDelete   EventType = eventUser + "." + eventDelete
 )
 
 const (
@@ -73,3 +88,16 @@ const (
 	EventCommentVote   EventType = eventComment + "." + eventVote
 	EventCommentFlag   EventType = eventComment + "." + eventFlag
 )
+
+const (
+	EventReviewApprove EventType = eventReview + "." + eventApprove
+	EventReviewReject  EventType = eventReview + "." + eventReject
+)
+
+const (
+	EventReportHandle EventType = eventReport + "." + eventHandle
+)
+
+const (
+	EventBadgeAward EventType = eventBadge + "." + eventAward
+)
@@ -67,12 +67,63 @@ const (
 	InboxSource                          NotificationSource = "inbox"
 	AllNewQuestionSource                 NotificationSource = "all_new_question"
 	AllNewQuestionForFollowingTagsSource NotificationSource = "all_new_question_for_following_tags"
+
+	// the types of the inbox notifications that users choose the channels for
+	AnswerToMyQuestionSource NotificationSource = "answer_to_my_question"
+	CommentReplySource       NotificationSource = "comment_reply"
+	MentionSource            NotificationSource = "mention"
+	InviteSource             NotificationSource = "invite"
+	BadgeSource              NotificationSource = "badge"
+	OtherInboxSource         NotificationSource = "other_inbox"
+
+	// the user level settings of the channels, they are saved along with the notification config
+	ChannelTargetSource NotificationSource = "channel_target"
+	QuietHoursSource    NotificationSource = "quiet_hours"
 )
 
 const (
-	EmailChannel NotificationChannelKey = "email"
+	EmailChannel       NotificationChannelKey = "email"
+	MixinChannel       NotificationChannelKey = "mixin"
+	WebhookChannel     NotificationChannelKey = "webhook"
+	ChatWebhookChannel NotificationChannelKey = "chat_webhook"
+	// PluginChannelPrefix the channel of the notification plugin is the prefix with the slug name of the plugin
+	PluginChannelPrefix = "plugin:"
 )
 
+// NotificationTypeSources the types of the inbox notifications that users choose the channels for
+var NotificationTypeSources = []NotificationSource{
+	AnswerToMyQuestionSource,
+	CommentReplySource,
+	MentionSource,
+	InviteSource,
+	BadgeSource,
+	OtherInboxSource,
+}
+
+// NotificationActionSourceMapping the type of the notification action, the action not in the mapping is other inbox
+var NotificationActionSourceMapping = map[string]NotificationSource{
+	NotificationAnswerTheQuestion:  AnswerToMyQuestionSource,
+	NotificationCommentQuestion:    CommentReplySource,
+	NotificationCommentAnswer:      CommentReplySource,
+	NotificationReplyToYou:         CommentReplySource,
+	NotificationMentionYou:         MentionSource,
+	NotificationInvitedYouToAnswer: InviteSource,
+	NotificationEarnedBadge:        BadgeSource,
+}
+
+// GetNotificationActionSource get the type of the notification action
+func GetNotificationActionSource(action string) NotificationSource {
+	if source, ok := NotificationActionSourceMapping[action]; ok {
+		return source
+	}
+	return OtherInboxSource
+}
+
+// NewPluginChannelKey the channel key of the notification plugin
+func NewPluginChannelKey(slugName string) NotificationChannelKey {
+	return NotificationChannelKey(PluginChannelPrefix + slugName)
+}
+
 const (
 	NotificationTypeInbox            = "inbox"
 	NotificationTypeAchievement      = "achievement"
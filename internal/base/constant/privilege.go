@@ -61,6 +61,7 @@ const (
 	RankQuestionCloseKey             = "rank.question.close"
 	RankQuestionReopenKey            = "rank.question.reopen"
 	RankTagUseReservedTagKey         = "rank.tag.use_reserved_tag"
+	RankWikiEditKey                  = "rank.wiki.edit"
 )
 
 var (
@@ -87,5 +88,6 @@ var (
 		{Label: reason.RankTagAuditLabel, Key: RankTagAuditKey},
 		{Label: reason.RankTagEditWithoutReviewLabel, Key: RankTagEditWithoutReviewKey},
 		{Label: reason.RankTagSynonymLabel, Key: RankTagSynonymKey},
+		{Label: reason.RankWikiEditLabel, Key: RankWikiEditKey},
 	}
 )
@@ -31,4 +31,6 @@ const (
 	SiteTypeTheme         = "theme"
 	SiteTypePrivileges    = "privileges"
 	SiteTypeUsers         = "users"
+	SiteTypeScim          = "scim"
+	SiteTypeCaptcha       = "captcha"
 )
@@ -23,26 +23,54 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/metrics"
+	"github.com/apache/incubator-answer/internal/service/analytics"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/draft"
+	"github.com/apache/incubator-answer/internal/service/notification_channel"
+	"github.com/apache/incubator-answer/internal/service/question_view"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/user_admin"
+	"github.com/apache/incubator-answer/internal/service/user_data"
 	"github.com/robfig/cron/v3"
 	"github.com/segmentfault/pacman/log"
 )
 
 // ScheduledTaskManager scheduled task manager
 type ScheduledTaskManager struct {
-	siteInfoService siteinfo_common.SiteInfoCommonService
-	questionService *content.QuestionService
+	siteInfoService     siteinfo_common.SiteInfoCommonService
+	questionService     *content.QuestionService
+	userDataService     *user_data.UserDataService
+	questionViewService *question_view.QuestionViewService
+	analyticsService    *analytics.AnalyticsService
+
+	notificationChannelService *notification_channel.NotificationChannelService
+	userAdminService           *user_admin.UserAdminService
+	draftService               *draft.DraftService
 }
 
 // NewScheduledTaskManager new scheduled task manager
 func NewScheduledTaskManager(
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	questionService *content.QuestionService,
+	userDataService *user_data.UserDataService,
+	questionViewService *question_view.QuestionViewService,
+	analyticsService *analytics.AnalyticsService,
+	notificationChannelService *notification_channel.NotificationChannelService,
+	userAdminService *user_admin.UserAdminService,
+	draftService *draft.DraftService,
 ) *ScheduledTaskManager {
 	manager := &ScheduledTaskManager{
-		siteInfoService: siteInfoService,
-		questionService: questionService,
+		siteInfoService:     siteInfoService,
+		questionService:     questionService,
+		userDataService:     userDataService,
+		questionViewService: questionViewService,
+		analyticsService:    analyticsService,
+
+		notificationChannelService: notificationChannelService,
+		userAdminService:           userAdminService,
+		draftService:               draftService,
 	}
 	return manager
 }
@@ -51,23 +79,64 @@ func (s *ScheduledTaskManager) Run() {
 	fmt.Println("start cron")
 	s.questionService.SitemapCron(context.Background())
 	c := cron.New()
-	_, err := c.AddFunc("0 */1 * * *", func() {
+	addJob(c, "0 */1 * * *", "sitemap", func() {
 		ctx := context.Background()
 		fmt.Println("sitemap cron execution")
 		s.questionService.SitemapCron(ctx)
 	})
-	if err != nil {
-		log.Error(err)
-	}
 
-	_, err = c.AddFunc("0 */1 * * *", func() {
-		ctx := context.Background()
+	addJob(c, "0 */1 * * *", "refresh_hottest", func() {
+		ctx := handler.WithAllSpaceAccess(context.Background())
 		fmt.Println("refresh hottest cron execution")
 		s.questionService.RefreshHottestCron(ctx)
 	})
+
+	addJob(c, "30 */1 * * *", "user_data", func() {
+		ctx := context.Background()
+		fmt.Println("user data cron execution")
+		s.userDataService.CleanExpiredExports(ctx)
+		s.userDataService.ProcessDueDeletions(ctx)
+	})
+
+	addJob(c, "* * * * *", "user_data_export", func() {
+		s.userDataService.ProcessPendingExports(context.Background())
+	})
+
+	addJob(c, "* * * * *", "flush_question_views", func() {
+		s.questionViewService.FlushQuestionViews(context.Background())
+	})
+
+	addJob(c, "10 */1 * * *", "analytics_rollup", func() {
+		ctx := context.Background()
+		fmt.Println("analytics rollup cron execution")
+		s.analyticsService.RollupCron(ctx)
+	})
+
+	addJob(c, "* * * * *", "notification_delivery", func() {
+		s.notificationChannelService.DeliverDueCron(context.Background())
+	})
+
+	addJob(c, "* * * * *", "user_suspension", func() {
+		s.userAdminService.ReinstateExpiredSuspensionsCron(context.Background())
+	})
+
+	addJob(c, "* * * * *", "question_schedule", func() {
+		s.questionService.QuestionScheduleCron(handler.WithAllSpaceAccess(context.Background()))
+	})
+
+	addJob(c, "20 3 * * *", "draft_expiry", func() {
+		s.draftService.RemoveExpiredDrafts(context.Background())
+	})
+
+	c.Start()
+}
+
+// addJob add the job to cron, the duration and result of each run are collected to the metrics
+func addJob(c *cron.Cron, spec, job string, fn func()) {
+	_, err := c.AddFunc(spec, func() {
+		metrics.RunCronJob(job, fn)
+	})
 	if err != nil {
 		log.Error(err)
 	}
-
-	c.Start()
 }
@@ -23,6 +23,8 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/apache/incubator-answer/internal/base/metrics"
+	"github.com/apache/incubator-answer/internal/base/tracing"
 	"github.com/apache/incubator-answer/pkg/dir"
 	"github.com/apache/incubator-answer/plugin"
 	_ "github.com/go-sql-driver/mysql"
@@ -49,7 +51,8 @@ func NewData(db *xorm.Engine, cache cache.Cache) (*Data, func(), error) {
 		log.Info("closing the data resources")
 		db.Close()
 	}
-	return &Data{DB: db, Cache: cache}, cleanup, nil
+	metrics.RegisterDBStats(db.DB().DB)
+	return &Data{DB: db, Cache: &metricsCache{Cache: cache}}, cleanup, nil
 }
 
 // NewDB new database instance
@@ -91,6 +94,7 @@ func NewDB(debug bool, dataConf *Database) (*xorm.Engine, error) {
 		engine.SetConnMaxLifetime(time.Duration(dataConf.ConnMaxLifeTime) * time.Second)
 	}
 	engine.SetColumnMapper(names.GonicMapper{})
+	engine.AddHook(tracing.NewXormHook(dataConf.Driver))
 	return engine, nil
 }
 
@@ -98,7 +102,7 @@ func NewDB(debug bool, dataConf *Database) (*xorm.Engine, error) {
 func NewCache(c *CacheConf) (cache.Cache, func(), error) {
 	var pluginCache plugin.Cache
 	_ = plugin.CallCache(func(fn plugin.Cache) error {
-		pluginCache = fn
+		pluginCache = plugin.NewTracedCache(fn)
 		return nil
 	})
 	if pluginCache != nil {
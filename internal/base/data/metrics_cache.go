@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/metrics"
+	"github.com/segmentfault/pacman/cache"
+)
+
+// metricsCache count the hits and misses of the cache reads
+type metricsCache struct {
+	cache.Cache
+}
+
+// GetString get string and count the hit or miss
+func (c *metricsCache) GetString(ctx context.Context, key string) (data string, exist bool, err error) {
+	data, exist, err = c.Cache.GetString(ctx, key)
+	if err == nil {
+		metrics.ObserveCacheRead(exist)
+	}
+	return data, exist, err
+}
+
+// GetInt64 get int64 and count the hit or miss
+func (c *metricsCache) GetInt64(ctx context.Context, key string) (data int64, exist bool, err error) {
+	data, exist, err = c.Cache.GetInt64(ctx, key)
+	if err == nil {
+		metrics.ObserveCacheRead(exist)
+	}
+	return data, exist, err
+}
@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package handler
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+)
+
+// SpaceAccess the spaces that the login user can access besides the public spaces
+type SpaceAccess struct {
+	// AllSpaces the admin and moderator can access all the spaces
+	AllSpaces bool
+	// MemberSpaceIDs the spaces that user is a member of
+	MemberSpaceIDs []string
+	// ModeratorSpaceIDs the spaces that user is a moderator of
+	ModeratorSpaceIDs []string
+}
+
+// GetSpaceAccess get space access from context, if not set, only public spaces can be accessed
+func GetSpaceAccess(ctx context.Context) *SpaceAccess {
+	access, ok := ctx.Value(constant.SpaceAccessFlag).(*SpaceAccess)
+	if ok && access != nil {
+		return access
+	}
+	return &SpaceAccess{}
+}
+
+// WithAllSpaceAccess used by the internal jobs that need to access all the spaces, such as cron jobs
+func WithAllSpaceAccess(ctx context.Context) context.Context {
+	return context.WithValue(ctx, constant.SpaceAccessFlag, &SpaceAccess{AllSpaces: true})
+}
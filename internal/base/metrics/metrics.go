@@ -0,0 +1,221 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/segmentfault/pacman/log"
+)
+
+const namespace = "answer"
+
+var (
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of the http requests by route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+	queueHandlerErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "queue_handler_errors_total",
+		Help:      "Count of the errors returned by the queue handlers.",
+	}, []string{"queue"})
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Count of the cache reads by result, hit or miss.",
+	}, []string{"result"})
+	emailSendFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "email_send_failures_total",
+		Help:      "Count of the emails failed to send.",
+	})
+	cronJobRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cron_job_runs_total",
+		Help:      "Count of the cron job runs by result, success or panic.",
+	}, []string{"job", "result"})
+	cronJobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "cron_job_duration_seconds",
+		Help:      "Duration of the cron job runs.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 15, 60, 300, 900},
+	}, []string{"job"})
+	pluginCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "plugin_call_duration_seconds",
+		Help:      "Duration of the plugin calls by plugin type and plugin.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"type", "plugin", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(httpRequestDuration, queueHandlerErrors, cacheRequests, emailSendFailures,
+		cronJobRuns, cronJobDuration, pluginCallDuration)
+}
+
+// Handler the http handler of the metrics in prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// NewEngine new the engine of the separate metrics server, only the path /metrics is served
+func NewEngine() *gin.Engine {
+	r := gin.New()
+	r.GET("/metrics", gin.WrapH(Handler()))
+	return r
+}
+
+// GinMiddleware observe the duration of the http requests, the route is the template path
+// so that the label values are bounded, the requests without matched route are labeled as "unmatched"
+func GinMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+		route := ctx.FullPath()
+		if len(route) == 0 {
+			route = "unmatched"
+		}
+		httpRequestDuration.WithLabelValues(ctx.Request.Method, route, fmt.Sprintf("%d", ctx.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+// queue the registered in-memory queue
+type queue struct {
+	capacity int
+	length   func() int
+}
+
+var (
+	queueLock sync.RWMutex
+	queues    = make(map[string]*queue)
+)
+
+// RegisterQueue register the in-memory queue to report its length and check its backlog
+func RegisterQueue(name string, capacity int, length func() int) {
+	queueLock.Lock()
+	queues[name] = &queue{capacity: capacity, length: length}
+	queueLock.Unlock()
+
+	register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "queue_length",
+		Help:        "Count of the messages waiting in the queue.",
+		ConstLabels: prometheus.Labels{"queue": name},
+	}, func() float64 { return float64(length()) }))
+	register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "queue_capacity",
+		Help:        "Capacity of the queue.",
+		ConstLabels: prometheus.Labels{"queue": name},
+	}, func() float64 { return float64(capacity) }))
+}
+
+// QueueBacklog the backlog of the queue
+type QueueBacklog struct {
+	Name     string
+	Length   int
+	Capacity int
+}
+
+// GetQueueBacklogs get the backlogs of the registered queues
+func GetQueueBacklogs() (backlogs []*QueueBacklog) {
+	queueLock.RLock()
+	defer queueLock.RUnlock()
+	backlogs = make([]*QueueBacklog, 0, len(queues))
+	for name, q := range queues {
+		backlogs = append(backlogs, &QueueBacklog{Name: name, Length: q.length(), Capacity: q.capacity})
+	}
+	sort.Slice(backlogs, func(i, j int) bool { return backlogs[i].Name < backlogs[j].Name })
+	return backlogs
+}
+
+// ObserveQueueHandlerError count the error returned by the queue handler
+func ObserveQueueHandlerError(name string) {
+	queueHandlerErrors.WithLabelValues(name).Inc()
+}
+
+// ObserveCacheRead count the cache read
+func ObserveCacheRead(hit bool) {
+	if hit {
+		cacheRequests.WithLabelValues("hit").Inc()
+	} else {
+		cacheRequests.WithLabelValues("miss").Inc()
+	}
+}
+
+// ObserveEmailSendFailure count the email failed to send
+func ObserveEmailSendFailure() {
+	emailSendFailures.Inc()
+}
+
+// ObservePluginCall observe the duration of the plugin call
+func ObservePluginCall(pluginType, slugName string, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	pluginCallDuration.WithLabelValues(pluginType, slugName, result).Observe(duration.Seconds())
+}
+
+// RunCronJob run the cron job and observe its duration and result, the panic of the job is recovered
+func RunCronJob(job string, fn func()) {
+	start := time.Now()
+	result := "success"
+	defer func() {
+		if r := recover(); r != nil {
+			result = "panic"
+			log.Errorf("cron job %s panic: %v", job, r)
+		}
+		cronJobRuns.WithLabelValues(job, result).Inc()
+		cronJobDuration.WithLabelValues(job).Observe(time.Since(start).Seconds())
+	}()
+	fn()
+}
+
+// RegisterDBStats register the connection pool stats of the database
+func RegisterDBStats(db *sql.DB) {
+	register(collectors.NewDBStatsCollector(db, namespace))
+}
+
+// register register the collector, the collector registered again is ignored
+func register(collector prometheus.Collector) {
+	err := prometheus.Register(collector)
+	if err == nil {
+		return
+	}
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if !errors.As(err, &alreadyRegistered) {
+		log.Errorf("register metrics collector failed: %v", err)
+	}
+}
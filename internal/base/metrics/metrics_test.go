@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func scrape(t *testing.T) string {
+	w := httptest.NewRecorder()
+	NewEngine().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	return w.Body.String()
+}
+
+func TestNewEngine(t *testing.T) {
+	w := httptest.NewRecorder()
+	NewEngine().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGinMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(GinMiddleware())
+	r.GET("/answer/api/v1/question/:id", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/answer/api/v1/question/10010000000000001", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/not-found", nil))
+
+	body := scrape(t)
+	assert.Contains(t, body, `answer_http_request_duration_seconds_count{method="GET",route="/answer/api/v1/question/:id",status="200"}`)
+	assert.Contains(t, body, `answer_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"}`)
+	assert.NotContains(t, body, "10010000000000001")
+}
+
+func TestRunCronJob(t *testing.T) {
+	ran := false
+	RunCronJob("test_success", func() { ran = true })
+	assert.True(t, ran)
+	assert.NotPanics(t, func() {
+		RunCronJob("test_panic", func() { panic("test") })
+	})
+
+	body := scrape(t)
+	assert.Contains(t, body, `answer_cron_job_runs_total{job="test_success",result="success"} 1`)
+	assert.Contains(t, body, `answer_cron_job_runs_total{job="test_panic",result="panic"} 1`)
+}
+
+func TestRegisterQueue(t *testing.T) {
+	length := 3
+	RegisterQueue("test_b", 10, func() int { return length })
+	RegisterQueue("test_a", 5, func() int { return 1 })
+	// registering again replaces the queue without panicking
+	RegisterQueue("test_b", 10, func() int { return length })
+
+	length = 4
+	var backlogs []*QueueBacklog
+	for _, backlog := range GetQueueBacklogs() {
+		if backlog.Name == "test_a" || backlog.Name == "test_b" {
+			backlogs = append(backlogs, backlog)
+		}
+	}
+	if assert.Len(t, backlogs, 2) {
+		assert.Equal(t, &QueueBacklog{Name: "test_a", Length: 1, Capacity: 5}, backlogs[0])
+		assert.Equal(t, &QueueBacklog{Name: "test_b", Length: 4, Capacity: 10}, backlogs[1])
+	}
+	assert.Contains(t, scrape(t), `answer_queue_length{queue="test_b"} 4`)
+}
+
+func TestRegister(t *testing.T) {
+	collector := prometheus.NewCounter(prometheus.CounterOpts{Namespace: namespace, Name: "test_register_total"})
+	register(collector)
+	assert.NotPanics(t, func() { register(collector) })
+}
@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ScimAuth check the scim bearer token generated in admin settings
+func (am *AuthUserMiddleware) ScimAuth() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		scimConfig, err := am.siteInfoCommonService.GetSiteScim(ctx)
+		if err != nil {
+			log.Error(err)
+		}
+		token := ExtractToken(ctx)
+		if err != nil || !scimConfig.Enable || len(scimConfig.Token) == 0 || len(token) == 0 ||
+			subtle.ConstantTimeCompare([]byte(scimConfig.Token), []byte(token)) != 1 {
+			ctx.Header("Content-Type", schema.ScimContentType)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, &schema.ScimErrorResp{
+				Schemas: []string{schema.ScimSchemaError},
+				Detail:  http.StatusText(http.StatusUnauthorized),
+				Status:  strconv.Itoa(http.StatusUnauthorized),
+			})
+			return
+		}
+		ctx.Next()
+	}
+}
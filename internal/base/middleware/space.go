@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/service/space"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
+)
+
+type SpaceMiddleware struct {
+	spaceService *space.SpaceService
+}
+
+func NewSpaceMiddleware(spaceService *space.SpaceService) *SpaceMiddleware {
+	return &SpaceMiddleware{
+		spaceService: spaceService,
+	}
+}
+
+// SetSpaceAccess set the spaces that login user can access into context,
+// it must be used after the auth middleware.
+func (sm *SpaceMiddleware) SetSpaceAccess() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		access, err := sm.spaceService.GetUserSpaceAccess(ctx, GetLoginUserIDFromContext(ctx))
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		ctx.Set(constant.SpaceAccessFlag, access)
+	}
+}
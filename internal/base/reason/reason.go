@@ -92,6 +92,8 @@ const (
 	RecommendTagEnter                = "error.tag.recommend_tag_enter"
 	RevisionReviewUnderway           = "error.revision.review_underway"
 	RevisionNoPermission             = "error.revision.no_permission"
+	RevisionNotFound                 = "error.revision.not_found"
+	RevisionObjectMismatch           = "error.revision.object_mismatch"
 	UserCannotUpdateYourRole         = "error.user.cannot_update_your_role"
 	TagCannotSetSynonymAsItself      = "error.tag.cannot_set_synonym_as_itself"
 	NotAllowedRegistration           = "error.user.not_allowed_registration"
@@ -115,3 +117,71 @@ const (
 	UserExternalLoginUnbindingForbidden = "error.user.external_login_unbinding_forbidden"
 	UserExternalLoginMissingUserID      = "error.user.external_login_missing_user_id"
 )
+
+// scim reasons
+const (
+	ScimInvalidFilter  = "error.scim.invalid_filter"
+	ScimInvalidValue   = "error.scim.invalid_value"
+	ScimGroupImmutable = "error.scim.group_immutable"
+
+	ScimExternalIDDuplicate = "error.scim.external_id_duplicate"
+)
+
+// user data reasons
+const (
+	UserDataExportExpired         = "error.user_data.export_expired"
+	UserDeletionRequestExist      = "error.user_data.deletion_request_exist"
+	UserDeletionAdminForbidden    = "error.user_data.admin_cannot_delete_self"
+	UserDeletionPasswordIncorrect = "error.user_data.password_incorrect"
+)
+
+// space reasons
+const (
+	SpaceNotFound          = "error.space.not_found"
+	SpaceSlugNameDuplicate = "error.space.slug_name_duplicate"
+	SpaceNotEmpty          = "error.space.not_empty"
+	SpaceMemberNotFound    = "error.space.member_not_found"
+	SpacePostNotPermitted  = "error.space.post_not_permitted"
+)
+
+// tag moderator reasons
+const (
+	TagModeratorAlreadyExist = "error.tag.moderator_already_exist"
+	TagModeratorNotFound     = "error.tag.moderator_not_found"
+)
+
+// tag hierarchy reasons
+const (
+	TagParentInvalid             = "error.tag.parent_invalid"
+	TagCategoryNotFound          = "error.tag.category_not_found"
+	TagCategorySlugNameDuplicate = "error.tag.category_slug_name_duplicate"
+)
+
+// analytics reasons
+const (
+	AnalyticsRangeInvalid = "error.analytics.range_invalid"
+)
+
+// code highlight reasons
+const (
+	CodeHighlightThemeNotFound = "error.code_highlight.theme_not_found"
+)
+
+// user suspension reasons
+const (
+	UserSuspensionReasonInvalid   = "error.user.suspension_reason_invalid"
+	UserSuspensionEndTimeInvalid  = "error.user.suspension_end_time_invalid"
+	UserNotSuspended              = "error.user.not_suspended"
+	UserSuspensionAlreadyAppealed = "error.user.suspension_already_appealed"
+)
+
+// reputation reasons
+const (
+	ReputationManagedByUserCenter = "error.user.reputation_managed_by_user_center"
+)
+
+// question schedule reasons
+const (
+	QuestionScheduleTimeInvalid = "error.question.schedule_time_invalid"
+	QuestionScheduled           = "error.question.scheduled"
+)
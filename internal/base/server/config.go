@@ -24,6 +24,13 @@ type HTTP struct {
 	Addr string `json:"addr" mapstructure:"addr"`
 }
 
+// Metrics metrics config
+type Metrics struct {
+	// Addr the listen address of the metrics server, the metrics are only served on the path /metrics
+	// of this separate listener, so that they are not exposed publicly. If it is empty, the metrics are disabled.
+	Addr string `json:"addr" mapstructure:"addr" yaml:"addr"`
+}
+
 // UI ui config
 type UI struct {
 	BaseURL string `json:"base_url" mapstructure:"base_url" yaml:"base_url"`
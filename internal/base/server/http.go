@@ -24,7 +24,9 @@ import (
 	"io/fs"
 
 	brotli "github.com/anargu/gin-brotli"
+	"github.com/apache/incubator-answer/internal/base/metrics"
 	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/tracing"
 	"github.com/apache/incubator-answer/internal/router"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/apache/incubator-answer/ui"
@@ -40,8 +42,11 @@ func NewHTTPServer(debug bool,
 	authUserMiddleware *middleware.AuthUserMiddleware,
 	avatarMiddleware *middleware.AvatarMiddleware,
 	shortIDMiddleware *middleware.ShortIDMiddleware,
+	spaceMiddleware *middleware.SpaceMiddleware,
 	templateRouter *router.TemplateRouter,
 	pluginAPIRouter *router.PluginAPIRouter,
+	scimRouter *router.ScimRouter,
+	healthRouter *router.HealthRouter,
 	uiConf *UI,
 ) *gin.Engine {
 
@@ -51,8 +56,10 @@ func NewHTTPServer(debug bool,
 		gin.SetMode(gin.ReleaseMode)
 	}
 	r := gin.New()
+	r.Use(tracing.GinMiddleware(), metrics.GinMiddleware())
 	r.Use(brotli.Brotli(brotli.DefaultCompression), middleware.ExtractAndSetAcceptLanguage, shortIDMiddleware.SetShortIDFlag())
-	r.GET("/healthz", func(ctx *gin.Context) { ctx.String(200, "OK") })
+	healthRouter.Register(r)
+	plugin.SetCallObserver(metrics.ObservePluginCall)
 
 	html, _ := fs.Sub(ui.Template, "template")
 	htmlTemplate := template.Must(template.New("").Funcs(funcMap).ParseFS(html, "*"))
@@ -72,23 +79,27 @@ func NewHTTPServer(debug bool,
 
 	// register api that no need to login
 	unAuthV1 := r.Group("/answer/api/v1")
-	unAuthV1.Use(authUserMiddleware.Auth(), authUserMiddleware.EjectUserBySiteInfo())
+	unAuthV1.Use(authUserMiddleware.Auth(), authUserMiddleware.EjectUserBySiteInfo(), spaceMiddleware.SetSpaceAccess())
 	answerRouter.RegisterUnAuthAnswerAPIRouter(unAuthV1)
 
 	// register api that must be authenticated but no need to check account status
 	authWithoutStatusV1 := r.Group("/answer/api/v1")
-	authWithoutStatusV1.Use(authUserMiddleware.MustAuthWithoutAccountAvailable())
+	authWithoutStatusV1.Use(authUserMiddleware.MustAuthWithoutAccountAvailable(), spaceMiddleware.SetSpaceAccess())
 	answerRouter.RegisterAuthUserWithAnyStatusAnswerAPIRouter(authWithoutStatusV1)
 
 	// register api that must be authenticated
 	authV1 := r.Group("/answer/api/v1")
-	authV1.Use(authUserMiddleware.MustAuthAndAccountAvailable())
+	authV1.Use(authUserMiddleware.MustAuthAndAccountAvailable(), spaceMiddleware.SetSpaceAccess())
 	answerRouter.RegisterAnswerAPIRouter(authV1)
 
 	adminauthV1 := r.Group("/answer/admin/api")
-	adminauthV1.Use(authUserMiddleware.AdminAuth())
+	adminauthV1.Use(authUserMiddleware.AdminAuth(), spaceMiddleware.SetSpaceAccess())
 	answerRouter.RegisterAnswerAdminAPIRouter(adminauthV1)
 
+	scimV2 := r.Group("/scim/v2")
+	scimV2.Use(authUserMiddleware.ScimAuth())
+	scimRouter.RegisterScimRouter(scimV2)
+
 	templateRouter.RegisterTemplateRouter(rootGroup, uiConf.BaseURL)
 
 	// plugin routes
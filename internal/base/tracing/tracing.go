@@ -0,0 +1,157 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/apache/incubator-answer/pkg/dir"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+
+	defaultServiceName  = "answer"
+	instrumentationName = "github.com/apache/incubator-answer"
+)
+
+// Config tracing config
+type Config struct {
+	// Exporter the exporter of the spans: otlp, stdout or file, the tracing is disabled if it is empty
+	Exporter string `json:"exporter" mapstructure:"exporter" yaml:"exporter"`
+	// Endpoint the endpoint of the otlp http exporter, e.g. localhost:4318
+	Endpoint string `json:"endpoint" mapstructure:"endpoint" yaml:"endpoint"`
+	// Insecure whether the otlp http exporter uses http instead of https
+	Insecure bool `json:"insecure" mapstructure:"insecure" yaml:"insecure"`
+	// FilePath the file which the file exporter writes the spans to
+	FilePath string `json:"file_path" mapstructure:"file_path" yaml:"file_path"`
+	// SampleRatio the ratio of the traces to be sampled, all traces are sampled if it is not in (0, 1)
+	SampleRatio float64 `json:"sample_ratio" mapstructure:"sample_ratio" yaml:"sample_ratio"`
+	// ServiceName the service name of the spans, default is answer
+	ServiceName string `json:"service_name" mapstructure:"service_name" yaml:"service_name"`
+}
+
+var serviceName = defaultServiceName
+
+// Init set up the global tracer provider according to the config,
+// the returned shutdown function flushes the spans and closes the exporter
+func Init(conf *Config, version string) (shutdown func(ctx context.Context) error, err error) {
+	shutdown = func(ctx context.Context) error { return nil }
+	if conf == nil || len(conf.Exporter) == 0 {
+		return shutdown, nil
+	}
+	if len(conf.ServiceName) > 0 {
+		serviceName = conf.ServiceName
+	}
+
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+	)
+	switch conf.Exporter {
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{}
+		if len(conf.Endpoint) > 0 {
+			opts = append(opts, otlptracehttp.WithEndpoint(conf.Endpoint))
+		}
+		if conf.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(context.Background(), opts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterFile:
+		if err = dir.CreateDirIfNotExist(filepath.Dir(conf.FilePath)); err != nil {
+			return shutdown, err
+		}
+		var file *os.File
+		file, err = os.OpenFile(conf.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return shutdown, err
+		}
+		closer = file
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+	default:
+		return shutdown, fmt.Errorf("unknown tracing exporter %s", conf.Exporter)
+	}
+	if err != nil {
+		return shutdown, err
+	}
+
+	sampler := sdktrace.AlwaysSample()
+	if conf.SampleRatio > 0 && conf.SampleRatio < 1 {
+		sampler = sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SampleRatio))
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			_ = closer.Close()
+		}
+		return err
+	}, nil
+}
+
+// Start start a span as the child of the span in the context.
+// The span of the http request is kept in the request of gin context rather than gin context itself,
+// so it is used as the parent when the context is a gin context. The returned context wraps the given
+// context, so the values in the gin context are still available.
+func Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		if ginCtx, ok := ctx.(*gin.Context); ok && ginCtx.Request != nil {
+			if parent := trace.SpanContextFromContext(ginCtx.Request.Context()); parent.IsValid() {
+				ctx = trace.ContextWithSpanContext(ctx, parent)
+			}
+		}
+	}
+	return otel.Tracer(instrumentationName).Start(ctx, spanName, opts...)
+}
+
+// GinMiddleware start a span for each http request, the span is named by the route template
+func GinMiddleware() gin.HandlerFunc {
+	return otelgin.Middleware(serviceName)
+}
@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tracing
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"xorm.io/xorm/contexts"
+)
+
+const maxSQLStatementLen = 2048
+
+var (
+	sqlStringLiteral = regexp.MustCompile(`'(?:[^']|'')*'`)
+	// the numbers in identifiers and the postgres placeholders such as $1 are kept
+	sqlNumberLiteral = regexp.MustCompile(`(^|[^\w$])\d+(?:\.\d+)?\b`)
+	sqlSpaces        = regexp.MustCompile(`\s+`)
+)
+
+// xormHook create a span for each sql executed by xorm
+type xormHook struct {
+	dbSystem string
+}
+
+// NewXormHook new xorm hook, the db system is the name of the database driver
+func NewXormHook(dbSystem string) contexts.Hook {
+	return &xormHook{dbSystem: dbSystem}
+}
+
+// BeforeProcess start the span of the sql
+func (h *xormHook) BeforeProcess(c *contexts.ContextHook) (context.Context, error) {
+	ctx := c.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := Start(ctx, "db."+sqlOperation(c.SQL), trace.WithSpanKind(trace.SpanKindClient))
+	if span.IsRecording() {
+		span.SetAttributes(
+			attribute.String("db.system", h.dbSystem),
+			attribute.String("db.statement", SanitizeSQL(c.SQL)),
+		)
+	}
+	return ctx, nil
+}
+
+// AfterProcess end the span of the sql
+func (h *xormHook) AfterProcess(c *contexts.ContextHook) error {
+	if c.Ctx == nil {
+		return nil
+	}
+	span := trace.SpanFromContext(c.Ctx)
+	if c.Err != nil {
+		span.RecordError(c.Err)
+		span.SetStatus(codes.Error, c.Err.Error())
+	}
+	span.End()
+	return nil
+}
+
+// SanitizeSQL replace the literals in the sql with placeholders, the arguments are never recorded
+func SanitizeSQL(sql string) string {
+	sql = sqlStringLiteral.ReplaceAllString(sql, "?")
+	sql = sqlNumberLiteral.ReplaceAllString(sql, "${1}?")
+	sql = strings.TrimSpace(sqlSpaces.ReplaceAllString(sql, " "))
+	if len(sql) > maxSQLStatementLen {
+		sql = sql[:maxSQLStatementLen]
+	}
+	return sql
+}
+
+// sqlOperation the first keyword of the sql, such as select or insert
+func sqlOperation(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if i := strings.IndexAny(sql, " \t\n"); i > 0 {
+		sql = sql[:i]
+	}
+	if len(sql) == 0 {
+		return "query"
+	}
+	return strings.ToLower(sql)
+}
@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeSQL(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM `user` WHERE id = ? AND name = ?",
+		SanitizeSQL("SELECT * FROM `user` WHERE id = 10 AND name = 'it''s'"))
+	assert.Equal(t, "SELECT id FROM question_v2 WHERE status IN (?,?) LIMIT ?",
+		SanitizeSQL("SELECT id FROM question_v2\n\tWHERE status IN (1,2) LIMIT 10"))
+	assert.Equal(t, "UPDATE tag SET follow_count = follow_count + ? WHERE id = $1",
+		SanitizeSQL("UPDATE tag SET follow_count = follow_count + 1 WHERE id = $1"))
+}
+
+func TestSQLOperation(t *testing.T) {
+	assert.Equal(t, "select", sqlOperation(" SELECT * FROM user"))
+	assert.Equal(t, "insert", sqlOperation("INSERT INTO user"))
+	assert.Equal(t, "query", sqlOperation(""))
+}
@@ -0,0 +1,161 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/plugin"
+	"xorm.io/xorm"
+)
+
+// PluginHookResult is the result of calling a lifecycle hook of plugin
+type PluginHookResult struct {
+	SlugName string
+	Err      error
+}
+
+// NewPluginEnv connect database and read the site url for plugin commands and lifecycle hooks
+func NewPluginEnv(dbConf *data.Database) (env *plugin.Env, cleanup func(), err error) {
+	db, err := data.NewDB(false, dbConf)
+	if err != nil {
+		return nil, nil, err
+	}
+	env, err = NewPluginEnvWithDB(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, err
+	}
+	return env, func() { _ = db.Close() }, nil
+}
+
+// NewPluginEnvWithDB read the site url with the database engine that is already connected, e.g. the one of the application
+func NewPluginEnvWithDB(db *xorm.Engine) (env *plugin.Env, err error) {
+	siteURL, err := getSiteURL(db)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.Env{DB: db, SiteURL: siteURL}, nil
+}
+
+// LoadPluginStatus load the plugin status from database, so that only the enabled plugins will be called
+func LoadPluginStatus(x *xorm.Engine) (err error) {
+	item := &entity.Config{Key: constant.PluginStatus}
+	exist, err := x.Get(item)
+	if err != nil {
+		return fmt.Errorf("get plugin status failed: %w", err)
+	}
+	if !exist {
+		return nil
+	}
+	return plugin.StatusManager.UnmarshalJSON([]byte(item.Value))
+}
+
+// LoadPluginConfig load the config of plugins from database, so that the plugins can work as in the application
+func LoadPluginConfig(x *xorm.Engine) (err error) {
+	pluginConfigs := make([]*entity.PluginConfig, 0)
+	if err = x.Find(&pluginConfigs); err != nil {
+		return fmt.Errorf("get plugin config failed: %w", err)
+	}
+	for _, pluginConfig := range pluginConfigs {
+		err = plugin.CallConfig(func(fn plugin.Config) error {
+			if fn.Info().SlugName == pluginConfig.PluginSlugName {
+				return fn.ConfigReceiver([]byte(pluginConfig.Value))
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("parse plugin config failed: %s %w", pluginConfig.PluginSlugName, err)
+		}
+	}
+	return nil
+}
+
+// StartPlugins call the OnStart hooks of all enabled lifecycle plugins
+func StartPlugins(ctx context.Context, env *plugin.Env) (results []*PluginHookResult) {
+	_ = plugin.CallWithContext(ctx, plugin.CallLifecycle, func(ctx context.Context, p plugin.Lifecycle) error {
+		results = append(results, &PluginHookResult{SlugName: p.Info().SlugName, Err: p.OnStart(ctx, env)})
+		return nil
+	})
+	return results
+}
+
+// StopPlugins call the OnStop hooks of all enabled lifecycle plugins
+func StopPlugins(ctx context.Context) (results []*PluginHookResult) {
+	_ = plugin.CallWithContext(ctx, plugin.CallLifecycle, func(ctx context.Context, p plugin.Lifecycle) error {
+		results = append(results, &PluginHookResult{SlugName: p.Info().SlugName, Err: p.OnStop(ctx)})
+		return nil
+	})
+	return results
+}
+
+// CheckLifecyclePlugins check the enabled lifecycle plugins by their OnCheck hooks instead of the OnStart and OnStop hooks,
+// the config saved for the plugin must be accepted by it before the check
+func CheckLifecyclePlugins(ctx context.Context, env *plugin.Env) (results []*PluginHookResult) {
+	_ = plugin.CallWithContext(ctx, plugin.CallLifecycle, func(ctx context.Context, p plugin.Lifecycle) error {
+		err := checkPluginConfig(env.DB, p)
+		if err == nil {
+			err = p.OnCheck(ctx, env)
+		}
+		results = append(results, &PluginHookResult{SlugName: p.Info().SlugName, Err: err})
+		return nil
+	})
+	return results
+}
+
+func checkPluginConfig(x *xorm.Engine, p plugin.Base) (err error) {
+	configPlugin, ok := p.(plugin.Config)
+	if !ok {
+		return nil
+	}
+	pluginConfig := &entity.PluginConfig{PluginSlugName: p.Info().SlugName}
+	exist, err := x.Get(pluginConfig)
+	if err != nil {
+		return fmt.Errorf("get plugin config failed: %w", err)
+	}
+	if !exist {
+		return nil
+	}
+	if err = configPlugin.ConfigReceiver([]byte(pluginConfig.Value)); err != nil {
+		return fmt.Errorf("parse plugin config failed: %w", err)
+	}
+	return nil
+}
+
+func getSiteURL(x *xorm.Engine) (siteURL string, err error) {
+	generalSiteInfo := &entity.SiteInfo{Type: constant.SiteTypeGeneral}
+	exist, err := x.Get(generalSiteInfo)
+	if err != nil {
+		return "", fmt.Errorf("get general site info failed: %w", err)
+	}
+	if !exist {
+		return "", nil
+	}
+	var content struct {
+		SiteURL string `json:"site_url"`
+	}
+	_ = json.Unmarshal([]byte(generalSiteInfo.Content), &content)
+	return content.SiteURL, nil
+}
@@ -0,0 +1,150 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+// lifecyclePlugin records the calls of its hooks
+type lifecyclePlugin struct {
+	slugName string
+	calls    []string
+	checkErr error
+}
+
+func (p *lifecyclePlugin) Info() plugin.Info {
+	return plugin.Info{SlugName: p.slugName}
+}
+
+func (p *lifecyclePlugin) OnStart(_ context.Context, env *plugin.Env) error {
+	p.calls = append(p.calls, "start "+env.SiteURL)
+	return nil
+}
+
+func (p *lifecyclePlugin) OnStop(_ context.Context) error {
+	p.calls = append(p.calls, "stop")
+	return fmt.Errorf("stop failed")
+}
+
+func (p *lifecyclePlugin) OnCheck(_ context.Context, env *plugin.Env) error {
+	p.calls = append(p.calls, "check "+env.SiteURL)
+	return p.checkErr
+}
+
+func (p *lifecyclePlugin) ConfigFields() []plugin.ConfigField {
+	return nil
+}
+
+func (p *lifecyclePlugin) ConfigReceiver(config []byte) error {
+	return json.Unmarshal(config, &struct{}{})
+}
+
+var (
+	testLifecyclePlugin         = &lifecyclePlugin{slugName: "cli_test_lifecycle"}
+	testDisabledLifecyclePlugin = &lifecyclePlugin{slugName: "cli_test_lifecycle_disabled"}
+)
+
+func init() {
+	plugin.Register(testLifecyclePlugin)
+	plugin.Register(testDisabledLifecyclePlugin)
+}
+
+func newPluginTestDB(t *testing.T) *xorm.Engine {
+	db, err := data.NewDB(false, &data.Database{
+		Driver:     string(schemas.SQLITE),
+		Connection: filepath.Join(t.TempDir(), "answer-plugin-test.db"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.Sync(new(entity.Config), new(entity.PluginConfig), new(entity.SiteInfo)))
+	_, err = db.Insert(&entity.SiteInfo{Type: constant.SiteTypeGeneral, Content: `{"site_url":"https://answer.test"}`})
+	require.NoError(t, err)
+	_, err = db.Insert(&entity.Config{Key: constant.PluginStatus,
+		Value: `{"cli_test_lifecycle":true,"cli_test_lifecycle_disabled":false}`})
+	require.NoError(t, err)
+	require.NoError(t, LoadPluginStatus(db))
+	return db
+}
+
+func TestStartAndStopPlugins(t *testing.T) {
+	db := newPluginTestDB(t)
+	testLifecyclePlugin.calls = nil
+	env, err := NewPluginEnvWithDB(db)
+	require.NoError(t, err)
+	assert.Equal(t, "https://answer.test", env.SiteURL)
+
+	results := StartPlugins(context.TODO(), env)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "cli_test_lifecycle", results[0].SlugName)
+		assert.NoError(t, results[0].Err)
+	}
+	results = StopPlugins(context.TODO())
+	if assert.Len(t, results, 1) {
+		assert.EqualError(t, results[0].Err, "stop failed")
+	}
+	assert.Equal(t, []string{"start https://answer.test", "stop"}, testLifecyclePlugin.calls)
+	assert.Empty(t, testDisabledLifecyclePlugin.calls)
+}
+
+func TestCheckLifecyclePlugins(t *testing.T) {
+	db := newPluginTestDB(t)
+	testLifecyclePlugin.calls = nil
+	t.Cleanup(func() { testLifecyclePlugin.checkErr = nil })
+	env, err := NewPluginEnvWithDB(db)
+	require.NoError(t, err)
+
+	results := CheckLifecyclePlugins(context.TODO(), env)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "cli_test_lifecycle", results[0].SlugName)
+		assert.NoError(t, results[0].Err)
+	}
+
+	// the error of the check hook is reported
+	testLifecyclePlugin.checkErr = fmt.Errorf("service unreachable")
+	results = CheckLifecyclePlugins(context.TODO(), env)
+	if assert.Len(t, results, 1) {
+		assert.EqualError(t, results[0].Err, "service unreachable")
+	}
+
+	// the plugin isn't checked if it doesn't accept its config
+	_, err = db.Insert(&entity.PluginConfig{PluginSlugName: "cli_test_lifecycle", Value: "{"})
+	require.NoError(t, err)
+	results = CheckLifecyclePlugins(context.TODO(), env)
+	if assert.Len(t, results, 1) {
+		assert.ErrorContains(t, results[0].Err, "parse plugin config failed")
+	}
+	// checking never calls the start or stop hooks
+	assert.Equal(t, []string{"check https://answer.test", "check https://answer.test"}, testLifecyclePlugin.calls)
+	assert.Empty(t, testDisabledLifecyclePlugin.calls)
+}
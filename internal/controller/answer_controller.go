@@ -32,6 +32,7 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/action"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/draft"
 	"github.com/apache/incubator-answer/internal/service/permission"
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
@@ -47,6 +48,7 @@ type AnswerController struct {
 	actionService         *action.CaptchaService
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService
 	rateLimitMiddleware   *middleware.RateLimitMiddleware
+	draftService          *draft.DraftService
 }
 
 // NewAnswerController new controller
@@ -56,6 +58,7 @@ func NewAnswerController(
 	actionService *action.CaptchaService,
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	rateLimitMiddleware *middleware.RateLimitMiddleware,
+	draftService *draft.DraftService,
 ) *AnswerController {
 	return &AnswerController{
 		answerService:         answerService,
@@ -63,6 +66,7 @@ func NewAnswerController(
 		actionService:         actionService,
 		siteInfoCommonService: siteInfoCommonService,
 		rateLimitMiddleware:   rateLimitMiddleware,
+		draftService:          draftService,
 	}
 }
 
@@ -97,7 +101,7 @@ func (ac *AnswerController) RemoveAnswer(ctx *gin.Context) {
 	}
 
 	objectOwner := ac.rankService.CheckOperationObjectOwner(ctx, req.UserID, req.ID)
-	canList, err := ac.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, err := ac.rankService.CheckOperationObjectPermissions(ctx, req.UserID, req.ID, []string{
 		permission.AnswerDelete,
 	})
 	if err != nil {
@@ -135,7 +139,7 @@ func (ac *AnswerController) RecoverAnswer(ctx *gin.Context) {
 	req.AnswerID = uid.DeShortID(req.AnswerID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 
-	canList, err := ac.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, err := ac.rankService.CheckOperationObjectPermissions(ctx, req.UserID, req.AnswerID, []string{
 		permission.AnswerUnDelete,
 	})
 	if err != nil {
@@ -271,6 +275,7 @@ func (ac *AnswerController) Add(ctx *gin.Context) {
 	if !isAdmin || !linkUrlLimitUser {
 		ac.actionService.ActionRecordAdd(ctx, entity.CaptchaActionAnswer, req.UserID)
 	}
+	ac.draftService.RemovePublishedDraft(ctx, req.UserID, entity.DraftTypeAnswer, req.QuestionID)
 	info, questionInfo, has, err := ac.answerService.Get(ctx, answerID, req.UserID)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
@@ -289,7 +294,7 @@ func (ac *AnswerController) Add(ctx *gin.Context) {
 		return
 	}
 	info.MemberActions = permission.GetAnswerPermission(ctx, req.UserID, info.UserID,
-		0, req.CanEdit, req.CanDelete, false)
+		0, req.CanEdit, req.CanDelete, false, false, false)
 	handler.HandleResponse(ctx, nil, gin.H{
 		"info":     info,
 		"question": questionInfo,
@@ -313,7 +318,7 @@ func (ac *AnswerController) Update(ctx *gin.Context) {
 	}
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 
-	canList, err := ac.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, err := ac.rankService.CheckOperationObjectPermissions(ctx, req.UserID, req.ID, []string{
 		permission.AnswerEdit,
 		permission.AnswerEditWithoutReview,
 		permission.LinkUrlLimit,
@@ -353,6 +358,7 @@ func (ac *AnswerController) Update(ctx *gin.Context) {
 	if !isAdmin || !linkUrlLimitUser {
 		ac.actionService.ActionRecordAdd(ctx, entity.CaptchaActionEdit, req.UserID)
 	}
+	ac.draftService.RemovePublishedDraft(ctx, req.UserID, entity.DraftTypeEdit, req.ID)
 	_, _, _, err = ac.answerService.Get(ctx, req.ID, req.UserID)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
@@ -383,10 +389,12 @@ func (ac *AnswerController) AnswerList(ctx *gin.Context) {
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 	req.QuestionID = uid.DeShortID(req.QuestionID)
 
-	canList, err := ac.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, err := ac.rankService.CheckOperationObjectPermissions(ctx, req.UserID, req.QuestionID, []string{
 		permission.AnswerEdit,
 		permission.AnswerDelete,
 		permission.AnswerUnDelete,
+		permission.WikiEdit,
+		permission.AnswerWiki,
 	})
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
@@ -395,6 +403,8 @@ func (ac *AnswerController) AnswerList(ctx *gin.Context) {
 	req.CanEdit = canList[0]
 	req.CanDelete = canList[1]
 	req.CanRecover = canList[2]
+	req.CanEditWiki = canList[3]
+	req.CanWiki = canList[4]
 
 	list, count, err := ac.answerService.SearchList(ctx, req)
 	if err != nil {
@@ -407,6 +417,39 @@ func (ac *AnswerController) AnswerList(ctx *gin.Context) {
 	})
 }
 
+// UpdateAnswerWiki convert the answer to or from community wiki
+// @Summary convert the answer to or from community wiki
+// @Description convert the answer to or from community wiki, the wiki answer can be edited by anyone
+// @Description who meets the rank of editing wiki and its votes award no reputation
+// @Tags api-answer
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateAnswerWikiReq  true "UpdateAnswerWikiReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/answer/wiki [put]
+func (ac *AnswerController) UpdateAnswerWiki(ctx *gin.Context) {
+	req := &schema.UpdateAnswerWikiReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ID = uid.DeShortID(req.ID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	canList, err := ac.rankService.CheckOperationObjectPermissions(ctx, req.UserID, req.ID, []string{
+		permission.AnswerWiki,
+	})
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !canList[0] {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+	err = ac.answerService.UpdateAnswerWiki(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // Accepted godoc
 // @Summary Accepted
 // @Description Accepted
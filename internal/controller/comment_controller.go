@@ -191,7 +191,7 @@ func (cc *CommentController) UpdateComment(ctx *gin.Context) {
 
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 	req.IsAdmin = middleware.GetIsAdminFromContext(ctx)
-	canList, err := cc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, err := cc.rankService.CheckOperationObjectPermissions(ctx, req.UserID, req.CommentID, []string{
 		permission.CommentEdit,
 		permission.LinkUrlLimit,
 	})
@@ -243,7 +243,7 @@ func (cc *CommentController) GetCommentWithPage(ctx *gin.Context) {
 	}
 	req.ObjectID = uid.DeShortID(req.ObjectID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	canList, err := cc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, err := cc.rankService.CheckOperationObjectPermissions(ctx, req.UserID, req.ObjectID, []string{
 		permission.CommentEdit,
 		permission.CommentDelete,
 	})
@@ -295,7 +295,7 @@ func (cc *CommentController) GetComment(ctx *gin.Context) {
 	}
 
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	canList, err := cc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, err := cc.rankService.CheckOperationObjectPermissions(ctx, req.UserID, req.ID, []string{
 		permission.CommentEdit,
 		permission.CommentDelete,
 	})
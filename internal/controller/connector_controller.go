@@ -20,6 +20,7 @@
 package controller
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -108,7 +109,11 @@ func (cc *ConnectorController) ConnectorLogin(connector plugin.Connector) (fn fu
 
 		receiverURL := fmt.Sprintf("%s%s%s%s", general.SiteUrl,
 			commonRouterPrefix, ConnectorRedirectRouterPrefix, connector.ConnectorSlugName())
-		redirectURL := connector.ConnectorSender(ctx, receiverURL)
+		var redirectURL string
+		_ = plugin.TraceCall(ctx, connector, func(_ context.Context) error {
+			redirectURL = connector.ConnectorSender(ctx, receiverURL)
+			return nil
+		})
 		if len(redirectURL) > 0 {
 			ctx.Redirect(http.StatusFound, redirectURL)
 		}
@@ -125,7 +130,11 @@ func (cc *ConnectorController) ConnectorRedirect(connector plugin.Connector) (fn
 		}
 		receiverURL := fmt.Sprintf("%s%s%s%s", siteGeneral.SiteUrl,
 			commonRouterPrefix, ConnectorRedirectRouterPrefix, connector.ConnectorSlugName())
-		userInfo, err := connector.ConnectorReceiver(ctx, receiverURL)
+		var userInfo plugin.ExternalLoginUserInfo
+		err = plugin.TraceCall(ctx, connector, func(_ context.Context) (err error) {
+			userInfo, err = connector.ConnectorReceiver(ctx, receiverURL)
+			return err
+		})
 		if err != nil {
 			log.Errorf("connector received failed, error info: %v, response data is: %s", err, userInfo.MetaInfo)
 			ctx.Redirect(http.StatusFound, "/50x")
@@ -177,7 +186,7 @@ func (cc *ConnectorController) ConnectorsInfo(ctx *gin.Context) {
 	}
 
 	resp := make([]*schema.ConnectorInfoResp, 0)
-	_ = plugin.CallConnector(func(fn plugin.Connector) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallConnector, func(_ context.Context, fn plugin.Connector) error {
 		connectorName := fn.ConnectorName()
 		resp = append(resp, &schema.ConnectorInfoResp{
 			Name: connectorName.Translate(ctx),
@@ -237,7 +246,7 @@ func (cc *ConnectorController) ConnectorsUserInfo(ctx *gin.Context) {
 	}
 
 	resp := make([]*schema.ConnectorUserInfoResp, 0)
-	_ = plugin.CallConnector(func(fn plugin.Connector) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallConnector, func(_ context.Context, fn plugin.Connector) error {
 		externalID := userExternalLoginMapping[fn.ConnectorSlugName()]
 		connectorName := fn.ConnectorName()
 		resp = append(resp, &schema.ConnectorUserInfoResp{
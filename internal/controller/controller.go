@@ -30,6 +30,7 @@ var ProviderSetController = wire.NewSet(
 	NewTagController,
 	NewFollowController,
 	NewCollectionController,
+	NewDraftController,
 	NewUserController,
 	NewQuestionController,
 	NewAnswerController,
@@ -53,4 +54,7 @@ var ProviderSetController = wire.NewSet(
 	NewEmbedController,
 	NewBadgeController,
 	NewRenderController,
+	NewScimController,
+	NewUserDataController,
+	NewSpaceController,
 )
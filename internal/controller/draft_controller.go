@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/draft"
+	"github.com/gin-gonic/gin"
+)
+
+// DraftController draft controller
+type DraftController struct {
+	draftService *draft.DraftService
+}
+
+// NewDraftController new controller
+func NewDraftController(draftService *draft.DraftService) *DraftController {
+	return &DraftController{draftService: draftService}
+}
+
+// SaveDraft save the draft
+// @Summary save the draft
+// @Description save the draft of the new question, the answer to the question or the edit of the post
+// @Tags Draft
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SaveDraftReq true "draft"
+// @Success 200 {object} handler.RespBody{data=schema.DraftInfo}
+// @Router /answer/api/v1/draft [put]
+func (dc *DraftController) SaveDraft(ctx *gin.Context) {
+	req := &schema.SaveDraftReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := dc.draftService.SaveDraft(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetDraft get the draft
+// @Summary get the draft
+// @Description get the draft of the context, conflict is true if the post has been changed since the draft is started
+// @Tags Draft
+// @Produce json
+// @Security ApiKeyAuth
+// @Param draft_type query string true "draft type" Enums(question, answer, edit)
+// @Param object_id query string false "question id for the answer draft, post id for the edit draft"
+// @Success 200 {object} handler.RespBody{data=schema.DraftInfo}
+// @Router /answer/api/v1/draft [get]
+func (dc *DraftController) GetDraft(ctx *gin.Context) {
+	req := &schema.GetDraftReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := dc.draftService.GetDraft(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RemoveDraft remove the draft
+// @Summary remove the draft
+// @Description remove the draft of the context
+// @Tags Draft
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveDraftReq true "draft"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/draft [delete]
+func (dc *DraftController) RemoveDraft(ctx *gin.Context) {
+	req := &schema.RemoveDraftReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := dc.draftService.RemoveDraft(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetDraftPage get the drafts of the login user
+// @Summary get the drafts of the login user
+// @Description get the drafts of the login user, the latest updated first
+// @Tags Draft
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.DraftInfo}}
+// @Router /answer/api/v1/personal/draft/page [get]
+func (dc *DraftController) GetDraftPage(ctx *gin.Context) {
+	req := &schema.GetDraftPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := dc.draftService.GetDraftPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -20,6 +20,8 @@
 package controller
 
 import (
+	"context"
+
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/gin-gonic/gin"
@@ -43,7 +45,7 @@ func NewEmbedController() *EmbedController {
 func (c *EmbedController) GetEmbedConfig(ctx *gin.Context) {
 	resp := make([]*plugin.EmbedConfig, 0)
 
-	err := plugin.CallEmbed(func(embed plugin.Embed) (err error) {
+	err := plugin.CallWithContext(ctx, plugin.CallEmbed, func(_ context.Context, embed plugin.Embed) (err error) {
 		resp, err = embed.GetEmbedConfigs(ctx)
 		return err
 	})
@@ -20,6 +20,7 @@
 package controller
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/apache/incubator-answer/internal/base/handler"
@@ -44,7 +45,7 @@ type GetCaptchaConfigResp struct {
 // GetCaptchaConfig get captcha config
 func (uc *CaptchaController) GetCaptchaConfig(ctx *gin.Context) {
 	resp := &GetCaptchaConfigResp{}
-	_ = plugin.CallCaptcha(func(fn plugin.Captcha) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallCaptcha, func(_ context.Context, fn plugin.Captcha) error {
 		resp.SlugName = fn.Info().SlugName
 		_ = json.Unmarshal([]byte(fn.GetConfig()), &resp.Config)
 		return nil
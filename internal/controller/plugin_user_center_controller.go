@@ -20,6 +20,7 @@
 package controller
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -76,7 +77,7 @@ func (uc *UserCenterController) UserCenterAgent(ctx *gin.Context) {
 	resp.AgentInfo.SignUpRedirectURL = fmt.Sprintf("%s%s%s", siteGeneral.SiteUrl,
 		commonRouterPrefix, UserCenterSignUpRedirectRouter)
 
-	_ = plugin.CallUserCenter(func(uc plugin.UserCenter) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallUserCenter, func(_ context.Context, uc plugin.UserCenter) error {
 		info := uc.Description()
 		resp.AgentInfo.Name = info.Name
 		resp.AgentInfo.DisplayName = info.DisplayName.Translate(ctx)
@@ -111,7 +112,7 @@ func (uc *UserCenterController) UserCenterPersonalBranding(ctx *gin.Context) {
 
 func (uc *UserCenterController) UserCenterLoginRedirect(ctx *gin.Context) {
 	var redirectURL string
-	_ = plugin.CallUserCenter(func(userCenter plugin.UserCenter) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallUserCenter, func(_ context.Context, userCenter plugin.UserCenter) error {
 		info := userCenter.Description()
 		redirectURL = info.LoginRedirectURL
 		return nil
@@ -121,7 +122,7 @@ func (uc *UserCenterController) UserCenterLoginRedirect(ctx *gin.Context) {
 
 func (uc *UserCenterController) UserCenterSignUpRedirect(ctx *gin.Context) {
 	var redirectURL string
-	_ = plugin.CallUserCenter(func(userCenter plugin.UserCenter) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallUserCenter, func(_ context.Context, userCenter plugin.UserCenter) error {
 		info := userCenter.Description()
 		redirectURL = info.LoginRedirectURL
 		return nil
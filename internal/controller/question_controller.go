@@ -32,7 +32,9 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/action"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/draft"
 	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/question_view"
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -49,6 +51,8 @@ type QuestionController struct {
 	siteInfoService     siteinfo_common.SiteInfoCommonService
 	actionService       *action.CaptchaService
 	rateLimitMiddleware *middleware.RateLimitMiddleware
+	questionViewService *question_view.QuestionViewService
+	draftService        *draft.DraftService
 }
 
 // NewQuestionController new controller
@@ -59,6 +63,8 @@ func NewQuestionController(
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	actionService *action.CaptchaService,
 	rateLimitMiddleware *middleware.RateLimitMiddleware,
+	questionViewService *question_view.QuestionViewService,
+	draftService *draft.DraftService,
 ) *QuestionController {
 	return &QuestionController{
 		questionService:     questionService,
@@ -67,6 +73,8 @@ func NewQuestionController(
 		siteInfoService:     siteInfoService,
 		actionService:       actionService,
 		rateLimitMiddleware: rateLimitMiddleware,
+		questionViewService: questionViewService,
+		draftService:        draftService,
 	}
 }
 
@@ -134,7 +142,7 @@ func (qc *QuestionController) OperationQuestion(ctx *gin.Context) {
 	}
 	req.ID = uid.DeShortID(req.ID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	canList, err := qc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, err := qc.rankService.CheckOperationObjectPermissions(ctx, req.UserID, req.ID, []string{
 		permission.QuestionPin,
 		permission.QuestionUnPin,
 		permission.QuestionHide,
@@ -158,6 +166,39 @@ func (qc *QuestionController) OperationQuestion(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// UpdateQuestionWiki convert the question to or from community wiki
+// @Summary convert the question to or from community wiki
+// @Description convert the question to or from community wiki, the wiki question can be edited by anyone
+// @Description who meets the rank of editing wiki and its votes award no reputation
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateQuestionWikiReq true "question"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/wiki [put]
+func (qc *QuestionController) UpdateQuestionWiki(ctx *gin.Context) {
+	req := &schema.UpdateQuestionWikiReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ID = uid.DeShortID(req.ID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	canList, err := qc.rankService.CheckOperationObjectPermissions(ctx, req.UserID, req.ID, []string{
+		permission.QuestionWiki,
+	})
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !canList[0] {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+	err = qc.questionService.UpdateQuestionWiki(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // CloseQuestion Close question
 // @Summary Close question
 // @Description Close question
@@ -175,12 +216,14 @@ func (qc *QuestionController) CloseQuestion(ctx *gin.Context) {
 	}
 	req.ID = uid.DeShortID(req.ID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	can, err := qc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionClose, "")
+	canList, err := qc.rankService.CheckOperationObjectPermissions(ctx, req.UserID, req.ID, []string{
+		permission.QuestionClose,
+	})
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
-	if !can {
+	if !canList[0] {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
@@ -206,12 +249,14 @@ func (qc *QuestionController) ReopenQuestion(ctx *gin.Context) {
 	}
 	req.QuestionID = uid.DeShortID(req.QuestionID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	can, err := qc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionReopen, "")
+	canList, err := qc.rankService.CheckOperationObjectPermissions(ctx, req.UserID, req.QuestionID, []string{
+		permission.QuestionReopen,
+	})
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
-	if !can {
+	if !canList[0] {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
@@ -235,7 +280,7 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 	id = uid.DeShortID(id)
 	userID := middleware.GetLoginUserIDFromContext(ctx)
 	req := schema.QuestionPermission{}
-	canList, err := qc.rankService.CheckOperationPermissions(ctx, userID, []string{
+	canList, err := qc.rankService.CheckOperationObjectPermissions(ctx, userID, id, []string{
 		permission.QuestionEdit,
 		permission.QuestionDelete,
 		permission.QuestionClose,
@@ -246,6 +291,7 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 		permission.QuestionShow,
 		permission.AnswerInviteSomeoneToAnswer,
 		permission.QuestionUnDelete,
+		permission.QuestionWiki,
 	})
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
@@ -263,8 +309,10 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 	req.CanShow = canList[7]
 	req.CanInviteOtherToAnswer = canList[8]
 	req.CanRecover = canList[9]
+	req.CanWiki = canList[10]
+	req.CanUnWiki = canList[10]
 
-	info, err := qc.questionService.GetQuestionAndAddPV(ctx, id, userID, req)
+	info, err := qc.questionService.GetQuestionAndAddPV(ctx, id, userID, ctx.ClientIP(), ctx.GetHeader("User-Agent"), req)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -275,6 +323,28 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 	handler.HandleResponse(ctx, nil, info)
 }
 
+// GetQuestionViewTrend get question view trend
+// @Summary get the daily views of the question, only the author and admin can see it
+// @Description get the daily views of the question, only the author and admin can see it
+// @Tags Question
+// @Security ApiKeyAuth
+// @Produce json
+// @Param question_id query string true "question id"
+// @Param days query int false "days, default 30, max 365"
+// @Success 200 {object} handler.RespBody{data=schema.GetQuestionViewTrendResp}
+// @Router /answer/api/v1/question/views [get]
+func (qc *QuestionController) GetQuestionViewTrend(ctx *gin.Context) {
+	req := &schema.GetQuestionViewTrendReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
+
+	resp, err := qc.questionViewService.GetQuestionViewTrend(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // GetQuestionInviteUserInfo get question invite user info
 // @Summary get question invite user info
 // @Description get question invite user info
@@ -406,6 +476,7 @@ func (qc *QuestionController) AddQuestion(ctx *gin.Context) {
 		permission.TagUseReservedTag,
 		permission.TagAdd,
 		permission.LinkUrlLimit,
+		permission.QuestionSchedule,
 	})
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
@@ -432,10 +503,15 @@ func (qc *QuestionController) AddQuestion(ctx *gin.Context) {
 	req.CanReopen = canList[4]
 	req.CanUseReservedTag = canList[5]
 	req.CanAddTag = canList[6]
+	req.CanSchedule = canList[8]
 	if !req.CanAdd {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
+	if req.PublishAt > 0 && !req.CanSchedule {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
 
 	// can add tag
 	hasNewTag, err := qc.questionService.HasNewTag(ctx, req.Tags)
@@ -481,6 +557,9 @@ func (qc *QuestionController) AddQuestion(ctx *gin.Context) {
 	if !isAdmin || !linkUrlLimitUser {
 		qc.actionService.ActionRecordAdd(ctx, entity.CaptchaActionQuestion, req.UserID)
 	}
+	if err == nil {
+		qc.draftService.RemovePublishedDraft(ctx, req.UserID, entity.DraftTypeQuestion, "")
+	}
 	handler.HandleResponse(ctx, err, resp)
 }
 
@@ -589,6 +668,7 @@ func (qc *QuestionController) AddQuestionByAnswer(ctx *gin.Context) {
 			handler.HandleResponse(ctx, err, nil)
 			return
 		}
+		qc.draftService.RemovePublishedDraft(ctx, answerReq.UserID, entity.DraftTypeQuestion, "")
 		info, questionInfo, has, err := qc.answerService.Get(ctx, answerID, req.UserID)
 		if err != nil {
 			handler.HandleResponse(ctx, err, nil)
@@ -626,7 +706,7 @@ func (qc *QuestionController) UpdateQuestion(ctx *gin.Context) {
 	}
 	req.ID = uid.DeShortID(req.ID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	canList, requireRanks, err := qc.rankService.CheckOperationPermissionsForRanks(ctx, req.UserID, []string{
+	canList, requireRanks, err := qc.rankService.CheckOperationObjectPermissionsForRanks(ctx, req.UserID, req.ID, []string{
 		permission.QuestionEdit,
 		permission.QuestionDelete,
 		permission.QuestionEditWithoutReview,
@@ -699,6 +779,7 @@ func (qc *QuestionController) UpdateQuestion(ctx *gin.Context) {
 	if !isAdmin || !linkUrlLimitUser {
 		qc.actionService.ActionRecordAdd(ctx, entity.CaptchaActionEdit, req.UserID)
 	}
+	qc.draftService.RemovePublishedDraft(ctx, req.UserID, entity.DraftTypeEdit, req.ID)
 	handler.HandleResponse(ctx, nil, &schema.UpdateQuestionResp{UrlTitle: respInfo.UrlTitle, WaitForReview: !req.NoNeedReview})
 }
 
@@ -720,7 +801,7 @@ func (qc *QuestionController) QuestionRecover(ctx *gin.Context) {
 	req.QuestionID = uid.DeShortID(req.QuestionID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 
-	canList, err := qc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, err := qc.rankService.CheckOperationObjectPermissions(ctx, req.UserID, req.QuestionID, []string{
 		permission.QuestionUnDelete,
 	})
 	if err != nil {
@@ -24,18 +24,21 @@ import (
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/internal/service/reputation"
 	"github.com/gin-gonic/gin"
 )
 
 // RankController rank controller
 type RankController struct {
-	rankService *rank.RankService
+	rankService       *rank.RankService
+	reputationService *reputation.ReputationService
 }
 
 // NewRankController new controller
 func NewRankController(
-	rankService *rank.RankService) *RankController {
-	return &RankController{rankService: rankService}
+	rankService *rank.RankService,
+	reputationService *reputation.ReputationService) *RankController {
+	return &RankController{rankService: rankService, reputationService: reputationService}
 }
 
 // GetRankPersonalWithPage user personal rank list
@@ -59,3 +62,26 @@ func (cc *RankController) GetRankPersonalWithPage(ctx *gin.Context) {
 	resp, err := cc.rankService.GetRankPersonalPage(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
 }
+
+// GetReputationLedger get the reputation ledger of the user
+// @Summary get the reputation ledger of the user
+// @Description get the activities of the user with the points under the current rules, the newest first.
+// @Description If username is empty, get the ledger of the login user.
+// @Tags Rank
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param username query string false "username"
+// @Success 200 {object} handler.RespBody{data=schema.GetReputationLedgerResp}
+// @Router /answer/api/v1/personal/reputation/ledger [get]
+func (cc *RankController) GetReputationLedger(ctx *gin.Context) {
+	req := &schema.GetReputationLedgerReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := cc.reputationService.GetReputationLedger(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
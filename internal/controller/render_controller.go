@@ -20,6 +20,8 @@
 package controller
 
 import (
+	"context"
+
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/gin-gonic/gin"
@@ -43,7 +45,7 @@ func NewRenderController() *RenderController {
 func (c *RenderController) GetRenderConfig(ctx *gin.Context) {
 	var resp *plugin.RenderConfig
 
-	_ = plugin.CallRender(func(render plugin.Render) (err error) {
+	_ = plugin.CallWithContext(ctx, plugin.CallRender, func(_ context.Context, render plugin.Render) (err error) {
 		resp = render.GetRenderConfig(ctx)
 		return nil
 	})
@@ -20,16 +20,16 @@
 package controller
 
 import (
+	"context"
+
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/middleware"
-	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/action"
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/gin-gonic/gin"
-	"github.com/segmentfault/pacman/errors"
 )
 
 // ReviewController review controller
@@ -73,7 +73,7 @@ func (rc *ReviewController) GetUnreviewedPostPage(ctx *gin.Context) {
 	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
 
 	req.ReviewerMapping = make(map[string]string)
-	_ = plugin.CallReviewer(func(base plugin.Reviewer) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallReviewer, func(_ context.Context, base plugin.Reviewer) error {
 		info := base.Info()
 		req.ReviewerMapping[info.SlugName] = info.Name.Translate(ctx)
 		return nil
@@ -102,11 +102,29 @@ func (rc *ReviewController) UpdateReview(ctx *gin.Context) {
 
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
-	if !req.IsAdmin {
-		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+
+	err := rc.reviewService.UpdateReview(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// AddSuspensionAppeal add the appeal of the suspension
+// @Summary add the appeal of the suspension, only one appeal is allowed for each suspension
+// @Description add the appeal of the suspension, only one appeal is allowed for each suspension
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddSuspensionAppealReq true "appeal"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/suspension/appeal [post]
+func (rc *ReviewController) AddSuspensionAppeal(ctx *gin.Context) {
+	req := &schema.AddSuspensionAppealReq{}
+	if handler.BindAndCheck(ctx, req) {
 		return
 	}
 
-	err := rc.reviewService.UpdateReview(ctx, req)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := rc.reviewService.AddSuspensionAppeal(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
@@ -24,8 +24,11 @@ import (
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/base/validator"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/action"
 	"github.com/apache/incubator-answer/internal/service/content"
 	"github.com/apache/incubator-answer/internal/service/permission"
 	"github.com/apache/incubator-answer/internal/service/rank"
@@ -39,16 +42,19 @@ import (
 type RevisionController struct {
 	revisionListService *content.RevisionService
 	rankService         *rank.RankService
+	actionService       *action.CaptchaService
 }
 
 // NewRevisionController new controller
 func NewRevisionController(
 	revisionListService *content.RevisionService,
 	rankService *rank.RankService,
+	actionService *action.CaptchaService,
 ) *RevisionController {
 	return &RevisionController{
 		revisionListService: revisionListService,
 		rankService:         rankService,
+		actionService:       actionService,
 	}
 }
 
@@ -81,6 +87,25 @@ func (rc *RevisionController) GetRevisionList(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, list)
 }
 
+// GetContributors godoc
+// @Summary get the contributors of the question or answer
+// @Description get the users who wrote or edited the question or answer, derived from the revisions
+// @Tags Revision
+// @Produce json
+// @Param object_id query string true "object id"
+// @Success 200 {object} handler.RespBody{data=[]schema.ContributorItem}
+// @Router /answer/api/v1/revisions/contributors [get]
+func (rc *RevisionController) GetContributors(ctx *gin.Context) {
+	req := &schema.GetContributorsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+
+	resp, err := rc.revisionListService.GetContributors(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // GetUnreviewedRevisionList godoc
 // @Summary get unreviewed revision list
 // @Description get unreviewed revision list
@@ -220,3 +245,110 @@ func (rc *RevisionController) GetReviewingType(ctx *gin.Context) {
 	resp, err := rc.revisionListService.GetReviewingType(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
 }
+
+// GetRevisionDiff godoc
+// @Summary get the diff between two revisions of the same object
+// @Description get the diff between two revisions of the same object
+// @Tags Revision
+// @Produce json
+// @Param base_revision_id query string true "base revision id"
+// @Param target_revision_id query string true "target revision id"
+// @Success 200 {object} handler.RespBody{data=schema.GetRevisionDiffResp}
+// @Router /answer/api/v1/revisions/diff [get]
+func (rc *RevisionController) GetRevisionDiff(ctx *gin.Context) {
+	req := &schema.GetRevisionDiffReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := rc.revisionListService.GetRevisionDiff(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RollbackRevision godoc
+// @Summary rollback the object to the revision
+// @Description rollback the object to the revision, the rollback is a new edit and follows the edit review rules
+// @Tags Revision
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RevisionRollbackReq true "rollback request"
+// @Success 200 {object} handler.RespBody{data=schema.RevisionRollbackResp}
+// @Router /answer/api/v1/revisions/rollback [post]
+func (rc *RevisionController) RollbackRevision(ctx *gin.Context) {
+	req := &schema.RevisionRollbackReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	revision, err := rc.revisionListService.GetRollbackRevision(ctx, req.RevisionID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	objectType, err := obj.GetObjectTypeStrByObjectID(revision.ObjectID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	var editPermission, editWithoutReviewPermission string
+	switch objectType {
+	case constant.QuestionObjectType:
+		editPermission, editWithoutReviewPermission = permission.QuestionEdit, permission.QuestionEditWithoutReview
+	case constant.AnswerObjectType:
+		editPermission, editWithoutReviewPermission = permission.AnswerEdit, permission.AnswerEditWithoutReview
+	case constant.TagObjectType:
+		editPermission, editWithoutReviewPermission = permission.TagEdit, permission.TagEditWithoutReview
+	default:
+		handler.HandleResponse(ctx, errors.BadRequest(reason.ObjectNotFound), nil)
+		return
+	}
+
+	canList, err := rc.rankService.CheckOperationObjectPermissions(ctx, req.UserID, revision.ObjectID, []string{
+		editPermission,
+		editWithoutReviewPermission,
+		permission.TagUseReservedTag,
+		permission.TagAdd,
+		permission.LinkUrlLimit,
+	})
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	linkUrlLimitUser := canList[4]
+	isAdmin := middleware.GetUserIsAdminModerator(ctx)
+	if !isAdmin || !linkUrlLimitUser {
+		captchaPass := rc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionEdit, req.UserID, req.CaptchaID, req.CaptchaCode)
+		if !captchaPass {
+			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
+				ErrorField: "captcha_code",
+				ErrorMsg:   translator.Tr(handler.GetLang(ctx), reason.CaptchaVerificationFailed),
+			})
+			handler.HandleResponse(ctx, errors.BadRequest(reason.CaptchaVerificationFailed), errFields)
+			return
+		}
+	}
+
+	objectOwner := false
+	if objectType != constant.TagObjectType {
+		objectOwner = rc.rankService.CheckOperationObjectOwner(ctx, req.UserID, revision.ObjectID)
+	}
+	req.CanEdit = canList[0] || objectOwner
+	req.NoNeedReview = canList[1] || objectOwner
+	req.CanUseReservedTag = canList[2]
+	req.CanAddTag = canList[3]
+	if !req.CanEdit {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	if err = rc.revisionListService.RollbackRevision(ctx, revision, req); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !isAdmin || !linkUrlLimitUser {
+		rc.actionService.ActionRecordAdd(ctx, entity.CaptchaActionEdit, req.UserID)
+	}
+	handler.HandleResponse(ctx, nil, &schema.RevisionRollbackResp{WaitForReview: !req.NoNeedReview})
+}
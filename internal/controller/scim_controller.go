@@ -0,0 +1,297 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/scim"
+	"github.com/gin-gonic/gin"
+	myErrors "github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ScimController scim 2.0 controller
+type ScimController struct {
+	scimService *scim.ScimService
+}
+
+// NewScimController new controller
+func NewScimController(scimService *scim.ScimService) *ScimController {
+	return &ScimController{scimService: scimService}
+}
+
+// GetServiceProviderConfig get service provider config
+// @Summary get scim service provider config
+// @Description get scim service provider config
+// @Tags SCIM
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} schema.ScimServiceProviderConfigResp
+// @Router /scim/v2/ServiceProviderConfig [get]
+func (sc *ScimController) GetServiceProviderConfig(ctx *gin.Context) {
+	handleScimResponse(ctx, nil, http.StatusOK, sc.scimService.GetServiceProviderConfig())
+}
+
+// ListUsers list users
+// @Summary list scim users
+// @Description list scim users, support filter by userName, emails, externalId, active etc.
+// @Tags SCIM
+// @Produce json
+// @Security ApiKeyAuth
+// @Param filter query string false "filter"
+// @Param startIndex query int false "start index, start from 1"
+// @Param count query int false "count"
+// @Success 200 {object} schema.ScimListResp
+// @Router /scim/v2/Users [get]
+func (sc *ScimController) ListUsers(ctx *gin.Context) {
+	req := &schema.ScimListReq{}
+	if bindScimRequest(ctx, req, ctx.ShouldBindQuery) {
+		return
+	}
+	resp, err := sc.scimService.ListUsers(ctx, req)
+	handleScimResponse(ctx, err, http.StatusOK, resp)
+}
+
+// GetUser get user
+// @Summary get scim user
+// @Description get scim user
+// @Tags SCIM
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "user id"
+// @Success 200 {object} schema.ScimUser
+// @Router /scim/v2/Users/{id} [get]
+func (sc *ScimController) GetUser(ctx *gin.Context) {
+	resp, err := sc.scimService.GetUser(ctx, ctx.Param("id"))
+	handleScimResponse(ctx, err, http.StatusOK, resp)
+}
+
+// CreateUser create user
+// @Summary create scim user
+// @Description create scim user
+// @Tags SCIM
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ScimUser true "user"
+// @Success 201 {object} schema.ScimUser
+// @Router /scim/v2/Users [post]
+func (sc *ScimController) CreateUser(ctx *gin.Context) {
+	req := &schema.ScimUser{}
+	if bindScimRequest(ctx, req, ctx.ShouldBindJSON) {
+		return
+	}
+	resp, err := sc.scimService.CreateUser(ctx, req)
+	handleScimResponse(ctx, err, http.StatusCreated, resp)
+}
+
+// ReplaceUser replace user
+// @Summary replace scim user
+// @Description replace scim user
+// @Tags SCIM
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "user id"
+// @Param data body schema.ScimUser true "user"
+// @Success 200 {object} schema.ScimUser
+// @Router /scim/v2/Users/{id} [put]
+func (sc *ScimController) ReplaceUser(ctx *gin.Context) {
+	req := &schema.ScimUser{}
+	if bindScimRequest(ctx, req, ctx.ShouldBindJSON) {
+		return
+	}
+	resp, err := sc.scimService.ReplaceUser(ctx, ctx.Param("id"), req)
+	handleScimResponse(ctx, err, http.StatusOK, resp)
+}
+
+// PatchUser patch user
+// @Summary patch scim user
+// @Description patch scim user
+// @Tags SCIM
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "user id"
+// @Param data body schema.ScimPatchReq true "patch operations"
+// @Success 200 {object} schema.ScimUser
+// @Router /scim/v2/Users/{id} [patch]
+func (sc *ScimController) PatchUser(ctx *gin.Context) {
+	req := &schema.ScimPatchReq{}
+	if bindScimRequest(ctx, req, ctx.ShouldBindJSON) {
+		return
+	}
+	resp, err := sc.scimService.PatchUser(ctx, ctx.Param("id"), req)
+	handleScimResponse(ctx, err, http.StatusOK, resp)
+}
+
+// DeleteUser delete user
+// @Summary delete scim user
+// @Description delete scim user, the user will be marked as deleted
+// @Tags SCIM
+// @Security ApiKeyAuth
+// @Param id path string true "user id"
+// @Success 204
+// @Router /scim/v2/Users/{id} [delete]
+func (sc *ScimController) DeleteUser(ctx *gin.Context) {
+	err := sc.scimService.DeleteUser(ctx, ctx.Param("id"))
+	handleScimResponse(ctx, err, http.StatusNoContent, nil)
+}
+
+// ListGroups list groups
+// @Summary list scim groups
+// @Description list scim groups, the groups are mapping to the roles except the default user role
+// @Tags SCIM
+// @Produce json
+// @Security ApiKeyAuth
+// @Param filter query string false "filter"
+// @Param startIndex query int false "start index, start from 1"
+// @Param count query int false "count"
+// @Param excludedAttributes query string false "excluded attributes, such as members"
+// @Success 200 {object} schema.ScimListResp
+// @Router /scim/v2/Groups [get]
+func (sc *ScimController) ListGroups(ctx *gin.Context) {
+	req := &schema.ScimListReq{}
+	if bindScimRequest(ctx, req, ctx.ShouldBindQuery) {
+		return
+	}
+	resp, err := sc.scimService.ListGroups(ctx, req)
+	handleScimResponse(ctx, err, http.StatusOK, resp)
+}
+
+// GetGroup get group
+// @Summary get scim group
+// @Description get scim group
+// @Tags SCIM
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "group id"
+// @Success 200 {object} schema.ScimGroup
+// @Router /scim/v2/Groups/{id} [get]
+func (sc *ScimController) GetGroup(ctx *gin.Context) {
+	resp, err := sc.scimService.GetGroup(ctx, ctx.Param("id"))
+	handleScimResponse(ctx, err, http.StatusOK, resp)
+}
+
+// ReplaceGroup replace group members
+// @Summary replace scim group
+// @Description replace scim group members, the display name can not be changed
+// @Tags SCIM
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "group id"
+// @Param data body schema.ScimGroup true "group"
+// @Success 200 {object} schema.ScimGroup
+// @Router /scim/v2/Groups/{id} [put]
+func (sc *ScimController) ReplaceGroup(ctx *gin.Context) {
+	req := &schema.ScimGroup{}
+	if bindScimRequest(ctx, req, ctx.ShouldBindJSON) {
+		return
+	}
+	resp, err := sc.scimService.ReplaceGroup(ctx, ctx.Param("id"), req)
+	handleScimResponse(ctx, err, http.StatusOK, resp)
+}
+
+// PatchGroup patch group members
+// @Summary patch scim group
+// @Description add or remove scim group members
+// @Tags SCIM
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "group id"
+// @Param data body schema.ScimPatchReq true "patch operations"
+// @Success 200 {object} schema.ScimGroup
+// @Router /scim/v2/Groups/{id} [patch]
+func (sc *ScimController) PatchGroup(ctx *gin.Context) {
+	req := &schema.ScimPatchReq{}
+	if bindScimRequest(ctx, req, ctx.ShouldBindJSON) {
+		return
+	}
+	resp, err := sc.scimService.PatchGroup(ctx, ctx.Param("id"), req)
+	handleScimResponse(ctx, err, http.StatusOK, resp)
+}
+
+// GroupImmutable groups are mapping to the built-in roles, so they can not be created or deleted
+// @Summary create or delete scim group
+// @Description groups can not be created or deleted
+// @Tags SCIM
+// @Security ApiKeyAuth
+// @Failure 400 {object} schema.ScimErrorResp
+// @Router /scim/v2/Groups [post]
+func (sc *ScimController) GroupImmutable(ctx *gin.Context) {
+	handleScimResponse(ctx, myErrors.BadRequest(reason.ScimGroupImmutable), http.StatusOK, nil)
+}
+
+func bindScimRequest(ctx *gin.Context, req interface{}, bind func(obj any) error) bool {
+	if err := bind(req); err != nil {
+		log.Errorf("scim bind request fail, %s", err.Error())
+		handleScimResponse(ctx, myErrors.BadRequest(reason.ScimInvalidValue), http.StatusOK, nil)
+		return true
+	}
+	return false
+}
+
+// handleScimResponse the scim response must follow the RFC 7644, so the common response body can not be used
+func handleScimResponse(ctx *gin.Context, err error, status int, data interface{}) {
+	ctx.Header("Content-Type", schema.ScimContentType)
+	if err == nil {
+		if data == nil {
+			ctx.Status(status)
+			return
+		}
+		ctx.JSON(status, data)
+		return
+	}
+
+	resp := &schema.ScimErrorResp{Schemas: []string{schema.ScimSchemaError}}
+	var myErr *myErrors.Error
+	if !errors.As(err, &myErr) {
+		log.Error(err)
+		myErr = myErrors.InternalServer(reason.UnknownError)
+	}
+	if myErrors.IsInternalServer(myErr) {
+		log.Error(myErr)
+	}
+	switch myErr.Reason {
+	case reason.EmailDuplicate, reason.UsernameDuplicate:
+		resp.ScimType = "uniqueness"
+	case reason.ScimInvalidFilter:
+		resp.ScimType = "invalidFilter"
+	case reason.ScimInvalidValue:
+		resp.ScimType = "invalidValue"
+	case reason.ScimGroupImmutable:
+		resp.ScimType = "mutability"
+	}
+	resp.Detail = translator.Tr(handler.GetLang(ctx), myErr.Reason)
+	if len(myErr.Message) > 0 {
+		resp.Detail = myErr.Message
+	}
+	resp.Status = strconv.Itoa(myErr.Code)
+	ctx.JSON(myErr.Code, resp)
+}
@@ -20,6 +20,8 @@
 package controller
 
 import (
+	"context"
+
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/base/reason"
@@ -101,7 +103,7 @@ func (sc *SearchController) Search(ctx *gin.Context) {
 // @Router /answer/api/v1/search/desc [get]
 func (sc *SearchController) SearchDesc(ctx *gin.Context) {
 	var finder plugin.Search
-	_ = plugin.CallSearch(func(search plugin.Search) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallSearch, func(_ context.Context, search plugin.Search) error {
 		finder = search
 		return nil
 	})
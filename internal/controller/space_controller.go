@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/service/space"
+	"github.com/gin-gonic/gin"
+)
+
+// SpaceController space controller
+type SpaceController struct {
+	spaceService *space.SpaceService
+}
+
+// NewSpaceController new controller
+func NewSpaceController(spaceService *space.SpaceService) *SpaceController {
+	return &SpaceController{spaceService: spaceService}
+}
+
+// GetSpaceList get the spaces that login user can see
+// @Summary get the spaces that login user can see
+// @Description get the spaces that login user can see, the hidden spaces are only visible to members
+// @Tags Space
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetSpaceResp}
+// @Router /answer/api/v1/spaces [get]
+func (sc *SpaceController) GetSpaceList(ctx *gin.Context) {
+	resp, err := sc.spaceService.GetSpaceList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
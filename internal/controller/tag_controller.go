@@ -28,15 +28,17 @@ import (
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/internal/service/tag"
 	"github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/internal/service/tag_moderator"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
 )
 
 // TagController tag controller
 type TagController struct {
-	tagService       *tag.TagService
-	tagCommonService *tag_common.TagCommonService
-	rankService      *rank.RankService
+	tagService          *tag.TagService
+	tagCommonService    *tag_common.TagCommonService
+	rankService         *rank.RankService
+	tagModeratorService *tag_moderator.TagModeratorService
 }
 
 // NewTagController new controller
@@ -44,8 +46,14 @@ func NewTagController(
 	tagService *tag.TagService,
 	tagCommonService *tag_common.TagCommonService,
 	rankService *rank.RankService,
+	tagModeratorService *tag_moderator.TagModeratorService,
 ) *TagController {
-	return &TagController{tagService: tagService, tagCommonService: tagCommonService, rankService: rankService}
+	return &TagController{
+		tagService:          tagService,
+		tagCommonService:    tagCommonService,
+		rankService:         rankService,
+		tagModeratorService: tagModeratorService,
+	}
 }
 
 // SearchTagLike get tag list
@@ -341,3 +349,81 @@ func (tc *TagController) UpdateTagSynonym(ctx *gin.Context) {
 	err = tc.tagService.UpdateTagSynonym(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// GetTagModerators get tag moderators
+// @Summary get tag moderators
+// @Description get tag moderators, the moderators of main tag are returned for the synonym
+// @Tags Tag
+// @Produce json
+// @Param tag_id query string true "tag id"
+// @Success 200 {object} handler.RespBody{data=[]schema.GetTagModeratorResp}
+// @Router /answer/api/v1/tag/moderators [get]
+func (tc *TagController) GetTagModerators(ctx *gin.Context) {
+	req := &schema.GetTagModeratorsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := tc.tagModeratorService.GetTagModerators(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddTagModerator add tag moderator
+// @Summary add tag moderator
+// @Description add tag moderator, only admin or moderator can do this
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddTagModeratorReq true "tag moderator"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/tag/moderator [post]
+func (tc *TagController) AddTagModerator(ctx *gin.Context) {
+	req := &schema.AddTagModeratorReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+
+	err := tc.tagModeratorService.AddTagModerator(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveTagModerator remove tag moderator
+// @Summary remove tag moderator
+// @Description remove tag moderator, only admin or moderator can do this
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveTagModeratorReq true "tag moderator"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/tag/moderator [delete]
+func (tc *TagController) RemoveTagModerator(ctx *gin.Context) {
+	req := &schema.RemoveTagModeratorReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+
+	err := tc.tagModeratorService.RemoveTagModerator(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetTagCategoryList get all tag categories
+// @Summary get all tag categories
+// @Description get all tag categories for grouping the tags on the tags page
+// @Tags Tag
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetTagCategoryResp}
+// @Router /answer/api/v1/tag/categories [get]
+func (tc *TagController) GetTagCategoryList(ctx *gin.Context) {
+	resp, err := tc.tagService.GetTagCategoryList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -20,6 +20,7 @@
 package controller
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/apache/incubator-answer/internal/service/content"
@@ -34,11 +35,13 @@ import (
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	templaterender "github.com/apache/incubator-answer/internal/controller/template_render"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/space"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/pkg/htmltext"
@@ -58,6 +61,7 @@ type TemplateController struct {
 	siteInfoService          siteinfo_common.SiteInfoCommonService
 	eventQueueService        event_queue.EventQueueService
 	userService              *content.UserService
+	spaceService             *space.SpaceService
 }
 
 // NewTemplateController new controller
@@ -66,6 +70,7 @@ func NewTemplateController(
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	eventQueueService event_queue.EventQueueService,
 	userService *content.UserService,
+	spaceService *space.SpaceService,
 ) *TemplateController {
 	script, css := GetStyle()
 	return &TemplateController{
@@ -75,6 +80,7 @@ func NewTemplateController(
 		siteInfoService:          siteInfoService,
 		eventQueueService:        eventQueueService,
 		userService:              userService,
+		spaceService:             spaceService,
 	}
 }
 func GetStyle() (script []string, css string) {
@@ -295,6 +301,13 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 
 	correctTitle := false
 
+	// the question in restricted space can not be rendered without login user,
+	// so leave it to the front-end which will request with the user token.
+	if err := tc.spaceService.CheckQuestionAccess(ctx, id); err != nil {
+		middleware.ShowIndexPage(ctx)
+		return
+	}
+
 	detail, err := tc.templateRenderController.QuestionDetail(ctx, id)
 	if err != nil {
 		tc.Page404(ctx)
@@ -528,7 +541,7 @@ func (tc *TemplateController) html(ctx *gin.Context, code int, tpl string, siteI
 		scriptPath = make([]string, len(tc.scriptPath))
 	)
 
-	_ = plugin.CallCDN(func(fn plugin.CDN) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallCDN, func(_ context.Context, fn plugin.CDN) error {
 		prefix = fn.GetStaticPrefix()
 		return nil
 	})
@@ -0,0 +1,139 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/user_data"
+	"github.com/gin-gonic/gin"
+)
+
+// UserDataController user personal data controller
+type UserDataController struct {
+	userDataService *user_data.UserDataService
+}
+
+// NewUserDataController new controller
+func NewUserDataController(userDataService *user_data.UserDataService) *UserDataController {
+	return &UserDataController{userDataService: userDataService}
+}
+
+// RequestExport request to export personal data
+// @Summary request to export personal data
+// @Description request to export personal data, the export file will be generated asynchronously
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.UserDataExportResp}
+// @Router /answer/api/v1/user/data/export [post]
+func (uc *UserDataController) RequestExport(ctx *gin.Context) {
+	req := &schema.UserDataExportReq{}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userDataService.RequestExport(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetExport get the latest personal data export
+// @Summary get the latest personal data export
+// @Description get the latest personal data export, the download url is available when the export is completed
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.UserDataExportResp}
+// @Router /answer/api/v1/user/data/export [get]
+func (uc *UserDataController) GetExport(ctx *gin.Context) {
+	req := &schema.GetUserDataExportReq{}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userDataService.GetExport(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// DownloadExport download personal data export file
+// @Summary download personal data export file
+// @Description download personal data export file by the token in download url
+// @Tags User
+// @Produce application/zip
+// @Param token query string true "download token"
+// @Success 200 {file} file
+// @Router /answer/api/v1/user/data/export/download [get]
+func (uc *UserDataController) DownloadExport(ctx *gin.Context) {
+	req := &schema.DownloadUserDataExportReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	filePath, err := uc.userDataService.GetExportFilePath(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.FileAttachment(filePath, "personal-data.zip")
+}
+
+// RequestDeletion request to delete account
+// @Summary request to delete account
+// @Description request to delete account, the account will be deleted after the grace period
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UserDeletionReq true "deletion request"
+// @Success 200 {object} handler.RespBody{data=schema.UserDeletionResp}
+// @Router /answer/api/v1/user/deletion [post]
+func (uc *UserDataController) RequestDeletion(ctx *gin.Context) {
+	req := &schema.UserDeletionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userDataService.RequestDeletion(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetDeletion get pending deletion request
+// @Summary get pending deletion request
+// @Description get pending deletion request, return null if there is no pending request
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.UserDeletionResp}
+// @Router /answer/api/v1/user/deletion [get]
+func (uc *UserDataController) GetDeletion(ctx *gin.Context) {
+	req := &schema.GetUserDeletionReq{}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userDataService.GetDeletion(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// CancelDeletion cancel pending deletion request
+// @Summary cancel pending deletion request
+// @Description cancel pending deletion request
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/deletion [delete]
+func (uc *UserDataController) CancelDeletion(ctx *gin.Context) {
+	req := &schema.CancelUserDeletionReq{}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := uc.userDataService.CancelDeletion(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/analytics"
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsController analytics controller
+type AnalyticsController struct {
+	analyticsService *analytics.AnalyticsService
+}
+
+// NewAnalyticsController new controller
+func NewAnalyticsController(analyticsService *analytics.AnalyticsService) *AnalyticsController {
+	return &AnalyticsController{analyticsService: analyticsService}
+}
+
+// GetAnalytics get analytics time series
+// @Summary get the time series of the community statistics
+// @Description get the time series of the community statistics of the whole site or a tag
+// @Tags admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param start_date query string false "start date, format: 2006-01-02"
+// @Param end_date query string false "end date, format: 2006-01-02"
+// @Param granularity query string false "granularity" Enums(day, week, month)
+// @Param tag_id query string false "tag id"
+// @Success 200 {object} handler.RespBody{data=schema.GetAnalyticsResp}
+// @Router /answer/admin/api/analytics [get]
+func (ac *AnalyticsController) GetAnalytics(ctx *gin.Context) {
+	req := &schema.GetAnalyticsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ac.analyticsService.GetAnalytics(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ExportAnalytics export analytics time series
+// @Summary export the time series of the community statistics as csv
+// @Description export the time series of the community statistics of the whole site or a tag as csv
+// @Tags admin
+// @Security ApiKeyAuth
+// @Produce text/csv
+// @Param start_date query string false "start date, format: 2006-01-02"
+// @Param end_date query string false "end date, format: 2006-01-02"
+// @Param granularity query string false "granularity" Enums(day, week, month)
+// @Param tag_id query string false "tag id"
+// @Success 200 {file} file
+// @Router /answer/admin/api/analytics/export [get]
+func (ac *AnalyticsController) ExportAnalytics(ctx *gin.Context) {
+	req := &schema.GetAnalyticsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	content, err := ac.analyticsService.ExportAnalyticsCSV(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="analytics-%s.csv"`, req.Granularity))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", content)
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/reputation"
+	"github.com/gin-gonic/gin"
+)
+
+// ReputationController reputation controller
+type ReputationController struct {
+	reputationService *reputation.ReputationService
+}
+
+// NewReputationController new controller
+func NewReputationController(reputationService *reputation.ReputationService) *ReputationController {
+	return &ReputationController{reputationService: reputationService}
+}
+
+// RecomputeReputation recompute the reputation of users
+// @Summary recompute the reputation of users
+// @Description replay the activities of the user or the users of the page under the current rules and report the drift,
+// @Description the corrections are applied as adjustment activities if apply is true
+// @Tags admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.RecomputeReputationReq true "recompute reputation"
+// @Success 200 {object} handler.RespBody{data=schema.RecomputeReputationResp}
+// @Router /answer/admin/api/reputation/recompute [post]
+func (rc *ReputationController) RecomputeReputation(ctx *gin.Context) {
+	req := &schema.RecomputeReputationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := rc.reputationService.RecomputeReputation(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetReputationLedger get the reputation ledger of the user
+// @Summary get the reputation ledger of the user
+// @Description get the activities of the user with the points under the current rules, the newest first
+// @Tags admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user_id query string false "user id"
+// @Param username query string false "username"
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=schema.GetReputationLedgerResp}
+// @Router /answer/admin/api/reputation/ledger [get]
+func (rc *ReputationController) GetReputationLedger(ctx *gin.Context) {
+	req := &schema.GetReputationLedgerReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := rc.reputationService.GetReputationLedger(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/action"
 	"github.com/apache/incubator-answer/internal/service/siteinfo"
 	"github.com/gin-gonic/gin"
 )
@@ -33,12 +34,17 @@ import (
 // SiteInfoController site info controller
 type SiteInfoController struct {
 	siteInfoService *siteinfo.SiteInfoService
+	captchaService  *action.CaptchaService
 }
 
 // NewSiteInfoController new site info controller
-func NewSiteInfoController(siteInfoService *siteinfo.SiteInfoService) *SiteInfoController {
+func NewSiteInfoController(
+	siteInfoService *siteinfo.SiteInfoService,
+	captchaService *action.CaptchaService,
+) *SiteInfoController {
 	return &SiteInfoController{
 		siteInfoService: siteInfoService,
+		captchaService:  captchaService,
 	}
 }
 
@@ -205,6 +211,18 @@ func (sc *SiteInfoController) GetCss(ctx *gin.Context) {
 	ctx.String(http.StatusOK, resp.CustomCss)
 }
 
+// GetCodeHighlightCss get the css of code highlight theme
+// @Summary get the css of code highlight theme
+// @Description get the css of code highlight theme that is set in site interface
+// @Tags site
+// @Produce text/css
+// @Success 200 {string} css ""
+// @Router /code-highlight.css [get]
+func (sc *SiteInfoController) GetCodeHighlightCss(ctx *gin.Context) {
+	ctx.Header("content-type", "text/css;charset=utf-8")
+	ctx.String(http.StatusOK, sc.siteInfoService.GetCodeHighlightCss(ctx))
+}
+
 // UpdateSeo update site seo information
 // @Summary update site seo information
 // @Description update site seo information
@@ -388,6 +406,86 @@ func (sc *SiteInfoController) UpdateSiteUsers(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// GetSiteScim get site scim provisioning config
+// @Summary get site scim provisioning config
+// @Description get site scim provisioning config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteScimResp}
+// @Router /answer/admin/api/siteinfo/scim [get]
+func (sc *SiteInfoController) GetSiteScim(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteScim(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteScim update site scim provisioning config
+// @Summary update site scim provisioning config
+// @Description update site scim provisioning config, the bearer token will be generated if it does not exist
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteScimReq true "scim config"
+// @Success 200 {object} handler.RespBody{data=schema.SiteScimResp}
+// @Router /answer/admin/api/siteinfo/scim [put]
+func (sc *SiteInfoController) UpdateSiteScim(ctx *gin.Context) {
+	req := &schema.SiteScimReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := sc.siteInfoService.SaveSiteScim(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetSiteCaptcha get site captcha thresholds
+// @Summary get site captcha thresholds
+// @Description get the thresholds that decide when the captcha is required for each action
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteCaptchaResp}
+// @Router /answer/admin/api/siteinfo/captcha [get]
+func (sc *SiteInfoController) GetSiteCaptcha(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteCaptcha(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteCaptcha update site captcha thresholds
+// @Summary update site captcha thresholds
+// @Description update site captcha thresholds, the actions not in the list use the default thresholds
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteCaptchaReq true "captcha thresholds"
+// @Success 200 {object} handler.RespBody{data=schema.SiteCaptchaResp}
+// @Router /answer/admin/api/siteinfo/captcha [put]
+func (sc *SiteInfoController) UpdateSiteCaptcha(ctx *gin.Context) {
+	req := &schema.SiteCaptchaReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := sc.siteInfoService.SaveSiteCaptcha(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetCaptchaStat get captcha statistics
+// @Summary get captcha statistics
+// @Description get how often the captcha of each action is required and failed in the recent days
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param days query int false "recent days, default 7"
+// @Success 200 {object} handler.RespBody{data=schema.GetCaptchaStatResp}
+// @Router /answer/admin/api/captcha/stat [get]
+func (sc *SiteInfoController) GetCaptchaStat(ctx *gin.Context) {
+	req := &schema.GetCaptchaStatReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := sc.captchaService.GetCaptchaStat(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // GetSMTPConfig get smtp config
 // @Summary GetSMTPConfig get smtp config
 // @Description GetSMTPConfig get smtp config
@@ -0,0 +1,163 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/space"
+	"github.com/gin-gonic/gin"
+)
+
+// SpaceController space controller
+type SpaceController struct {
+	spaceService *space.SpaceService
+}
+
+// NewSpaceController new controller
+func NewSpaceController(spaceService *space.SpaceService) *SpaceController {
+	return &SpaceController{spaceService: spaceService}
+}
+
+// GetSpaceList get all spaces
+// @Summary get all spaces
+// @Description get all spaces
+// @Tags admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetSpaceResp}
+// @Router /answer/admin/api/spaces [get]
+func (sc *SpaceController) GetSpaceList(ctx *gin.Context) {
+	resp, err := sc.spaceService.GetAdminSpaceList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddSpace add space
+// @Summary add space
+// @Description add space
+// @Tags admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.AddSpaceReq true "space"
+// @Success 200 {object} handler.RespBody{data=schema.AddSpaceResp}
+// @Router /answer/admin/api/space [post]
+func (sc *SpaceController) AddSpace(ctx *gin.Context) {
+	req := &schema.AddSpaceReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := sc.spaceService.AddSpace(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSpace update space
+// @Summary update space
+// @Description update space
+// @Tags admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateSpaceReq true "space"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/space [put]
+func (sc *SpaceController) UpdateSpace(ctx *gin.Context) {
+	req := &schema.UpdateSpaceReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.spaceService.UpdateSpace(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveSpace remove space
+// @Summary remove space
+// @Description remove space, only the space without any questions can be removed
+// @Tags admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveSpaceReq true "space"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/space [delete]
+func (sc *SpaceController) RemoveSpace(ctx *gin.Context) {
+	req := &schema.RemoveSpaceReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.spaceService.RemoveSpace(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSpaceMembers get space members
+// @Summary get space members
+// @Description get space members
+// @Tags admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param space_id query string true "space id"
+// @Success 200 {object} handler.RespBody{data=[]schema.GetSpaceMemberResp}
+// @Router /answer/admin/api/space/members [get]
+func (sc *SpaceController) GetSpaceMembers(ctx *gin.Context) {
+	req := &schema.GetSpaceMembersReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := sc.spaceService.GetSpaceMembers(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// SaveSpaceMember add or update space member
+// @Summary add or update space member
+// @Description add or update space member, the member can be a user or a role
+// @Tags admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.SpaceMemberReq true "member"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/space/member [put]
+func (sc *SpaceController) SaveSpaceMember(ctx *gin.Context) {
+	req := &schema.SpaceMemberReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.spaceService.SaveSpaceMember(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveSpaceMember remove space member
+// @Summary remove space member
+// @Description remove space member
+// @Tags admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveSpaceMemberReq true "member"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/space/member [delete]
+func (sc *SpaceController) RemoveSpaceMember(ctx *gin.Context) {
+	req := &schema.RemoveSpaceMemberReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.spaceService.RemoveSpaceMember(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
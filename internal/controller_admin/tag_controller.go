@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/tag"
+	"github.com/gin-gonic/gin"
+)
+
+// TagController tag hierarchy and category controller
+type TagController struct {
+	tagService *tag.TagService
+}
+
+// NewTagController new controller
+func NewTagController(tagService *tag.TagService) *TagController {
+	return &TagController{tagService: tagService}
+}
+
+// UpdateTagHierarchy update the parent tag and the category of the tag
+// @Summary update the parent tag and the category of the tag
+// @Description update the parent tag and the category of the tag, the change is recorded as a tag revision
+// @Tags admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateTagHierarchyReq true "tag hierarchy"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/hierarchy [put]
+func (tc *TagController) UpdateTagHierarchy(ctx *gin.Context) {
+	req := &schema.UpdateTagHierarchyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := tc.tagService.UpdateTagHierarchy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetTagCategoryList get all tag categories
+// @Summary get all tag categories
+// @Description get all tag categories
+// @Tags admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetTagCategoryResp}
+// @Router /answer/admin/api/tag/categories [get]
+func (tc *TagController) GetTagCategoryList(ctx *gin.Context) {
+	resp, err := tc.tagService.GetTagCategoryList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddTagCategory add tag category
+// @Summary add tag category
+// @Description add tag category
+// @Tags admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.AddTagCategoryReq true "tag category"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/category [post]
+func (tc *TagController) AddTagCategory(ctx *gin.Context) {
+	req := &schema.AddTagCategoryReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := tc.tagService.AddTagCategory(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UpdateTagCategory update tag category
+// @Summary update tag category
+// @Description update tag category
+// @Tags admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateTagCategoryReq true "tag category"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/category [put]
+func (tc *TagController) UpdateTagCategory(ctx *gin.Context) {
+	req := &schema.UpdateTagCategoryReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := tc.tagService.UpdateTagCategory(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveTagCategory remove tag category
+// @Summary remove tag category
+// @Description remove tag category, the tags in it become uncategorized
+// @Tags admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveTagCategoryReq true "tag category"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/category [delete]
+func (tc *TagController) RemoveTagCategory(ctx *gin.Context) {
+	req := &schema.RemoveTagCategoryReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := tc.tagService.RemoveTagCategory(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/tag_moderator"
+	"github.com/gin-gonic/gin"
+)
+
+// TagModeratorController tag moderator controller
+type TagModeratorController struct {
+	tagModeratorService *tag_moderator.TagModeratorService
+}
+
+// NewTagModeratorController new controller
+func NewTagModeratorController(tagModeratorService *tag_moderator.TagModeratorService) *TagModeratorController {
+	return &TagModeratorController{tagModeratorService: tagModeratorService}
+}
+
+// GetTagModerators get tag moderators
+// @Summary get tag moderators
+// @Description get tag moderators
+// @Tags admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param tag_id query string true "tag id"
+// @Success 200 {object} handler.RespBody{data=[]schema.GetTagModeratorResp}
+// @Router /answer/admin/api/tag/moderators [get]
+func (tc *TagModeratorController) GetTagModerators(ctx *gin.Context) {
+	req := &schema.GetTagModeratorsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := tc.tagModeratorService.GetTagModerators(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddTagModerator add tag moderator
+// @Summary add tag moderator
+// @Description add tag moderator, the moderator of synonym is added to its main tag
+// @Tags admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.AddTagModeratorReq true "tag moderator"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/moderator [post]
+func (tc *TagModeratorController) AddTagModerator(ctx *gin.Context) {
+	req := &schema.AddTagModeratorReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := tc.tagModeratorService.AddTagModerator(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveTagModerator remove tag moderator
+// @Summary remove tag moderator
+// @Description remove tag moderator
+// @Tags admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveTagModeratorReq true "tag moderator"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/moderator [delete]
+func (tc *TagModeratorController) RemoveTagModerator(ctx *gin.Context) {
+	req := &schema.RemoveTagModeratorReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := tc.tagModeratorService.RemoveTagModerator(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -67,6 +67,25 @@ func (uc *UserAdminController) UpdateUserStatus(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// GetUserSuspensionHistory get the suspension history of the user
+// @Summary get the suspension history of the user
+// @Description get the suspension history of the user, the latest suspension first
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param user_id query string true "user id"
+// @Success 200 {object} handler.RespBody{data=[]schema.UserSuspensionHistoryItem}
+// @Router /answer/admin/api/user/suspension/history [get]
+func (uc *UserAdminController) GetUserSuspensionHistory(ctx *gin.Context) {
+	req := &schema.GetUserSuspensionHistoryReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := uc.userService.GetUserSuspensionHistory(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // UpdateUserRole update user role
 // @Summary update user role
 // @Description update user role
@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// AnalyticsDaily community statistics of one day, the tag id is 0 for the whole site
+type AnalyticsDaily struct {
+	ID                       int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt                time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt                time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	StatDate                 string    `xorm:"not null default '' VARCHAR(10) UNIQUE(analytics_day) stat_date"`
+	TagID                    string    `xorm:"not null default 0 BIGINT(20) UNIQUE(analytics_day) tag_id"`
+	QuestionCount            int       `xorm:"not null default 0 INT(11) question_count"`
+	AnswerCount              int       `xorm:"not null default 0 INT(11) answer_count"`
+	CommentCount             int       `xorm:"not null default 0 INT(11) comment_count"`
+	UserCount                int       `xorm:"not null default 0 INT(11) user_count"`
+	ActiveUserCount          int       `xorm:"not null default 0 INT(11) active_user_count"`
+	VoteCount                int       `xorm:"not null default 0 INT(11) vote_count"`
+	AcceptedCount            int       `xorm:"not null default 0 INT(11) accepted_count"`
+	UnansweredCount          int       `xorm:"not null default 0 INT(11) unanswered_count"`
+	FirstAnsweredCount       int       `xorm:"not null default 0 INT(11) first_answered_count"`
+	MedianFirstAnswerSeconds int       `xorm:"not null default 0 INT(11) median_first_answer_seconds"`
+}
+
+// TableName analytics daily table name
+func (AnalyticsDaily) TableName() string {
+	return "analytics_daily"
+}
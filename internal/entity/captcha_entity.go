@@ -19,6 +19,8 @@
 
 package entity
 
+import "time"
+
 const (
 	CaptchaActionEmail            = "email"
 	CaptchaActionPassword         = "password"
@@ -39,3 +41,19 @@ type ActionRecordInfo struct {
 	Num      int    `json:"num"`
 	Config   string `json:"config"`
 }
+
+// CaptchaStatDaily how often the captcha of the action is required and failed in one day
+type CaptchaStatDaily struct {
+	ID             int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt      time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt      time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	Action         string    `xorm:"not null default '' VARCHAR(50) UNIQUE(captcha_action_day) action"`
+	StatDate       string    `xorm:"not null default '' VARCHAR(10) UNIQUE(captcha_action_day) stat_date"`
+	TriggeredCount int       `xorm:"not null default 0 INT(11) triggered_count"`
+	FailedCount    int       `xorm:"not null default 0 INT(11) failed_count"`
+}
+
+// TableName captcha stat daily table name
+func (CaptchaStatDaily) TableName() string {
+	return "captcha_stat_daily"
+}
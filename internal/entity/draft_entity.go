@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	// DraftTypeQuestion the draft of a new question, the object id is 0
+	DraftTypeQuestion = "question"
+	// DraftTypeAnswer the draft of a new answer, the object id is the question id
+	DraftTypeAnswer = "answer"
+	// DraftTypeEdit the draft of the edit, the object id is the question or answer id
+	DraftTypeEdit = "edit"
+)
+
+// Draft the unpublished content of the user, one draft for each user and context
+type Draft struct {
+	ID           string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt    time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt    time.Time `xorm:"updated index TIMESTAMP updated_at"`
+	UserID       string    `xorm:"not null default 0 unique(draft_context) BIGINT(20) user_id"`
+	DraftType    string    `xorm:"not null default '' unique(draft_context) VARCHAR(20) draft_type"`
+	ObjectID     string    `xorm:"not null default 0 unique(draft_context) BIGINT(20) object_id"`
+	Title        string    `xorm:"not null default '' VARCHAR(150) title"`
+	OriginalText string    `xorm:"not null MEDIUMTEXT original_text"`
+	Tags         string    `xorm:"TEXT tags"`
+	// BaseRevisionID the last revision of the object when the draft is started, it is used to detect the conflict
+	BaseRevisionID string `xorm:"not null default 0 BIGINT(20) base_revision_id"`
+}
+
+// TableName draft table name
+func (Draft) TableName() string {
+	return "draft"
+}
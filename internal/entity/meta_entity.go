@@ -24,9 +24,11 @@ import "time"
 const (
 	QuestionEditSummaryKey = "question.edit.summary"
 	QuestionCloseReasonKey = "question.close.reason"
-	AnswerEditSummaryKey   = "answer.edit.summary"
-	TagEditSummaryKey      = "tag.edit.summary"
-	ObjectReactSummaryKey  = "object.react.summary"
+	// QuestionCloseUserKey the user who schedules the question to be closed
+	QuestionCloseUserKey  = "question.close.user"
+	AnswerEditSummaryKey  = "answer.edit.summary"
+	TagEditSummaryKey     = "tag.edit.summary"
+	ObjectReactSummaryKey = "object.react.summary"
 )
 
 // Meta meta
@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	NotificationDeliveryStatusPending = 1
+	NotificationDeliveryStatusSent    = 2
+	NotificationDeliveryStatusFailed  = 3
+	NotificationDeliveryStatusSkipped = 4
+)
+
+// NotificationDelivery the delivery of the notification through one channel
+type NotificationDelivery struct {
+	ID             string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt      time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt      time.Time `xorm:"updated TIMESTAMP updated_at"`
+	NotificationID string    `xorm:"not null default 0 index BIGINT(20) notification_id"`
+	UserID         string    `xorm:"not null default 0 index BIGINT(20) user_id"`
+	Source         string    `xorm:"not null default '' VARCHAR(64) source"`
+	Channel        string    `xorm:"not null default '' VARCHAR(128) channel"`
+	Status         int       `xorm:"not null default 1 index INT(11) status"`
+	Attempts       int       `xorm:"not null default 0 INT(11) attempts"`
+	Message        string    `xorm:"not null TEXT message"`
+	ErrorMsg       string    `xorm:"not null default '' VARCHAR(500) error_msg"`
+	ScheduledAt    time.Time `xorm:"index TIMESTAMP scheduled_at"`
+	DeliveredAt    time.Time `xorm:"TIMESTAMP delivered_at"`
+}
+
+// TableName notification delivery table name
+func (NotificationDelivery) TableName() string {
+	return "notification_delivery"
+}
@@ -28,6 +28,7 @@ const (
 	QuestionStatusClosed    = 2
 	QuestionStatusDeleted   = 10
 	QuestionStatusPending   = 11
+	QuestionStatusScheduled = 12
 	QuestionUnPin           = 1
 	QuestionPin             = 2
 	QuestionShow            = 1
@@ -39,6 +40,7 @@ var AdminQuestionSearchStatus = map[string]int{
 	"closed":    QuestionStatusClosed,
 	"deleted":   QuestionStatusDeleted,
 	"pending":   QuestionStatusPending,
+	"scheduled": QuestionStatusScheduled,
 }
 
 var AdminQuestionSearchStatusIntToString = map[int]string{
@@ -46,6 +48,7 @@ var AdminQuestionSearchStatusIntToString = map[int]string{
 	QuestionStatusClosed:    "closed",
 	QuestionStatusDeleted:   "deleted",
 	QuestionStatusPending:   "pending",
+	QuestionStatusScheduled: "scheduled",
 }
 
 // Question question
@@ -73,6 +76,11 @@ type Question struct {
 	LastAnswerID     string    `xorm:"not null default 0 BIGINT(20) last_answer_id"`
 	PostUpdateTime   time.Time `xorm:"post_update_time TIMESTAMP"`
 	RevisionID       string    `xorm:"not null default 0 BIGINT(20) revision_id"`
+	SpaceID          string    `xorm:"not null default 0 BIGINT(20) INDEX space_id"`
+	Wiki             bool      `xorm:"not null default false BOOL wiki"`
+	PublishAt        time.Time `xorm:"publish_at TIMESTAMP INDEX"`
+	UnpinAt          time.Time `xorm:"unpin_at TIMESTAMP INDEX"`
+	CloseAt          time.Time `xorm:"close_at TIMESTAMP INDEX"`
 }
 
 // TableName question table name
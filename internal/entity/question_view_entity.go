@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// QuestionViewDaily question views of one day
+type QuestionViewDaily struct {
+	ID              int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt       time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt       time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	QuestionID      string    `xorm:"not null default 0 BIGINT(20) UNIQUE(question_view_day) question_id"`
+	ViewDate        string    `xorm:"not null default '' VARCHAR(10) UNIQUE(question_view_day) view_date"`
+	ViewCount       int       `xorm:"not null default 0 INT(11) view_count"`
+	UniqueViewCount int       `xorm:"not null default 0 INT(11) unique_view_count"`
+	Pending         bool      `xorm:"not null default false BOOL INDEX pending"`
+}
+
+// TableName question view daily table name
+func (QuestionViewDaily) TableName() string {
+	return "question_view_daily"
+}
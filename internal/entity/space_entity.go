@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	// SpaceVisibilityPublic everyone can see and post questions in the space
+	SpaceVisibilityPublic = 1
+	// SpaceVisibilityMembers only members can see and post questions, the space is listed to everyone
+	SpaceVisibilityMembers = 2
+	// SpaceVisibilityHidden only members can see and post questions, the space is not listed to non-members
+	SpaceVisibilityHidden = 3
+
+	SpaceMemberTypeUser = 1
+	SpaceMemberTypeRole = 2
+
+	// NoSpaceID the questions that do not belong to any space
+	NoSpaceID = "0"
+)
+
+// Space space that questions belong to
+type Space struct {
+	ID          string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	SlugName    string    `xorm:"not null default '' VARCHAR(35) UNIQUE slug_name"`
+	DisplayName string    `xorm:"not null default '' VARCHAR(35) display_name"`
+	Description string    `xorm:"not null TEXT description"`
+	Visibility  int       `xorm:"not null default 1 INT(11) visibility"`
+}
+
+// TableName space table name
+func (Space) TableName() string {
+	return "space"
+}
+
+// SpaceMember space member, the member can be a user or all the users with a role
+type SpaceMember struct {
+	ID          int       `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	SpaceID     string    `xorm:"not null default 0 BIGINT(20) UNIQUE(space_member) space_id"`
+	MemberType  int       `xorm:"not null default 1 INT(11) UNIQUE(space_member) member_type"`
+	MemberID    string    `xorm:"not null default 0 BIGINT(20) UNIQUE(space_member) INDEX member_id"`
+	IsModerator bool      `xorm:"not null default false BOOL is_moderator"`
+}
+
+// TableName space member table name
+func (SpaceMember) TableName() string {
+	return "space_member"
+}
@@ -0,0 +1,37 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// TagCategory the category for grouping tags on the tags page
+type TagCategory struct {
+	ID          string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	SlugName    string    `xorm:"not null default '' VARCHAR(35) UNIQUE slug_name"`
+	DisplayName string    `xorm:"not null default '' VARCHAR(35) display_name"`
+	SortOrder   int       `xorm:"not null default 0 INT(11) sort_order"`
+}
+
+// TableName tag category table name
+func (TagCategory) TableName() string {
+	return "tag_category"
+}
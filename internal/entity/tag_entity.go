@@ -38,6 +38,8 @@ type Tag struct {
 	UpdatedAt       time.Time `xorm:"updated TIMESTAMP updated_at"`
 	MainTagID       int64     `xorm:"not null default 0 BIGINT(20) main_tag_id"`
 	MainTagSlugName string    `xorm:"not null default '' VARCHAR(35) main_tag_slug_name"`
+	ParentTagID     int64     `xorm:"not null default 0 BIGINT(20) INDEX parent_tag_id"`
+	CategoryID      int64     `xorm:"not null default 0 BIGINT(20) INDEX category_id"`
 	SlugName        string    `xorm:"not null default '' unique VARCHAR(35) slug_name"`
 	DisplayName     string    `xorm:"not null default '' VARCHAR(35) display_name"`
 	OriginalText    string    `xorm:"not null MEDIUMTEXT original_text"`
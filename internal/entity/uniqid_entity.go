@@ -19,6 +19,8 @@
 
 package entity
 
+import "time"
+
 // Uniqid uniqid
 type Uniqid struct {
 	ID         int64 `xorm:"not null pk autoincr BIGINT(20) id"`
@@ -29,3 +31,19 @@ type Uniqid struct {
 func (Uniqid) TableName() string {
 	return "uniqid"
 }
+
+// UniqidSequenceID the id of the only row in the uniqid sequence table
+const UniqidSequenceID = 1
+
+// UniqidSequence the next id of the uniqid, the ids are shared by all object types like the uniqid table
+// and reserved in blocks by each process
+type UniqidSequence struct {
+	ID        int       `xorm:"not null pk INT(11) id"`
+	NextID    int64     `xorm:"not null default 1 BIGINT(20) next_id"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+}
+
+// TableName uniqid sequence table name
+func (UniqidSequence) TableName() string {
+	return "uniqid_sequence"
+}
@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	UserDataExportStatusPending   = 1
+	UserDataExportStatusCompleted = 2
+	UserDataExportStatusFailed    = 3
+
+	UserDeletionStatusPending   = 1
+	UserDeletionStatusCanceled  = 2
+	UserDeletionStatusCompleted = 3
+
+	UserDeletionModeAnonymize = "anonymize"
+	UserDeletionModeRemove    = "remove"
+)
+
+// UserDataExport user personal data export job
+type UserDataExport struct {
+	ID            int       `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt     time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt     time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID        string    `xorm:"not null default 0 index BIGINT(20) user_id"`
+	Status        int       `xorm:"not null default 1 INT(11) status"`
+	FileName      string    `xorm:"not null default '' VARCHAR(100) file_name"`
+	DownloadToken string    `xorm:"not null default '' index VARCHAR(64) download_token"`
+	ExpiredAt     time.Time `xorm:"TIMESTAMP expired_at"`
+}
+
+// TableName user data export table name
+func (UserDataExport) TableName() string {
+	return "user_data_export"
+}
+
+// UserDeletionRequest user self-service account deletion request
+type UserDeletionRequest struct {
+	ID          int       `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID      string    `xorm:"not null default 0 index BIGINT(20) user_id"`
+	Mode        string    `xorm:"not null default '' VARCHAR(20) mode"`
+	Status      int       `xorm:"not null default 1 INT(11) status"`
+	ScheduledAt time.Time `xorm:"TIMESTAMP scheduled_at"`
+}
+
+// TableName user deletion request table name
+func (UserDeletionRequest) TableName() string {
+	return "user_deletion_request"
+}
@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	UserSuspensionStatusActive   = 1
+	UserSuspensionStatusLifted   = 2
+	UserSuspensionStatusReversed = 3
+	UserSuspensionStatusExpired  = 4
+)
+
+const (
+	UserSuspensionAppealStatusNone     = 0
+	UserSuspensionAppealStatusPending  = 1
+	UserSuspensionAppealStatusAccepted = 2
+	UserSuspensionAppealStatusRejected = 3
+)
+
+// UserSuspension the suspension of the user, the records are kept as the suspension history of the user
+type UserSuspension struct {
+	ID             string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt      time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt      time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID         string    `xorm:"not null default 0 index BIGINT(20) user_id"`
+	OperatorUserID string    `xorm:"not null default 0 BIGINT(20) operator_user_id"`
+	ReasonType     int       `xorm:"not null default 0 INT(11) reason_type"`
+	Message        string    `xorm:"not null TEXT message"`
+	// EndAt is zero if the user is suspended until the suspension is lifted manually
+	EndAt          time.Time `xorm:"index TIMESTAMP end_at"`
+	Status         int       `xorm:"not null default 1 index INT(11) status"`
+	LiftedAt       time.Time `xorm:"TIMESTAMP lifted_at"`
+	LiftedUserID   string    `xorm:"not null default 0 BIGINT(20) lifted_user_id"`
+	AppealStatus   int       `xorm:"not null default 0 INT(11) appeal_status"`
+	AppealContent  string    `xorm:"not null TEXT appeal_content"`
+	AppealedAt     time.Time `xorm:"TIMESTAMP appealed_at"`
+	AppealReviewID int       `xorm:"not null default 0 index BIGINT(20) appeal_review_id"`
+}
+
+// TableName user suspension table name
+func (UserSuspension) TableName() string {
+	return "user_suspension"
+}
@@ -0,0 +1,123 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package maintenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/pkg/dir"
+	"xorm.io/xorm"
+)
+
+const defaultBatchSize = 100
+
+// Options the options of maintenance task
+type Options struct {
+	// BatchSize the number of rows handled in one batch
+	BatchSize int
+	// DryRun only prints what would be changed without writing anything
+	DryRun bool
+	// Resume continues from the checkpoint of the last interrupted run
+	Resume bool
+	// CheckpointDir the directory to save the checkpoint
+	CheckpointDir string
+}
+
+// task is a maintenance task, it saves the progress to the checkpoint file after each batch,
+// so that the interrupted task can be resumed.
+type task struct {
+	name       string
+	db         *xorm.Engine
+	opts       *Options
+	checkpoint map[string]string
+}
+
+func newTask(name string, dbConf *data.Database, opts *Options) (t *task, err error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	t = &task{name: name, opts: opts, checkpoint: make(map[string]string)}
+	if opts.Resume {
+		if err = t.loadCheckpoint(); err != nil {
+			return nil, err
+		}
+	}
+	t.db, err = data.NewDB(false, dbConf)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *task) checkpointPath() string {
+	return filepath.Join(t.opts.CheckpointDir, fmt.Sprintf("maintenance_%s.json", t.name))
+}
+
+func (t *task) loadCheckpoint() error {
+	content, err := os.ReadFile(t.checkpointPath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read checkpoint failed: %w", err)
+	}
+	if err = json.Unmarshal(content, &t.checkpoint); err != nil {
+		return fmt.Errorf("parse checkpoint failed: %w", err)
+	}
+	fmt.Printf("[%s] resume from checkpoint %s\n", t.name, t.checkpointPath())
+	return nil
+}
+
+// progress returns the saved progress of the key, or the default value if there is no progress
+func (t *task) progress(key, defaultValue string) string {
+	if value, ok := t.checkpoint[key]; ok {
+		return value
+	}
+	return defaultValue
+}
+
+// saveProgress saves the progress of the key to the checkpoint file, it does nothing in dry run mode
+func (t *task) saveProgress(key, value string) error {
+	t.checkpoint[key] = value
+	if t.opts.DryRun {
+		return nil
+	}
+	if err := dir.CreateDirIfNotExist(t.opts.CheckpointDir); err != nil {
+		return err
+	}
+	content, _ := json.Marshal(t.checkpoint)
+	return os.WriteFile(t.checkpointPath(), content, 0o644)
+}
+
+// finish closes the database and removes the checkpoint when the task is done
+func (t *task) finish(err error) error {
+	_ = t.db.Close()
+	if err != nil || t.opts.DryRun {
+		return err
+	}
+	if removeErr := os.Remove(t.checkpointPath()); removeErr != nil && !os.IsNotExist(removeErr) {
+		return removeErr
+	}
+	return nil
+}
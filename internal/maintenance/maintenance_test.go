@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package maintenance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) *data.Database {
+	dbConf := &data.Database{Driver: "sqlite", Connection: filepath.Join(t.TempDir(), "answer.db")}
+	db, err := data.NewDB(false, dbConf)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.Sync(new(entity.Question), new(entity.Answer), new(entity.Comment), new(entity.User),
+		new(entity.Tag), new(entity.TagRel), new(entity.PluginConfig), new(entity.Config), new(entity.Activity)))
+
+	_, err = db.Insert(
+		&entity.User{ID: "1", Username: "u1", Bio: "**bio**", QuestionCount: 5, AnswerCount: 5},
+		&entity.Question{ID: "10", UserID: "1", OriginalText: "# title", Status: entity.QuestionStatusAvailable, AnswerCount: 3},
+		&entity.Answer{ID: "20", QuestionID: "10", UserID: "1", OriginalText: "*answer*", Status: entity.AnswerStatusAvailable},
+		&entity.Answer{ID: "21", QuestionID: "10", UserID: "1", OriginalText: "deleted", Status: entity.AnswerStatusDeleted},
+	)
+	require.NoError(t, err)
+	return dbConf
+}
+
+func getTestUser(t *testing.T, dbConf *data.Database) *entity.User {
+	db, err := data.NewDB(false, dbConf)
+	require.NoError(t, err)
+	defer db.Close()
+	user := &entity.User{}
+	_, err = db.ID("1").Get(user)
+	require.NoError(t, err)
+	return user
+}
+
+func TestRecount(t *testing.T) {
+	dbConf := newTestDB(t)
+	opts := &Options{CheckpointDir: t.TempDir()}
+
+	require.NoError(t, Recount(dbConf, &Options{CheckpointDir: opts.CheckpointDir, DryRun: true}))
+	user := getTestUser(t, dbConf)
+	assert.Equal(t, 5, user.QuestionCount)
+	assert.Equal(t, 5, user.AnswerCount)
+
+	require.NoError(t, Recount(dbConf, opts))
+	user = getTestUser(t, dbConf)
+	assert.Equal(t, 1, user.QuestionCount)
+	assert.Equal(t, 1, user.AnswerCount)
+
+	db, err := data.NewDB(false, dbConf)
+	require.NoError(t, err)
+	defer db.Close()
+	question := &entity.Question{}
+	_, err = db.ID("10").Get(question)
+	require.NoError(t, err)
+	assert.Equal(t, 1, question.AnswerCount)
+
+	_, err = os.Stat(filepath.Join(opts.CheckpointDir, "maintenance_recount.json"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRerender(t *testing.T) {
+	dbConf := newTestDB(t)
+	require.NoError(t, Rerender(dbConf, &Options{CheckpointDir: t.TempDir()}))
+
+	user := getTestUser(t, dbConf)
+	assert.Equal(t, "<p><strong>bio</strong></p>\n", user.BioHTML)
+
+	db, err := data.NewDB(false, dbConf)
+	require.NoError(t, err)
+	defer db.Close()
+	answer := &entity.Answer{}
+	_, err = db.ID("20").Get(answer)
+	require.NoError(t, err)
+	assert.Equal(t, "<p><em>answer</em></p>\n", answer.ParsedText)
+}
+
+func TestResume(t *testing.T) {
+	dbConf := newTestDB(t)
+	opts := &Options{CheckpointDir: t.TempDir(), Resume: true}
+	checkpoint := `{"user.bio_html":"1"}`
+	require.NoError(t, os.WriteFile(filepath.Join(opts.CheckpointDir, "maintenance_rerender.json"), []byte(checkpoint), 0o644))
+
+	require.NoError(t, Rerender(dbConf, opts))
+	// the user has been handled before the interruption, so it should be skipped
+	user := getTestUser(t, dbConf)
+	assert.Empty(t, user.BioHTML)
+}
+
+func TestRecomputeReputation(t *testing.T) {
+	dbConf := newTestDB(t)
+	db, err := data.NewDB(false, dbConf)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Insert(
+		&entity.Config{ID: 2, Key: "answer.voted_up", Value: `10`},
+		&entity.Config{ID: 10, Key: "user.activated", Value: `1`},
+		&entity.Config{ID: 136, Key: "user.reputation_adjust", Value: `0`},
+		&entity.Activity{UserID: "1", ObjectID: "0", ActivityType: 10, Rank: 1, HasRank: 1},
+		&entity.Activity{UserID: "1", ObjectID: "20", ActivityType: 2, Rank: 5, HasRank: 1},
+	)
+	require.NoError(t, err)
+	_, err = db.ID("1").Cols("`rank`").Update(&entity.User{Rank: 6})
+	require.NoError(t, err)
+
+	require.NoError(t, RecomputeReputation(dbConf, &Options{CheckpointDir: t.TempDir(), DryRun: true}))
+	assert.Equal(t, 6, getTestUser(t, dbConf).Rank)
+
+	require.NoError(t, RecomputeReputation(dbConf, &Options{CheckpointDir: t.TempDir()}))
+	assert.Equal(t, 11, getTestUser(t, dbConf).Rank)
+	adjust := &entity.Activity{}
+	exist, err := db.Where("activity_type = ?", 136).Get(adjust)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 5, adjust.Rank)
+
+	// the adjustment is not replayed, so there is no drift any more
+	require.NoError(t, RecomputeReputation(dbConf, &Options{CheckpointDir: t.TempDir()}))
+	assert.Equal(t, 11, getTestUser(t, dbConf).Rank)
+	count, err := db.Where("activity_type = ?", 136).Count(&entity.Activity{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,139 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package maintenance
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/builder"
+)
+
+// recountTarget the counter column that should be equal to the number of related rows in another table
+type recountTarget struct {
+	Table  string
+	Column string
+	// CountTable the table of the related rows, and ForeignKey is the column that refers to the Table
+	CountTable string
+	ForeignKey string
+	// Cond the condition of the related rows that should be counted
+	Cond builder.Cond
+}
+
+// the conditions are the same as the ones used when the counters are updated in the services
+var recountTargets = []*recountTarget{
+	{
+		Table: "question", Column: "answer_count", CountTable: "answer", ForeignKey: "question_id",
+		Cond: builder.Eq{"status": entity.AnswerStatusAvailable},
+	},
+	{
+		Table: "tag", Column: "question_count", CountTable: "tag_rel", ForeignKey: "tag_id",
+		Cond: builder.Eq{"status": entity.TagRelStatusAvailable},
+	},
+	{
+		Table: "user", Column: "question_count", CountTable: "question", ForeignKey: "user_id",
+		Cond: builder.Lt{"status": entity.QuestionStatusDeleted},
+	},
+	{
+		Table: "user", Column: "answer_count", CountTable: "answer", ForeignKey: "user_id",
+		Cond: builder.Eq{"status": entity.AnswerStatusAvailable},
+	},
+}
+
+type recountRow struct {
+	ID    string `xorm:"id"`
+	Count int64  `xorm:"count"`
+}
+
+// Recount count the answers of questions, the questions of tags and the questions and answers of users again
+func Recount(dbConf *data.Database, opts *Options) (err error) {
+	t, err := newTask("recount", dbConf, opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = t.finish(err)
+	}()
+
+	for _, target := range recountTargets {
+		if err = t.recountTable(target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *task) recountTable(target *recountTarget) (err error) {
+	key := target.Table + "." + target.Column
+	lastID := t.progress(key, "0")
+	processed, changed := 0, 0
+	for {
+		rows := make([]*recountRow, 0, t.opts.BatchSize)
+		err = t.db.Table(target.Table).Select(fmt.Sprintf("id, %s AS count", target.Column)).
+			Where("id > ?", lastID).OrderBy("id ASC").Limit(t.opts.BatchSize).Find(&rows)
+		if err != nil {
+			return fmt.Errorf("get %s failed: %w", target.Table, err)
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		ids := make([]string, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		counts := make([]*recountRow, 0, len(rows))
+		err = t.db.Table(target.CountTable).Select(fmt.Sprintf("%s AS id, COUNT(*) AS count", target.ForeignKey)).
+			Where(target.Cond).And(builder.In(target.ForeignKey, ids)).GroupBy(target.ForeignKey).Find(&counts)
+		if err != nil {
+			return fmt.Errorf("count %s failed: %w", target.CountTable, err)
+		}
+		countMapping := make(map[string]int64, len(counts))
+		for _, c := range counts {
+			countMapping[c.ID] = c.Count
+		}
+
+		for _, row := range rows {
+			count := countMapping[row.ID]
+			if count == row.Count {
+				continue
+			}
+			changed++
+			fmt.Printf("[recount] %s %s %s: %d -> %d\n", target.Table, row.ID, target.Column, row.Count, count)
+			if t.opts.DryRun {
+				continue
+			}
+			_, err = t.db.Table(target.Table).Where("id = ?", row.ID).Update(map[string]any{target.Column: count})
+			if err != nil {
+				return fmt.Errorf("update %s %s failed: %w", target.Table, row.ID, err)
+			}
+		}
+		processed += len(rows)
+		lastID = rows[len(rows)-1].ID
+		if err = t.saveProgress(key, lastID); err != nil {
+			return err
+		}
+		fmt.Printf("[recount] %s.%s: %d processed, %d changed, last id %s\n",
+			target.Table, target.Column, processed, changed, lastID)
+	}
+	fmt.Printf("[recount] %s.%s done: %d processed, %d changed\n", target.Table, target.Column, processed, changed)
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/cli"
+	"github.com/apache/incubator-answer/internal/repo/search_sync"
+	"github.com/apache/incubator-answer/plugin"
+)
+
+// Reindex push all questions and answers to the enabled search plugin
+func Reindex(dbConf *data.Database, opts *Options) (err error) {
+	t, err := newTask("reindex", dbConf, opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = t.finish(err)
+	}()
+
+	if err = cli.LoadPluginStatus(t.db); err != nil {
+		return err
+	}
+	if err = cli.LoadPluginConfig(t.db); err != nil {
+		return err
+	}
+	var search plugin.Search
+	_ = plugin.CallSearch(func(s plugin.Search) error {
+		search = s
+		return nil
+	})
+	if search == nil {
+		fmt.Println("[reindex] no search plugin is enabled, nothing to do")
+		return nil
+	}
+	fmt.Printf("[reindex] reindex to search plugin %s\n", search.Info().SlugName)
+
+	syncer := search_sync.NewPluginSyncer(&data.Data{DB: t.db})
+	if err = t.reindex(search, "question", syncer.GetQuestionsPage); err != nil {
+		return err
+	}
+	return t.reindex(search, "answer", syncer.GetAnswersPage)
+}
+
+func (t *task) reindex(search plugin.Search, objectType string,
+	getPage func(ctx context.Context, page, pageSize int) ([]*plugin.SearchContent, error)) (err error) {
+	ctx := context.Background()
+	page, _ := strconv.Atoi(t.progress(objectType, "0"))
+	processed := 0
+	for {
+		page++
+		contents, err := getPage(ctx, page, t.opts.BatchSize)
+		if err != nil {
+			return fmt.Errorf("get %s page %d failed: %w", objectType, page, err)
+		}
+		if len(contents) == 0 {
+			break
+		}
+		if !t.opts.DryRun {
+			for _, content := range contents {
+				err = plugin.TraceCall(ctx, search, func(ctx context.Context) error {
+					return search.UpdateContent(ctx, content)
+				})
+				if err != nil {
+					return fmt.Errorf("update %s %s to search failed: %w", objectType, content.ObjectID, err)
+				}
+			}
+		}
+		processed += len(contents)
+		if err = t.saveProgress(objectType, strconv.Itoa(page)); err != nil {
+			return err
+		}
+		fmt.Printf("[reindex] %s: %d processed, page %d\n", objectType, processed, page)
+	}
+	fmt.Printf("[reindex] %s done: %d processed\n", objectType, processed)
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package maintenance
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/reputation"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// RecomputeReputation replay the activities of all users under the current reputation rules and report the drift,
+// the corrections are recorded as adjustment activities unless it is a dry run
+func RecomputeReputation(dbConf *data.Database, opts *Options) (err error) {
+	t, err := newTask("reputation", dbConf, opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = t.finish(err)
+	}()
+
+	configs := make([]*entity.Config, 0)
+	if err = t.db.Find(&configs); err != nil {
+		return fmt.Errorf("get config failed: %w", err)
+	}
+	rules := reputation.NewReplayRules(configs)
+	for _, table := range []string{entity.Question{}.TableName(), entity.Answer{}.TableName()} {
+		objectIDs := make([]string, 0)
+		if err = t.db.Table(table).Where(builder.Eq{"wiki": true}).Cols("id").Find(&objectIDs); err != nil {
+			return fmt.Errorf("get wiki %s failed: %w", table, err)
+		}
+		rules.SetWikiObjects(objectIDs)
+	}
+	if rules.AdjustType == 0 && !opts.DryRun {
+		return fmt.Errorf("the reputation adjustment activity is not found, please upgrade first")
+	}
+
+	const key = "user.rank"
+	lastID := t.progress(key, "0")
+	processed, drifted := 0, 0
+	for {
+		users := make([]*entity.User, 0, t.opts.BatchSize)
+		err = t.db.Where("id > ?", lastID).And(builder.Neq{"status": entity.UserStatusDeleted}).
+			OrderBy("id ASC").Limit(t.opts.BatchSize).Find(&users)
+		if err != nil {
+			return fmt.Errorf("get user failed: %w", err)
+		}
+		if len(users) == 0 {
+			break
+		}
+
+		ids := make([]string, 0, len(users))
+		for _, user := range users {
+			ids = append(ids, user.ID)
+		}
+		activities := make([]*entity.Activity, 0)
+		err = t.db.In("user_id", ids).And(builder.Eq{"cancelled": entity.ActivityAvailable}).
+			OrderBy("id ASC").Find(&activities)
+		if err != nil {
+			return fmt.Errorf("get activity failed: %w", err)
+		}
+		activityMapping := make(map[string][]*entity.Activity, len(users))
+		for _, act := range activities {
+			activityMapping[act.UserID] = append(activityMapping[act.UserID], act)
+		}
+
+		for _, user := range users {
+			result := rules.Replay(user, activityMapping[user.ID])
+			drift := result.Drift(user)
+			if drift == 0 {
+				continue
+			}
+			drifted++
+			fmt.Printf("[reputation] user %s %s: %d -> %d (drift %+d)\n",
+				user.ID, user.Username, user.Rank, result.Expected, drift)
+			if t.opts.DryRun {
+				continue
+			}
+			if err = t.adjustUserRank(user, result.Expected, rules.NewAdjustActivity(user.ID, 0, -drift)); err != nil {
+				return fmt.Errorf("adjust user %s failed: %w", user.ID, err)
+			}
+		}
+		processed += len(users)
+		lastID = users[len(users)-1].ID
+		if err = t.saveProgress(key, lastID); err != nil {
+			return err
+		}
+		fmt.Printf("[reputation] %d processed, %d drifted, last id %s\n", processed, drifted, lastID)
+	}
+	fmt.Printf("[reputation] done: %d processed, %d drifted\n", processed, drifted)
+	return nil
+}
+
+func (t *task) adjustUserRank(user *entity.User, expected int, activity *entity.Activity) (err error) {
+	_, err = t.db.Transaction(func(session *xorm.Session) (result any, err error) {
+		affected, err := session.ID(user.ID).Where(builder.Eq{"`rank`": user.Rank}).
+			Cols("`rank`").Update(&entity.User{Rank: expected})
+		if err != nil || affected == 0 {
+			return nil, err
+		}
+		_, err = session.Insert(activity)
+		return nil, err
+	})
+	return err
+}
@@ -0,0 +1,117 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package maintenance
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/cli"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/plugin"
+)
+
+// rerenderTarget the table that stores the markdown content and the rendered html
+type rerenderTarget struct {
+	Table  string
+	Source string
+	Target string
+	Render func(source string) string
+}
+
+var rerenderTargets = []*rerenderTarget{
+	{Table: "question", Source: "original_text", Target: "parsed_text", Render: converter.Markdown2HTML},
+	{Table: "answer", Source: "original_text", Target: "parsed_text", Render: converter.Markdown2HTML},
+	{Table: "comment", Source: "original_text", Target: "parsed_text", Render: converter.Markdown2HTML},
+	{Table: "user", Source: "bio", Target: "bio_html", Render: converter.Markdown2BasicHTML},
+}
+
+type rerenderRow struct {
+	ID      string `xorm:"id"`
+	Source  string `xorm:"source"`
+	Current string `xorm:"current"`
+}
+
+// Rerender render the stored markdown of questions, answers, comments and user bios again and save the html
+func Rerender(dbConf *data.Database, opts *Options) (err error) {
+	t, err := newTask("rerender", dbConf, opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = t.finish(err)
+	}()
+
+	// the content should be rendered by the markdown extensions of enabled plugins as well
+	if err = cli.LoadPluginStatus(t.db); err != nil {
+		return err
+	}
+	if err = cli.LoadPluginConfig(t.db); err != nil {
+		return err
+	}
+	converter.RegisterMarkdownExtensionsFunc(plugin.GetMarkdownExtenders)
+
+	for _, target := range rerenderTargets {
+		if err = t.rerenderTable(target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *task) rerenderTable(target *rerenderTarget) (err error) {
+	key := target.Table + "." + target.Target
+	lastID := t.progress(key, "0")
+	processed, changed := 0, 0
+	for {
+		rows := make([]*rerenderRow, 0, t.opts.BatchSize)
+		err = t.db.Table(target.Table).
+			Select(fmt.Sprintf("id, %s AS source, %s AS current", target.Source, target.Target)).
+			Where("id > ?", lastID).OrderBy("id ASC").Limit(t.opts.BatchSize).Find(&rows)
+		if err != nil {
+			return fmt.Errorf("get %s failed: %w", target.Table, err)
+		}
+		if len(rows) == 0 {
+			break
+		}
+		for _, row := range rows {
+			html := target.Render(row.Source)
+			if html == row.Current {
+				continue
+			}
+			changed++
+			if t.opts.DryRun {
+				continue
+			}
+			_, err = t.db.Table(target.Table).Where("id = ?", row.ID).Update(map[string]any{target.Target: html})
+			if err != nil {
+				return fmt.Errorf("update %s %s failed: %w", target.Table, row.ID, err)
+			}
+		}
+		processed += len(rows)
+		lastID = rows[len(rows)-1].ID
+		if err = t.saveProgress(key, lastID); err != nil {
+			return err
+		}
+		fmt.Printf("[rerender] %s: %d processed, %d changed, last id %s\n", target.Table, processed, changed, lastID)
+	}
+	fmt.Printf("[rerender] %s done: %d processed, %d changed\n", target.Table, processed, changed)
+	return nil
+}
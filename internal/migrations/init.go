@@ -325,6 +325,7 @@ func (m *Mentor) initDefaultContent() {
 		LastAnswerID:     a1Id,
 		PostUpdateTime:   now,
 		RevisionID:       "0",
+		SpaceID:          entity.NoSpaceID,
 	}
 
 	a1 := &entity.Answer{
@@ -355,6 +356,7 @@ func (m *Mentor) initDefaultContent() {
 		LastAnswerID:     a2Id,
 		PostUpdateTime:   now,
 		RevisionID:       "0",
+		SpaceID:          entity.NoSpaceID,
 	}
 
 	a2 := &entity.Answer{
@@ -58,6 +58,10 @@ var (
 		&entity.Tag{},
 		&entity.TagRel{},
 		&entity.Uniqid{},
+		&entity.UniqidSequence{},
+		&entity.UserSuspension{},
+		&entity.CaptchaStatDaily{},
+		&entity.Draft{},
 		&entity.User{},
 		&entity.Version{},
 		&entity.Role{},
@@ -72,6 +76,15 @@ var (
 		&entity.Badge{},
 		&entity.BadgeGroup{},
 		&entity.BadgeAward{},
+		&entity.UserDataExport{},
+		&entity.UserDeletionRequest{},
+		&entity.Space{},
+		&entity.SpaceMember{},
+		&entity.TagModerator{},
+		&entity.TagCategory{},
+		&entity.QuestionViewDaily{},
+		&entity.AnalyticsDaily{},
+		&entity.NotificationDelivery{},
 	}
 
 	roles = []*entity.Role{
@@ -122,6 +135,9 @@ var (
 		{ID: 39, Name: "recover answer", PowerType: permission.AnswerUnDelete, Description: "recover deleted answer"},
 		{ID: 40, Name: "recover question", PowerType: permission.QuestionUnDelete, Description: "recover deleted question"},
 		{ID: 41, Name: "recover tag", PowerType: permission.TagUnDelete, Description: "recover deleted tag"},
+		{ID: 42, Name: "question wiki", PowerType: permission.QuestionWiki, Description: "convert the question to or from community wiki"},
+		{ID: 43, Name: "answer wiki", PowerType: permission.AnswerWiki, Description: "convert the answer to or from community wiki"},
+		{ID: 44, Name: "question schedule", PowerType: permission.QuestionSchedule, Description: "schedule the question to be published later"},
 	}
 
 	rolePowerRels = []*entity.RolePowerRel{
@@ -167,6 +183,9 @@ var (
 		{RoleID: 2, PowerType: permission.AnswerUnDelete},
 		{RoleID: 2, PowerType: permission.QuestionUnDelete},
 		{RoleID: 2, PowerType: permission.TagUnDelete},
+		{RoleID: 2, PowerType: permission.QuestionWiki},
+		{RoleID: 2, PowerType: permission.AnswerWiki},
+		{RoleID: 2, PowerType: permission.QuestionSchedule},
 
 		{RoleID: 3, PowerType: permission.QuestionAdd},
 		{RoleID: 3, PowerType: permission.QuestionEdit},
@@ -209,6 +228,9 @@ var (
 		{RoleID: 3, PowerType: permission.AnswerUnDelete},
 		{RoleID: 3, PowerType: permission.QuestionUnDelete},
 		{RoleID: 3, PowerType: permission.TagUnDelete},
+		{RoleID: 3, PowerType: permission.QuestionWiki},
+		{RoleID: 3, PowerType: permission.AnswerWiki},
+		{RoleID: 3, PowerType: permission.QuestionSchedule},
 	}
 
 	adminUserRoleRel = &entity.UserRoleRel{
@@ -346,6 +368,16 @@ var (
 		{ID: 128, Key: "rank.answer.undeleted", Value: `-1`},
 		{ID: 129, Key: "rank.question.undeleted", Value: `-1`},
 		{ID: 130, Key: "rank.tag.undeleted", Value: `-1`},
+		{ID: 131, Key: "reason.suspend_spam", Value: `{"name":"spam","description":"Posting spam, advertisements or vandalism."}`},
+		{ID: 132, Key: "reason.suspend_abusive", Value: `{"name":"rude or abusive","description":"Behaving in a way that a reasonable person would find inappropriate for respectful discourse."}`},
+		{ID: 133, Key: "reason.suspend_voting_fraud", Value: `{"name":"voting fraud","description":"Voting irregularities, such as voting with multiple accounts."}`},
+		{ID: 134, Key: "reason.suspend_other", Value: `{"name":"something else","description":"Violating the community guidelines for another reason not listed above.","content_type":"textarea"}`},
+		{ID: 135, Key: "user.suspend.reasons", Value: `["reason.suspend_spam","reason.suspend_abusive","reason.suspend_voting_fraud","reason.suspend_other"]`},
+		{ID: 136, Key: "user.reputation_adjust", Value: `0`},
+		{ID: 137, Key: "rank.wiki.edit", Value: `100`},
+		{ID: 138, Key: "rank.question.wiki", Value: `-1`},
+		{ID: 139, Key: "rank.answer.wiki", Value: `-1`},
+		{ID: 140, Key: "rank.question.schedule", Value: `-1`},
 	}
 
 	defaultBadgeGroupTable = []*entity.BadgeGroup{
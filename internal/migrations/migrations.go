@@ -98,6 +98,21 @@ var migrations = []Migration{
 	NewMigration("v1.3.0", "add review", addReview, false),
 	NewMigration("v1.3.6", "add hot score to question table", addQuestionHotScore, true),
 	NewMigration("v1.4.0", "add badge/badge_group/badge_award table", addBadges, true),
+	NewMigration("v1.4.1", "add user data export and deletion request table", addUserDataExportAndDeletion, false),
+	NewMigration("v1.4.2", "add space and space member table", addSpace, true),
+	NewMigration("v1.4.3", "add tag moderator table", addTagModerator, true),
+	NewMigration("v1.4.4", "add tag parent and tag category", addTagHierarchy, true),
+	NewMigration("v1.4.5", "add question view daily table", addQuestionViewDaily, true),
+	NewMigration("v1.4.6", "add analytics daily table", addAnalyticsDaily, true),
+	NewMigration("v1.4.7", "add notification delivery table", addNotificationDelivery, true),
+	NewMigration("v1.4.8", "add uniqid sequence table and compact uniqid table", addUniqidSequence, true),
+	NewMigration("v1.4.9", "add user suspension table and suspension reasons", addUserSuspension, true),
+	NewMigration("v1.4.10", "add captcha stat daily table", addCaptchaStatDaily, false),
+	NewMigration("v1.4.11", "add reputation adjustment activity", addReputationAdjustActivity, false),
+	NewMigration("v1.4.12", "add draft table", addDraft, false),
+	NewMigration("v1.4.13", "add community wiki", addCommunityWiki, true),
+	NewMigration("v1.4.14", "add question schedule", addQuestionSchedule, true),
+	NewMigration("v1.4.15", "add pending flag to question view daily table", addQuestionViewPending, false),
 }
 
 func GetMigrations() []Migration {
@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+// addUniqidSequence the ids are reserved in blocks from the sequence instead of inserting into the uniqid table,
+// so the uniqid table only needs to keep the last row
+func addUniqidSequence(ctx context.Context, x *xorm.Engine) error {
+	err := x.Context(ctx).Sync(new(entity.UniqidSequence))
+	if err != nil {
+		return fmt.Errorf("sync table failed: %w", err)
+	}
+
+	var maxID int64
+	_, err = x.Context(ctx).Table(entity.Uniqid{}.TableName()).Select("COALESCE(MAX(id), 0)").Get(&maxID)
+	if err != nil {
+		return fmt.Errorf("get max uniqid failed: %w", err)
+	}
+
+	exist, err := x.Context(ctx).Exist(&entity.UniqidSequence{ID: entity.UniqidSequenceID})
+	if err != nil {
+		return fmt.Errorf("get uniqid sequence failed: %w", err)
+	}
+	if !exist {
+		_, err = x.Context(ctx).Insert(&entity.UniqidSequence{ID: entity.UniqidSequenceID, NextID: maxID + 1})
+		if err != nil {
+			return fmt.Errorf("add uniqid sequence failed: %w", err)
+		}
+	}
+
+	_, err = x.Context(ctx).Where("id < ?", maxID).Delete(&entity.Uniqid{})
+	if err != nil {
+		return fmt.Errorf("compact uniqid table failed: %w", err)
+	}
+	return nil
+}
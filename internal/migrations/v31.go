@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+func addUserSuspension(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.UserSuspension)); err != nil {
+		return fmt.Errorf("sync table failed: %w", err)
+	}
+
+	defaultConfigTable := []*entity.Config{
+		{ID: 131, Key: "reason.suspend_spam", Value: `{"name":"spam","description":"Posting spam, advertisements or vandalism."}`},
+		{ID: 132, Key: "reason.suspend_abusive", Value: `{"name":"rude or abusive","description":"Behaving in a way that a reasonable person would find inappropriate for respectful discourse."}`},
+		{ID: 133, Key: "reason.suspend_voting_fraud", Value: `{"name":"voting fraud","description":"Voting irregularities, such as voting with multiple accounts."}`},
+		{ID: 134, Key: "reason.suspend_other", Value: `{"name":"something else","description":"Violating the community guidelines for another reason not listed above.","content_type":"textarea"}`},
+		{ID: 135, Key: "user.suspend.reasons", Value: `["reason.suspend_spam","reason.suspend_abusive","reason.suspend_voting_fraud","reason.suspend_other"]`},
+	}
+	for _, c := range defaultConfigTable {
+		exist, err := x.Context(ctx).Get(&entity.Config{ID: c.ID})
+		if err != nil {
+			return fmt.Errorf("get config failed: %w", err)
+		}
+		if exist {
+			if _, err = x.Context(ctx).ID(c.ID).Update(c); err != nil {
+				return fmt.Errorf("update config failed: %w", err)
+			}
+			continue
+		}
+		if _, err = x.Context(ctx).Insert(&entity.Config{ID: c.ID, Key: c.Key, Value: c.Value}); err != nil {
+			return fmt.Errorf("add config failed: %w", err)
+		}
+	}
+	return nil
+}
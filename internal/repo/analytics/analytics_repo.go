@@ -0,0 +1,230 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/analytics"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// analyticsRepo analytics repository
+type analyticsRepo struct {
+	data *data.Data
+}
+
+// NewAnalyticsRepo new repository
+func NewAnalyticsRepo(data *data.Data) analytics.AnalyticsRepo {
+	return &analyticsRepo{
+		data: data,
+	}
+}
+
+// GetQuestionsByCreatedTime get the questions created in the time range
+func (ar *analyticsRepo) GetQuestionsByCreatedTime(ctx context.Context, start, end time.Time) (
+	questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	err = ar.data.DB.Context(ctx).Cols("id", "user_id", "created_at", "accepted_answer_id", "answer_count").
+		Where("created_at >= ? AND created_at < ?", start, end).
+		In("status", []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed}).
+		Find(&questions)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return questions, nil
+}
+
+// GetAnswersByCreatedTime get the answers created in the time range
+func (ar *analyticsRepo) GetAnswersByCreatedTime(ctx context.Context, start, end time.Time) (
+	answers []*entity.Answer, err error) {
+	answers = make([]*entity.Answer, 0)
+	err = ar.data.DB.Context(ctx).Cols("id", "question_id", "user_id").
+		Where("created_at >= ? AND created_at < ?", start, end).
+		And("status = ?", entity.AnswerStatusAvailable).
+		Find(&answers)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return answers, nil
+}
+
+// GetCommentsByCreatedTime get the comments created in the time range
+func (ar *analyticsRepo) GetCommentsByCreatedTime(ctx context.Context, start, end time.Time) (
+	comments []*entity.Comment, err error) {
+	comments = make([]*entity.Comment, 0)
+	err = ar.data.DB.Context(ctx).Cols("id", "question_id", "user_id").
+		Where("created_at >= ? AND created_at < ?", start, end).
+		And("status = ?", entity.CommentStatusAvailable).
+		Find(&comments)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return comments, nil
+}
+
+// GetVotesByCreatedTime get the vote activities created in the time range
+func (ar *analyticsRepo) GetVotesByCreatedTime(ctx context.Context, start, end time.Time, activityTypes []int) (
+	votes []*entity.Activity, err error) {
+	votes = make([]*entity.Activity, 0)
+	if len(activityTypes) == 0 {
+		return votes, nil
+	}
+	err = ar.data.DB.Context(ctx).Cols("id", "object_id", "trigger_user_id", "activity_type").
+		Where("created_at >= ? AND created_at < ?", start, end).
+		And("cancelled = ?", entity.ActivityAvailable).
+		In("activity_type", activityTypes).
+		Find(&votes)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return votes, nil
+}
+
+// GetUserCountByCreatedTime get the count of users registered in the time range
+func (ar *analyticsRepo) GetUserCountByCreatedTime(ctx context.Context, start, end time.Time) (count int64, err error) {
+	count, err = ar.data.DB.Context(ctx).
+		Where("created_at >= ? AND created_at < ?", start, end).
+		And("status <> ?", entity.UserStatusDeleted).
+		Count(&entity.User{})
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return count, nil
+}
+
+// GetFirstAnswerTimes get the created time of the first answer of the questions
+func (ar *analyticsRepo) GetFirstAnswerTimes(ctx context.Context, questionIDs []string) (
+	mapping map[string]time.Time, err error) {
+	mapping = make(map[string]time.Time)
+	if len(questionIDs) == 0 {
+		return mapping, nil
+	}
+	answers := make([]*entity.Answer, 0)
+	err = ar.data.DB.Context(ctx).Cols("question_id", "created_at").
+		In("question_id", questionIDs).And("status = ?", entity.AnswerStatusAvailable).
+		Find(&answers)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, answer := range answers {
+		if first, ok := mapping[answer.QuestionID]; !ok || answer.CreatedAt.Before(first) {
+			mapping[answer.QuestionID] = answer.CreatedAt
+		}
+	}
+	return mapping, nil
+}
+
+// GetAnswerQuestionIDs get the question id of the answers
+func (ar *analyticsRepo) GetAnswerQuestionIDs(ctx context.Context, answerIDs []string) (
+	mapping map[string]string, err error) {
+	mapping = make(map[string]string)
+	if len(answerIDs) == 0 {
+		return mapping, nil
+	}
+	answers := make([]*entity.Answer, 0)
+	err = ar.data.DB.Context(ctx).Cols("id", "question_id").In("id", answerIDs).Find(&answers)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, answer := range answers {
+		mapping[answer.ID] = answer.QuestionID
+	}
+	return mapping, nil
+}
+
+// GetQuestionTagIDs get the tag ids of the questions
+func (ar *analyticsRepo) GetQuestionTagIDs(ctx context.Context, questionIDs []string) (
+	mapping map[string][]string, err error) {
+	mapping = make(map[string][]string)
+	if len(questionIDs) == 0 {
+		return mapping, nil
+	}
+	tagRelList := make([]*entity.TagRel, 0)
+	err = ar.data.DB.Context(ctx).Cols("object_id", "tag_id").
+		In("object_id", questionIDs).And("status = ?", entity.TagRelStatusAvailable).
+		Find(&tagRelList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, rel := range tagRelList {
+		mapping[rel.ObjectID] = append(mapping[rel.ObjectID], rel.TagID)
+	}
+	return mapping, nil
+}
+
+// GetEarliestUserCreatedTime get the created time of the first user, the history begins from it
+func (ar *analyticsRepo) GetEarliestUserCreatedTime(ctx context.Context) (createdAt time.Time, exist bool, err error) {
+	user := &entity.User{}
+	exist, err = ar.data.DB.Context(ctx).Cols("created_at").Asc("created_at").Get(user)
+	if err != nil {
+		return createdAt, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return user.CreatedAt, exist, nil
+}
+
+// SaveAnalyticsDaily replace the statistics of the date
+func (ar *analyticsRepo) SaveAnalyticsDaily(ctx context.Context, date string, list []*entity.AnalyticsDaily) (err error) {
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where("stat_date = ?", date).Delete(&entity.AnalyticsDaily{}); err != nil {
+			return nil, err
+		}
+		if len(list) == 0 {
+			return nil, nil
+		}
+		_, err = session.Insert(list)
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetAnalyticsDailyList get the statistics of the tag between start date and end date
+func (ar *analyticsRepo) GetAnalyticsDailyList(ctx context.Context, tagID, startDate, endDate string) (
+	list []*entity.AnalyticsDaily, err error) {
+	list = make([]*entity.AnalyticsDaily, 0)
+	err = ar.data.DB.Context(ctx).Where("tag_id = ?", tagID).
+		And("stat_date >= ? AND stat_date <= ?", startDate, endDate).Asc("stat_date").Find(&list)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return list, nil
+}
+
+// GetAnalyticsDates get the dates which have been computed between start date and end date
+func (ar *analyticsRepo) GetAnalyticsDates(ctx context.Context, startDate, endDate string) (
+	dates []string, err error) {
+	dates = make([]string, 0)
+	err = ar.data.DB.Context(ctx).Table(entity.AnalyticsDaily{}.TableName()).
+		Where("tag_id = ?", 0).And("stat_date >= ? AND stat_date <= ?", startDate, endDate).
+		Cols("stat_date").Find(&dates)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return dates, nil
+}
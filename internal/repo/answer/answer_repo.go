@@ -29,6 +29,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/pager"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/space"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
@@ -333,6 +334,7 @@ func (ar *answerRepo) SearchList(ctx context.Context, search *entity.AnswerSearc
 	if len(search.UserID) > 0 {
 		session = session.And("user_id = ?", search.UserID)
 	}
+	session = session.And(space.QuestionIDAccessCond(ctx, "question_id"))
 	switch search.Order {
 	case entity.AnswerSearchOrderByTime:
 		session = session.OrderBy("created_at desc")
@@ -387,6 +389,7 @@ func (ar *answerRepo) GetPersonalAnswerPage(ctx context.Context, req *entity.Per
 	} else {
 		session = session.And("status = ?", entity.AnswerStatusAvailable)
 	}
+	session = session.And(space.QuestionIDAccessCond(ctx, "question_id"))
 	resp = make([]*entity.Answer, 0)
 	total, err = pager.Help(req.Page, req.PageSize, &resp, cond, session)
 	if err != nil {
@@ -506,6 +509,8 @@ func (ar *answerRepo) updateSearch(ctx context.Context, answerID string) (err er
 		Score:       int64(answer.VoteCount),
 		HasAccepted: answer.Accepted == schema.AnswerAcceptedEnable,
 	}
-	err = s.UpdateContent(ctx, content)
+	err = plugin.TraceCall(ctx, s, func(ctx context.Context) error {
+		return s.UpdateContent(ctx, content)
+	})
 	return
 }
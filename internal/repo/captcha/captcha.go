@@ -115,3 +115,52 @@ func (cr *captchaRepo) DelCaptcha(ctx context.Context, key string) (err error) {
 	}
 	return nil
 }
+
+// AddCaptchaStat add the counts to the daily statistics of the captcha of the action.
+// The counts are increased first, the statistics of the day is inserted only if it doesn't exist.
+func (cr *captchaRepo) AddCaptchaStat(ctx context.Context, date, actionType string,
+	triggeredCount, failedCount int) (err error) {
+	if triggeredCount == 0 && failedCount == 0 {
+		return nil
+	}
+	affected, err := cr.incrCaptchaStat(ctx, date, actionType, triggeredCount, failedCount)
+	if err == nil && affected == 0 {
+		_, err = cr.data.DB.Context(ctx).Insert(&entity.CaptchaStatDaily{
+			Action:         actionType,
+			StatDate:       date,
+			TriggeredCount: triggeredCount,
+			FailedCount:    failedCount,
+		})
+		if err != nil {
+			// the statistics is inserted by other request at the same time, so the unique key is violated
+			log.Debugf("insert captcha stat failed, try to increase it again: %v", err)
+			affected, err = cr.incrCaptchaStat(ctx, date, actionType, triggeredCount, failedCount)
+			if err == nil && affected == 0 {
+				err = fmt.Errorf("captcha stat of %s on %s not found", actionType, date)
+			}
+		}
+	}
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (cr *captchaRepo) incrCaptchaStat(ctx context.Context, date, actionType string,
+	triggeredCount, failedCount int) (affected int64, err error) {
+	return cr.data.DB.Context(ctx).Where("action = ? AND stat_date = ?", actionType, date).
+		Incr("triggered_count", triggeredCount).Incr("failed_count", failedCount).
+		Update(&entity.CaptchaStatDaily{})
+}
+
+// GetCaptchaStatList get the daily statistics of the captcha between start date and end date
+func (cr *captchaRepo) GetCaptchaStatList(ctx context.Context, startDate, endDate string) (
+	list []*entity.CaptchaStatDaily, err error) {
+	list = make([]*entity.CaptchaStatDaily, 0)
+	err = cr.data.DB.Context(ctx).Where("stat_date >= ? AND stat_date <= ?", startDate, endDate).
+		Asc("stat_date").Find(&list)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return list, nil
+}
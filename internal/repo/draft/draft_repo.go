@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package draft
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/draft"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// draftRepo draft repository
+type draftRepo struct {
+	data *data.Data
+}
+
+// NewDraftRepo new repository
+func NewDraftRepo(data *data.Data) draft.DraftRepo {
+	return &draftRepo{
+		data: data,
+	}
+}
+
+// SaveDraft add the draft or update the content of the existing one
+func (dr *draftRepo) SaveDraft(ctx context.Context, draft *entity.Draft) (err error) {
+	if len(draft.ID) == 0 {
+		_, err = dr.data.DB.Context(ctx).Insert(draft)
+	} else {
+		_, err = dr.data.DB.Context(ctx).ID(draft.ID).
+			Cols("title", "original_text", "tags", "base_revision_id").Update(draft)
+	}
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDraft get the draft of the context
+func (dr *draftRepo) GetDraft(ctx context.Context, userID, draftType, objectID string) (
+	draft *entity.Draft, exist bool, err error) {
+	draft = &entity.Draft{}
+	exist, err = dr.data.DB.Context(ctx).
+		Where(builder.Eq{"user_id": userID, "draft_type": draftType, "object_id": objectID}).Get(draft)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveDraft remove the draft of the context
+func (dr *draftRepo) RemoveDraft(ctx context.Context, userID, draftType, objectID string) (err error) {
+	_, err = dr.data.DB.Context(ctx).
+		Where(builder.Eq{"user_id": userID, "draft_type": draftType, "object_id": objectID}).Delete(&entity.Draft{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDraftPage get the drafts of the user, the latest updated first
+func (dr *draftRepo) GetDraftPage(ctx context.Context, userID string, page, pageSize int) (
+	drafts []*entity.Draft, total int64, err error) {
+	drafts = make([]*entity.Draft, 0)
+	session := dr.data.DB.Context(ctx).Desc("updated_at")
+	total, err = pager.Help(page, pageSize, &drafts, &entity.Draft{UserID: userID}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveExpiredDrafts remove the drafts that are not updated after the time
+func (dr *draftRepo) RemoveExpiredDrafts(ctx context.Context, before time.Time) (count int64, err error) {
+	count, err = dr.data.DB.Context(ctx).Where(builder.Lt{"updated_at": before}).Delete(&entity.Draft{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
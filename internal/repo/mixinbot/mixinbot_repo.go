@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mixinbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/service/mixinbot"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// mixinBotRepo mixin bot repository
+type mixinBotRepo struct {
+	data *data.Data
+}
+
+// NewMixinBotRepo new repository
+func NewMixinBotRepo(data *data.Data) mixinbot.MixinBotRepo {
+	return &mixinBotRepo{
+		data: data,
+	}
+}
+
+// SetCardObject remember the object of the card sent by the bot
+func (mr *mixinBotRepo) SetCardObject(ctx context.Context, messageID string, object *mixinbot.CardObject) (err error) {
+	cacheData, _ := json.Marshal(object)
+	err = mr.data.Cache.SetString(ctx, constant.MixinBotCardCacheKey+messageID, string(cacheData),
+		constant.MixinBotCardCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetCardObject get the object of the card sent by the bot
+func (mr *mixinBotRepo) GetCardObject(ctx context.Context, messageID string) (
+	object *mixinbot.CardObject, exist bool, err error) {
+	res, exist, err := mr.data.Cache.GetString(ctx, constant.MixinBotCardCacheKey+messageID)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, false, nil
+	}
+	object = &mixinbot.CardObject{}
+	if err = json.Unmarshal([]byte(res), object); err != nil {
+		return nil, false, nil
+	}
+	return object, true, nil
+}
+
+// CheckAndRecordMessage record the message, returns true if the message has been recorded before
+func (mr *mixinBotRepo) CheckAndRecordMessage(ctx context.Context, key string) (duplicate bool, err error) {
+	_, exist, err := mr.data.Cache.GetString(ctx, constant.MixinBotMessageCacheKey+key)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return true, nil
+	}
+	err = mr.data.Cache.SetString(ctx, constant.MixinBotMessageCacheKey+key,
+		fmt.Sprintf("%d", time.Now().Unix()), constant.MixinBotMessageCacheTime)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return false, nil
+}
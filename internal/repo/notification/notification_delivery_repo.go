@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/notification_channel"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// notificationDeliveryRepo notification delivery repository
+type notificationDeliveryRepo struct {
+	data *data.Data
+}
+
+// NewNotificationDeliveryRepo new repository
+func NewNotificationDeliveryRepo(data *data.Data) notification_channel.NotificationDeliveryRepo {
+	return &notificationDeliveryRepo{
+		data: data,
+	}
+}
+
+// AddDelivery add notification delivery
+func (nr *notificationDeliveryRepo) AddDelivery(ctx context.Context, delivery *entity.NotificationDelivery) (err error) {
+	_, err = nr.data.DB.Context(ctx).Insert(delivery)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateDelivery update the status of the notification delivery
+func (nr *notificationDeliveryRepo) UpdateDelivery(ctx context.Context, delivery *entity.NotificationDelivery) (err error) {
+	_, err = nr.data.DB.Context(ctx).ID(delivery.ID).
+		Cols("status", "attempts", "error_msg", "scheduled_at", "delivered_at").Update(delivery)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDueDeliveries get the pending deliveries that are scheduled before the time
+func (nr *notificationDeliveryRepo) GetDueDeliveries(ctx context.Context, before time.Time, limit int) (
+	deliveries []*entity.NotificationDelivery, err error) {
+	deliveries = make([]*entity.NotificationDelivery, 0)
+	err = nr.data.DB.Context(ctx).
+		Where("status = ?", entity.NotificationDeliveryStatusPending).
+		And("scheduled_at <= ?", before).
+		Asc("scheduled_at").Limit(limit).Find(&deliveries)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
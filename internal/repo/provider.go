@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/repo/activity"
 	"github.com/apache/incubator-answer/internal/repo/activity_common"
+	"github.com/apache/incubator-answer/internal/repo/analytics"
 	"github.com/apache/incubator-answer/internal/repo/answer"
 	"github.com/apache/incubator-answer/internal/repo/auth"
 	"github.com/apache/incubator-answer/internal/repo/badge"
@@ -32,9 +33,11 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/collection"
 	"github.com/apache/incubator-answer/internal/repo/comment"
 	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/draft"
 	"github.com/apache/incubator-answer/internal/repo/export"
 	"github.com/apache/incubator-answer/internal/repo/limit"
 	"github.com/apache/incubator-answer/internal/repo/meta"
+	"github.com/apache/incubator-answer/internal/repo/mixinbot"
 	"github.com/apache/incubator-answer/internal/repo/notification"
 	"github.com/apache/incubator-answer/internal/repo/plugin_config"
 	"github.com/apache/incubator-answer/internal/repo/question"
@@ -44,12 +47,15 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/review"
 	"github.com/apache/incubator-answer/internal/repo/revision"
 	"github.com/apache/incubator-answer/internal/repo/role"
+	"github.com/apache/incubator-answer/internal/repo/scim"
 	"github.com/apache/incubator-answer/internal/repo/search_common"
 	"github.com/apache/incubator-answer/internal/repo/site_info"
+	"github.com/apache/incubator-answer/internal/repo/space"
 	"github.com/apache/incubator-answer/internal/repo/tag"
 	"github.com/apache/incubator-answer/internal/repo/tag_common"
 	"github.com/apache/incubator-answer/internal/repo/unique"
 	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/repo/user_data"
 	"github.com/apache/incubator-answer/internal/repo/user_external_login"
 	"github.com/apache/incubator-answer/internal/repo/user_notification_config"
 	"github.com/google/wire"
@@ -63,6 +69,7 @@ var ProviderSetRepo = wire.NewSet(
 	comment.NewCommentRepo,
 	comment.NewCommentCommonRepo,
 	captcha.NewCaptchaRepo,
+	draft.NewDraftRepo,
 	unique.NewUniqueIDRepo,
 	report.NewReportRepo,
 	activity_common.NewFollowRepo,
@@ -70,8 +77,11 @@ var ProviderSetRepo = wire.NewSet(
 	config.NewConfigRepo,
 	user.NewUserRepo,
 	user.NewUserAdminRepo,
+	user.NewUserSuspensionRepo,
 	rank.NewUserRankRepo,
+	rank.NewReputationRepo,
 	question.NewQuestionRepo,
+	question.NewQuestionViewRepo,
 	answer.NewAnswerRepo,
 	activity_common.NewActivityRepo,
 	activity.NewVoteRepo,
@@ -83,6 +93,8 @@ var ProviderSetRepo = wire.NewSet(
 	tag.NewTagRepo,
 	tag_common.NewTagCommonRepo,
 	tag.NewTagRelRepo,
+	tag.NewTagModeratorRepo,
+	tag.NewTagCategoryRepo,
 	collection.NewCollectionRepo,
 	collection.NewCollectionGroupRepo,
 	auth.NewAuthRepo,
@@ -93,11 +105,14 @@ var ProviderSetRepo = wire.NewSet(
 	reason.NewReasonRepo,
 	site_info.NewSiteInfo,
 	notification.NewNotificationRepo,
+	notification.NewNotificationDeliveryRepo,
 	role.NewRoleRepo,
 	role.NewUserRoleRelRepo,
 	role.NewRolePowerRelRepo,
 	role.NewPowerRepo,
 	user_external_login.NewUserExternalLoginRepo,
+	user_external_login.NewExternalProfileRepo,
+	mixinbot.NewMixinBotRepo,
 	plugin_config.NewPluginConfigRepo,
 	user_notification_config.NewUserNotificationConfigRepo,
 	limit.NewRateLimitRepo,
@@ -107,4 +122,8 @@ var ProviderSetRepo = wire.NewSet(
 	badge.NewEventRuleRepo,
 	badge_group.NewBadgeGroupRepo,
 	badge_award.NewBadgeAwardRepo,
+	scim.NewScimRepo,
+	user_data.NewUserDataRepo,
+	space.NewSpaceRepo,
+	analytics.NewAnalyticsRepo,
 )
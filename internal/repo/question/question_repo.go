@@ -33,6 +33,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/pager"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/space"
 	"github.com/apache/incubator-answer/internal/schema"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	"github.com/apache/incubator-answer/internal/service/unique"
@@ -64,6 +65,9 @@ func NewQuestionRepo(
 
 // AddQuestion add question
 func (qr *questionRepo) AddQuestion(ctx context.Context, question *entity.Question) (err error) {
+	if len(question.SpaceID) == 0 {
+		question.SpaceID = entity.NoSpaceID
+	}
 	question.ID, err = qr.uniqueIDRepo.GenUniqueIDStr(ctx, question.TableName())
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
@@ -102,17 +106,6 @@ func (qr *questionRepo) UpdateQuestion(ctx context.Context, question *entity.Que
 	return
 }
 
-func (qr *questionRepo) UpdatePvCount(ctx context.Context, questionID string) (err error) {
-	questionID = uid.DeShortID(questionID)
-	question := &entity.Question{}
-	_, err = qr.data.DB.Context(ctx).Where("id =?", questionID).Incr("view_count", 1).Update(question)
-	if err != nil {
-		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
-	}
-	_ = qr.UpdateSearch(ctx, question.ID)
-	return nil
-}
-
 func (qr *questionRepo) UpdateAnswerCount(ctx context.Context, questionID string, num int) (err error) {
 	questionID = uid.DeShortID(questionID)
 	question := &entity.Question{}
@@ -179,13 +172,71 @@ func (qr *questionRepo) RecoverQuestion(ctx context.Context, questionID string)
 
 func (qr *questionRepo) UpdateQuestionOperation(ctx context.Context, question *entity.Question) (err error) {
 	question.ID = uid.DeShortID(question.ID)
-	_, err = qr.data.DB.Context(ctx).Where("id =?", question.ID).Cols("pin", "show").Update(question)
+	_, err = qr.data.DB.Context(ctx).Where("id =?", question.ID).Cols("pin", "show", "unpin_at", "close_at").Update(question)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 	return nil
 }
 
+// PublishScheduledQuestion publish the scheduled question,
+// published is false if the question is no longer scheduled, such as it has been published by the other instance
+func (qr *questionRepo) PublishScheduledQuestion(ctx context.Context, question *entity.Question) (
+	published bool, err error) {
+	question.ID = uid.DeShortID(question.ID)
+	question.Status = entity.QuestionStatusAvailable
+	affected, err := qr.data.DB.Context(ctx).Where("id = ?", question.ID).
+		And("status = ?", entity.QuestionStatusScheduled).
+		Cols("status", "created_at", "post_update_time").Update(question)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if handler.GetEnableShortID(ctx) {
+		question.ID = uid.EnShortID(question.ID)
+	}
+	if affected == 0 {
+		return false, nil
+	}
+	_ = qr.UpdateSearch(ctx, question.ID)
+	return true, nil
+}
+
+// GetScheduledQuestions get the scheduled questions that should be published before the given time
+func (qr *questionRepo) GetScheduledQuestions(ctx context.Context, before time.Time) (
+	questionList []*entity.Question, err error) {
+	questionList = make([]*entity.Question, 0)
+	session := qr.data.DB.Context(ctx).Where("status = ?", entity.QuestionStatusScheduled)
+	session.And(builder.Lte{"publish_at": before})
+	err = session.OrderBy("publish_at ASC").Find(&questionList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if handler.GetEnableShortID(ctx) {
+		for _, item := range questionList {
+			item.ID = uid.EnShortID(item.ID)
+		}
+	}
+	return
+}
+
+// GetExpiredPinnedQuestions get the questions whose unpin or close time is before the given time
+func (qr *questionRepo) GetExpiredPinnedQuestions(ctx context.Context, before time.Time) (
+	questionList []*entity.Question, err error) {
+	questionList = make([]*entity.Question, 0)
+	session := qr.data.DB.Context(ctx).Where(builder.Lt{"status": entity.QuestionStatusDeleted})
+	session.And(builder.Lte{"unpin_at": before}.Or(builder.Lte{"close_at": before}))
+	err = session.Find(&questionList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if handler.GetEnableShortID(ctx) {
+		for _, item := range questionList {
+			item.ID = uid.EnShortID(item.ID)
+		}
+	}
+	return
+}
+
 func (qr *questionRepo) UpdateAccepted(ctx context.Context, question *entity.Question) (err error) {
 	question.ID = uid.DeShortID(question.ID)
 	_, err = qr.data.DB.Context(ctx).Where("id =?", question.ID).Cols("accepted_answer_id").Update(question)
@@ -228,8 +279,9 @@ func (qr *questionRepo) GetQuestionsByTitle(ctx context.Context, title string, p
 	questionList []*entity.Question, err error) {
 	questionList = make([]*entity.Question, 0)
 	session := qr.data.DB.Context(ctx)
-	session.Where("status != ?", entity.QuestionStatusDeleted)
+	session.In("status", []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed})
 	session.Where("title like ?", "%"+title+"%")
+	session.And(space.QuestionAccessCond(ctx, "space_id"))
 	session.Limit(pageSize)
 	err = session.Find(&questionList)
 	if err != nil {
@@ -313,6 +365,7 @@ func (qr *questionRepo) SitemapQuestions(ctx context.Context, page, pageSize int
 	session.Select("id,title,created_at,post_update_time")
 	session.Where("`show` = ?", entity.QuestionShow)
 	session.Where("status = ? OR status = ?", entity.QuestionStatusAvailable, entity.QuestionStatusClosed)
+	session.And(space.PublicQuestionCond("space_id"))
 	session.Limit(pageSize, page*pageSize)
 	session.Asc("created_at")
 	err = session.Find(&rows)
@@ -345,7 +398,7 @@ func (qr *questionRepo) SitemapQuestions(ctx context.Context, page, pageSize int
 
 // GetQuestionPage query question page
 func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
-	tagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) (
+	tagIDs []string, spaceID, userID, orderCond string, inDays int, showHidden, showPending bool) (
 	questionList []*entity.Question, total int64, err error) {
 	questionList = make([]*entity.Question, 0)
 	session := qr.data.DB.Context(ctx)
@@ -354,6 +407,10 @@ func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
 		status = append(status, entity.QuestionStatusPending)
 	}
 	session.In("question.status", status)
+	session.And(space.QuestionAccessCond(ctx, "question.space_id"))
+	if len(spaceID) > 0 {
+		session.And("question.space_id = ?", spaceID)
+	}
 	if len(tagIDs) > 0 {
 		session.Join("LEFT", "tag_rel", "question.id = tag_rel.object_id")
 		session.In("tag_rel.tag_id", tagIDs)
@@ -439,6 +496,7 @@ func (qr *questionRepo) GetRecommendQuestionPageByTags(ctx context.Context, user
 
 	session.
 		And("question.show = ? and question.status = ?", entity.QuestionShow, entity.QuestionStatusAvailable).
+		And(space.QuestionAccessCond(ctx, "question.space_id")).
 		Distinct("question.id").
 		OrderBy(orderBySQL)
 
@@ -544,6 +602,10 @@ func (qr *questionRepo) UpdateSearch(ctx context.Context, questionID string) (er
 	if err != nil {
 		return err
 	}
+	// the scheduled question is indexed when it is published
+	if question.Status == entity.QuestionStatusScheduled {
+		return nil
+	}
 
 	// get tags
 	var (
@@ -575,7 +637,9 @@ func (qr *questionRepo) UpdateSearch(ctx context.Context, questionID string) (er
 		Score:       int64(question.VoteCount),
 		HasAccepted: question.AcceptedAnswerID != "" && question.AcceptedAnswerID != "0",
 	}
-	err = s.UpdateContent(ctx, content)
+	err = plugin.TraceCall(ctx, s, func(ctx context.Context) error {
+		return s.UpdateContent(ctx, content)
+	})
 	return
 }
 
@@ -0,0 +1,256 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/question_view"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// questionViewRepo question view repository
+type questionViewRepo struct {
+	data *data.Data
+	// initLock guards the initialization of the keys for the caches which can not increase a missing key
+	initLock sync.Mutex
+}
+
+// NewQuestionViewRepo new repository
+func NewQuestionViewRepo(data *data.Data) question_view.QuestionViewRepo {
+	return &questionViewRepo{
+		data: data,
+	}
+}
+
+// RecordView record the viewer of the question, newView is false if the viewer has viewed it in the window,
+// newUniqueView is false if the viewer has viewed it in the day
+func (qr *questionViewRepo) RecordView(ctx context.Context, date, questionID, fingerprint string) (
+	newView, newUniqueView bool, err error) {
+	newView, err = qr.setIfNotExist(ctx, fmt.Sprintf(constant.QuestionViewCacheKey, questionID, fingerprint),
+		constant.QuestionViewCacheTime)
+	if err != nil || !newView {
+		return false, false, err
+	}
+	newUniqueView, err = qr.setIfNotExist(ctx,
+		fmt.Sprintf(constant.QuestionUniqueViewCacheKey, date, questionID, fingerprint),
+		constant.QuestionUniqueViewCacheTime)
+	if err != nil {
+		return false, false, err
+	}
+	return newView, newUniqueView, nil
+}
+
+// AddPendingView add the views waiting for flushing to the database. The first pending views of the date
+// mark the daily bucket as pending, so that any instance can find and flush them.
+func (qr *questionViewRepo) AddPendingView(ctx context.Context, date, questionID string,
+	viewCount, uniqueViewCount int64) (err error) {
+	first := false
+	if viewCount > 0 {
+		total, err := qr.increase(ctx, fmt.Sprintf(constant.QuestionPendingViewCacheKey, date, questionID),
+			viewCount, constant.QuestionPendingViewCacheTime)
+		if err != nil {
+			return err
+		}
+		first = total == viewCount
+	}
+	if uniqueViewCount > 0 {
+		total, err := qr.increase(ctx, fmt.Sprintf(constant.QuestionPendingUniqueViewCacheKey, date, questionID),
+			uniqueViewCount, constant.QuestionPendingViewCacheTime)
+		if err != nil {
+			return err
+		}
+		first = first || total == uniqueViewCount
+	}
+	if !first {
+		return nil
+	}
+	return qr.markPending(ctx, date, questionID)
+}
+
+// TakePendingView take out the views waiting for flushing to the database, the pending mark is cleared before
+// taking, so the views added concurrently mark the daily bucket again and are kept for the next flushing
+func (qr *questionViewRepo) TakePendingView(ctx context.Context, date, questionID string) (
+	viewCount, uniqueViewCount int64, err error) {
+	_, err = qr.data.DB.Context(ctx).Where("question_id = ? AND view_date = ?", questionID, date).
+		Cols("pending").Update(&entity.QuestionViewDaily{Pending: false})
+	if err != nil {
+		return 0, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	viewCount, err = qr.take(ctx, fmt.Sprintf(constant.QuestionPendingViewCacheKey, date, questionID))
+	if err != nil {
+		return 0, 0, err
+	}
+	uniqueViewCount, err = qr.take(ctx, fmt.Sprintf(constant.QuestionPendingUniqueViewCacheKey, date, questionID))
+	if err != nil {
+		return viewCount, 0, err
+	}
+	return viewCount, uniqueViewCount, nil
+}
+
+// GetPendingViewDailyList get the daily buckets which have views waiting for flushing
+func (qr *questionViewRepo) GetPendingViewDailyList(ctx context.Context) (list []*entity.QuestionViewDaily, err error) {
+	list = make([]*entity.QuestionViewDaily, 0)
+	err = qr.data.DB.Context(ctx).Where("pending = ?", true).Asc("id").Find(&list)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return list, nil
+}
+
+// SaveQuestionViews add the views to the question and to the daily bucket of the question in one transaction
+func (qr *questionViewRepo) SaveQuestionViews(ctx context.Context, date, questionID string,
+	viewCount, uniqueViewCount int) (err error) {
+	_, err = qr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		_, err = session.Where("id = ?", questionID).
+			Incr("view_count", viewCount).Incr("unique_view_count", uniqueViewCount).
+			Update(&entity.Question{})
+		if err != nil {
+			return nil, err
+		}
+		exist, err := session.Where("question_id = ? AND view_date = ?", questionID, date).
+			Exist(&entity.QuestionViewDaily{})
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			_, err = session.Where("question_id = ? AND view_date = ?", questionID, date).
+				Incr("view_count", viewCount).Incr("unique_view_count", uniqueViewCount).
+				Update(&entity.QuestionViewDaily{})
+			return nil, err
+		}
+		_, err = session.Insert(&entity.QuestionViewDaily{
+			QuestionID:      questionID,
+			ViewDate:        date,
+			ViewCount:       viewCount,
+			UniqueViewCount: uniqueViewCount,
+		})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetQuestionViewDailyList get the daily buckets of the question between start date and end date
+func (qr *questionViewRepo) GetQuestionViewDailyList(ctx context.Context, questionID, startDate, endDate string) (
+	list []*entity.QuestionViewDaily, err error) {
+	list = make([]*entity.QuestionViewDaily, 0)
+	err = qr.data.DB.Context(ctx).Where("question_id = ?", questionID).
+		And("view_date >= ? AND view_date <= ?", startDate, endDate).Asc("view_date").Find(&list)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return list, nil
+}
+
+// markPending mark the daily bucket as pending, the bucket is created if it does not exist
+func (qr *questionViewRepo) markPending(ctx context.Context, date, questionID string) (err error) {
+	session := qr.data.DB.Context(ctx)
+	exist, err := session.Where("question_id = ? AND view_date = ?", questionID, date).
+		Exist(&entity.QuestionViewDaily{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		_, err = qr.data.DB.Context(ctx).Insert(&entity.QuestionViewDaily{
+			QuestionID: questionID,
+			ViewDate:   date,
+			Pending:    true,
+		})
+		if err == nil {
+			return nil
+		}
+		// the bucket is created concurrently, mark it below
+	}
+	_, err = qr.data.DB.Context(ctx).Where("question_id = ? AND view_date = ?", questionID, date).
+		Cols("pending").Update(&entity.QuestionViewDaily{Pending: true})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// setIfNotExist set the key atomically, ok is true only for the caller which creates the key
+func (qr *questionViewRepo) setIfNotExist(ctx context.Context, key string, ttl time.Duration) (ok bool, err error) {
+	value, err := qr.increase(ctx, key, 1, ttl)
+	if err != nil {
+		return false, err
+	}
+	if value != 1 {
+		return false, nil
+	}
+	// the key may be created by the increasing without ttl, only its existence matters so it is safe to set it again
+	if err = qr.data.Cache.SetInt64(ctx, key, value, ttl); err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return true, nil
+}
+
+// increase increase the value of the key atomically and return the new value,
+// the key is created with the ttl if the cache can not increase a missing key
+func (qr *questionViewRepo) increase(ctx context.Context, key string, value int64, ttl time.Duration) (
+	total int64, err error) {
+	if total, err = qr.data.Cache.Increase(ctx, key, value); err == nil {
+		return total, nil
+	}
+
+	// the memory cache can not increase a missing key, it is local to this instance so a lock is enough
+	qr.initLock.Lock()
+	defer qr.initLock.Unlock()
+	_, exist, err := qr.data.Cache.GetInt64(ctx, key)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		total, err = qr.data.Cache.Increase(ctx, key, value)
+	} else {
+		total, err = value, qr.data.Cache.SetInt64(ctx, key, value, ttl)
+	}
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return total, nil
+}
+
+// take get the value and decrease it, the views added concurrently are kept for the next flushing
+func (qr *questionViewRepo) take(ctx context.Context, key string) (value int64, err error) {
+	value, exist, err := qr.data.Cache.GetInt64(ctx, key)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist || value <= 0 {
+		return 0, nil
+	}
+	if _, err = qr.data.Cache.Decrease(ctx, key, value); err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return value, nil
+}
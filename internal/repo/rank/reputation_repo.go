@@ -0,0 +1,162 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package rank
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/reputation"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// reputationRepo reputation repository
+type reputationRepo struct {
+	data *data.Data
+}
+
+// NewReputationRepo new repository
+func NewReputationRepo(data *data.Data) reputation.ReputationRepo {
+	return &reputationRepo{
+		data: data,
+	}
+}
+
+// GetRuleConfigs get all configs, the reputation rules are the configs whose id is the activity type
+func (rr *reputationRepo) GetRuleConfigs(ctx context.Context) (configs []*entity.Config, err error) {
+	configs = make([]*entity.Config, 0)
+	err = rr.data.DB.Context(ctx).Find(&configs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUser get the user including the deleted one
+func (rr *reputationRepo) GetUser(ctx context.Context, userID string) (user *entity.User, exist bool, err error) {
+	user = &entity.User{}
+	exist, err = rr.data.DB.Context(ctx).ID(userID).Get(user)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserPage get the users that are not deleted in the order of id
+func (rr *reputationRepo) GetUserPage(ctx context.Context, page, pageSize int) (
+	users []*entity.User, total int64, err error) {
+	users = make([]*entity.User, 0)
+	session := rr.data.DB.Context(ctx).Where(builder.Neq{"status": entity.UserStatusDeleted}).Asc("id")
+	total, err = pager.Help(page, pageSize, &users, &entity.User{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserActivities get the available activities of the users
+func (rr *reputationRepo) GetUserActivities(ctx context.Context, userIDs []string) (
+	activities []*entity.Activity, err error) {
+	activities = make([]*entity.Activity, 0)
+	err = rr.data.DB.Context(ctx).In("user_id", userIDs).
+		Where(builder.Eq{"cancelled": entity.ActivityAvailable}).Asc("id").Find(&activities)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetWikiObjectIDs get the ids of the community wiki questions and answers
+func (rr *reputationRepo) GetWikiObjectIDs(ctx context.Context) (objectIDs []string, err error) {
+	objectIDs = make([]string, 0)
+	questionIDs := make([]string, 0)
+	err = rr.data.DB.Context(ctx).Table(entity.Question{}.TableName()).
+		Where(builder.Eq{"wiki": true}).Cols("id").Find(&questionIDs)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	answerIDs := make([]string, 0)
+	err = rr.data.DB.Context(ctx).Table(entity.Answer{}.TableName()).
+		Where(builder.Eq{"wiki": true}).Cols("id").Find(&answerIDs)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	objectIDs = append(objectIDs, questionIDs...)
+	objectIDs = append(objectIDs, answerIDs...)
+	return objectIDs, nil
+}
+
+// AdjustUserRank set the user rank to the expected one and record the adjustment activity,
+// adjusted is false if the user rank has been changed since it was read
+func (rr *reputationRepo) AdjustUserRank(ctx context.Context, user *entity.User, expected int,
+	activity *entity.Activity) (adjusted bool, err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		affected, err := session.ID(user.ID).Where(builder.Eq{"`rank`": user.Rank}).
+			Cols("`rank`").Update(&entity.User{Rank: expected})
+		if err != nil || affected == 0 {
+			return nil, err
+		}
+		if _, err = session.Insert(activity); err != nil {
+			return nil, err
+		}
+		adjusted = true
+		return nil, nil
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return adjusted, nil
+}
+
+// SetLedgerCache set the replayed ledger of the user
+func (rr *reputationRepo) SetLedgerCache(ctx context.Context, userID string, ledger *reputation.LedgerCache) (err error) {
+	cacheData, _ := json.Marshal(ledger)
+	err = rr.data.Cache.SetString(ctx, constant.ReputationLedgerCacheKey+userID, string(cacheData),
+		constant.ReputationLedgerCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetLedgerCache get the replayed ledger of the user
+func (rr *reputationRepo) GetLedgerCache(ctx context.Context, userID string) (
+	ledger *reputation.LedgerCache, exist bool, err error) {
+	res, exist, err := rr.data.Cache.GetString(ctx, constant.ReputationLedgerCacheKey+userID)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, false, nil
+	}
+	ledger = &reputation.LedgerCache{}
+	if err = json.Unmarshal([]byte(res), ledger); err != nil {
+		return nil, false, nil
+	}
+	return ledger, true, nil
+}
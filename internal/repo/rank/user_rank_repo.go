@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/pager"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/plugin"
@@ -63,6 +64,7 @@ func (ur *UserRankRepo) CheckReachLimit(ctx context.Context, session *xorm.Sessi
 	reach bool, err error) {
 	session.Where(builder.Eq{"user_id": userID})
 	session.Where(builder.Eq{"cancelled": 0})
+	session.Where(ur.excludeAdjustCond(ctx))
 	session.Where(builder.Between{
 		Col:     "updated_at",
 		LessVal: now.BeginningOfDay(),
@@ -175,6 +177,7 @@ func (ur *UserRankRepo) checkUserTodayRank(ctx context.Context,
 	start, end := now.BeginningOfDay(), now.EndOfDay()
 	session.Where(builder.Eq{"user_id": userID})
 	session.Where(builder.Eq{"cancelled": 0})
+	session.Where(ur.excludeAdjustCond(ctx))
 	session.Where(builder.Between{
 		Col:     "updated_at",
 		LessVal: start,
@@ -198,6 +201,16 @@ func (ur *UserRankRepo) checkUserTodayRank(ctx context.Context,
 	return true, nil
 }
 
+// excludeAdjustCond the reputation corrections are not earned, so they are not counted in the daily limit
+func (ur *UserRankRepo) excludeAdjustCond(ctx context.Context) builder.Cond {
+	adjustType, err := ur.configService.GetIDByKey(ctx, activity_type.ReputationAdjust)
+	if err != nil {
+		log.Error(err)
+		return builder.NewCond()
+	}
+	return builder.Neq{"activity_type": adjustType}
+}
+
 func (ur *UserRankRepo) UserRankPage(ctx context.Context, userID string, page, pageSize int) (
 	rankPage []*entity.Activity, total int64, err error,
 ) {
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/analytics"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_analyticsRepo_SaveAnalyticsDaily(t *testing.T) {
+	analyticsRepo := analytics.NewAnalyticsRepo(testDataSource)
+	err := analyticsRepo.SaveAnalyticsDaily(context.TODO(), "2024-01-01", []*entity.AnalyticsDaily{
+		{StatDate: "2024-01-01", TagID: "0", QuestionCount: 1},
+		{StatDate: "2024-01-01", TagID: "10030000000000001", QuestionCount: 1},
+	})
+	assert.NoError(t, err)
+	err = analyticsRepo.SaveAnalyticsDaily(context.TODO(), "2024-01-01", []*entity.AnalyticsDaily{
+		{StatDate: "2024-01-01", TagID: "0", QuestionCount: 2},
+	})
+	assert.NoError(t, err)
+
+	list, err := analyticsRepo.GetAnalyticsDailyList(context.TODO(), "0", "2024-01-01", "2024-01-31")
+	assert.NoError(t, err)
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, 2, list[0].QuestionCount)
+	}
+	list, err = analyticsRepo.GetAnalyticsDailyList(context.TODO(), "10030000000000001", "2024-01-01", "2024-01-31")
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+
+	dates, err := analyticsRepo.GetAnalyticsDates(context.TODO(), "2024-01-01", "2024-01-31")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2024-01-01"}, dates)
+}
+
+func Test_analyticsRepo_GetQuestionsByCreatedTime(t *testing.T) {
+	analyticsRepo := analytics.NewAnalyticsRepo(testDataSource)
+	end := time.Now().Add(time.Hour)
+	_, err := analyticsRepo.GetQuestionsByCreatedTime(context.TODO(), end.AddDate(0, 0, -1), end)
+	assert.NoError(t, err)
+	_, err = analyticsRepo.GetVotesByCreatedTime(context.TODO(), end.AddDate(0, 0, -1), end, []int{28})
+	assert.NoError(t, err)
+	_, err = analyticsRepo.GetUserCountByCreatedTime(context.TODO(), end.AddDate(0, 0, -1), end)
+	assert.NoError(t, err)
+	_, exist, err := analyticsRepo.GetEarliestUserCreatedTime(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, exist)
+}
@@ -21,6 +21,7 @@ package repo_test
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	"github.com/apache/incubator-answer/internal/repo/captcha"
@@ -56,3 +57,34 @@ func Test_captchaRepo_SetCaptcha(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, capt, gotCaptcha)
 }
+
+func Test_captchaRepo_AddCaptchaStat(t *testing.T) {
+	captchaRepo := captcha.NewCaptchaRepo(testDataSource)
+	date := "2024-01-02"
+	getStat := func() (triggered, failed int) {
+		list, err := captchaRepo.GetCaptchaStatList(context.TODO(), date, date)
+		assert.NoError(t, err)
+		for _, stat := range list {
+			if stat.Action == actionType {
+				return stat.TriggeredCount, stat.FailedCount
+			}
+		}
+		return 0, 0
+	}
+	triggered, failed := getStat()
+
+	// the first statistics of the day is added by many requests at the same time
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(failed int) {
+			defer wg.Done()
+			assert.NoError(t, captchaRepo.AddCaptchaStat(context.TODO(), date, actionType, 1, failed))
+		}(i % 2)
+	}
+	wg.Wait()
+
+	gotTriggered, gotFailed := getStat()
+	assert.Equal(t, triggered+10, gotTriggered)
+	assert.Equal(t, failed+5, gotFailed)
+}
@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/draft"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_draftRepo_SaveDraft(t *testing.T) {
+	ctx := context.TODO()
+	draftRepo := draft.NewDraftRepo(testDataSource)
+
+	questionDraft := &entity.Draft{UserID: "910", DraftType: entity.DraftTypeQuestion, ObjectID: "0",
+		Title: "title", OriginalText: "content"}
+	assert.NoError(t, draftRepo.SaveDraft(ctx, questionDraft))
+	assert.NotEmpty(t, questionDraft.ID)
+
+	// the draft of the same context is updated
+	got, exist, err := draftRepo.GetDraft(ctx, "910", entity.DraftTypeQuestion, "0")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	got.OriginalText = "new content"
+	assert.NoError(t, draftRepo.SaveDraft(ctx, got))
+	got, _, err = draftRepo.GetDraft(ctx, "910", entity.DraftTypeQuestion, "0")
+	assert.NoError(t, err)
+	assert.Equal(t, questionDraft.ID, got.ID)
+	assert.Equal(t, "new content", got.OriginalText)
+
+	answerDraft := &entity.Draft{UserID: "910", DraftType: entity.DraftTypeAnswer, ObjectID: "10010000000000001",
+		OriginalText: "answer"}
+	assert.NoError(t, draftRepo.SaveDraft(ctx, answerDraft))
+	drafts, total, err := draftRepo.GetDraftPage(ctx, "910", 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, drafts, 2)
+
+	assert.NoError(t, draftRepo.RemoveDraft(ctx, "910", entity.DraftTypeAnswer, "10010000000000001"))
+	_, exist, err = draftRepo.GetDraft(ctx, "910", entity.DraftTypeAnswer, "10010000000000001")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_draftRepo_RemoveExpiredDrafts(t *testing.T) {
+	ctx := context.TODO()
+	draftRepo := draft.NewDraftRepo(testDataSource)
+
+	expired := &entity.Draft{UserID: "911", DraftType: entity.DraftTypeQuestion, ObjectID: "0", OriginalText: "old"}
+	notExpired := &entity.Draft{UserID: "912", DraftType: entity.DraftTypeQuestion, ObjectID: "0", OriginalText: "new"}
+	assert.NoError(t, draftRepo.SaveDraft(ctx, expired))
+	assert.NoError(t, draftRepo.SaveDraft(ctx, notExpired))
+	_, err := testDataSource.DB.Context(ctx).ID(expired.ID).NoAutoTime().Cols("updated_at").
+		Update(&entity.Draft{UpdatedAt: time.Now().Add(-48 * time.Hour)})
+	assert.NoError(t, err)
+
+	count, err := draftRepo.RemoveExpiredDrafts(ctx, time.Now().Add(-24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	_, exist, err := draftRepo.GetDraft(ctx, "912", entity.DraftTypeQuestion, "0")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+}
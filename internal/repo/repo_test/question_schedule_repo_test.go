@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/question"
+	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_questionRepo_GetScheduledQuestions(t *testing.T) {
+	ctx := context.TODO()
+	questionRepo := question.NewQuestionRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource))
+
+	due := &entity.Question{UserID: "1", Title: "scheduled question due", OriginalText: "due",
+		Status: entity.QuestionStatusScheduled, Show: entity.QuestionShow, PublishAt: time.Now().Add(-time.Minute)}
+	notDue := &entity.Question{UserID: "1", Title: "scheduled question not due", OriginalText: "not due",
+		Status: entity.QuestionStatusScheduled, Show: entity.QuestionShow, PublishAt: time.Now().Add(time.Hour)}
+	for _, q := range []*entity.Question{due, notDue} {
+		assert.NoError(t, questionRepo.AddQuestion(ctx, q))
+	}
+
+	questionList, err := questionRepo.GetScheduledQuestions(ctx, time.Now())
+	assert.NoError(t, err)
+	if assert.Len(t, questionList, 1) {
+		assert.Equal(t, due.ID, questionList[0].ID)
+	}
+}
+
+func Test_questionRepo_PublishScheduledQuestion(t *testing.T) {
+	ctx := context.TODO()
+	questionRepo := question.NewQuestionRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource))
+
+	scheduled := &entity.Question{UserID: "1", Title: "scheduled question to publish", OriginalText: "publish",
+		Status: entity.QuestionStatusScheduled, Show: entity.QuestionShow, PublishAt: time.Now().Add(-time.Minute)}
+	assert.NoError(t, questionRepo.AddQuestion(ctx, scheduled))
+
+	// the same question is loaded by two instances, only the first one publishes it
+	first, second := *scheduled, *scheduled
+	published, err := questionRepo.PublishScheduledQuestion(ctx, &first)
+	assert.NoError(t, err)
+	assert.True(t, published)
+	published, err = questionRepo.PublishScheduledQuestion(ctx, &second)
+	assert.NoError(t, err)
+	assert.False(t, published)
+
+	got, exist, err := questionRepo.GetQuestion(ctx, scheduled.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, entity.QuestionStatusAvailable, got.Status)
+}
+
+func Test_questionRepo_GetExpiredPinnedQuestions(t *testing.T) {
+	ctx := context.TODO()
+	questionRepo := question.NewQuestionRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource))
+
+	unpin := &entity.Question{UserID: "1", Title: "pinned question to unpin", OriginalText: "unpin",
+		Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow, Pin: entity.QuestionPin,
+		UnpinAt: time.Now().Add(-time.Minute)}
+	closing := &entity.Question{UserID: "1", Title: "pinned question to close", OriginalText: "close",
+		Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow, Pin: entity.QuestionPin,
+		UnpinAt: time.Now().Add(time.Hour), CloseAt: time.Now().Add(-time.Minute)}
+	pinned := &entity.Question{UserID: "1", Title: "pinned question", OriginalText: "pinned",
+		Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow, Pin: entity.QuestionPin}
+	for _, q := range []*entity.Question{unpin, closing, pinned} {
+		assert.NoError(t, questionRepo.AddQuestion(ctx, q))
+	}
+
+	questionList, err := questionRepo.GetExpiredPinnedQuestions(ctx, time.Now())
+	assert.NoError(t, err)
+	ids := make([]string, 0)
+	for _, q := range questionList {
+		ids = append(ids, q.ID)
+	}
+	assert.ElementsMatch(t, []string{unpin.ID, closing.ID}, ids)
+
+	// clearing the times makes the question no longer expired
+	unpin.Pin = entity.QuestionUnPin
+	unpin.UnpinAt = time.Time{}
+	assert.NoError(t, questionRepo.UpdateQuestionOperation(ctx, unpin))
+	questionList, err = questionRepo.GetExpiredPinnedQuestions(ctx, time.Now())
+	assert.NoError(t, err)
+	if assert.Len(t, questionList, 1) {
+		assert.Equal(t, closing.ID, questionList[0].ID)
+	}
+}
+
+func Test_questionRepo_GetQuestionsByTitleWithoutScheduled(t *testing.T) {
+	ctx := handler.WithAllSpaceAccess(context.TODO())
+	questionRepo := question.NewQuestionRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource))
+
+	available := &entity.Question{UserID: "1", Title: "title search available", OriginalText: "available",
+		Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow}
+	scheduled := &entity.Question{UserID: "1", Title: "title search scheduled", OriginalText: "scheduled",
+		Status: entity.QuestionStatusScheduled, Show: entity.QuestionShow, PublishAt: time.Now().Add(time.Hour)}
+	for _, q := range []*entity.Question{available, scheduled} {
+		assert.NoError(t, questionRepo.AddQuestion(ctx, q))
+	}
+
+	questionList, err := questionRepo.GetQuestionsByTitle(ctx, "title search", 10)
+	assert.NoError(t, err)
+	if assert.Len(t, questionList, 1) {
+		assert.Equal(t, available.ID, questionList[0].ID)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/question"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_questionViewRepo_RecordView(t *testing.T) {
+	questionViewRepo := question.NewQuestionViewRepo(testDataSource)
+	newView, newUniqueView, err := questionViewRepo.RecordView(context.TODO(), "2024-01-01", "10010000000000101", "u1")
+	assert.NoError(t, err)
+	assert.True(t, newView)
+	assert.True(t, newUniqueView)
+
+	newView, newUniqueView, err = questionViewRepo.RecordView(context.TODO(), "2024-01-01", "10010000000000101", "u1")
+	assert.NoError(t, err)
+	assert.False(t, newView)
+	assert.False(t, newUniqueView)
+}
+
+func Test_questionViewRepo_PendingView(t *testing.T) {
+	questionViewRepo := question.NewQuestionViewRepo(testDataSource)
+	err := questionViewRepo.AddPendingView(context.TODO(), "2024-01-01", "10010000000000102", 1, 1)
+	assert.NoError(t, err)
+	err = questionViewRepo.AddPendingView(context.TODO(), "2024-01-01", "10010000000000102", 1, 0)
+	assert.NoError(t, err)
+
+	viewCount, uniqueViewCount, err := questionViewRepo.TakePendingView(context.TODO(), "2024-01-01", "10010000000000102")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), viewCount)
+	assert.Equal(t, int64(1), uniqueViewCount)
+
+	viewCount, uniqueViewCount, err = questionViewRepo.TakePendingView(context.TODO(), "2024-01-01", "10010000000000102")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), viewCount)
+	assert.Equal(t, int64(0), uniqueViewCount)
+}
+
+func Test_questionViewRepo_GetPendingViewDailyList(t *testing.T) {
+	questionViewRepo := question.NewQuestionViewRepo(testDataSource)
+	err := questionViewRepo.AddPendingView(context.TODO(), "2024-01-02", "10010000000000104", 1, 1)
+	assert.NoError(t, err)
+
+	list, err := questionViewRepo.GetPendingViewDailyList(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, containsPendingView(list, "2024-01-02", "10010000000000104"))
+
+	_, _, err = questionViewRepo.TakePendingView(context.TODO(), "2024-01-02", "10010000000000104")
+	assert.NoError(t, err)
+	list, err = questionViewRepo.GetPendingViewDailyList(context.TODO())
+	assert.NoError(t, err)
+	assert.False(t, containsPendingView(list, "2024-01-02", "10010000000000104"))
+
+	// the views put back after a failed flushing mark the bucket again
+	err = questionViewRepo.AddPendingView(context.TODO(), "2024-01-02", "10010000000000104", 1, 1)
+	assert.NoError(t, err)
+	list, err = questionViewRepo.GetPendingViewDailyList(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, containsPendingView(list, "2024-01-02", "10010000000000104"))
+}
+
+func containsPendingView(list []*entity.QuestionViewDaily, date, questionID string) bool {
+	for _, item := range list {
+		if item.ViewDate == date && item.QuestionID == questionID {
+			return true
+		}
+	}
+	return false
+}
+
+func Test_questionViewRepo_SaveQuestionViews(t *testing.T) {
+	questionViewRepo := question.NewQuestionViewRepo(testDataSource)
+	err := questionViewRepo.SaveQuestionViews(context.TODO(), "2024-01-01", "10010000000000103", 2, 1)
+	assert.NoError(t, err)
+	err = questionViewRepo.SaveQuestionViews(context.TODO(), "2024-01-01", "10010000000000103", 3, 2)
+	assert.NoError(t, err)
+	err = questionViewRepo.SaveQuestionViews(context.TODO(), "2024-01-03", "10010000000000103", 1, 1)
+	assert.NoError(t, err)
+
+	list, err := questionViewRepo.GetQuestionViewDailyList(context.TODO(), "10010000000000103", "2024-01-01", "2024-01-02")
+	assert.NoError(t, err)
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, 5, list[0].ViewCount)
+		assert.Equal(t, 3, list[0].UniqueViewCount)
+	}
+}
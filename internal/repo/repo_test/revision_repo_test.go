@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/repo/question"
 	"github.com/apache/incubator-answer/internal/repo/revision"
@@ -118,3 +119,33 @@ func Test_revisionRepo_GetRevisionList(t *testing.T) {
 	assert.NoError(t, err)
 	assert.GreaterOrEqual(t, len(revs), 1)
 }
+
+func Test_revisionRepo_GetUnreviewedRevisionPageByTags(t *testing.T) {
+	var (
+		ctx          = context.TODO()
+		uniqueIDRepo = unique.NewUniqueIDRepo(testDataSource)
+		revisionRepo = revision.NewRevisionRepo(testDataSource, uniqueIDRepo)
+		questionRepo = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+	)
+
+	tagged := &entity.Question{UserID: "1", Title: "tagged revision question", OriginalText: "tagged",
+		Status: entity.QuestionStatusAvailable, RevisionID: "0"}
+	untagged := &entity.Question{UserID: "1", Title: "untagged revision question", OriginalText: "untagged",
+		Status: entity.QuestionStatusAvailable, RevisionID: "0"}
+	for _, item := range []*entity.Question{tagged, untagged} {
+		assert.NoError(t, questionRepo.AddQuestion(ctx, item))
+		rev := getRev(item.ID, item.Title, "{}")
+		rev.Status = entity.RevisionUnreviewedStatus
+		assert.NoError(t, revisionRepo.AddRevision(ctx, rev, false))
+	}
+	_, err := testDataSource.DB.Insert(&entity.TagRel{ObjectID: tagged.ID, TagID: "99001", Status: entity.TagRelStatusAvailable})
+	assert.NoError(t, err)
+
+	objectTypes := []int{constant.ObjectTypeStrMapping[constant.QuestionObjectType]}
+	revisions, total, err := revisionRepo.GetUnreviewedRevisionPage(ctx, 1, 10, objectTypes, []string{"99001"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	if assert.Len(t, revisions, 1) {
+		assert.Equal(t, tagged.ID, revisions[0].ObjectID)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	scimrepo "github.com/apache/incubator-answer/internal/repo/scim"
+	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/service/scim"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_scimRepo_GetUserPageLikeEscape(t *testing.T) {
+	ctx := context.TODO()
+	userRepo := user.NewUserRepo(testDataSource)
+	scimRepo := scimrepo.NewScimRepo(testDataSource)
+
+	for _, u := range []*entity.User{
+		{Username: "scim_like_1", EMail: "scim_100%@example.com", DisplayName: "scim 100%", Status: entity.UserStatusAvailable, MailStatus: entity.EmailStatusAvailable},
+		{Username: "scim_like_2", EMail: "scim_1000@example.com", DisplayName: "scim 1000", Status: entity.UserStatusAvailable, MailStatus: entity.EmailStatusAvailable},
+	} {
+		assert.NoError(t, userRepo.AddUser(ctx, u))
+	}
+
+	filter, err := scim.ParseFilter(`displayName co "100%"`)
+	assert.NoError(t, err)
+	users, total, err := scimRepo.GetUserPage(ctx, filter, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	if assert.Len(t, users, 1) {
+		assert.Equal(t, "scim_like_1", users[0].Username)
+	}
+
+	filter, err = scim.ParseFilter(`userName sw "scim_like_"`)
+	assert.NoError(t, err)
+	_, total, err = scimRepo.GetUserPage(ctx, filter, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	// count=0 only returns the total results
+	users, total, err = scimRepo.GetUserPage(ctx, filter, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, users, 0)
+
+	filter, err = scim.ParseFilter(`userName co ""`)
+	assert.NoError(t, err)
+	_, _, err = scimRepo.GetUserPage(ctx, filter, 0, 10)
+	assert.Error(t, err)
+}
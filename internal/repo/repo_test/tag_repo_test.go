@@ -197,3 +197,29 @@ func Test_tagRepo_UpdateTagSynonym(t *testing.T) {
 	assert.True(t, exist)
 	assert.Equal(t, testTagList[0].ID, fmt.Sprintf("%d", gotTag.MainTagID))
 }
+
+func Test_tagRepo_UpdateTagHierarchy(t *testing.T) {
+	tagOnce.Do(addTagList)
+	tagRepo := tag.NewTagRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource))
+	tagCommonRepo := tag_common.NewTagCommonRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource))
+
+	err := tagRepo.UpdateTagHierarchy(context.TODO(), testTagList[2].ID, converter.StringToInt64(testTagList[0].ID), 1)
+	assert.NoError(t, err)
+
+	childTagIDs, err := tagRepo.GetChildTagIDs(context.TODO(), []string{testTagList[0].ID})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{testTagList[2].ID}, childTagIDs)
+
+	err = tagRepo.ClearTagCategory(context.TODO(), 1)
+	assert.NoError(t, err)
+	gotTag, exist, err := tagCommonRepo.GetTagByID(context.TODO(), testTagList[2].ID, true)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, int64(0), gotTag.CategoryID)
+
+	err = tagRepo.UpdateTagHierarchy(context.TODO(), testTagList[2].ID, 0, 0)
+	assert.NoError(t, err)
+	childTagIDs, err = tagRepo.GetChildTagIDs(context.TODO(), []string{testTagList[0].ID})
+	assert.NoError(t, err)
+	assert.Empty(t, childTagIDs)
+}
@@ -0,0 +1,144 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm/schemas"
+)
+
+func Test_uniqueIDRepo_GenUniqueIDStr(t *testing.T) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+
+	first, err := uniqueIDRepo.GenUniqueIDStr(context.TODO(), entity.Question{}.TableName())
+	assert.NoError(t, err)
+	assert.Len(t, first, 17)
+	assert.Equal(t, "1001", first[:4])
+
+	second, err := uniqueIDRepo.GenUniqueIDStr(context.TODO(), entity.Question{}.TableName())
+	assert.NoError(t, err)
+	firstID, _ := strconv.ParseInt(first, 10, 64)
+	secondID, _ := strconv.ParseInt(second, 10, 64)
+	assert.Equal(t, firstID+1, secondID)
+
+	tagID, err := uniqueIDRepo.GenUniqueIDStr(context.TODO(), entity.Tag{}.TableName())
+	assert.NoError(t, err)
+	assert.Equal(t, "1003", tagID[:4])
+}
+
+func Test_uniqueIDRepo_GenUniqueIDStrConcurrently(t *testing.T) {
+	// each repo reserves its own blocks like the processes of different replicas
+	repos := []interface {
+		GenUniqueIDStr(ctx context.Context, key string) (string, error)
+	}{
+		unique.NewUniqueIDRepo(testDataSource),
+		unique.NewUniqueIDRepo(testDataSource),
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		ids = make(map[string]bool)
+	)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(repoIndex int) {
+			defer wg.Done()
+			for j := 0; j < 150; j++ {
+				id, err := repos[repoIndex].GenUniqueIDStr(context.TODO(), entity.Answer{}.TableName())
+				assert.NoError(t, err)
+				mu.Lock()
+				assert.False(t, ids[id], "duplicate id %s", id)
+				ids[id] = true
+				mu.Unlock()
+			}
+		}(i % len(repos))
+	}
+	wg.Wait()
+	assert.Len(t, ids, 600)
+}
+
+func Test_uniqueIDRepo_GenUniqueIDStrWithoutSequence(t *testing.T) {
+	// the migrations that run before the sequence table is created still insert into the uniqid table
+	engine, err := data.NewDB(false, &data.Database{
+		Driver:     string(schemas.SQLITE),
+		Connection: filepath.Join(t.TempDir(), "answer-uniqid-test.db"),
+	})
+	assert.NoError(t, err)
+	defer engine.Close()
+	assert.NoError(t, engine.Sync(new(entity.Uniqid)))
+
+	uniqueIDRepo := unique.NewUniqueIDRepo(&data.Data{DB: engine})
+	id, err := uniqueIDRepo.GenUniqueIDStr(context.TODO(), new(entity.Badge).TableName())
+	assert.NoError(t, err)
+	assert.Equal(t, "10090000000000001", id)
+
+	count, err := engine.Count(&entity.Uniqid{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func Test_uniqueIDRepo_GenUniqueIDStrWithLegacyProcess(t *testing.T) {
+	// the processes of the old version still insert into the uniqid table during a rolling upgrade
+	engine, err := data.NewDB(false, &data.Database{
+		Driver:     string(schemas.SQLITE),
+		Connection: filepath.Join(t.TempDir(), "answer-uniqid-legacy-test.db"),
+	})
+	assert.NoError(t, err)
+	defer engine.Close()
+	assert.NoError(t, engine.Sync(new(entity.Uniqid), new(entity.UniqidSequence)))
+	_, err = engine.Insert(&entity.UniqidSequence{ID: entity.UniqidSequenceID, NextID: 1})
+	assert.NoError(t, err)
+
+	ids := make(map[int64]bool)
+	genLegacyID := func() {
+		bean := &entity.Uniqid{UniqidType: 2}
+		_, err := engine.Insert(bean)
+		assert.NoError(t, err)
+		assert.False(t, ids[bean.ID], "duplicate legacy id %d", bean.ID)
+		ids[bean.ID] = true
+	}
+	// the old process runs ahead of the sequence
+	for i := 0; i < 5; i++ {
+		genLegacyID()
+	}
+
+	uniqueIDRepo := unique.NewUniqueIDRepo(&data.Data{DB: engine})
+	for i := 0; i < 250; i++ {
+		uniqueID, err := uniqueIDRepo.GenUniqueIDStr(context.TODO(), entity.Answer{}.TableName())
+		assert.NoError(t, err)
+		id, _ := strconv.ParseInt(uniqueID[4:], 10, 64)
+		assert.False(t, ids[id], "duplicate id %d", id)
+		ids[id] = true
+		if i%10 == 0 {
+			genLegacyID()
+		}
+	}
+	assert.Len(t, ids, 5+250+25)
+}
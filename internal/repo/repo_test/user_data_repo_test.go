@@ -0,0 +1,136 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/repo/user_data"
+	"github.com/apache/incubator-answer/internal/repo/user_external_login"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_userDataRepo_ErasePersonalData(t *testing.T) {
+	ctx := context.TODO()
+	userRepo := user.NewUserRepo(testDataSource)
+	userDataRepo := user_data.NewUserDataRepo(testDataSource)
+	externalLoginRepo := user_external_login.NewUserExternalLoginRepo(testDataSource)
+
+	userInfo := &entity.User{Username: "erase_user", EMail: "erase_user@example.com", DisplayName: "Erase",
+		Avatar: "avatar", Bio: "bio", BioHTML: "<p>bio</p>", Website: "https://example.com", Location: "earth",
+		Status: entity.UserStatusAvailable, MailStatus: entity.EmailStatusAvailable}
+	assert.NoError(t, userRepo.AddUser(ctx, userInfo))
+	assert.NoError(t, externalLoginRepo.AddUserExternalLogin(ctx, &entity.UserExternalLogin{
+		UserID: userInfo.ID, Provider: "erase", ExternalID: "erase-1", MetaInfo: "{}"}))
+
+	externalProfileRepo := user_external_login.NewExternalProfileRepo(testDataSource)
+	assert.NoError(t, externalProfileRepo.SetCacheExternalProfile(ctx, userInfo.ID, &schema.ExternalProfile{}))
+
+	assert.NoError(t, userDataRepo.ErasePersonalData(ctx, userInfo.ID))
+
+	_, exist, err := externalProfileRepo.GetCacheExternalProfile(ctx, userInfo.ID)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	got, exist, err := userRepo.GetByUserID(ctx, userInfo.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Empty(t, got.DisplayName)
+	assert.Empty(t, got.Avatar)
+	assert.Empty(t, got.Bio)
+	assert.Empty(t, got.BioHTML)
+	assert.Empty(t, got.Website)
+	assert.Empty(t, got.Location)
+	assert.Equal(t, "~deleted-"+userInfo.ID, got.Username)
+	assert.Equal(t, "deleted-"+userInfo.ID+"@answer.invalid", got.EMail)
+
+	_, exist, err = externalLoginRepo.GetByUserID(ctx, "erase", userInfo.ID)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_userDataRepo_GetUserByUsernameAndEmail(t *testing.T) {
+	ctx := context.TODO()
+	userRepo := user.NewUserRepo(testDataSource)
+	userDataRepo := user_data.NewUserDataRepo(testDataSource)
+
+	userInfo := &entity.User{Username: "~ghost_test", EMail: "ghost_test@answer.invalid",
+		Status: entity.UserStatusAvailable, MailStatus: entity.EmailStatusAvailable}
+	assert.NoError(t, userRepo.AddUser(ctx, userInfo))
+
+	got, exist, err := userDataRepo.GetUserByUsernameAndEmail(ctx, "~ghost_test", "ghost_test@answer.invalid")
+	assert.NoError(t, err)
+	if assert.True(t, exist) {
+		assert.Equal(t, userInfo.ID, got.ID)
+	}
+	_, exist, err = userDataRepo.GetUserByUsernameAndEmail(ctx, "~ghost_test", "ghost_test@example.com")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_userDataRepo_ReassignUserContent(t *testing.T) {
+	ctx := context.TODO()
+	userDataRepo := user_data.NewUserDataRepo(testDataSource)
+
+	vote := &entity.Activity{UserID: "910", ObjectID: "1", OriginalObjectID: "1", ActivityType: 1}
+	received := &entity.Activity{UserID: "912", TriggerUserID: 910, ObjectID: "1", OriginalObjectID: "1", ActivityType: 1}
+	collection := &entity.Collection{ID: "9100", UserID: "910", ObjectID: "1", UserCollectionGroupID: "0"}
+	_, err := testDataSource.DB.Insert(vote, received, collection)
+	assert.NoError(t, err)
+
+	assert.NoError(t, userDataRepo.ReassignUserContent(ctx, "910", "911"))
+
+	gotVote := &entity.Activity{}
+	_, err = testDataSource.DB.ID(vote.ID).Get(gotVote)
+	assert.NoError(t, err)
+	assert.Equal(t, "911", gotVote.UserID)
+	gotReceived := &entity.Activity{}
+	_, err = testDataSource.DB.ID(received.ID).Get(gotReceived)
+	assert.NoError(t, err)
+	assert.Equal(t, "912", gotReceived.UserID)
+	assert.Equal(t, int64(911), gotReceived.TriggerUserID)
+	gotCollection := &entity.Collection{}
+	_, err = testDataSource.DB.ID(collection.ID).Get(gotCollection)
+	assert.NoError(t, err)
+	assert.Equal(t, "911", gotCollection.UserID)
+}
+
+func Test_userDataRepo_GetPendingExports(t *testing.T) {
+	ctx := context.TODO()
+	userDataRepo := user_data.NewUserDataRepo(testDataSource)
+
+	pending := &entity.UserDataExport{UserID: "920", Status: entity.UserDataExportStatusPending}
+	completed := &entity.UserDataExport{UserID: "921", Status: entity.UserDataExportStatusCompleted}
+	assert.NoError(t, userDataRepo.AddExport(ctx, pending))
+	assert.NoError(t, userDataRepo.AddExport(ctx, completed))
+
+	exports, err := userDataRepo.GetPendingExports(ctx)
+	assert.NoError(t, err)
+	ids := make([]int, 0)
+	for _, export := range exports {
+		ids = append(ids, export.ID)
+	}
+	assert.Contains(t, ids, pending.ID)
+	assert.NotContains(t, ids, completed.ID)
+}
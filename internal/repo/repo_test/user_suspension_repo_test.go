@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_userSuspensionRepo_GetExpiredSuspensions(t *testing.T) {
+	ctx := context.TODO()
+	userSuspensionRepo := user.NewUserSuspensionRepo(testDataSource)
+
+	indefinite := &entity.UserSuspension{UserID: "900", Status: entity.UserSuspensionStatusActive}
+	expired := &entity.UserSuspension{UserID: "901", Status: entity.UserSuspensionStatusActive,
+		EndAt: time.Now().Add(-time.Minute)}
+	notExpired := &entity.UserSuspension{UserID: "902", Status: entity.UserSuspensionStatusActive,
+		EndAt: time.Now().Add(time.Hour)}
+	for _, suspension := range []*entity.UserSuspension{indefinite, expired, notExpired} {
+		assert.NoError(t, userSuspensionRepo.AddSuspension(ctx, suspension))
+	}
+
+	suspensions, err := userSuspensionRepo.GetExpiredSuspensions(ctx, time.Now(), 10)
+	assert.NoError(t, err)
+	if assert.Len(t, suspensions, 1) {
+		assert.Equal(t, expired.ID, suspensions[0].ID)
+	}
+
+	got, exist, err := userSuspensionRepo.GetActiveSuspension(ctx, "900")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.True(t, got.EndAt.IsZero())
+
+	// only one appeal is recorded with its review
+	got.AppealStatus = entity.UserSuspensionAppealStatusPending
+	got.AppealContent = "appeal"
+	review := &entity.Review{UserID: "900", ObjectID: "900", ReviewerUserID: "0", Status: entity.ReviewStatusPending}
+	updated, err := userSuspensionRepo.UpdateAppeal(ctx, got, review)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.NotZero(t, review.ID)
+	appealed, exist, err := userSuspensionRepo.GetSuspensionByAppealReviewID(ctx, review.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, got.ID, appealed.ID)
+
+	another := &entity.Review{UserID: "900", ObjectID: "900", ReviewerUserID: "0", Status: entity.ReviewStatusPending}
+	updated, err = userSuspensionRepo.UpdateAppeal(ctx, got, another)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+	// the review isn't added if the suspension has been appealed
+	assert.Zero(t, another.ID)
+
+	history, err := userSuspensionRepo.GetSuspensionList(ctx, "901")
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/service/review"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
 )
 
 // reviewRepo review repository
@@ -81,10 +82,19 @@ func (cr *reviewRepo) GetReviewCount(ctx context.Context, status int) (count int
 	return
 }
 
-// GetReviewPage get review page
-func (cr *reviewRepo) GetReviewPage(ctx context.Context, page, pageSize int, cond *entity.Review) (
+// GetReviewPage get review page, if tagIDs is not empty, only the reviews of questions with these tags
+// and their answers are returned
+func (cr *reviewRepo) GetReviewPage(ctx context.Context, page, pageSize int, cond *entity.Review, tagIDs []string) (
 	reviewList []*entity.Review, total int64, err error) {
 	session := cr.data.DB.Context(ctx).Asc("created_at")
+	if len(tagIDs) > 0 {
+		questionIDs := builder.Select("object_id").From(entity.TagRel{}.TableName()).
+			Where(builder.In("tag_id", tagIDs).
+				And(builder.In("status", []int{entity.TagRelStatusAvailable, entity.TagRelStatusHide})))
+		answerIDs := builder.Select("id").From(entity.Answer{}.TableName()).
+			Where(builder.In("question_id", questionIDs))
+		session.And(builder.Or(builder.In("object_id", questionIDs), builder.In("object_id", answerIDs)))
+	}
 	reviewList = make([]*entity.Review, 0)
 	total, err = pager.Help(page, pageSize, &reviewList, cond, session)
 	if err != nil {
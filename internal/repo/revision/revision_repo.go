@@ -188,9 +188,10 @@ func (rr *revisionRepo) allowRecord(objectType int) (ok bool) {
 	}
 }
 
-// GetUnreviewedRevisionPage get unreviewed revision page
+// GetUnreviewedRevisionPage get unreviewed revision page, if tagIDs is not empty, only the revisions of
+// questions with these tags and their answers are returned
 func (rr *revisionRepo) GetUnreviewedRevisionPage(ctx context.Context, page int, pageSize int,
-	objectTypeList []int) (revisionList []*entity.Revision, total int64, err error) {
+	objectTypeList []int, tagIDs []string) (revisionList []*entity.Revision, total int64, err error) {
 	revisionList = make([]*entity.Revision, 0)
 	if len(objectTypeList) == 0 {
 		return revisionList, 0, nil
@@ -198,6 +199,14 @@ func (rr *revisionRepo) GetUnreviewedRevisionPage(ctx context.Context, page int,
 	session := rr.data.DB.Context(ctx)
 	session = session.And("status = ?", entity.RevisionUnreviewedStatus)
 	session = session.In("object_type", objectTypeList)
+	if len(tagIDs) > 0 {
+		questionIDs := builder.Select("object_id").From(entity.TagRel{}.TableName()).
+			Where(builder.In("tag_id", tagIDs).
+				And(builder.In("status", []int{entity.TagRelStatusAvailable, entity.TagRelStatusHide})))
+		answerIDs := builder.Select("id").From(entity.Answer{}.TableName()).
+			Where(builder.In("question_id", questionIDs))
+		session = session.And(builder.Or(builder.In("object_id", questionIDs), builder.In("object_id", answerIDs)))
+	}
 	session = session.OrderBy("created_at asc")
 
 	total, err = pager.Help(page, pageSize, &revisionList, &entity.Revision{}, session)
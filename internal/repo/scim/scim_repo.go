@@ -0,0 +1,199 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package scim
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/scim"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// scimRepo scim repository
+type scimRepo struct {
+	data *data.Data
+}
+
+// NewScimRepo new repository
+func NewScimRepo(data *data.Data) scim.ScimRepo {
+	return &scimRepo{
+		data: data,
+	}
+}
+
+// userFilterColumns scim user attributes mapping to user table columns
+var userFilterColumns = map[string]string{
+	"id":                "`user`.id",
+	"username":          "`user`.username",
+	"displayname":       "`user`.display_name",
+	"name.formatted":    "`user`.display_name",
+	"emails":            "`user`.e_mail",
+	"emails.value":      "`user`.e_mail",
+	"meta.created":      "`user`.created_at",
+	"meta.lastmodified": "`user`.updated_at",
+}
+
+// GetUserPage get user page by scim filter, deleted users are excluded
+func (sr *scimRepo) GetUserPage(ctx context.Context, filter *scim.Filter, offset, limit int) (
+	users []*entity.User, total int64, err error) {
+	cond := builder.NewCond().And(builder.Neq{"`user`.status": entity.UserStatusDeleted})
+	if filter != nil {
+		filterCond, err := sr.buildCond(filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		cond = cond.And(filterCond)
+	}
+
+	users = make([]*entity.User, 0)
+	if limit == 0 {
+		// only the total results are required
+		total, err = sr.data.DB.Context(ctx).Where(cond).Count(&entity.User{})
+	} else {
+		total, err = sr.data.DB.Context(ctx).Where(cond).Asc("`user`.created_at").Limit(limit, offset).FindAndCount(&users)
+	}
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return users, total, nil
+}
+
+// GetExternalIDMapping get scim external id by user ids
+func (sr *scimRepo) GetExternalIDMapping(ctx context.Context, userIDs []string) (mapping map[string]string, err error) {
+	mapping = make(map[string]string, len(userIDs))
+	bindings := make([]*entity.UserExternalLogin, 0)
+	err = sr.data.DB.Context(ctx).Where("provider = ?", scim.ExternalLoginProvider).In("user_id", userIDs).Find(&bindings)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, binding := range bindings {
+		mapping[binding.UserID] = binding.ExternalID
+	}
+	return mapping, nil
+}
+
+func (sr *scimRepo) buildCond(filter *scim.Filter) (cond builder.Cond, err error) {
+	switch filter.Op {
+	case scim.FilterOpAnd, scim.FilterOpOr:
+		left, err := sr.buildCond(filter.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := sr.buildCond(filter.Right)
+		if err != nil {
+			return nil, err
+		}
+		if filter.Op == scim.FilterOpAnd {
+			return builder.And(left, right), nil
+		}
+		return builder.Or(left, right), nil
+	case scim.FilterOpNot:
+		inner, err := sr.buildCond(filter.Left)
+		if err != nil {
+			return nil, err
+		}
+		return builder.Not{inner}, nil
+	}
+
+	switch filter.Attr {
+	case "active":
+		return buildActiveCond(filter)
+	case "externalid":
+		sub := builder.Select("user_id").From("user_external_login").
+			Where(builder.Eq{"provider": scim.ExternalLoginProvider})
+		if filter.Op != scim.FilterOpPr {
+			valueCond, err := buildValueCond("external_id", filter)
+			if err != nil {
+				return nil, err
+			}
+			sub = sub.And(valueCond)
+		}
+		return builder.In("`user`.id", sub), nil
+	}
+	column, ok := userFilterColumns[filter.Attr]
+	if !ok {
+		return nil, errors.BadRequest(reason.ScimInvalidFilter).WithMsg(fmt.Sprintf("unsupported attribute %s", filter.Attr))
+	}
+	if filter.Op == scim.FilterOpPr {
+		return builder.And(builder.NotNull{column}, builder.Neq{column: ""}), nil
+	}
+	return buildValueCond(column, filter)
+}
+
+// likeEscaper escape the LIKE wildcards, '!' is used as the escape character because it
+// has no special meaning in the string literals of all supported databases
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+func buildValueCond(column string, filter *scim.Filter) (cond builder.Cond, err error) {
+	value := filter.ValueString()
+	switch filter.Op {
+	case scim.FilterOpEq:
+		return builder.Eq{column: value}, nil
+	case scim.FilterOpNe:
+		return builder.Neq{column: value}, nil
+	case scim.FilterOpCo, scim.FilterOpSw, scim.FilterOpEw:
+		if len(value) == 0 {
+			return nil, errors.BadRequest(reason.ScimInvalidFilter).WithMsg("empty value is not allowed for co, sw and ew")
+		}
+		pattern := likeEscaper.Replace(value)
+		switch filter.Op {
+		case scim.FilterOpCo:
+			pattern = "%" + pattern + "%"
+		case scim.FilterOpSw:
+			pattern = pattern + "%"
+		default:
+			pattern = "%" + pattern
+		}
+		return builder.Expr(column+" LIKE ? ESCAPE '!'", pattern), nil
+	case scim.FilterOpGt:
+		return builder.Gt{column: value}, nil
+	case scim.FilterOpLt:
+		return builder.Lt{column: value}, nil
+	case scim.FilterOpGe:
+		return builder.Gte{column: value}, nil
+	case scim.FilterOpLe:
+		return builder.Lte{column: value}, nil
+	}
+	return nil, errors.BadRequest(reason.ScimInvalidFilter)
+}
+
+// buildActiveCond only the available user is active
+func buildActiveCond(filter *scim.Filter) (cond builder.Cond, err error) {
+	if filter.Op == scim.FilterOpPr {
+		return builder.Expr("1 = 1"), nil
+	}
+	active, ok := filter.Value.(bool)
+	if !ok || (filter.Op != scim.FilterOpEq && filter.Op != scim.FilterOpNe) {
+		return nil, errors.BadRequest(reason.ScimInvalidFilter)
+	}
+	if filter.Op == scim.FilterOpNe {
+		active = !active
+	}
+	if active {
+		return builder.Eq{"`user`.status": entity.UserStatusAvailable}, nil
+	}
+	return builder.Neq{"`user`.status": entity.UserStatusAvailable}, nil
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/space"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/search_common"
 	"github.com/apache/incubator-answer/internal/service/unique"
@@ -190,6 +191,13 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 		argsA = append(argsA, votes)
 	}
 
+	// check space
+	spaceCond, spaceArgs := questionAccessCond(ctx)
+	b.And(spaceCond)
+	ub.And(spaceCond)
+	argsQ = append(argsQ, spaceArgs...)
+	argsA = append(argsA, spaceArgs...)
+
 	//b = b.Union("all", ub)
 	ubSQL, _, err := ub.ToSQL()
 	if err != nil {
@@ -312,6 +320,11 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 		args = append(args, answers)
 	}
 
+	// check space
+	spaceCond, spaceArgs := questionAccessCond(ctx)
+	b.And(spaceCond)
+	args = append(args, spaceArgs...)
+
 	queryArgs := []interface{}{}
 	countArgs := []interface{}{}
 
@@ -407,6 +420,11 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 		args = append(args, questionID)
 	}
 
+	// check space
+	spaceCond, spaceArgs := questionAccessCond(ctx)
+	b.And(spaceCond)
+	args = append(args, spaceArgs...)
+
 	queryArgs := []interface{}{}
 	countArgs := []interface{}{}
 
@@ -471,12 +489,14 @@ func (sr *searchRepo) ParseSearchPluginResult(ctx context.Context, sres []plugin
 		switch r.Type {
 		case "question":
 			b = builder.MySQL().Select(qFields...).From("question").Where(builder.Eq{"id": r.ID}).
-				And(builder.Lt{"`status`": entity.QuestionStatusDeleted})
+				And(builder.Lt{"`status`": entity.QuestionStatusDeleted}).
+				And(space.QuestionAccessCond(ctx, "`question`.`space_id`"))
 		case "answer":
 			b = builder.MySQL().Select(aFields...).From("answer").LeftJoin("`question`", "`question`.`id` = `answer`.`question_id`").
 				Where(builder.Eq{"`answer`.`id`": r.ID}).
 				And(builder.Lt{"`question`.`status`": entity.QuestionStatusDeleted}).
-				And(builder.Lt{"`answer`.`status`": entity.AnswerStatusDeleted}).And(builder.Eq{"`question`.`show`": entity.QuestionShow})
+				And(builder.Lt{"`answer`.`status`": entity.AnswerStatusDeleted}).And(builder.Eq{"`question`.`show`": entity.QuestionShow}).
+				And(space.QuestionAccessCond(ctx, "`question`.`space_id`"))
 		}
 		qres, err = sr.data.DB.Context(ctx).Query(b)
 		if err != nil || len(qres) == 0 {
@@ -487,6 +507,14 @@ func (sr *searchRepo) ParseSearchPluginResult(ctx context.Context, sres []plugin
 	return sr.parseResult(ctx, res, words)
 }
 
+// questionAccessCond the condition of questions that login user can access, the args are returned
+// separately because the search sql is executed with the args that collected manually
+func questionAccessCond(ctx context.Context) (cond builder.Cond, args []any) {
+	cond = space.QuestionAccessCond(ctx, "`question`.`space_id`")
+	_, args, _ = builder.ToSQL(cond)
+	return cond, args
+}
+
 // parseResult parse search result, return the data structure
 func (sr *searchRepo) parseResult(ctx context.Context, res []map[string][]byte, words []string) (resp []*schema.SearchResult, err error) {
 	questionIDs := make([]string, 0)
@@ -42,7 +42,7 @@ func (p *PluginSyncer) GetAnswersPage(ctx context.Context, page, pageSize int) (
 	answerList []*plugin.SearchContent, err error) {
 	answers := make([]*entity.Answer, 0)
 	startNum := (page - 1) * pageSize
-	err = p.data.DB.Context(ctx).Limit(pageSize, startNum).Find(&answers)
+	err = p.data.DB.Context(ctx).OrderBy("id ASC").Limit(pageSize, startNum).Find(&answers)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +53,7 @@ func (p *PluginSyncer) GetQuestionsPage(ctx context.Context, page, pageSize int)
 	questionList []*plugin.SearchContent, err error) {
 	questions := make([]*entity.Question, 0)
 	startNum := (page - 1) * pageSize
-	err = p.data.DB.Context(ctx).Limit(pageSize, startNum).Find(&questions)
+	err = p.data.DB.Context(ctx).OrderBy("id ASC").Limit(pageSize, startNum).Find(&questions)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package space
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/builder"
+)
+
+// QuestionAccessCond the condition of questions that the login user in context can access,
+// spaceIDColumn is the column of question space id, such as `question.space_id`.
+func QuestionAccessCond(ctx context.Context, spaceIDColumn string) builder.Cond {
+	access := handler.GetSpaceAccess(ctx)
+	if access.AllSpaces {
+		return builder.NewCond()
+	}
+	cond := builder.Or(
+		builder.Eq{spaceIDColumn: entity.NoSpaceID},
+		builder.In(spaceIDColumn, builder.Select("id").From(entity.Space{}.TableName()).
+			Where(builder.Eq{"visibility": entity.SpaceVisibilityPublic})),
+	)
+	if len(access.MemberSpaceIDs) > 0 {
+		cond = cond.Or(builder.In(spaceIDColumn, access.MemberSpaceIDs))
+	}
+	return cond
+}
+
+// PublicQuestionCond the condition of questions that everyone can access, such as the questions in sitemap
+func PublicQuestionCond(spaceIDColumn string) builder.Cond {
+	return QuestionAccessCond(context.Background(), spaceIDColumn)
+}
+
+// QuestionIDAccessCond the condition of objects whose question can be accessed by the login user in context,
+// questionIDColumn is the column of question id, such as `answer.question_id`.
+func QuestionIDAccessCond(ctx context.Context, questionIDColumn string) builder.Cond {
+	if handler.GetSpaceAccess(ctx).AllSpaces {
+		return builder.NewCond()
+	}
+	return builder.In(questionIDColumn, builder.Select("id").From(entity.Question{}.TableName()).
+		Where(QuestionAccessCond(ctx, "space_id")))
+}
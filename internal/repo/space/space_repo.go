@@ -0,0 +1,196 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package space
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/space"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// spaceRepo space repository
+type spaceRepo struct {
+	data *data.Data
+}
+
+// NewSpaceRepo new repository
+func NewSpaceRepo(data *data.Data) space.SpaceRepo {
+	return &spaceRepo{
+		data: data,
+	}
+}
+
+// AddSpace add space
+func (sr *spaceRepo) AddSpace(ctx context.Context, space *entity.Space) (err error) {
+	_, err = sr.data.DB.Context(ctx).Insert(space)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateSpace update space
+func (sr *spaceRepo) UpdateSpace(ctx context.Context, space *entity.Space) (err error) {
+	_, err = sr.data.DB.Context(ctx).ID(space.ID).
+		Cols("slug_name", "display_name", "description", "visibility").Update(space)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveSpace remove space and its members
+func (sr *spaceRepo) RemoveSpace(ctx context.Context, spaceID string) (err error) {
+	_, err = sr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where("space_id = ?", spaceID).Delete(&entity.SpaceMember{}); err != nil {
+			return nil, err
+		}
+		_, err = session.ID(spaceID).Delete(&entity.Space{})
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSpace get space by id
+func (sr *spaceRepo) GetSpace(ctx context.Context, spaceID string) (space *entity.Space, exist bool, err error) {
+	space = &entity.Space{}
+	exist, err = sr.data.DB.Context(ctx).ID(spaceID).Get(space)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSpaceBySlugName get space by slug name
+func (sr *spaceRepo) GetSpaceBySlugName(ctx context.Context, slugName string) (
+	space *entity.Space, exist bool, err error) {
+	space = &entity.Space{}
+	exist, err = sr.data.DB.Context(ctx).Where("slug_name = ?", slugName).Get(space)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSpaceList get all spaces
+func (sr *spaceRepo) GetSpaceList(ctx context.Context) (spaces []*entity.Space, err error) {
+	spaces = make([]*entity.Space, 0)
+	err = sr.data.DB.Context(ctx).Asc("id").Find(&spaces)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSpaceQuestionCount get the count of questions in space, including the deleted questions
+func (sr *spaceRepo) GetSpaceQuestionCount(ctx context.Context, spaceID string) (count int64, err error) {
+	count, err = sr.data.DB.Context(ctx).Where("space_id = ?", spaceID).Count(&entity.Question{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddSpaceMember add space member
+func (sr *spaceRepo) AddSpaceMember(ctx context.Context, member *entity.SpaceMember) (err error) {
+	_, err = sr.data.DB.Context(ctx).Insert(member)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateSpaceMember update space member
+func (sr *spaceRepo) UpdateSpaceMember(ctx context.Context, member *entity.SpaceMember) (err error) {
+	_, err = sr.data.DB.Context(ctx).ID(member.ID).Cols("is_moderator").Update(member)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveSpaceMember remove space member
+func (sr *spaceRepo) RemoveSpaceMember(ctx context.Context, spaceID string, memberType int, memberID string) (err error) {
+	_, err = sr.data.DB.Context(ctx).Where("space_id = ? AND member_type = ? AND member_id = ?",
+		spaceID, memberType, memberID).Delete(&entity.SpaceMember{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSpaceMember get space member
+func (sr *spaceRepo) GetSpaceMember(ctx context.Context, spaceID string, memberType int, memberID string) (
+	member *entity.SpaceMember, exist bool, err error) {
+	member = &entity.SpaceMember{}
+	exist, err = sr.data.DB.Context(ctx).Where("space_id = ? AND member_type = ? AND member_id = ?",
+		spaceID, memberType, memberID).Get(member)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSpaceMembers get all members of space
+func (sr *spaceRepo) GetSpaceMembers(ctx context.Context, spaceID string) (members []*entity.SpaceMember, err error) {
+	members = make([]*entity.SpaceMember, 0)
+	err = sr.data.DB.Context(ctx).Where("space_id = ?", spaceID).Asc("id").Find(&members)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetMemberships get the memberships of user, including the memberships of user role
+func (sr *spaceRepo) GetMemberships(ctx context.Context, userID string, roleID int) (
+	members []*entity.SpaceMember, err error) {
+	members = make([]*entity.SpaceMember, 0)
+	err = sr.data.DB.Context(ctx).Where(builder.Or(
+		builder.Eq{"member_type": entity.SpaceMemberTypeUser, "member_id": userID},
+		builder.Eq{"member_type": entity.SpaceMemberTypeRole, "member_id": strconv.Itoa(roleID)},
+	)).Find(&members)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionSpaceID get the space id of question
+func (sr *spaceRepo) GetQuestionSpaceID(ctx context.Context, questionID string) (
+	spaceID string, exist bool, err error) {
+	question := &entity.Question{}
+	exist, err = sr.data.DB.Context(ctx).ID(uid.DeShortID(questionID)).Cols("space_id").Get(question)
+	if err != nil {
+		return "", false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return question.SpaceID, exist, nil
+}
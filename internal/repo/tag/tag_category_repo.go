@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/tag"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// tagCategoryRepo tag category repository
+type tagCategoryRepo struct {
+	data *data.Data
+}
+
+// NewTagCategoryRepo new repository
+func NewTagCategoryRepo(data *data.Data) tag.TagCategoryRepo {
+	return &tagCategoryRepo{
+		data: data,
+	}
+}
+
+// AddTagCategory add tag category
+func (tr *tagCategoryRepo) AddTagCategory(ctx context.Context, category *entity.TagCategory) (err error) {
+	_, err = tr.data.DB.Context(ctx).Insert(category)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateTagCategory update tag category
+func (tr *tagCategoryRepo) UpdateTagCategory(ctx context.Context, category *entity.TagCategory) (err error) {
+	_, err = tr.data.DB.Context(ctx).ID(category.ID).
+		Cols("slug_name", "display_name", "sort_order").Update(category)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveTagCategory remove tag category
+func (tr *tagCategoryRepo) RemoveTagCategory(ctx context.Context, categoryID string) (err error) {
+	_, err = tr.data.DB.Context(ctx).ID(categoryID).Delete(&entity.TagCategory{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagCategory get tag category by id
+func (tr *tagCategoryRepo) GetTagCategory(ctx context.Context, categoryID string) (
+	category *entity.TagCategory, exist bool, err error) {
+	category = &entity.TagCategory{}
+	exist, err = tr.data.DB.Context(ctx).ID(categoryID).Get(category)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagCategoryBySlugName get tag category by slug name
+func (tr *tagCategoryRepo) GetTagCategoryBySlugName(ctx context.Context, slugName string) (
+	category *entity.TagCategory, exist bool, err error) {
+	category = &entity.TagCategory{}
+	exist, err = tr.data.DB.Context(ctx).Where("slug_name = ?", slugName).Get(category)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagCategoryList get all tag categories
+func (tr *tagCategoryRepo) GetTagCategoryList(ctx context.Context) (categories []*entity.TagCategory, err error) {
+	categories = make([]*entity.TagCategory, 0)
+	err = tr.data.DB.Context(ctx).Asc("sort_order", "id").Find(&categories)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
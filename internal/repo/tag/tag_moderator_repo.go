@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/tag_moderator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// tagModeratorRepo tag moderator repository
+type tagModeratorRepo struct {
+	data *data.Data
+}
+
+// NewTagModeratorRepo new repository
+func NewTagModeratorRepo(data *data.Data) tag_moderator.TagModeratorRepo {
+	return &tagModeratorRepo{
+		data: data,
+	}
+}
+
+// AddTagModerator add tag moderator
+func (tr *tagModeratorRepo) AddTagModerator(ctx context.Context, moderator *entity.TagModerator) (err error) {
+	_, err = tr.data.DB.Context(ctx).Insert(moderator)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveTagModerator remove tag moderator
+func (tr *tagModeratorRepo) RemoveTagModerator(ctx context.Context, tagID, userID string) (err error) {
+	_, err = tr.data.DB.Context(ctx).Where("tag_id = ? AND user_id = ?", tagID, userID).
+		Delete(&entity.TagModerator{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagModerator get tag moderator
+func (tr *tagModeratorRepo) GetTagModerator(ctx context.Context, tagID, userID string) (
+	moderator *entity.TagModerator, exist bool, err error) {
+	moderator = &entity.TagModerator{}
+	exist, err = tr.data.DB.Context(ctx).Where("tag_id = ? AND user_id = ?", tagID, userID).Get(moderator)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagModerators get moderators of the tag
+func (tr *tagModeratorRepo) GetTagModerators(ctx context.Context, tagID string) (
+	moderators []*entity.TagModerator, err error) {
+	moderators = make([]*entity.TagModerator, 0)
+	err = tr.data.DB.Context(ctx).Where("tag_id = ?", tagID).Asc("id").Find(&moderators)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetModeratedTagIDs get ids of the tags moderated by the user
+func (tr *tagModeratorRepo) GetModeratedTagIDs(ctx context.Context, userID string) (tagIDs []string, err error) {
+	tagIDs = make([]string, 0)
+	err = tr.data.DB.Context(ctx).Table(entity.TagModerator{}.TableName()).
+		Where("user_id = ?", userID).Cols("tag_id").Find(&tagIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -138,3 +138,38 @@ func (tr *tagRepo) GetTagList(ctx context.Context, tag *entity.Tag) (tagList []*
 	}
 	return
 }
+
+// UpdateTagHierarchy update the parent tag and the category of the tag
+func (tr *tagRepo) UpdateTagHierarchy(ctx context.Context, tagID string, parentTagID, categoryID int64) (err error) {
+	_, err = tr.data.DB.Context(ctx).ID(tagID).Cols("parent_tag_id", "category_id").
+		Update(&entity.Tag{ParentTagID: parentTagID, CategoryID: categoryID})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetChildTagIDs get the ids of the direct children of the tags
+func (tr *tagRepo) GetChildTagIDs(ctx context.Context, parentTagIDs []string) (tagIDs []string, err error) {
+	tagIDs = make([]string, 0)
+	if len(parentTagIDs) == 0 {
+		return tagIDs, nil
+	}
+	err = tr.data.DB.Context(ctx).Table(entity.Tag{}.TableName()).Cols("id").
+		Where(builder.Eq{"status": entity.TagStatusAvailable}).
+		In("parent_tag_id", parentTagIDs).Find(&tagIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ClearTagCategory remove the category from all the tags in it
+func (tr *tagRepo) ClearTagCategory(ctx context.Context, categoryID int64) (err error) {
+	_, err = tr.data.DB.Context(ctx).Where("category_id = ?", categoryID).Cols("category_id").
+		Update(&entity.Tag{CategoryID: 0})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
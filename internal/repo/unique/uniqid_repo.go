@@ -22,6 +22,8 @@ package unique
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/data"
@@ -29,11 +31,30 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/service/unique"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm/schemas"
 )
 
+const (
+	// idBlockSize the number of ids that each process reserves at a time
+	idBlockSize = 100
+	// maxReserveRetry the times to retry when the block is reserved by other processes at the same time
+	maxReserveRetry = 10
+)
+
+// idBlock the ids in [next, end) are reserved by this process
+type idBlock struct {
+	next int64
+	end  int64
+}
+
 // uniqueIDRepo Unique id repository
 type uniqueIDRepo struct {
 	data *data.Data
+
+	mu    sync.Mutex
+	block *idBlock
+	// sequenceExist the sequence table is created by the migration, the migrations before it use the uniqid table
+	sequenceExist bool
 }
 
 // NewUniqueIDRepo new repository
@@ -47,10 +68,122 @@ func NewUniqueIDRepo(data *data.Data) unique.UniqueIDRepo {
 // 1 + 00x(objectType) + 000000000000x(id)
 func (ur *uniqueIDRepo) GenUniqueIDStr(ctx context.Context, key string) (uniqueID string, err error) {
 	objectType := constant.ObjectTypeStrMapping[key]
+	id, err := ur.nextID(ctx, objectType)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("1%03d%013d", objectType, id), nil
+}
+
+// nextID get the next id from the block of this process, reserves a new block if the block is used up
+func (ur *uniqueIDRepo) nextID(ctx context.Context, objectType int) (id int64, err error) {
+	ur.mu.Lock()
+	defer ur.mu.Unlock()
+	if !ur.sequenceExist {
+		ur.sequenceExist, err = ur.data.DB.Context(ctx).IsTableExist(&entity.UniqidSequence{})
+		if err != nil {
+			return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if !ur.sequenceExist {
+			return ur.genLegacyID(ctx, objectType)
+		}
+	}
+	if ur.block == nil || ur.block.next >= ur.block.end {
+		ur.block, err = ur.reserveBlock(ctx)
+		if err != nil {
+			return 0, err
+		}
+	}
+	id = ur.block.next
+	ur.block.next++
+	return id, nil
+}
+
+// reserveBlock reserves the next block of the sequence. The sequence is advanced by compare and swap,
+// so the processes that share the database never get the same block.
+// The processes of the old version still generate the ids by the uniqid table during a rolling upgrade,
+// so the block starts after the ids of the uniqid table, and the uniqid table is advanced past the block.
+func (ur *uniqueIDRepo) reserveBlock(ctx context.Context) (block *idBlock, err error) {
+	lastErr := fmt.Errorf("reserve id block failed")
+	for i := 0; i < maxReserveRetry; i++ {
+		seq := &entity.UniqidSequence{}
+		exist, err := ur.data.DB.Context(ctx).ID(entity.UniqidSequenceID).Get(seq)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if !exist {
+			// the sequence is created by other process at the same time if it fails, just try again
+			_, lastErr = ur.data.DB.Context(ctx).Insert(&entity.UniqidSequence{ID: entity.UniqidSequenceID, NextID: 1})
+			continue
+		}
+
+		legacyNextID, err := ur.getLegacyNextID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		block = &idBlock{next: max(seq.NextID, legacyNextID)}
+		block.end = block.next + idBlockSize
+		reserved, err := ur.advanceLegacyID(ctx, block)
+		if err != nil {
+			return nil, err
+		}
+		if !reserved {
+			lastErr = fmt.Errorf("id block [%d, %d) is used by the uniqid table", block.next, block.end)
+			continue
+		}
+
+		affected, err := ur.data.DB.Context(ctx).Table(entity.UniqidSequence{}.TableName()).
+			Where("id = ?", entity.UniqidSequenceID).And("next_id = ?", seq.NextID).
+			Update(map[string]any{"next_id": block.end, "updated_at": time.Now()})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if affected == 1 {
+			return block, nil
+		}
+	}
+	return nil, errors.InternalServer(reason.DatabaseError).WithError(lastErr).WithStack()
+}
+
+// advanceLegacyID inserts the last id of the block into the uniqid table, so that the ids generated by
+// the uniqid table later are after the block. It returns false if any id of the block is already used.
+func (ur *uniqueIDRepo) advanceLegacyID(ctx context.Context, block *idBlock) (reserved bool, err error) {
+	lastID := block.end - 1
+	if _, err = ur.data.DB.Context(ctx).Insert(&entity.Uniqid{ID: lastID}); err != nil {
+		// the last id is used by the uniqid table at the same time
+		return false, nil
+	}
+	if ur.data.DB.Dialect().URI().DBType == schemas.POSTGRES {
+		// the serial of postgres isn't advanced by inserting the id explicitly
+		_, err = ur.data.DB.Context(ctx).Exec(
+			"SELECT setval(pg_get_serial_sequence('uniqid', 'id'), GREATEST(nextval(pg_get_serial_sequence('uniqid', 'id')), ?))", lastID)
+		if err != nil {
+			return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	used, err := ur.data.DB.Context(ctx).Where("id >= ? AND id < ?", block.next, lastID).Exist(&entity.Uniqid{})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return !used, nil
+}
+
+// getLegacyNextID the sequence starts after the ids that are generated by the uniqid table
+func (ur *uniqueIDRepo) getLegacyNextID(ctx context.Context) (id int64, err error) {
+	var maxID int64
+	_, err = ur.data.DB.Context(ctx).Table(entity.Uniqid{}.TableName()).Select("COALESCE(MAX(id), 0)").Get(&maxID)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return maxID + 1, nil
+}
+
+// genLegacyID generate the id by inserting into the uniqid table before the sequence table is created
+func (ur *uniqueIDRepo) genLegacyID(ctx context.Context, objectType int) (id int64, err error) {
 	bean := &entity.Uniqid{UniqidType: objectType}
 	_, err = ur.data.DB.Context(ctx).Insert(bean)
 	if err != nil {
-		return "", errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-	return fmt.Sprintf("1%03d%013d", objectType, bean.ID), nil
+	return bean.ID, nil
 }
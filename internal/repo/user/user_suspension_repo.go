@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/user_suspension"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// userSuspensionRepo user suspension repository
+type userSuspensionRepo struct {
+	data *data.Data
+}
+
+// NewUserSuspensionRepo new repository
+func NewUserSuspensionRepo(data *data.Data) user_suspension.UserSuspensionRepo {
+	return &userSuspensionRepo{
+		data: data,
+	}
+}
+
+// AddSuspension add user suspension
+func (ur *userSuspensionRepo) AddSuspension(ctx context.Context, suspension *entity.UserSuspension) (err error) {
+	_, err = ur.data.DB.Context(ctx).Insert(suspension)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateSuspension update the columns of the user suspension
+func (ur *userSuspensionRepo) UpdateSuspension(ctx context.Context, suspension *entity.UserSuspension,
+	cols ...string) (err error) {
+	_, err = ur.data.DB.Context(ctx).ID(suspension.ID).Cols(cols...).Update(suspension)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateAppeal record the appeal of the suspension and add the review that handles it in one transaction,
+// updated is false if the suspension has been appealed
+func (ur *userSuspensionRepo) UpdateAppeal(ctx context.Context, suspension *entity.UserSuspension,
+	review *entity.Review) (updated bool, err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		affected, err := session.ID(suspension.ID).
+			Where("appeal_status = ?", entity.UserSuspensionAppealStatusNone).
+			Cols("appeal_status", "appeal_content", "appealed_at").Update(suspension)
+		if err != nil || affected == 0 {
+			return nil, err
+		}
+		if _, err = session.Insert(review); err != nil {
+			return nil, err
+		}
+		suspension.AppealReviewID = review.ID
+		if _, err = session.ID(suspension.ID).Cols("appeal_review_id").Update(suspension); err != nil {
+			return nil, err
+		}
+		updated = true
+		return nil, nil
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return updated, nil
+}
+
+// GetSuspensionByAppealReviewID get the user suspension whose appeal is handled by the review
+func (ur *userSuspensionRepo) GetSuspensionByAppealReviewID(ctx context.Context, reviewID int) (
+	suspension *entity.UserSuspension, exist bool, err error) {
+	suspension = &entity.UserSuspension{}
+	exist, err = ur.data.DB.Context(ctx).Where("appeal_review_id = ?", reviewID).Get(suspension)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetActiveSuspension get the active suspension of the user
+func (ur *userSuspensionRepo) GetActiveSuspension(ctx context.Context, userID string) (
+	suspension *entity.UserSuspension, exist bool, err error) {
+	suspension = &entity.UserSuspension{}
+	exist, err = ur.data.DB.Context(ctx).
+		Where("user_id = ? AND status = ?", userID, entity.UserSuspensionStatusActive).
+		Desc("id").Get(suspension)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSuspensionList get all suspensions of the user, the latest one first
+func (ur *userSuspensionRepo) GetSuspensionList(ctx context.Context, userID string) (
+	suspensions []*entity.UserSuspension, err error) {
+	suspensions = make([]*entity.UserSuspension, 0)
+	err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("id").Find(&suspensions)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetExpiredSuspensions get the active suspensions that end before the time
+func (ur *userSuspensionRepo) GetExpiredSuspensions(ctx context.Context, before time.Time, limit int) (
+	suspensions []*entity.UserSuspension, err error) {
+	suspensions = make([]*entity.UserSuspension, 0)
+	err = ur.data.DB.Context(ctx).
+		Where("status = ?", entity.UserSuspensionStatusActive).
+		And("end_at IS NOT NULL").And("end_at <= ?", before).
+		Asc("end_at").Limit(limit).Find(&suspensions)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
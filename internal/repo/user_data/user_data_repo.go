@@ -0,0 +1,279 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/user_external_login"
+	"github.com/apache/incubator-answer/internal/service/user_data"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// userDataRepo user data repository
+type userDataRepo struct {
+	data *data.Data
+}
+
+// NewUserDataRepo new repository
+func NewUserDataRepo(data *data.Data) user_data.UserDataRepo {
+	return &userDataRepo{
+		data: data,
+	}
+}
+
+// AddExport add export job
+func (ur *userDataRepo) AddExport(ctx context.Context, export *entity.UserDataExport) (err error) {
+	_, err = ur.data.DB.Context(ctx).Insert(export)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateExport update export job
+func (ur *userDataRepo) UpdateExport(ctx context.Context, export *entity.UserDataExport) (err error) {
+	_, err = ur.data.DB.Context(ctx).ID(export.ID).
+		Cols("status", "file_name", "download_token", "expired_at").Update(export)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveExport remove export job
+func (ur *userDataRepo) RemoveExport(ctx context.Context, id int) (err error) {
+	_, err = ur.data.DB.Context(ctx).ID(id).Delete(&entity.UserDataExport{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetLatestExport get the latest export job of user
+func (ur *userDataRepo) GetLatestExport(ctx context.Context, userID string) (
+	export *entity.UserDataExport, exist bool, err error) {
+	export = &entity.UserDataExport{}
+	exist, err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("id").Get(export)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetExportByToken get export job by download token
+func (ur *userDataRepo) GetExportByToken(ctx context.Context, token string) (
+	export *entity.UserDataExport, exist bool, err error) {
+	export = &entity.UserDataExport{}
+	exist, err = ur.data.DB.Context(ctx).Where("download_token = ?", token).Get(export)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetExpiredExports get the completed or failed export jobs expired before the time
+func (ur *userDataRepo) GetExpiredExports(ctx context.Context, before time.Time) (
+	exports []*entity.UserDataExport, err error) {
+	exports = make([]*entity.UserDataExport, 0)
+	err = ur.data.DB.Context(ctx).Where("status <> ?", entity.UserDataExportStatusPending).
+		Where("expired_at < ?", before).Find(&exports)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPendingExports get the export jobs that are not generated yet
+func (ur *userDataRepo) GetPendingExports(ctx context.Context) (exports []*entity.UserDataExport, err error) {
+	exports = make([]*entity.UserDataExport, 0)
+	err = ur.data.DB.Context(ctx).Where("status = ?", entity.UserDataExportStatusPending).
+		Asc("id").Find(&exports)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddDeletionRequest add deletion request
+func (ur *userDataRepo) AddDeletionRequest(ctx context.Context, req *entity.UserDeletionRequest) (err error) {
+	_, err = ur.data.DB.Context(ctx).Insert(req)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateDeletionRequestStatus update deletion request status
+func (ur *userDataRepo) UpdateDeletionRequestStatus(ctx context.Context, id, status int) (err error) {
+	_, err = ur.data.DB.Context(ctx).ID(id).Cols("status").Update(&entity.UserDeletionRequest{Status: status})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPendingDeletionRequest get pending deletion request of user
+func (ur *userDataRepo) GetPendingDeletionRequest(ctx context.Context, userID string) (
+	req *entity.UserDeletionRequest, exist bool, err error) {
+	req = &entity.UserDeletionRequest{}
+	exist, err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).
+		Where("status = ?", entity.UserDeletionStatusPending).Get(req)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDueDeletionRequests get pending deletion requests whose grace period is over
+func (ur *userDataRepo) GetDueDeletionRequests(ctx context.Context, before time.Time) (
+	reqs []*entity.UserDeletionRequest, err error) {
+	reqs = make([]*entity.UserDeletionRequest, 0)
+	err = ur.data.DB.Context(ctx).Where("status = ?", entity.UserDeletionStatusPending).
+		Where("scheduled_at <= ?", before).Asc("id").Find(&reqs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPersonalData get all the personal data of user
+func (ur *userDataRepo) GetPersonalData(ctx context.Context, userID string, voteActivityTypes []int) (
+	personalData *user_data.PersonalData, err error) {
+	personalData = &user_data.PersonalData{
+		Questions:     make([]*entity.Question, 0),
+		Answers:       make([]*entity.Answer, 0),
+		Comments:      make([]*entity.Comment, 0),
+		Votes:         make([]*entity.Activity, 0),
+		Collections:   make([]*entity.Collection, 0),
+		BadgeAwards:   make([]*entity.BadgeAward, 0),
+		Notifications: make([]*entity.Notification, 0),
+		Drafts:        make([]*entity.Draft, 0),
+	}
+	if err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Asc("created_at").Find(&personalData.Questions); err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Asc("created_at").Find(&personalData.Answers); err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Asc("created_at").Find(&personalData.Comments); err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(voteActivityTypes) > 0 {
+		err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Where("cancelled = ?", entity.ActivityAvailable).
+			In("activity_type", voteActivityTypes).Asc("created_at").Find(&personalData.Votes)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	if err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Asc("created_at").Find(&personalData.Collections); err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Where("is_badge_deleted = ?", entity.IsBadgeNotDeleted).
+		Asc("created_at").Find(&personalData.BadgeAwards)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Asc("created_at").Find(&personalData.Notifications); err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Asc("created_at").Find(&personalData.Drafts); err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return personalData, nil
+}
+
+// ReassignUserContent reassign all the content authored by user to another user
+func (ur *userDataRepo) ReassignUserContent(ctx context.Context, fromUserID, toUserID string) (err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		for _, bean := range []interface{}{&entity.Question{}, &entity.Answer{}, &entity.Comment{}, &entity.Revision{},
+			&entity.Activity{}, &entity.Collection{}, &entity.CollectionGroup{}} {
+			if _, err = session.Table(bean).Where("user_id = ?", fromUserID).
+				Update(map[string]interface{}{"user_id": toUserID}); err != nil {
+				return nil, err
+			}
+		}
+		for _, bean := range []interface{}{&entity.Question{}, &entity.Answer{}} {
+			if _, err = session.Table(bean).Where("last_edit_user_id = ?", fromUserID).
+				Update(map[string]interface{}{"last_edit_user_id": toUserID}); err != nil {
+				return nil, err
+			}
+		}
+		// the votes that the user cast on the posts of others are kept, the ghost user becomes the voter
+		if _, err = session.Table(&entity.Activity{}).Where("trigger_user_id = ?", fromUserID).
+			Update(map[string]interface{}{"trigger_user_id": toUserID}); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserByUsernameAndEmail get the user whose username and email are both matched
+func (ur *userDataRepo) GetUserByUsernameAndEmail(ctx context.Context, username, email string) (
+	user *entity.User, exist bool, err error) {
+	user = &entity.User{}
+	exist, err = ur.data.DB.Context(ctx).Where("username = ?", username).And("e_mail = ?", email).Get(user)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ErasePersonalData erase the profile of user and remove all the external login bindings.
+// The username and email are replaced by the placeholders that can not identify the user,
+// the username does not match the username pattern, so it can not be claimed by anyone.
+func (ur *userDataRepo) ErasePersonalData(ctx context.Context, userID string) (err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		_, err = session.Table(&entity.User{}).Where("id = ?", userID).Update(map[string]interface{}{
+			"username":     fmt.Sprintf("~deleted-%s", userID),
+			"e_mail":       fmt.Sprintf("deleted-%s@answer.invalid", userID),
+			"display_name": "",
+			"avatar":       "",
+			"mobile":       "",
+			"bio":          "",
+			"bio_html":     "",
+			"website":      "",
+			"location":     "",
+			"ip_info":      "",
+		})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Where("user_id = ?", userID).Delete(&entity.UserExternalLogin{})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	user_external_login.RemoveCacheExternalProfile(ctx, ur.data, userID)
+	return nil
+}
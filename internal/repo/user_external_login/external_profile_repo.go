@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_external_login
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/external_profile"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+type externalProfileRepo struct {
+	data *data.Data
+}
+
+// NewExternalProfileRepo new repository
+func NewExternalProfileRepo(data *data.Data) external_profile.ExternalProfileRepo {
+	return &externalProfileRepo{
+		data: data,
+	}
+}
+
+// GetUserExternalLoginListByUserIDs get the external logins of the users
+func (er *externalProfileRepo) GetUserExternalLoginListByUserIDs(ctx context.Context, userIDs []string) (
+	resp []*entity.UserExternalLogin, err error) {
+	resp = make([]*entity.UserExternalLogin, 0)
+	err = er.data.DB.Context(ctx).In("user_id", userIDs).OrderBy("id ASC").Find(&resp)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetCacheExternalProfile get the cached external profile of the user
+func (er *externalProfileRepo) GetCacheExternalProfile(ctx context.Context, userID string) (
+	profile *schema.ExternalProfile, exist bool, err error) {
+	res, exist, err := er.data.Cache.GetString(ctx, constant.UserExternalProfileCacheKey+userID)
+	if err != nil || !exist {
+		return nil, false, err
+	}
+	profile = &schema.ExternalProfile{}
+	if err = json.Unmarshal([]byte(res), profile); err != nil {
+		return nil, false, nil
+	}
+	return profile, true, nil
+}
+
+// SetCacheExternalProfile cache the external profile of the user
+func (er *externalProfileRepo) SetCacheExternalProfile(ctx context.Context, userID string,
+	profile *schema.ExternalProfile) (err error) {
+	cacheData, _ := json.Marshal(profile)
+	return er.data.Cache.SetString(ctx, constant.UserExternalProfileCacheKey+userID,
+		string(cacheData), constant.UserExternalProfileCacheTime)
+}
+
+// RemoveCacheExternalProfile remove the cached external profile of the user, it should be called
+// whenever the external logins of the user are changed, such as login, binding, unbinding and erasing.
+func RemoveCacheExternalProfile(ctx context.Context, data *data.Data, userID string) {
+	if err := data.Cache.Del(ctx, constant.UserExternalProfileCacheKey+userID); err != nil {
+		log.Errorf("remove external profile cache failed: %v", err)
+	}
+}
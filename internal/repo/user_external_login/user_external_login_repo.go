@@ -47,8 +47,9 @@ func NewUserExternalLoginRepo(data *data.Data) user_external_login.UserExternalL
 func (ur *userExternalLoginRepo) AddUserExternalLogin(ctx context.Context, user *entity.UserExternalLogin) (err error) {
 	_, err = ur.data.DB.Context(ctx).Insert(user)
 	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	RemoveCacheExternalProfile(ctx, ur.data, user.UserID)
 	return
 }
 
@@ -56,8 +57,9 @@ func (ur *userExternalLoginRepo) AddUserExternalLogin(ctx context.Context, user
 func (ur *userExternalLoginRepo) UpdateInfo(ctx context.Context, userInfo *entity.UserExternalLogin) (err error) {
 	_, err = ur.data.DB.Context(ctx).ID(userInfo.ID).Update(userInfo)
 	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	RemoveCacheExternalProfile(ctx, ur.data, userInfo.UserID)
 	return
 }
 
@@ -99,8 +101,9 @@ func (ur *userExternalLoginRepo) DeleteUserExternalLogin(ctx context.Context, us
 	cond := &entity.UserExternalLogin{}
 	_, err = ur.data.DB.Context(ctx).Where("user_id = ? AND external_id = ?", userID, externalID).Delete(cond)
 	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	RemoveCacheExternalProfile(ctx, ur.data, userID)
 	return
 }
 
@@ -126,12 +129,3 @@ func (ur *userExternalLoginRepo) GetCacheUserExternalLoginInfo(
 	_ = json.Unmarshal([]byte(res), &info)
 	return info, nil
 }
-
-func (ur *userExternalLoginRepo) ListAllExternalLoginInfo(ctx context.Context) (resp []*entity.UserExternalLogin, err error) {
-	resp = make([]*entity.UserExternalLogin, 0)
-	err = ur.data.DB.Context(ctx).Find(&resp)
-	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
-	}
-	return
-}
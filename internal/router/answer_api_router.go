@@ -35,6 +35,7 @@ type AnswerAPIRouter struct {
 	tagController           *controller.TagController
 	followController        *controller.FollowController
 	collectionController    *controller.CollectionController
+	draftController         *controller.DraftController
 	questionController      *controller.QuestionController
 	answerController        *controller.AnswerController
 	searchController        *controller.SearchController
@@ -57,6 +58,13 @@ type AnswerAPIRouter struct {
 	metaController          *controller.MetaController
 	badgeController         *controller.BadgeController
 	adminBadgeController    *controller_admin.BadgeController
+	userDataController      *controller.UserDataController
+	spaceController         *controller.SpaceController
+	adminSpaceController    *controller_admin.SpaceController
+	tagModeratorController  *controller_admin.TagModeratorController
+	adminTagController      *controller_admin.TagController
+	analyticsController     *controller_admin.AnalyticsController
+	reputationController    *controller_admin.ReputationController
 }
 
 func NewAnswerAPIRouter(
@@ -68,6 +76,7 @@ func NewAnswerAPIRouter(
 	tagController *controller.TagController,
 	followController *controller.FollowController,
 	collectionController *controller.CollectionController,
+	draftController *controller.DraftController,
 	questionController *controller.QuestionController,
 	answerController *controller.AnswerController,
 	searchController *controller.SearchController,
@@ -90,6 +99,13 @@ func NewAnswerAPIRouter(
 	metaController *controller.MetaController,
 	badgeController *controller.BadgeController,
 	adminBadgeController *controller_admin.BadgeController,
+	userDataController *controller.UserDataController,
+	spaceController *controller.SpaceController,
+	adminSpaceController *controller_admin.SpaceController,
+	tagModeratorController *controller_admin.TagModeratorController,
+	adminTagController *controller_admin.TagController,
+	analyticsController *controller_admin.AnalyticsController,
+	reputationController *controller_admin.ReputationController,
 ) *AnswerAPIRouter {
 	return &AnswerAPIRouter{
 		langController:          langController,
@@ -100,6 +116,7 @@ func NewAnswerAPIRouter(
 		tagController:           tagController,
 		followController:        followController,
 		collectionController:    collectionController,
+		draftController:         draftController,
 		questionController:      questionController,
 		answerController:        answerController,
 		searchController:        searchController,
@@ -122,6 +139,13 @@ func NewAnswerAPIRouter(
 		metaController:          metaController,
 		badgeController:         badgeController,
 		adminBadgeController:    adminBadgeController,
+		userDataController:      userDataController,
+		spaceController:         spaceController,
+		adminSpaceController:    adminSpaceController,
+		tagModeratorController:  tagModeratorController,
+		adminTagController:      adminTagController,
+		analyticsController:     analyticsController,
+		reputationController:    reputationController,
 	}
 }
 
@@ -177,6 +201,8 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 
 	// revision
 	r.GET("/revisions", a.revisionController.GetRevisionList)
+	r.GET("/revisions/diff", a.revisionController.GetRevisionDiff)
+	r.GET("/revisions/contributors", a.revisionController.GetContributors)
 
 	// tag
 	r.GET("/tags/page", a.tagController.GetTagWithPage)
@@ -184,6 +210,8 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/tag", a.tagController.GetTagInfo)
 	r.GET("/tags", a.tagController.GetTagsBySlugName)
 	r.GET("/tag/synonyms", a.tagController.GetTagSynonyms)
+	r.GET("/tag/moderators", a.tagController.GetTagModerators)
+	r.GET("/tag/categories", a.tagController.GetTagCategoryList)
 
 	// search
 	r.GET("/search", a.searchController.Search)
@@ -191,6 +219,7 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 
 	// rank
 	r.GET("/personal/rank/page", a.rankController.GetRankPersonalWithPage)
+	r.GET("/personal/reputation/ledger", a.rankController.GetReputationLedger)
 
 	// reaction
 	r.GET("/meta/reaction", a.metaController.GetReaction)
@@ -201,12 +230,19 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/badge/user/awards/recent", a.badgeController.GetRecentBadgeAwardListByUsername)
 	r.GET("/badge/user/awards", a.badgeController.GetAllBadgeAwardListByUsername)
 	r.GET("/badges", a.badgeController.GetBadgeList)
+
+	// user data export, the download url is protected by the token
+	r.GET("/user/data/export/download", a.userDataController.DownloadExport)
+
+	// space
+	r.GET("/spaces", a.spaceController.GetSpaceList)
 }
 
 func (a *AnswerAPIRouter) RegisterAuthUserWithAnyStatusAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/user/logout", a.userController.UserLogout)
 	r.POST("/user/email/change/code", middleware.BanAPIForUserCenter, a.userController.UserChangeEmailSendCode)
 	r.POST("/user/email/verification/send", middleware.BanAPIForUserCenter, a.userController.UserVerifyEmailSend)
+	r.POST("/user/suspension/appeal", a.reviewController.AddSuspensionAppeal)
 }
 
 func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
@@ -214,6 +250,7 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/revisions/unreviewed", a.revisionController.GetUnreviewedRevisionList)
 	r.PUT("/revisions/audit", a.revisionController.RevisionAudit)
 	r.GET("/revisions/edit/check", a.revisionController.CheckCanUpdateRevision)
+	r.POST("/revisions/rollback", a.revisionController.RollbackRevision)
 	r.GET("/reviewing/type", a.revisionController.GetReviewingType)
 
 	// comment
@@ -245,11 +282,19 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.POST("/tag/recover", a.tagController.RecoverTag)
 	r.DELETE("/tag", a.tagController.RemoveTag)
 	r.PUT("/tag/synonym", a.tagController.UpdateTagSynonym)
+	r.POST("/tag/moderator", a.tagController.AddTagModerator)
+	r.DELETE("/tag/moderator", a.tagController.RemoveTagModerator)
 
 	// collection
 	r.POST("/collection/switch", a.collectionController.CollectionSwitch)
 	r.GET("/personal/collection/page", a.questionController.PersonalCollectionPage)
 
+	// draft
+	r.GET("/draft", a.draftController.GetDraft)
+	r.PUT("/draft", a.draftController.SaveDraft)
+	r.DELETE("/draft", a.draftController.RemoveDraft)
+	r.GET("/personal/draft/page", a.draftController.GetDraftPage)
+
 	// question
 	r.POST("/question", a.questionController.AddQuestion)
 	r.POST("/question/answer", a.questionController.AddQuestionByAnswer)
@@ -258,14 +303,17 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.DELETE("/question", a.questionController.RemoveQuestion)
 	r.PUT("/question/status", a.questionController.CloseQuestion)
 	r.PUT("/question/operation", a.questionController.OperationQuestion)
+	r.PUT("/question/wiki", a.questionController.UpdateQuestionWiki)
 	r.PUT("/question/reopen", a.questionController.ReopenQuestion)
 	r.GET("/question/similar", a.questionController.GetSimilarQuestions)
+	r.GET("/question/views", a.questionController.GetQuestionViewTrend)
 	r.POST("/question/recover", a.questionController.QuestionRecover)
 
 	// answer
 	r.POST("/answer", a.answerController.Add)
 	r.PUT("/answer", a.answerController.Update)
 	r.POST("/answer/acceptance", a.answerController.Accepted)
+	r.PUT("/answer/wiki", a.answerController.UpdateAnswerWiki)
 	r.DELETE("/answer", a.answerController.RemoveAnswer)
 	r.POST("/answer/recover", a.answerController.RecoverAnswer)
 
@@ -276,6 +324,11 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/user/notification/config", a.userController.GetUserNotificationConfig)
 	r.PUT("/user/notification/config", a.userController.UpdateUserNotificationConfig)
 	r.GET("/user/info/search", a.userController.SearchUserListByName)
+	r.POST("/user/data/export", a.userDataController.RequestExport)
+	r.GET("/user/data/export", a.userDataController.GetExport)
+	r.POST("/user/deletion", middleware.BanAPIForUserCenter, a.userDataController.RequestDeletion)
+	r.GET("/user/deletion", a.userDataController.GetDeletion)
+	r.DELETE("/user/deletion", a.userDataController.CancelDeletion)
 
 	// vote
 	r.GET("/personal/vote/page", a.voteController.UserVotes)
@@ -319,6 +372,11 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	// user
 	r.GET("/users/page", a.adminUserController.GetUserPage)
 	r.PUT("/user/status", a.adminUserController.UpdateUserStatus)
+	r.GET("/user/suspension/history", a.adminUserController.GetUserSuspensionHistory)
+
+	// reputation
+	r.POST("/reputation/recompute", a.reputationController.RecomputeReputation)
+	r.GET("/reputation/ledger", a.reputationController.GetReputationLedger)
 	r.PUT("/user/role", a.adminUserController.UpdateUserRole)
 	r.GET("/user/activation", a.adminUserController.GetUserActivation)
 	r.POST("/user/activation", a.adminUserController.SendUserActivation)
@@ -357,6 +415,11 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/siteinfo/theme", a.adminSiteInfoController.SaveSiteTheme)
 	r.GET("/siteinfo/users", a.adminSiteInfoController.GetSiteUsers)
 	r.PUT("/siteinfo/users", a.adminSiteInfoController.UpdateSiteUsers)
+	r.GET("/siteinfo/scim", a.adminSiteInfoController.GetSiteScim)
+	r.PUT("/siteinfo/scim", a.adminSiteInfoController.UpdateSiteScim)
+	r.GET("/siteinfo/captcha", a.adminSiteInfoController.GetSiteCaptcha)
+	r.PUT("/siteinfo/captcha", a.adminSiteInfoController.UpdateSiteCaptcha)
+	r.GET("/captcha/stat", a.adminSiteInfoController.GetCaptchaStat)
 	r.GET("/setting/smtp", a.adminSiteInfoController.GetSMTPConfig)
 	r.PUT("/setting/smtp", a.adminSiteInfoController.UpdateSMTPConfig)
 	r.GET("/setting/privileges", a.adminSiteInfoController.GetPrivilegesConfig)
@@ -365,6 +428,10 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	// dashboard
 	r.GET("/dashboard", a.dashboardController.DashboardInfo)
 
+	// analytics
+	r.GET("/analytics", a.analyticsController.GetAnalytics)
+	r.GET("/analytics/export", a.analyticsController.ExportAnalytics)
+
 	// roles
 	r.GET("/roles", a.roleController.GetRoleList)
 
@@ -377,4 +444,25 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	// badge
 	r.GET("/badges", a.adminBadgeController.GetBadgeList)
 	r.PUT("/badge/status", a.adminBadgeController.UpdateBadgeStatus)
+
+	// space
+	r.GET("/spaces", a.adminSpaceController.GetSpaceList)
+	r.POST("/space", a.adminSpaceController.AddSpace)
+	r.PUT("/space", a.adminSpaceController.UpdateSpace)
+	r.DELETE("/space", a.adminSpaceController.RemoveSpace)
+	r.GET("/space/members", a.adminSpaceController.GetSpaceMembers)
+	r.PUT("/space/member", a.adminSpaceController.SaveSpaceMember)
+	r.DELETE("/space/member", a.adminSpaceController.RemoveSpaceMember)
+
+	// tag moderator
+	r.GET("/tag/moderators", a.tagModeratorController.GetTagModerators)
+	r.POST("/tag/moderator", a.tagModeratorController.AddTagModerator)
+	r.DELETE("/tag/moderator", a.tagModeratorController.RemoveTagModerator)
+
+	// tag hierarchy and category
+	r.PUT("/tag/hierarchy", a.adminTagController.UpdateTagHierarchy)
+	r.GET("/tag/categories", a.adminTagController.GetTagCategoryList)
+	r.POST("/tag/category", a.adminTagController.AddTagCategory)
+	r.PUT("/tag/category", a.adminTagController.UpdateTagCategory)
+	r.DELETE("/tag/category", a.adminTagController.RemoveTagCategory)
 }
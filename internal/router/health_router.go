@@ -0,0 +1,112 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/metrics"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	readinessCheckTimeout   = 3 * time.Second
+	readinessCacheKey       = "answer:readiness:"
+	readinessCacheTime      = 10 * time.Second
+	readinessQueueThreshold = 0.9
+)
+
+// HealthRouter health router
+type HealthRouter struct {
+	data *data.Data
+	// cacheKey each instance checks its own key, so the replicas that share the cache do not overwrite each other
+	cacheKey string
+}
+
+// NewHealthRouter new health router
+func NewHealthRouter(data *data.Data) *HealthRouter {
+	return &HealthRouter{
+		data:     data,
+		cacheKey: readinessCacheKey + uid.IDStr(),
+	}
+}
+
+// Register register the liveness and readiness probes
+func (h *HealthRouter) Register(r *gin.Engine) {
+	r.GET("/healthz", func(ctx *gin.Context) { ctx.String(http.StatusOK, "OK") })
+	r.GET("/readyz", h.Readyz)
+}
+
+// Readyz the server is ready when the database and the cache are reachable
+// and no queue is nearly full. The reasons of the failed checks are only logged,
+// because the endpoint is not authenticated.
+func (h *HealthRouter) Readyz(ctx *gin.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	ready := true
+	checks := make(map[string]string)
+	check := func(name string, err error) {
+		if err != nil {
+			ready = false
+			checks[name] = "failed"
+			log.Warnf("readiness check %s failed: %v", name, err)
+			return
+		}
+		checks[name] = "ok"
+	}
+	check("database", h.data.DB.DB().PingContext(checkCtx))
+	check("cache", h.checkCache(checkCtx))
+	for _, backlog := range metrics.GetQueueBacklogs() {
+		var err error
+		if float64(backlog.Length) >= float64(backlog.Capacity)*readinessQueueThreshold {
+			err = fmt.Errorf("backlog %d of capacity %d", backlog.Length, backlog.Capacity)
+		}
+		check("queue_"+backlog.Name, err)
+	}
+
+	status := http.StatusOK
+	if !ready {
+		status = http.StatusServiceUnavailable
+	}
+	ctx.JSON(status, gin.H{"ready": ready, "checks": checks})
+}
+
+func (h *HealthRouter) checkCache(ctx context.Context) error {
+	value := fmt.Sprintf("%d", time.Now().UnixNano())
+	if err := h.data.Cache.SetString(ctx, h.cacheKey, value, readinessCacheTime); err != nil {
+		return err
+	}
+	// the concurrent probes of this instance may write another value, so only the errors are failures
+	_, exist, err := h.data.Cache.GetString(ctx, h.cacheKey)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return fmt.Errorf("cache value not found")
+	}
+	return nil
+}
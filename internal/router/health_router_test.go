@@ -0,0 +1,116 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/contrib/cache/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/xorm/schemas"
+)
+
+func newHealthTestData(t *testing.T) *data.Data {
+	db, err := data.NewDB(false, &data.Database{
+		Driver:     string(schemas.SQLITE),
+		Connection: filepath.Join(t.TempDir(), "answer-health-test.db"),
+	})
+	require.NoError(t, err)
+	dataSource, cleanup, err := data.NewData(db, memory.NewCache())
+	require.NoError(t, err)
+	t.Cleanup(cleanup)
+	return dataSource
+}
+
+func newHealthTestEngine(t *testing.T) *gin.Engine {
+	return newHealthTestEngineWithData(newHealthTestData(t))
+}
+
+func newHealthTestEngineWithData(dataSource *data.Data) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	NewHealthRouter(dataSource).Register(r)
+	return r
+}
+
+func readyz(t *testing.T, r *gin.Engine) (status int, resp map[string]any) {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, resp
+}
+
+func TestHealthRouter_Healthz(t *testing.T) {
+	r := newHealthTestEngine(t)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "OK", w.Body.String())
+}
+
+func TestHealthRouter_Readyz(t *testing.T) {
+	r := newHealthTestEngine(t)
+	length := 0
+	metrics.RegisterQueue("health_test", 10, func() int { return length })
+	t.Cleanup(func() { length = 0 })
+
+	status, resp := readyz(t, r)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, true, resp["ready"])
+	checks, _ := resp["checks"].(map[string]any)
+	assert.Equal(t, "ok", checks["database"])
+	assert.Equal(t, "ok", checks["cache"])
+	assert.Equal(t, "ok", checks["queue_health_test"])
+
+	// the queue is nearly full
+	length = 9
+	status, resp = readyz(t, r)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, false, resp["ready"])
+	checks, _ = resp["checks"].(map[string]any)
+	assert.Equal(t, "failed", checks["queue_health_test"])
+}
+
+func TestHealthRouter_ReadyzSharedCache(t *testing.T) {
+	// the replicas share the same cache
+	dataSource := newHealthTestData(t)
+	replicas := []*gin.Engine{newHealthTestEngineWithData(dataSource), newHealthTestEngineWithData(dataSource)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(r *gin.Engine) {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+		}(replicas[i%len(replicas)])
+	}
+	wg.Wait()
+}
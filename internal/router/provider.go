@@ -29,4 +29,6 @@ var ProviderSetRouter = wire.NewSet(
 	NewUIRouter,
 	NewTemplateRouter,
 	NewPluginAPIRouter,
+	NewScimRouter,
+	NewHealthRouter,
 )
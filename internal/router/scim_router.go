@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package router
+
+import (
+	"github.com/apache/incubator-answer/internal/controller"
+	"github.com/gin-gonic/gin"
+)
+
+type ScimRouter struct {
+	scimController *controller.ScimController
+}
+
+func NewScimRouter(scimController *controller.ScimController) *ScimRouter {
+	return &ScimRouter{
+		scimController: scimController,
+	}
+}
+
+// RegisterScimRouter register scim 2.0 router, the router group must be authenticated by scim token
+func (sr *ScimRouter) RegisterScimRouter(r *gin.RouterGroup) {
+	r.GET("/ServiceProviderConfig", sr.scimController.GetServiceProviderConfig)
+
+	r.GET("/Users", sr.scimController.ListUsers)
+	r.POST("/Users", sr.scimController.CreateUser)
+	r.GET("/Users/:id", sr.scimController.GetUser)
+	r.PUT("/Users/:id", sr.scimController.ReplaceUser)
+	r.PATCH("/Users/:id", sr.scimController.PatchUser)
+	r.DELETE("/Users/:id", sr.scimController.DeleteUser)
+
+	r.GET("/Groups", sr.scimController.ListGroups)
+	r.POST("/Groups", sr.scimController.GroupImmutable)
+	r.GET("/Groups/:id", sr.scimController.GetGroup)
+	r.PUT("/Groups/:id", sr.scimController.ReplaceGroup)
+	r.PATCH("/Groups/:id", sr.scimController.PatchGroup)
+	r.DELETE("/Groups/:id", sr.scimController.GroupImmutable)
+}
@@ -57,6 +57,7 @@ func (a *TemplateRouter) RegisterTemplateRouter(r *gin.RouterGroup, baseURLPath
 
 	seoNoAuth.GET("/robots.txt", a.siteInfoController.GetRobots)
 	seoNoAuth.GET("/custom.css", a.siteInfoController.GetCss)
+	seoNoAuth.GET("/code-highlight.css", a.siteInfoController.GetCodeHighlightCss)
 
 	seoNoAuth.GET("/404", a.templateController.Page404)
 
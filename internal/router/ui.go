@@ -20,6 +20,7 @@
 package router
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"github.com/apache/incubator-answer/plugin"
@@ -143,7 +144,7 @@ func (a *UIRouter) Register(r *gin.Engine, baseURLPath string) {
 		}
 
 		cdnPrefix := ""
-		_ = plugin.CallCDN(func(fn plugin.CDN) error {
+		_ = plugin.CallWithContext(c, plugin.CallCDN, func(_ context.Context, fn plugin.CDN) error {
 			cdnPrefix = fn.GetStaticPrefix()
 			return nil
 		})
@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	AnalyticsGranularityDay   = "day"
+	AnalyticsGranularityWeek  = "week"
+	AnalyticsGranularityMonth = "month"
+
+	// AnalyticsDateFormat date format of the analytics
+	AnalyticsDateFormat = "2006-01-02"
+	// AnalyticsDefaultDays default days of the analytics range
+	AnalyticsDefaultDays = 30
+	// AnalyticsMaxDays max days of the analytics range
+	AnalyticsMaxDays = 3 * 366
+)
+
+// GetAnalyticsReq get analytics time series request
+type GetAnalyticsReq struct {
+	// start date, format: 2006-01-02, default 30 days before end date
+	StartDate string `validate:"omitempty" form:"start_date"`
+	// end date, format: 2006-01-02, default today
+	EndDate     string `validate:"omitempty" form:"end_date"`
+	Granularity string `validate:"omitempty,oneof=day week month" form:"granularity"`
+	// tag id, empty for the whole site
+	TagID string `validate:"omitempty" form:"tag_id"`
+}
+
+// GetAnalyticsResp get analytics time series response
+type GetAnalyticsResp struct {
+	StartDate   string                 `json:"start_date"`
+	EndDate     string                 `json:"end_date"`
+	Granularity string                 `json:"granularity"`
+	TagID       string                 `json:"tag_id"`
+	Series      []*AnalyticsSeriesItem `json:"series"`
+}
+
+// AnalyticsSeriesItem statistics of one period
+type AnalyticsSeriesItem struct {
+	// the first day of the period
+	Date          string `json:"date"`
+	QuestionCount int    `json:"question_count"`
+	AnswerCount   int    `json:"answer_count"`
+	CommentCount  int    `json:"comment_count"`
+	// new users, always 0 for a tag
+	UserCount int `json:"user_count"`
+	// average daily active users of the period
+	ActiveUserCount int `json:"active_user_count"`
+	VoteCount       int `json:"vote_count"`
+	// the ratio of the questions asked in the period which have an accepted answer
+	AcceptRate float64 `json:"accept_rate"`
+	// the ratio of the questions asked in the period which have no answer
+	UnansweredRatio float64 `json:"unanswered_ratio"`
+	// median seconds from asking to the first answer of the questions asked in the period
+	MedianFirstAnswerSeconds int `json:"median_first_answer_seconds"`
+}
@@ -73,6 +73,7 @@ type AnswerUpdateReq struct {
 	UserID       string `json:"-"`
 	NoNeedReview bool   `json:"-"`
 	CanEdit      bool   `json:"-"`
+	IsRollback   bool   `json:"-"`
 	CaptchaID    string `json:"captcha_id"`
 	CaptchaCode  string `json:"captcha_code"`
 }
@@ -88,15 +89,17 @@ type AnswerUpdateResp struct {
 }
 
 type AnswerListReq struct {
-	QuestionID string `json:"question_id" form:"question_id"`
-	Order      string `json:"order" form:"order"`
-	Page       int    `json:"page" form:"page"`
-	PageSize   int    `json:"page_size" form:"page_size"`
-	UserID     string `json:"-"`
-	IsAdmin    bool   `json:"-"`
-	CanEdit    bool   `json:"-"`
-	CanDelete  bool   `json:"-"`
-	CanRecover bool   `json:"-"`
+	QuestionID  string `json:"question_id" form:"question_id"`
+	Order       string `json:"order" form:"order"`
+	Page        int    `json:"page" form:"page"`
+	PageSize    int    `json:"page_size" form:"page_size"`
+	UserID      string `json:"-"`
+	IsAdmin     bool   `json:"-"`
+	CanEdit     bool   `json:"-"`
+	CanDelete   bool   `json:"-"`
+	CanRecover  bool   `json:"-"`
+	CanEditWiki bool   `json:"-"`
+	CanWiki     bool   `json:"-"`
 }
 
 type AnswerInfo struct {
@@ -116,6 +119,7 @@ type AnswerInfo struct {
 	VoteCount      int               `json:"vote_count"`
 	QuestionInfo   *QuestionInfoResp `json:"question_info,omitempty"`
 	Status         int               `json:"status"`
+	Wiki           bool              `json:"wiki"`
 
 	// MemberActions
 	MemberActions []*PermissionMemberAction `json:"member_actions"`
@@ -137,6 +141,13 @@ type AdminAnswerInfo struct {
 	} `json:"question_info"`
 }
 
+// UpdateAnswerWikiReq convert the answer to or from community wiki
+type UpdateAnswerWikiReq struct {
+	ID     string `validate:"required" json:"id"`
+	Wiki   bool   `json:"wiki"`
+	UserID string `json:"-"`
+}
+
 type AcceptAnswerReq struct {
 	QuestionID string `validate:"required,gt=0,lte=30" json:"question_id"`
 	AnswerID   string `validate:"omitempty" json:"answer_id"`
@@ -35,7 +35,14 @@ type UpdateUserStatusReq struct {
 	UserID           string `validate:"required" json:"user_id"`
 	Status           string `validate:"required,oneof=normal suspended deleted inactive" json:"status" enums:"normal,suspended,deleted,inactive"`
 	RemoveAllContent bool   `validate:"omitempty" json:"remove_all_content"`
-	LoginUserID      string `json:"-"`
+	// the following fields are used when suspending the user
+	// reason type, the id of the reason chosen from the user suspend reasons
+	SuspendReasonType int `validate:"omitempty" json:"suspend_reason_type"`
+	// message shown to the suspended user
+	SuspendMessage string `validate:"omitempty,lte=5000" json:"suspend_message"`
+	// unix timestamp of the end of the suspension, 0 means the suspension is lifted manually only
+	SuspendedUntil int64  `validate:"omitempty,min=0" json:"suspended_until"`
+	LoginUserID    string `json:"-"`
 }
 
 func (r *UpdateUserStatusReq) IsNormal() bool    { return r.Status == constant.UserNormal }
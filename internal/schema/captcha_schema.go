@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "github.com/apache/incubator-answer/internal/entity"
+
+const (
+	// CaptchaStatDefaultDays default days of the captcha statistics
+	CaptchaStatDefaultDays = 7
+	// CaptchaStatDateFormat date format of the daily captcha statistics
+	CaptchaStatDateFormat = "2006-01-02"
+)
+
+const (
+	// CaptchaModeAlways the captcha is required every time
+	CaptchaModeAlways = "always"
+	// CaptchaModeNever the captcha is never required
+	CaptchaModeNever = "never"
+	// CaptchaModeThreshold the captcha is required when the action exceeds the thresholds
+	CaptchaModeThreshold = "threshold"
+)
+
+// CaptchaActionConfig the thresholds that decide when the captcha is required for the action
+type CaptchaActionConfig struct {
+	Action string `validate:"required,oneof=email password edit_userinfo question answer comment edit invitation_answer search report delete vote" json:"action"`
+	Mode   string `validate:"required,oneof=always never threshold" json:"mode"`
+	// the captcha is required once the action has been performed max_attempts times, 0 means no limit
+	MaxAttempts int `validate:"omitempty,min=0" json:"max_attempts"`
+	// the attempts are counted from zero again when the action is not performed for window seconds,
+	// 0 means the attempts are not reset
+	Window int64 `validate:"omitempty,min=0" json:"window"`
+	// the captcha is required if the action is performed again within min_interval seconds, 0 means no limit
+	MinInterval int64 `validate:"omitempty,min=0" json:"min_interval"`
+	// the users whose reputation reaches exempt_rank never need the captcha, 0 means no one is exempted
+	ExemptRank int `validate:"omitempty,min=0" json:"exempt_rank"`
+}
+
+// DefaultCaptchaActionConfigs the thresholds used when the action is not configured by the admin
+var DefaultCaptchaActionConfigs = []*CaptchaActionConfig{
+	{Action: entity.CaptchaActionEmail, Mode: CaptchaModeAlways},
+	{Action: entity.CaptchaActionPassword, Mode: CaptchaModeThreshold, MaxAttempts: 3, Window: 60 * 30},
+	{Action: entity.CaptchaActionEditUserinfo, Mode: CaptchaModeThreshold, MaxAttempts: 3, Window: 60 * 30},
+	{Action: entity.CaptchaActionQuestion, Mode: CaptchaModeThreshold, MaxAttempts: 10, MinInterval: 5},
+	{Action: entity.CaptchaActionAnswer, Mode: CaptchaModeThreshold, MaxAttempts: 10, MinInterval: 5},
+	{Action: entity.CaptchaActionComment, Mode: CaptchaModeThreshold, MaxAttempts: 30, MinInterval: 1},
+	{Action: entity.CaptchaActionEdit, Mode: CaptchaModeThreshold, MaxAttempts: 10},
+	{Action: entity.CaptchaActionInvitationAnswer, Mode: CaptchaModeThreshold, MaxAttempts: 30},
+	{Action: entity.CaptchaActionSearch, Mode: CaptchaModeThreshold, MaxAttempts: 20, Window: 60},
+	{Action: entity.CaptchaActionReport, Mode: CaptchaModeThreshold, MaxAttempts: 30, MinInterval: 1},
+	{Action: entity.CaptchaActionDelete, Mode: CaptchaModeThreshold, MaxAttempts: 5, MinInterval: 5},
+	{Action: entity.CaptchaActionVote, Mode: CaptchaModeThreshold, MaxAttempts: 40},
+}
+
+// SiteCaptchaReq site captcha thresholds request, the actions not in the list use the default thresholds
+type SiteCaptchaReq struct {
+	Actions []*CaptchaActionConfig `validate:"omitempty,dive" json:"actions"`
+}
+
+// SiteCaptchaResp site captcha thresholds response
+type SiteCaptchaResp struct {
+	Actions []*CaptchaActionConfig `json:"actions"`
+}
+
+// FillDefault add the default thresholds of the actions that are not configured
+func (r *SiteCaptchaResp) FillDefault() {
+	configured := make(map[string]*CaptchaActionConfig, len(r.Actions))
+	for _, config := range r.Actions {
+		configured[config.Action] = config
+	}
+	actions := make([]*CaptchaActionConfig, 0, len(DefaultCaptchaActionConfigs))
+	for _, defaultConfig := range DefaultCaptchaActionConfigs {
+		if config, ok := configured[defaultConfig.Action]; ok {
+			actions = append(actions, config)
+			continue
+		}
+		config := *defaultConfig
+		actions = append(actions, &config)
+	}
+	r.Actions = actions
+}
+
+// GetActionConfig get the thresholds of the action, nil if the action is unknown
+func (r *SiteCaptchaResp) GetActionConfig(action string) *CaptchaActionConfig {
+	for _, config := range r.Actions {
+		if config.Action == action {
+			return config
+		}
+	}
+	return nil
+}
+
+// GetCaptchaStatReq get captcha statistics request
+type GetCaptchaStatReq struct {
+	// the statistics of the recent days are returned, today included
+	Days int `validate:"omitempty,min=1,max=365" form:"days"`
+}
+
+// CaptchaStatItem the statistics of the captcha of the action
+type CaptchaStatItem struct {
+	Action string `json:"action"`
+	// how many times the captcha is required
+	TriggeredCount int `json:"triggered_count"`
+	// how many times the captcha is wrong
+	FailedCount int `json:"failed_count"`
+	// failed count / triggered count
+	FailureRate float64 `json:"failure_rate"`
+}
+
+// GetCaptchaStatResp get captcha statistics response
+type GetCaptchaStatResp struct {
+	StartDate string             `json:"start_date"`
+	EndDate   string             `json:"end_date"`
+	Actions   []*CaptchaStatItem `json:"actions"`
+}
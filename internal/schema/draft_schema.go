@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+)
+
+// DraftExpireDuration the draft that is not updated in this duration is removed
+const DraftExpireDuration = 30 * 24 * time.Hour
+
+// DraftContext the context of the draft, one draft for each user and context
+type DraftContext struct {
+	DraftType string `validate:"required,oneof=question answer edit" json:"draft_type" form:"draft_type"`
+	// the question id for the answer draft, the question or answer id for the edit draft
+	ObjectID string `validate:"omitempty" json:"object_id" form:"object_id"`
+	UserID   string `json:"-"`
+}
+
+// GetDraftReq get the draft of the context
+type GetDraftReq struct {
+	DraftContext
+}
+
+// RemoveDraftReq remove the draft of the context
+type RemoveDraftReq struct {
+	DraftContext
+}
+
+// SaveDraftReq save the draft of the context
+type SaveDraftReq struct {
+	DraftContext
+	Title   string     `validate:"omitempty,lte=150" json:"title"`
+	Content string     `validate:"omitempty,lte=65535" json:"content"`
+	Tags    []*TagItem `validate:"omitempty,dive" json:"tags"`
+	// the revision that the draft is based on, the last revision of the object is used if it is empty
+	BaseRevisionID string `validate:"omitempty" json:"base_revision_id"`
+}
+
+// GetDraftPageReq get the drafts of the user
+type GetDraftPageReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1" form:"page_size"`
+	UserID   string `json:"-"`
+}
+
+// DraftInfo the draft
+type DraftInfo struct {
+	ID        string     `json:"id"`
+	DraftType string     `json:"draft_type"`
+	ObjectID  string     `json:"object_id"`
+	Title     string     `json:"title"`
+	Content   string     `json:"content"`
+	Tags      []*TagItem `json:"tags"`
+	// the revision that the draft is based on
+	BaseRevisionID string `json:"base_revision_id"`
+	// the last revision of the object now
+	LatestRevisionID string `json:"latest_revision_id"`
+	// whether the object has been changed since the draft is started
+	Conflict  bool  `json:"conflict"`
+	CreatedAt int64 `json:"created_at"`
+	UpdatedAt int64 `json:"updated_at"`
+	ExpiredAt int64 `json:"expired_at"`
+}
+
+// ConvertFromEntity convert the draft entity, the revisions are filled by the caller
+func (d *DraftInfo) ConvertFromEntity(draft *entity.Draft) {
+	d.ID = draft.ID
+	d.DraftType = draft.DraftType
+	d.ObjectID = draft.ObjectID
+	d.Title = draft.Title
+	d.Content = draft.OriginalText
+	d.Tags = make([]*TagItem, 0)
+	if len(draft.Tags) > 0 {
+		_ = json.Unmarshal([]byte(draft.Tags), &d.Tags)
+	}
+	d.BaseRevisionID = draft.BaseRevisionID
+	d.CreatedAt = draft.CreatedAt.Unix()
+	d.UpdatedAt = draft.UpdatedAt.Unix()
+	d.ExpiredAt = draft.UpdatedAt.Add(DraftExpireDuration).Unix()
+}
@@ -127,6 +127,21 @@ type NewCommentTemplateData struct {
 	UnsubscribeUrl string
 }
 
+type NotificationTemplateRawData struct {
+	Description     string
+	Title           string
+	Url             string
+	UnsubscribeCode string
+}
+
+type NotificationTemplateData struct {
+	SiteName       string
+	Description    string
+	Title          string
+	Url            string
+	UnsubscribeUrl string
+}
+
 type NewQuestionTemplateRawData struct {
 	QuestionAuthorDisplayName string
 	QuestionAuthorUserID      string
@@ -85,6 +85,12 @@ func (e *EventMsg) TID(triggerObjectID string) *EventMsg {
 	return e
 }
 
+// OID set trigger object id which is not a short id, such as user id, review id
+func (e *EventMsg) OID(objectID string) *EventMsg {
+	e.TriggerObjectID = objectID
+	return e
+}
+
 // AddExtra add extra info
 func (e *EventMsg) AddExtra(key, value string) *EventMsg {
 	e.ExtraInfo[key] = value
@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/plugin"
+)
+
+// NotificationChannelMsg the notification delivered through the channels, it is saved with the delivery so that
+// the delivery can be retried after the quiet hours or the failure
+type NotificationChannelMsg struct {
+	plugin.NotificationMessage
+	NotificationID string                      `json:"notification_id"`
+	Source         constant.NotificationSource `json:"source"`
+	// Description the translated description of the notification, such as "someone replied your topic"
+	Description string `json:"description"`
+	// Target where the channel delivers to, such as the url of the webhook
+	Target     string `json:"target"`
+	QuestionID string `json:"question_id"`
+	AnswerID   string `json:"answer_id"`
+	CommentID  string `json:"comment_id"`
+}
+
+func (m *NotificationChannelMsg) ToJSONString() string {
+	data, _ := json.Marshal(m)
+	return string(data)
+}
+
+func NewNotificationChannelMsgFromJSON(jsonStr string) (*NotificationChannelMsg, error) {
+	m := &NotificationChannelMsg{}
+	if err := json.Unmarshal([]byte(jsonStr), m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -59,6 +59,19 @@ type OperationQuestionReq struct {
 	UserID    string `json:"-"`         // user_id
 	CanPin    bool   `json:"-"`
 	CanList   bool   `json:"-"`
+
+	// the following fields are used when pinning the question
+	// unix timestamp to unpin the question automatically, 0 means unpin manually only
+	UnpinAt int64 `validate:"omitempty,min=0" json:"unpin_at"`
+	// unix timestamp to close the question automatically, 0 means close manually only
+	CloseAt int64 `validate:"omitempty,min=0" json:"close_at"`
+}
+
+// UpdateQuestionWikiReq convert the question to or from community wiki
+type UpdateQuestionWikiReq struct {
+	ID     string `validate:"required" json:"id"`
+	Wiki   bool   `json:"wiki"`
+	UserID string `json:"-"`
 }
 
 type CloseQuestionMeta struct {
@@ -81,6 +94,11 @@ type QuestionAdd struct {
 	HTML string `json:"-"`
 	// tags
 	Tags []*TagItem `validate:"required,dive" json:"tags"`
+	// space id, empty means the question does not belong to any space
+	SpaceID string `validate:"omitempty" json:"space_id"`
+	// unix timestamp to publish the question, 0 means publish at once.
+	// Only the users who can schedule questions can set it, the question is hidden until then.
+	PublishAt int64 `validate:"omitempty,min=0" json:"publish_at"`
 	// user id
 	UserID string `json:"-"`
 	QuestionPermission
@@ -111,6 +129,8 @@ type QuestionAddByAnswer struct {
 	AnswerHTML    string `json:"-"`
 	// tags
 	Tags []*TagItem `validate:"required,dive" json:"tags"`
+	// space id, empty means the question does not belong to any space
+	SpaceID string `validate:"omitempty" json:"space_id"`
 	// user id
 	UserID              string   `json:"-"`
 	MentionUsernameList []string `validate:"omitempty" json:"mention_username_list"`
@@ -155,6 +175,11 @@ type QuestionPermission struct {
 	CanInviteOtherToAnswer bool `json:"-"`
 	CanAddTag              bool `json:"-"`
 	CanRecover             bool `json:"-"`
+	// whether user can convert it to or from community wiki
+	CanWiki   bool `json:"-"`
+	CanUnWiki bool `json:"-"`
+	// whether user can schedule it to be published later
+	CanSchedule bool `json:"-"`
 }
 
 type CheckCanQuestionUpdate struct {
@@ -182,6 +207,8 @@ type QuestionUpdate struct {
 	// user id
 	UserID       string `json:"-"`
 	NoNeedReview bool   `json:"-"`
+	// whether this update rollbacks the question to a previous revision
+	IsRollback bool `json:"-"`
 	QuestionPermission
 	CaptchaID   string `json:"captcha_id"` // captcha_id
 	CaptchaCode string `json:"captcha_code"`
@@ -226,6 +253,7 @@ type QuestionInfoResp struct {
 	HTML                 string         `json:"html"`
 	Description          string         `json:"description"`
 	Tags                 []*TagResp     `json:"tags"`
+	SpaceID              string         `json:"space_id"`
 	ViewCount            int            `json:"view_count"`
 	UniqueViewCount      int            `json:"unique_view_count"`
 	VoteCount            int            `json:"vote_count"`
@@ -241,6 +269,10 @@ type QuestionInfoResp struct {
 	Pin                  int            `json:"pin"`
 	Show                 int            `json:"show"`
 	Status               int            `json:"status"`
+	Wiki                 bool           `json:"wiki"`
+	PublishAt            int64          `json:"publish_at,omitempty"`
+	UnpinAt              int64          `json:"unpin_at,omitempty"`
+	CloseAt              int64          `json:"close_at,omitempty"`
 	Operation            *Operation     `json:"operation,omitempty"`
 	UserID               string         `json:"-"`
 	LastEditUserID       string         `json:"-"`
@@ -278,6 +310,11 @@ type AdminQuestionInfo struct {
 	EditTime         int64          `json:"edit_time"`
 	UserID           string         `json:"-" `
 	UserInfo         *UserBasicInfo `json:"user_info"`
+
+	// the scheduled time to publish, unpin or close the question, 0 means not scheduled
+	PublishTime int64 `json:"publish_time"`
+	UnpinTime   int64 `json:"unpin_time"`
+	CloseTime   int64 `json:"close_time"`
 }
 
 type OperationLevel string
@@ -359,6 +396,8 @@ type QuestionPageReq struct {
 	Tag       string `validate:"omitempty,gt=0,lte=100" form:"tag"`
 	Username  string `validate:"omitempty,gt=0,lte=100" form:"username"`
 	InDays    int    `validate:"omitempty,min=1" form:"in_days"`
+	// space slug name
+	Space string `validate:"omitempty,gt=0,lte=35" form:"space"`
 
 	LoginUserID      string `json:"-"`
 	UserIDBeSearched string `json:"-"`
@@ -415,7 +454,7 @@ type QuestionPageRespOperator struct {
 type AdminQuestionPageReq struct {
 	Page        int    `validate:"omitempty,min=1" form:"page"`
 	PageSize    int    `validate:"omitempty,min=1" form:"page_size"`
-	StatusCond  string `validate:"omitempty,oneof=normal closed deleted pending" form:"status"`
+	StatusCond  string `validate:"omitempty,oneof=normal closed deleted pending scheduled" form:"status"`
 	Query       string `validate:"omitempty,gt=0,lte=100" json:"query" form:"query" `
 	Status      int    `json:"-"`
 	LoginUserID string `json:"-"`
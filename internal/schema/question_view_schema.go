@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// QuestionViewTrendDefaultDays default days of the question view trend
+	QuestionViewTrendDefaultDays = 30
+	// QuestionViewDateFormat date format of the question view daily bucket
+	QuestionViewDateFormat = "2006-01-02"
+)
+
+// AddQuestionViewReq add question view request
+type AddQuestionViewReq struct {
+	QuestionID string
+	UserID     string
+	IP         string
+	UserAgent  string
+}
+
+// GetQuestionViewTrendReq get question view trend request
+type GetQuestionViewTrendReq struct {
+	QuestionID string `validate:"required" form:"question_id"`
+	Days       int    `validate:"omitempty,min=1,max=365" form:"days"`
+	UserID     string `json:"-"`
+	IsAdmin    bool   `json:"-"`
+}
+
+// GetQuestionViewTrendResp get question view trend response
+type GetQuestionViewTrendResp struct {
+	ViewCount       int                      `json:"view_count"`
+	UniqueViewCount int                      `json:"unique_view_count"`
+	Days            []*QuestionViewDailyItem `json:"days"`
+}
+
+// QuestionViewDailyItem question views of one day
+type QuestionViewDailyItem struct {
+	Date            string `json:"date"`
+	ViewCount       int    `json:"view_count"`
+	UniqueViewCount int    `json:"unique_view_count"`
+}
@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// GetReputationLedgerReq get the reputation ledger of the user
+type GetReputationLedgerReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1" form:"page_size"`
+	Username string `validate:"omitempty,gt=0,lte=100" form:"username"`
+	// admin can get the ledger of any user by user id
+	UserID string `validate:"omitempty" form:"user_id"`
+}
+
+// GetReputationLedgerResp the reputation ledger of the user
+type GetReputationLedgerResp struct {
+	UserInfo *UserBasicInfo `json:"user_info"`
+	// the stored reputation of the user
+	Reputation int `json:"reputation"`
+	// the reputation before the first activity
+	Opening int `json:"opening"`
+	// the reputation replayed from the activities under the current rules
+	Expected int `json:"expected"`
+	// how much the stored reputation is more than the replayed one
+	Drift int                     `json:"drift"`
+	Count int64                   `json:"count"`
+	List  []*ReputationLedgerItem `json:"list"`
+}
+
+// ReputationLedgerItem one activity of the reputation ledger, the newest first
+type ReputationLedgerItem struct {
+	ActivityID   string `json:"activity_id"`
+	CreatedAt    int64  `json:"created_at"`
+	ActivityType string `json:"activity_type"`
+	RankType     string `json:"rank_type"`
+	ObjectID     string `json:"object_id"`
+	ObjectType   string `json:"object_type"`
+	Title        string `json:"title"`
+	UrlTitle     string `json:"url_title"`
+	// the points awarded when the activity happened
+	Awarded int `json:"awarded"`
+	// the points under the current rules
+	Points int `json:"points"`
+	// the reputation after this activity
+	Balance int `json:"balance"`
+	// why the points are different from the points of the rule
+	Note string `json:"note" enums:"daily_limit,min_rank,adjustment,wiki"`
+}
+
+// RecomputeReputationReq replay the activities of users and report the drift
+type RecomputeReputationReq struct {
+	// only recompute this user, otherwise recompute the users of the page
+	UserID   string `validate:"omitempty" json:"user_id"`
+	Page     int    `validate:"omitempty,min=1" json:"page"`
+	PageSize int    `validate:"omitempty,min=1,max=500" json:"page_size"`
+	// apply the corrections as adjustment activities, otherwise only report the drift
+	Apply       bool   `json:"apply"`
+	LoginUserID string `json:"-"`
+}
+
+// RecomputeReputationResp the drift of the recomputed users
+type RecomputeReputationResp struct {
+	Checked int                    `json:"checked"`
+	Drifted int                    `json:"drifted"`
+	Applied int                    `json:"applied"`
+	Total   int64                  `json:"total"`
+	List    []*ReputationDriftItem `json:"list"`
+}
+
+// ReputationDriftItem the user whose stored reputation is different from the replayed one
+type ReputationDriftItem struct {
+	UserID     string `json:"user_id"`
+	Username   string `json:"username"`
+	Reputation int    `json:"reputation"`
+	Expected   int    `json:"expected"`
+	Drift      int    `json:"drift"`
+	Applied    bool   `json:"applied"`
+}
@@ -65,7 +65,7 @@ type GetUnreviewedPostPageResp struct {
 	QuestionID           string        `json:"question_id"`
 	AnswerID             string        `json:"answer_id"`
 	CommentID            string        `json:"comment_id"`
-	ObjectType           string        `json:"object_type" enums:"question,answer,comment"`
+	ObjectType           string        `json:"object_type" enums:"question,answer,comment,user"`
 	Title                string        `json:"title"`
 	UrlTitle             string        `json:"url_title"`
 	OriginalText         string        `json:"original_text"`
@@ -77,4 +77,6 @@ type GetUnreviewedPostPageResp struct {
 	SubmitAt             int64         `json:"submit_at"`
 	SubmitterDisplayName string        `json:"submitter_display_name"`
 	Reason               string        `json:"reason"`
+	// the appealed suspension, only returned when the object type is user
+	Suspension *UserSuspensionInfo `json:"suspension,omitempty"`
 }
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/pkg/diff"
 )
 
 // AddRevisionDTO add revision request
@@ -47,6 +48,23 @@ type GetRevisionListReq struct {
 	ObjectID string `validate:"required" comment:"object_id" form:"object_id"`
 }
 
+// GetContributorsReq get the contributors of the question or answer request
+type GetContributorsReq struct {
+	// object id
+	ObjectID string `validate:"required" comment:"object_id" form:"object_id"`
+}
+
+// ContributorItem the user who wrote or edited the question or answer
+type ContributorItem struct {
+	UserInfo *UserBasicInfo `json:"user_info"`
+	// whether the user is the author
+	IsAuthor bool `json:"is_author"`
+	// the number of the visible revisions of the user
+	EditCount int `json:"edit_count"`
+	// the time of the last revision of the user
+	LastEditTime int64 `json:"last_edit_time"`
+}
+
 const RevisionAuditApprove = "approve"
 const RevisionAuditReject = "reject"
 
@@ -134,3 +152,52 @@ type GetReviewingTypeResp struct {
 	Label      string `json:"label"`
 	TodoAmount int64  `json:"todo_amount"`
 }
+
+// GetRevisionDiffReq get the diff between two revisions request
+type GetRevisionDiffReq struct {
+	// the older revision
+	BaseRevisionID string `validate:"required" form:"base_revision_id"`
+	// the newer revision
+	TargetRevisionID string `validate:"required" form:"target_revision_id"`
+}
+
+// GetRevisionDiffResp get the diff between two revisions response
+type GetRevisionDiffResp struct {
+	ObjectID         string `json:"object_id"`
+	ObjectType       string `json:"object_type"`
+	BaseRevisionID   string `json:"base_revision_id"`
+	TargetRevisionID string `json:"target_revision_id"`
+	// word level diff of the title, the slug name for tag
+	Title []*diff.Chunk `json:"title"`
+	// line level diff of the markdown content
+	Content []*diff.Chunk `json:"content"`
+	// tag set diff, only for question
+	Tags *RevisionTagDiff `json:"tags"`
+}
+
+// RevisionTagDiff the tag slug names that are added, removed or unchanged
+type RevisionTagDiff struct {
+	Added     []string `json:"added"`
+	Removed   []string `json:"removed"`
+	Unchanged []string `json:"unchanged"`
+}
+
+// RevisionRollbackReq rollback the object to the revision request
+type RevisionRollbackReq struct {
+	RevisionID  string `validate:"required" json:"revision_id"`
+	EditSummary string `validate:"omitempty" json:"edit_summary"`
+	CaptchaID   string `json:"captcha_id"`
+	CaptchaCode string `json:"captcha_code"`
+	UserID      string `json:"-"`
+	// whether user can edit the object
+	CanEdit bool `json:"-"`
+	// whether the rollback takes effect without review
+	NoNeedReview      bool `json:"-"`
+	CanUseReservedTag bool `json:"-"`
+	CanAddTag         bool `json:"-"`
+}
+
+// RevisionRollbackResp rollback the object to the revision response
+type RevisionRollbackResp struct {
+	WaitForReview bool `json:"wait_for_review"`
+}
@@ -0,0 +1,229 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"strings"
+)
+
+const (
+	ScimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	ScimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+
+	ScimResourceTypeUser  = "User"
+	ScimResourceTypeGroup = "Group"
+
+	ScimContentType = "application/scim+json"
+
+	ScimDefaultCount = 100
+	ScimMaxCount     = 200
+)
+
+// ScimListReq scim list resources request
+type ScimListReq struct {
+	Filter             string `form:"filter"`
+	StartIndex         int    `form:"startIndex"`
+	Count              *int   `form:"count"`
+	ExcludedAttributes string `form:"excludedAttributes"`
+}
+
+// ExcludeMembers whether the group members should be excluded from response
+func (r *ScimListReq) ExcludeMembers() bool {
+	for _, attr := range strings.Split(r.ExcludedAttributes, ",") {
+		if strings.EqualFold(strings.TrimSpace(attr), "members") {
+			return true
+		}
+	}
+	return false
+}
+
+// Format normalize the pagination parameters according to RFC 7644 3.4.2.4
+func (r *ScimListReq) Format() {
+	if r.StartIndex < 1 {
+		r.StartIndex = 1
+	}
+	// count=0 means only the total results are required, so the default is only used when it is absent
+	count := ScimDefaultCount
+	if r.Count != nil {
+		count = *r.Count
+	}
+	if count < 0 {
+		count = 0
+	}
+	if count > ScimMaxCount {
+		count = ScimMaxCount
+	}
+	r.Count = &count
+}
+
+// ScimListResp scim list response
+type ScimListResp struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int64       `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// ScimMeta scim resource meta
+type ScimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+// ScimName scim user name
+type ScimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// ScimMultiValued scim multi valued attribute, such as emails or groups
+type ScimMultiValued struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// ScimUser scim user resource
+type ScimUser struct {
+	Schemas     []string           `json:"schemas"`
+	ID          string             `json:"id,omitempty"`
+	ExternalID  string             `json:"externalId,omitempty"`
+	UserName    string             `json:"userName"`
+	Name        *ScimName          `json:"name,omitempty"`
+	DisplayName string             `json:"displayName,omitempty"`
+	Password    string             `json:"password,omitempty"`
+	Active      *bool              `json:"active,omitempty"`
+	Emails      []*ScimMultiValued `json:"emails,omitempty"`
+	Groups      []*ScimMultiValued `json:"groups,omitempty"`
+	Meta        *ScimMeta          `json:"meta,omitempty"`
+}
+
+// GetPrimaryEmail get the primary email, if no primary email, the first one will be returned
+func (u *ScimUser) GetPrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary && len(email.Value) > 0 {
+			return email.Value
+		}
+	}
+	for _, email := range u.Emails {
+		if len(email.Value) > 0 {
+			return email.Value
+		}
+	}
+	if strings.Contains(u.UserName, "@") {
+		return u.UserName
+	}
+	return ""
+}
+
+// GetDisplayName get display name, fallback to name.formatted, given and family name, user name
+func (u *ScimUser) GetDisplayName() string {
+	if len(u.DisplayName) > 0 {
+		return u.DisplayName
+	}
+	if u.Name != nil {
+		if len(u.Name.Formatted) > 0 {
+			return u.Name.Formatted
+		}
+		if name := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); len(name) > 0 {
+			return name
+		}
+	}
+	return u.UserName
+}
+
+// IsActive if active is not set, user is active by default
+func (u *ScimUser) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// ScimGroup scim group resource, mapping to answer role
+type ScimGroup struct {
+	Schemas     []string           `json:"schemas"`
+	ID          string             `json:"id"`
+	DisplayName string             `json:"displayName"`
+	Members     []*ScimMultiValued `json:"members"`
+	Meta        *ScimMeta          `json:"meta,omitempty"`
+}
+
+// ScimPatchReq scim patch request
+type ScimPatchReq struct {
+	Schemas    []string            `json:"schemas"`
+	Operations []*ScimPatchOperate `json:"Operations"`
+}
+
+// ScimPatchOperate scim patch operation
+type ScimPatchOperate struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// ScimErrorResp scim error response
+type ScimErrorResp struct {
+	Schemas  []string `json:"schemas"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+	Status   string   `json:"status"`
+}
+
+// ScimServiceProviderConfigResp scim service provider config
+type ScimServiceProviderConfigResp struct {
+	Schemas               []string                    `json:"schemas"`
+	Patch                 ScimSupported               `json:"patch"`
+	Bulk                  ScimBulkSupported           `json:"bulk"`
+	Filter                ScimFilterSupported         `json:"filter"`
+	ChangePassword        ScimSupported               `json:"changePassword"`
+	Sort                  ScimSupported               `json:"sort"`
+	Etag                  ScimSupported               `json:"etag"`
+	AuthenticationSchemes []*ScimAuthenticationScheme `json:"authenticationSchemes"`
+}
+
+type ScimSupported struct {
+	Supported bool `json:"supported"`
+}
+
+type ScimBulkSupported struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type ScimFilterSupported struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type ScimAuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func bindScimListReq(t *testing.T, query string) *ScimListReq {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest("GET", "/scim/v2/Users"+query, nil)
+	req := &ScimListReq{}
+	assert.NoError(t, ctx.ShouldBindQuery(req))
+	req.Format()
+	return req
+}
+
+func TestScimListReq_Format(t *testing.T) {
+	req := bindScimListReq(t, "")
+	assert.Equal(t, 1, req.StartIndex)
+	assert.Equal(t, ScimDefaultCount, *req.Count)
+
+	// count=0 only returns the total results
+	req = bindScimListReq(t, "?count=0")
+	assert.Equal(t, 0, *req.Count)
+
+	req = bindScimListReq(t, "?startIndex=0&count=-1")
+	assert.Equal(t, 1, req.StartIndex)
+	assert.Equal(t, 0, *req.Count)
+
+	req = bindScimListReq(t, "?count=1000")
+	assert.Equal(t, ScimMaxCount, *req.Count)
+}
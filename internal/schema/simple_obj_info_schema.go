@@ -38,6 +38,7 @@ type SimpleObjectInfo struct {
 	ObjectType          string `json:"object_type"`
 	Title               string `json:"title"`
 	Content             string `json:"content"`
+	Wiki                bool   `json:"wiki"`
 }
 
 // IsDeleted is deleted
@@ -60,6 +60,8 @@ func (r *SiteGeneralReq) FormatSiteUrl() {
 type SiteInterfaceReq struct {
 	Language string `validate:"required,gt=1,lte=128" form:"language" json:"language"`
 	TimeZone string `validate:"required,gt=1,lte=128" form:"time_zone" json:"time_zone"`
+	// CodeHighlightTheme the theme name of server-side code highlight, such as github, monokai
+	CodeHighlightTheme string `validate:"omitempty,lte=64" form:"code_highlight_theme" json:"code_highlight_theme"`
 }
 
 // SiteBrandingReq site branding request
@@ -135,6 +137,19 @@ type SiteLoginReq struct {
 	AllowEmailDomains       []string `json:"allow_email_domains"`
 }
 
+// SiteScimReq site scim provisioning request
+type SiteScimReq struct {
+	Enable bool `json:"enable"`
+	// if reset token is true, a new bearer token will be generated
+	ResetToken bool `json:"reset_token"`
+}
+
+// SiteScimResp site scim provisioning response
+type SiteScimResp struct {
+	Enable bool   `json:"enable"`
+	Token  string `json:"token"`
+}
+
 // SiteCustomCssHTMLReq site custom css html
 type SiteCustomCssHTMLReq struct {
 	CustomHead    string `validate:"omitempty,gt=0,lte=65536" json:"custom_head"`
@@ -359,6 +374,7 @@ var (
 		constant.RankTagAuditKey:                  {1, 2500, 5000},
 		constant.RankTagEditWithoutReviewKey:      {1, 10000, 20000},
 		constant.RankTagSynonymKey:                {1, 10000, 20000},
+		constant.RankWikiEditKey:                  {1, 50, 100},
 	}
 )
 
@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/validator"
+)
+
+// AddSpaceReq add space request
+type AddSpaceReq struct {
+	SlugName    string `validate:"required,gt=0,lte=35" json:"slug_name"`
+	DisplayName string `validate:"required,gt=0,lte=35" json:"display_name"`
+	Description string `validate:"omitempty,lte=65536" json:"description"`
+	// 1: public 2: members only 3: hidden
+	Visibility int `validate:"required,oneof=1 2 3" json:"visibility"`
+}
+
+func (r *AddSpaceReq) Check() (errFields []*validator.FormErrorField, err error) {
+	r.SlugName = strings.ToLower(r.SlugName)
+	return nil, nil
+}
+
+// AddSpaceResp add space response
+type AddSpaceResp struct {
+	ID string `json:"id"`
+}
+
+// UpdateSpaceReq update space request
+type UpdateSpaceReq struct {
+	ID          string `validate:"required" json:"id"`
+	SlugName    string `validate:"required,gt=0,lte=35" json:"slug_name"`
+	DisplayName string `validate:"required,gt=0,lte=35" json:"display_name"`
+	Description string `validate:"omitempty,lte=65536" json:"description"`
+	// 1: public 2: members only 3: hidden
+	Visibility int `validate:"required,oneof=1 2 3" json:"visibility"`
+}
+
+func (r *UpdateSpaceReq) Check() (errFields []*validator.FormErrorField, err error) {
+	r.SlugName = strings.ToLower(r.SlugName)
+	return nil, nil
+}
+
+// RemoveSpaceReq remove space request
+type RemoveSpaceReq struct {
+	ID string `validate:"required" json:"id"`
+}
+
+// GetSpaceInfoReq get space info request
+type GetSpaceInfoReq struct {
+	ID string `validate:"required" form:"id"`
+}
+
+// GetSpaceResp get space response
+type GetSpaceResp struct {
+	ID          string `json:"id"`
+	SlugName    string `json:"slug_name"`
+	DisplayName string `json:"display_name"`
+	Description string `json:"description"`
+	Visibility  int    `json:"visibility"`
+	// whether the login user is a member of this space
+	IsMember bool `json:"is_member"`
+	// whether the login user can post questions in this space
+	CanPost bool `json:"can_post"`
+}
+
+// GetSpaceMembersReq get space members request
+type GetSpaceMembersReq struct {
+	SpaceID string `validate:"required" form:"space_id"`
+}
+
+// SpaceMemberReq add or update space member request
+type SpaceMemberReq struct {
+	SpaceID string `validate:"required" json:"space_id"`
+	// 1: user 2: role
+	MemberType int `validate:"required,oneof=1 2" json:"member_type"`
+	// user id or role id
+	MemberID    string `validate:"required" json:"member_id"`
+	IsModerator bool   `json:"is_moderator"`
+}
+
+// RemoveSpaceMemberReq remove space member request
+type RemoveSpaceMemberReq struct {
+	SpaceID    string `validate:"required" json:"space_id"`
+	MemberType int    `validate:"required,oneof=1 2" json:"member_type"`
+	MemberID   string `validate:"required" json:"member_id"`
+}
+
+// GetSpaceMemberResp get space member response
+type GetSpaceMemberResp struct {
+	MemberType int    `json:"member_type"`
+	MemberID   string `json:"member_id"`
+	// username of user or name of role
+	DisplayName string `json:"display_name"`
+	IsModerator bool   `json:"is_moderator"`
+}
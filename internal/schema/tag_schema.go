@@ -87,6 +87,10 @@ type GetTagResp struct {
 	MainTagSlugName string `json:"main_tag_slug_name"`
 	Recommend       bool   `json:"recommend"`
 	Reserved        bool   `json:"reserved"`
+	// if parent tag id is not empty, this tag is a child of the parent tag
+	ParentTagID       string `json:"parent_tag_id"`
+	ParentTagSlugName string `json:"parent_tag_slug_name"`
+	CategoryID        string `json:"category_id"`
 }
 
 func (tr *GetTagResp) GetExcerpt() {
@@ -126,6 +130,10 @@ type GetTagPageResp struct {
 	UpdatedAt int64 `json:"updated_at"`
 	Recommend bool  `json:"recommend"`
 	Reserved  bool  `json:"reserved"`
+	// parent tag id
+	ParentTagID string `json:"parent_tag_id"`
+	// category id
+	CategoryID string `json:"category_id"`
 }
 
 func (tr *GetTagPageResp) GetExcerpt() {
@@ -205,6 +213,8 @@ type UpdateTagReq struct {
 	// user id
 	UserID       string `json:"-"`
 	NoNeedReview bool   `json:"-"`
+	// whether this update rollbacks the tag to a previous revision
+	IsRollback bool `json:"-"`
 }
 
 func (r *UpdateTagReq) Check() (errFields []*validator.FormErrorField, err error) {
@@ -235,6 +245,8 @@ type GetTagWithPageReq struct {
 	DisplayName string `validate:"omitempty,gt=0,lte=35" form:"display_name"`
 	// query condition
 	QueryCond string `validate:"omitempty,oneof=popular name newest" form:"query_cond"`
+	// category id
+	CategoryID string `validate:"omitempty" form:"category_id"`
 	// user id
 	UserID string `json:"-"`
 }
@@ -305,3 +317,43 @@ type GetTagBasicResp struct {
 	Recommend   bool   `json:"recommend"`
 	Reserved    bool   `json:"reserved"`
 }
+
+// UpdateTagHierarchyReq update the parent tag and the category of the tag request
+type UpdateTagHierarchyReq struct {
+	// tag_id
+	TagID string `validate:"required" json:"tag_id"`
+	// parent tag id, empty means the tag has no parent
+	ParentTagID string `validate:"omitempty" json:"parent_tag_id"`
+	// category id, empty means the tag has no category
+	CategoryID string `validate:"omitempty" json:"category_id"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// GetTagCategoryResp get tag category response
+type GetTagCategoryResp struct {
+	ID          string `json:"id"`
+	SlugName    string `json:"slug_name"`
+	DisplayName string `json:"display_name"`
+	SortOrder   int    `json:"sort_order"`
+}
+
+// AddTagCategoryReq add tag category request
+type AddTagCategoryReq struct {
+	SlugName    string `validate:"required,gt=0,lte=35" json:"slug_name"`
+	DisplayName string `validate:"required,gt=0,lte=35" json:"display_name"`
+	SortOrder   int    `validate:"omitempty,min=0" json:"sort_order"`
+}
+
+// UpdateTagCategoryReq update tag category request
+type UpdateTagCategoryReq struct {
+	ID          string `validate:"required" json:"id"`
+	SlugName    string `validate:"required,gt=0,lte=35" json:"slug_name"`
+	DisplayName string `validate:"required,gt=0,lte=35" json:"display_name"`
+	SortOrder   int    `validate:"omitempty,min=0" json:"sort_order"`
+}
+
+// RemoveTagCategoryReq remove tag category request
+type RemoveTagCategoryReq struct {
+	ID string `validate:"required" json:"id"`
+}
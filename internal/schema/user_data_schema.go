@@ -0,0 +1,157 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// UserDataExportReq user request to export personal data
+type UserDataExportReq struct {
+	UserID string `json:"-"`
+}
+
+// GetUserDataExportReq get the latest personal data export of user
+type GetUserDataExportReq struct {
+	UserID string `json:"-"`
+}
+
+// UserDataExportResp user personal data export job
+type UserDataExportResp struct {
+	// pending, completed, failed
+	Status      string `json:"status"`
+	DownloadURL string `json:"download_url"`
+	CreatedAt   int64  `json:"created_at"`
+	ExpiredAt   int64  `json:"expired_at"`
+}
+
+// DownloadUserDataExportReq download personal data export file
+type DownloadUserDataExportReq struct {
+	Token string `validate:"required,lte=64" form:"token"`
+}
+
+// UserDeletionReq user request to delete account
+type UserDeletionReq struct {
+	// anonymize: keep the content and reassign to the ghost user; remove: remove all the content
+	Mode   string `validate:"required,oneof=anonymize remove" json:"mode"`
+	Pass   string `validate:"omitempty,lte=32" json:"pass"`
+	UserID string `json:"-"`
+}
+
+// GetUserDeletionReq get user pending deletion request
+type GetUserDeletionReq struct {
+	UserID string `json:"-"`
+}
+
+// CancelUserDeletionReq cancel user pending deletion request
+type CancelUserDeletionReq struct {
+	UserID string `json:"-"`
+}
+
+// UserDeletionResp user deletion request
+type UserDeletionResp struct {
+	Mode        string `json:"mode"`
+	CreatedAt   int64  `json:"created_at"`
+	ScheduledAt int64  `json:"scheduled_at"`
+}
+
+// UserDataExportProfile personal profile in export file
+type UserDataExportProfile struct {
+	ID            string `json:"id"`
+	Username      string `json:"username"`
+	DisplayName   string `json:"display_name"`
+	Email         string `json:"e_mail"`
+	Avatar        string `json:"avatar"`
+	Bio           string `json:"bio"`
+	Website       string `json:"website"`
+	Location      string `json:"location"`
+	Language      string `json:"language"`
+	Rank          int    `json:"rank"`
+	CreatedAt     int64  `json:"created_at"`
+	LastLoginDate int64  `json:"last_login_date"`
+}
+
+// UserDataExportQuestion question in export file
+type UserDataExportQuestion struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Content     string `json:"content"`
+	Status      string `json:"status"`
+	ViewCount   int    `json:"view_count"`
+	VoteCount   int    `json:"vote_count"`
+	AnswerCount int    `json:"answer_count"`
+	CreatedAt   int64  `json:"created_at"`
+	UpdatedAt   int64  `json:"updated_at"`
+}
+
+// UserDataExportAnswer answer in export file
+type UserDataExportAnswer struct {
+	ID         string `json:"id"`
+	QuestionID string `json:"question_id"`
+	Content    string `json:"content"`
+	Accepted   bool   `json:"accepted"`
+	VoteCount  int    `json:"vote_count"`
+	CreatedAt  int64  `json:"created_at"`
+	UpdatedAt  int64  `json:"updated_at"`
+}
+
+// UserDataExportComment comment in export file
+type UserDataExportComment struct {
+	ID        string `json:"id"`
+	ObjectID  string `json:"object_id"`
+	Content   string `json:"content"`
+	VoteCount int    `json:"vote_count"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// UserDataExportVote vote in export file
+type UserDataExportVote struct {
+	ObjectID  string `json:"object_id"`
+	Type      string `json:"type"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// UserDataExportCollection collection in export file
+type UserDataExportCollection struct {
+	ObjectID  string `json:"object_id"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// UserDataExportBadge badge award in export file
+type UserDataExportBadge struct {
+	BadgeID   string `json:"badge_id"`
+	AwardKey  string `json:"award_key"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// UserDataExportNotification notification in export file
+type UserDataExportNotification struct {
+	ID        string `json:"id"`
+	ObjectID  string `json:"object_id"`
+	Content   string `json:"content"`
+	IsRead    bool   `json:"is_read"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// UserDataExportDraft draft in export file
+type UserDataExportDraft struct {
+	DraftType string `json:"draft_type"`
+	ObjectID  string `json:"object_id"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
@@ -19,6 +19,8 @@
 
 package schema
 
+import "github.com/apache/incubator-answer/internal/entity"
+
 // UserExternalLoginResp user external login resp
 type UserExternalLoginResp struct {
 	BindingKey  string `json:"binding_key"`
@@ -75,6 +77,29 @@ type ExternalLoginUserInfoCache struct {
 	Bio string
 }
 
+// ExternalProfile the normalized profile of the user decoded from the meta info of all external logins
+type ExternalProfile struct {
+	// Providers the providers that the user has logged in with
+	Providers []string `json:"providers"`
+	// Membership the membership provided by the third-party login platform, the plan is empty if not a member
+	Membership entity.Membership `json:"membership"`
+	// Verified whether the user is verified by any third-party login platform
+	Verified bool `json:"verified"`
+}
+
+// GetMembership returns the membership, it is safe to call on a nil profile
+func (p *ExternalProfile) GetMembership() entity.Membership {
+	if p == nil {
+		return entity.Membership{}
+	}
+	return p.Membership
+}
+
+// IsVerified returns whether the user is verified, it is safe to call on a nil profile
+func (p *ExternalProfile) IsVerified() bool {
+	return p != nil && p.Verified
+}
+
 // ExternalLoginUnbindingReq external login unbinding user
 type ExternalLoginUnbindingReq struct {
 	ExternalID string `validate:"required,gt=0,lte=128" json:"external_id"`
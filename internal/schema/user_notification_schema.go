@@ -21,8 +21,15 @@ package schema
 
 import (
 	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
 	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/validator"
 	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/segmentfault/pacman/errors"
 )
 
 type NotificationChannelConfig struct {
@@ -56,10 +63,15 @@ type NotificationConfig struct {
 	Inbox                          NotificationChannelConfig `json:"inbox"`
 	AllNewQuestion                 NotificationChannelConfig `json:"all_new_question"`
 	AllNewQuestionForFollowingTags NotificationChannelConfig `json:"all_new_question_for_following_tags"`
+	// Types the channels chosen for each type of the inbox notifications
+	Types map[constant.NotificationSource]NotificationChannels `json:"types"`
+	// ChannelTargets where the webhook channels deliver to
+	ChannelTargets NotificationChannelTargets `validate:"omitempty,dive" json:"channel_targets"`
+	QuietHours     *NotificationQuietHours    `json:"quiet_hours"`
 }
 
 func NewNotificationConfig(configs []*entity.UserNotificationConfig) NotificationConfig {
-	nc := NotificationConfig{}
+	nc := NotificationConfig{Types: make(map[constant.NotificationSource]NotificationChannels)}
 	for _, item := range configs {
 		switch item.Source {
 		case string(constant.InboxSource):
@@ -68,6 +80,15 @@ func NewNotificationConfig(configs []*entity.UserNotificationConfig) Notificatio
 			nc.AllNewQuestion = NewNotificationChannelConfigFormJson(item.Channels)
 		case string(constant.AllNewQuestionForFollowingTagsSource):
 			nc.AllNewQuestionForFollowingTags = NewNotificationChannelConfigFormJson(item.Channels)
+		case string(constant.ChannelTargetSource):
+			_ = json.Unmarshal([]byte(item.Channels), &nc.ChannelTargets)
+		case string(constant.QuietHoursSource):
+			nc.QuietHours = &NotificationQuietHours{}
+			_ = json.Unmarshal([]byte(item.Channels), nc.QuietHours)
+		default:
+			if IsNotificationTypeSource(constant.NotificationSource(item.Source)) {
+				nc.Types[constant.NotificationSource(item.Source)] = NewNotificationChannelsFormJson(item.Channels)
+			}
 		}
 	}
 	return nc
@@ -86,6 +107,116 @@ func (n *NotificationConfig) Format() {
 		n.AllNewQuestionForFollowingTags.Key = constant.EmailChannel
 		n.AllNewQuestionForFollowingTags.Enable = false
 	}
+	if n.Types == nil {
+		n.Types = make(map[constant.NotificationSource]NotificationChannels)
+	}
+}
+
+// IsChannelEnabled whether the user receives the type of notifications through the channel.
+// If the user has not chosen the channel for the type, the email follows the inbox config for the types that
+// have email templates, the mixin and plugin channels are enabled, and the others are disabled.
+func (n *NotificationConfig) IsChannelEnabled(source constant.NotificationSource, key constant.NotificationChannelKey) bool {
+	for _, channel := range n.Types[source] {
+		if channel.Key == key {
+			return channel.Enable
+		}
+	}
+	switch {
+	case key == constant.EmailChannel:
+		return HasNotificationEmailTemplate(source) && n.Inbox.Key == constant.EmailChannel && n.Inbox.Enable
+	case key == constant.MixinChannel:
+		return true
+	case strings.HasPrefix(string(key), constant.PluginChannelPrefix):
+		return true
+	default:
+		return false
+	}
+}
+
+// GetChannelTarget get where the channel delivers to
+func (n *NotificationConfig) GetChannelTarget(key constant.NotificationChannelKey) string {
+	for _, target := range n.ChannelTargets {
+		if target.Key == key {
+			return target.Target
+		}
+	}
+	return ""
+}
+
+// IsNotificationTypeSource whether the source is one of the types of the inbox notifications
+func IsNotificationTypeSource(source constant.NotificationSource) bool {
+	for _, s := range constant.NotificationTypeSources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// HasNotificationEmailTemplate the emails of these types are sent with their own templates when the content is posted
+func HasNotificationEmailTemplate(source constant.NotificationSource) bool {
+	return source == constant.AnswerToMyQuestionSource ||
+		source == constant.CommentReplySource ||
+		source == constant.InviteSource
+}
+
+// NotificationChannelTarget where the channel delivers to, such as the url of the webhook
+type NotificationChannelTarget struct {
+	Key    constant.NotificationChannelKey `json:"key"`
+	Target string                          `validate:"omitempty,lte=512" json:"target"`
+}
+
+type NotificationChannelTargets []*NotificationChannelTarget
+
+// NotificationQuietHours the notifications are delivered after the quiet hours, except the inbox
+type NotificationQuietHours struct {
+	Enable bool `json:"enable"`
+	// Start and End are in the format of 15:04, the quiet hours cross midnight if the start is later than the end
+	Start    string `validate:"omitempty,datetime=15:04" json:"start"`
+	End      string `validate:"omitempty,datetime=15:04" json:"end"`
+	TimeZone string `validate:"omitempty,timezone" json:"time_zone"`
+}
+
+// QuietUntil returns the end of the quiet hours if the time is in the quiet hours, otherwise returns zero time.
+// The default time zone is used if the user has not set the time zone.
+func (q *NotificationQuietHours) QuietUntil(now time.Time, defaultTimeZone string) time.Time {
+	if q == nil || !q.Enable || len(q.Start) == 0 || len(q.End) == 0 || q.Start == q.End {
+		return time.Time{}
+	}
+	timeZone := q.TimeZone
+	if len(timeZone) == 0 {
+		timeZone = defaultTimeZone
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	start, err := time.Parse("15:04", q.Start)
+	if err != nil {
+		return time.Time{}
+	}
+	end, err := time.Parse("15:04", q.End)
+	if err != nil {
+		return time.Time{}
+	}
+
+	local := now.In(location)
+	startAt := time.Date(local.Year(), local.Month(), local.Day(), start.Hour(), start.Minute(), 0, 0, location)
+	endAt := time.Date(local.Year(), local.Month(), local.Day(), end.Hour(), end.Minute(), 0, 0, location)
+	if startAt.Before(endAt) {
+		if !local.Before(startAt) && local.Before(endAt) {
+			return endAt
+		}
+		return time.Time{}
+	}
+	// the quiet hours cross midnight
+	if local.Before(endAt) {
+		return endAt
+	}
+	if !local.Before(startAt) {
+		return endAt.AddDate(0, 0, 1)
+	}
+	return time.Time{}
 }
 
 // UpdateUserNotificationConfigReq update user notification config request
@@ -94,7 +225,30 @@ type UpdateUserNotificationConfigReq struct {
 	UserID string `json:"-"`
 }
 
+func (req *UpdateUserNotificationConfigReq) Check() (errFields []*validator.FormErrorField, err error) {
+	for source := range req.Types {
+		if !IsNotificationTypeSource(source) {
+			delete(req.Types, source)
+		}
+	}
+	for _, target := range req.ChannelTargets {
+		if len(target.Target) == 0 {
+			continue
+		}
+		u, err := url.Parse(target.Target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return append(errFields, &validator.FormErrorField{
+				ErrorField: "channel_targets",
+				ErrorMsg:   reason.InvalidURLError,
+			}), errors.BadRequest(reason.InvalidURLError)
+		}
+	}
+	return nil, nil
+}
+
 // GetUserNotificationConfigResp get user notification config response
 type GetUserNotificationConfigResp struct {
 	NotificationConfig
+	// AvailableChannels the channels that users can choose for the types of the inbox notifications
+	AvailableChannels []constant.NotificationChannelKey `json:"available_channels"`
 }
@@ -96,6 +96,8 @@ type UserLoginResp struct {
 	HavePassword bool `json:"have_password"`
 	// visit token
 	VisitToken string `json:"visit_token"`
+	// the active suspension of the user, only returned when the user is suspended
+	Suspension *UserSuspensionInfo `json:"suspension,omitempty"`
 }
 
 func (r *UserLoginResp) ConvertFromUserEntity(userInfo *entity.User) {
@@ -157,6 +159,8 @@ type GetOtherUserInfoByUsernameResp struct {
 	Status     string            `json:"status"`
 	StatusMsg  string            `json:"status_msg,omitempty"`
 	MemberShip entity.Membership `json:"member_ship"`
+	// the active suspension, only returned to the user himself and the admin
+	Suspension *UserSuspensionInfo `json:"suspension,omitempty"`
 }
 
 func (r *GetOtherUserInfoByUsernameResp) ConvertFromUserEntity(userInfo *entity.User) {
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "github.com/apache/incubator-answer/internal/entity"
+
+var userSuspensionStatusMapping = map[int]string{
+	entity.UserSuspensionStatusActive:   "active",
+	entity.UserSuspensionStatusLifted:   "lifted",
+	entity.UserSuspensionStatusReversed: "reversed",
+	entity.UserSuspensionStatusExpired:  "expired",
+}
+
+var userSuspensionAppealStatusMapping = map[int]string{
+	entity.UserSuspensionAppealStatusNone:     "none",
+	entity.UserSuspensionAppealStatusPending:  "pending",
+	entity.UserSuspensionAppealStatusAccepted: "accepted",
+	entity.UserSuspensionAppealStatusRejected: "rejected",
+}
+
+// UserSuspensionInfo the suspension shown to the suspended user and the moderators
+type UserSuspensionInfo struct {
+	ID string `json:"id"`
+	// the reason chosen from the configured reasons, nil if the suspension has no reason
+	Reason *ReasonItem `json:"reason"`
+	// the message from the moderator
+	Message   string `json:"message"`
+	CreatedAt int64  `json:"created_at"`
+	// end time of the suspension, 0 means the suspension is lifted manually only
+	EndAt         int64  `json:"end_at"`
+	Status        string `json:"status" enums:"active,lifted,reversed,expired"`
+	AppealStatus  string `json:"appeal_status" enums:"none,pending,accepted,rejected"`
+	AppealContent string `json:"appeal_content"`
+	AppealedAt    int64  `json:"appealed_at"`
+	// whether the user can appeal the suspension, only one appeal is allowed for each suspension
+	CanAppeal bool `json:"can_appeal"`
+}
+
+// ConvertFromEntity convert the suspension entity, the reason is filled by the caller
+func (r *UserSuspensionInfo) ConvertFromEntity(suspension *entity.UserSuspension) {
+	r.ID = suspension.ID
+	r.Message = suspension.Message
+	r.CreatedAt = suspension.CreatedAt.Unix()
+	if !suspension.EndAt.IsZero() {
+		r.EndAt = suspension.EndAt.Unix()
+	}
+	r.Status = userSuspensionStatusMapping[suspension.Status]
+	r.AppealStatus = userSuspensionAppealStatusMapping[suspension.AppealStatus]
+	r.AppealContent = suspension.AppealContent
+	if !suspension.AppealedAt.IsZero() {
+		r.AppealedAt = suspension.AppealedAt.Unix()
+	}
+	r.CanAppeal = suspension.Status == entity.UserSuspensionStatusActive &&
+		suspension.AppealStatus == entity.UserSuspensionAppealStatusNone
+}
+
+// UserSuspensionHistoryItem the suspension in the history of the user
+type UserSuspensionHistoryItem struct {
+	*UserSuspensionInfo
+	OperatorUserInfo *UserBasicInfo `json:"operator_user_info"`
+	LiftedAt         int64          `json:"lifted_at"`
+	LiftedUserInfo   *UserBasicInfo `json:"lifted_user_info"`
+}
+
+// AddSuspensionAppealReq add suspension appeal request
+type AddSuspensionAppealReq struct {
+	Content string `validate:"required,notblank,gte=6,lte=5000" json:"content"`
+	UserID  string `json:"-"`
+}
+
+// GetUserSuspensionHistoryReq get user suspension history request
+type GetUserSuspensionHistoryReq struct {
+	UserID string `validate:"required" form:"user_id"`
+}
@@ -48,6 +48,8 @@ type VoteOperationInfo struct {
 	VoteUp bool
 	// vote down
 	VoteDown bool
+	// the object is a community wiki post, its owner gets no reputation from the votes
+	Wiki bool
 	// vote activity info
 	Activities []*VoteActivity
 }
@@ -21,9 +21,12 @@ package action
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/token"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/segmentfault/pacman/log"
@@ -37,17 +40,27 @@ type CaptchaRepo interface {
 	SetActionType(ctx context.Context, unit, actionType, config string, amount int) (err error)
 	GetActionType(ctx context.Context, unit, actionType string) (actioninfo *entity.ActionRecordInfo, err error)
 	DelActionType(ctx context.Context, unit, actionType string) (err error)
+	AddCaptchaStat(ctx context.Context, date, actionType string, triggeredCount, failedCount int) (err error)
+	GetCaptchaStatList(ctx context.Context, startDate, endDate string) (list []*entity.CaptchaStatDaily, err error)
 }
 
 // CaptchaService kit service
 type CaptchaService struct {
-	captchaRepo CaptchaRepo
+	captchaRepo           CaptchaRepo
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	userRepo              usercommon.UserRepo
 }
 
 // NewCaptchaService captcha service
-func NewCaptchaService(captchaRepo CaptchaRepo) *CaptchaService {
+func NewCaptchaService(
+	captchaRepo CaptchaRepo,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	userRepo usercommon.UserRepo,
+) *CaptchaService {
 	return &CaptchaService{
-		captchaRepo: captchaRepo,
+		captchaRepo:           captchaRepo,
+		siteInfoCommonService: siteInfoCommonService,
+		userRepo:              userRepo,
 	}
 }
 
@@ -101,11 +114,64 @@ func (cs *CaptchaService) ActionRecordVerifyCaptcha(
 	}
 	pass, err := cs.VerifyCaptcha(ctx, captchaID, captchaCode)
 	if err != nil {
-		return false
+		pass = false
 	}
+	cs.addCaptchaStat(ctx, actionType, pass)
 	return pass
 }
 
+// addCaptchaStat record that the captcha of the action is required and whether it is passed
+func (cs *CaptchaService) addCaptchaStat(ctx context.Context, actionType string, pass bool) {
+	failedCount := 0
+	if !pass {
+		failedCount = 1
+	}
+	date := time.Now().Format(schema.CaptchaStatDateFormat)
+	if err := cs.captchaRepo.AddCaptchaStat(ctx, date, actionType, 1, failedCount); err != nil {
+		log.Errorf("add captcha stat failed: %v", err)
+	}
+}
+
+// GetCaptchaStat get how often the captcha of each action is required and failed in the recent days
+func (cs *CaptchaService) GetCaptchaStat(ctx context.Context, req *schema.GetCaptchaStatReq) (
+	resp *schema.GetCaptchaStatResp, err error) {
+	days := req.Days
+	if days <= 0 {
+		days = schema.CaptchaStatDefaultDays
+	}
+	now := time.Now()
+	resp = &schema.GetCaptchaStatResp{
+		StartDate: now.AddDate(0, 0, 1-days).Format(schema.CaptchaStatDateFormat),
+		EndDate:   now.Format(schema.CaptchaStatDateFormat),
+		Actions:   make([]*schema.CaptchaStatItem, 0, len(schema.DefaultCaptchaActionConfigs)),
+	}
+	list, err := cs.captchaRepo.GetCaptchaStatList(ctx, resp.StartDate, resp.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	actionMapping := make(map[string]*schema.CaptchaStatItem)
+	for _, config := range schema.DefaultCaptchaActionConfigs {
+		item := &schema.CaptchaStatItem{Action: config.Action}
+		actionMapping[config.Action] = item
+		resp.Actions = append(resp.Actions, item)
+	}
+	for _, stat := range list {
+		item, ok := actionMapping[stat.Action]
+		if !ok {
+			continue
+		}
+		item.TriggeredCount += stat.TriggeredCount
+		item.FailedCount += stat.FailedCount
+	}
+	for _, item := range resp.Actions {
+		if item.TriggeredCount > 0 {
+			item.FailureRate = float64(item.FailedCount) / float64(item.TriggeredCount)
+		}
+	}
+	return resp, nil
+}
+
 func (cs *CaptchaService) ActionRecordAdd(ctx context.Context, actionType string, unit string) (int, error) {
 	info, err := cs.captchaRepo.GetActionType(ctx, unit, actionType)
 	if err != nil {
@@ -134,7 +200,7 @@ func (cs *CaptchaService) ActionRecordDel(ctx context.Context, actionType string
 func (cs *CaptchaService) GenerateCaptcha(ctx context.Context) (key, captchaBase64 string, err error) {
 	realCaptcha := ""
 	key = token.GenerateToken()
-	_ = plugin.CallCaptcha(func(fn plugin.Captcha) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallCaptcha, func(_ context.Context, fn plugin.Captcha) error {
 		if captcha, code := fn.Create(); len(code) > 0 {
 			captchaBase64 = captcha
 			realCaptcha = code
@@ -153,7 +219,7 @@ func (cs *CaptchaService) GenerateCaptcha(ctx context.Context) (key, captchaBase
 func (cs *CaptchaService) VerifyCaptcha(ctx context.Context, key, captcha string) (isCorrect bool, err error) {
 	realCaptcha, _ := cs.captchaRepo.GetCaptcha(ctx, key)
 
-	_ = plugin.CallCaptcha(func(fn plugin.Captcha) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallCaptcha, func(_ context.Context, fn plugin.Captcha) error {
 		isCorrect = fn.Verify(realCaptcha, captcha)
 		return nil
 	})
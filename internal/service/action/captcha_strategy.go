@@ -23,6 +23,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/segmentfault/pacman/log"
 
@@ -37,188 +38,70 @@ func (cs *CaptchaService) ValidationStrategy(ctx context.Context, unit, actionTy
 	if !plugin.CaptchaEnabled() {
 		return true
 	}
-	info, err := cs.captchaRepo.GetActionType(ctx, unit, actionType)
-	if err != nil {
-		log.Error(err)
-		return false
-	}
-	switch actionType {
-	case entity.CaptchaActionEmail:
-		return cs.CaptchaActionEmail(ctx, unit, info)
-	case entity.CaptchaActionPassword:
-		return cs.CaptchaActionPassword(ctx, unit, info)
-	case entity.CaptchaActionEditUserinfo:
-		return cs.CaptchaActionEditUserinfo(ctx, unit, info)
-	case entity.CaptchaActionQuestion:
-		return cs.CaptchaActionQuestion(ctx, unit, info)
-	case entity.CaptchaActionAnswer:
-		return cs.CaptchaActionAnswer(ctx, unit, info)
-	case entity.CaptchaActionComment:
-		return cs.CaptchaActionComment(ctx, unit, info)
-	case entity.CaptchaActionEdit:
-		return cs.CaptchaActionEdit(ctx, unit, info)
-	case entity.CaptchaActionInvitationAnswer:
-		return cs.CaptchaActionInvitationAnswer(ctx, unit, info)
-	case entity.CaptchaActionSearch:
-		return cs.CaptchaActionSearch(ctx, unit, info)
-	case entity.CaptchaActionReport:
-		return cs.CaptchaActionReport(ctx, unit, info)
-	case entity.CaptchaActionDelete:
-		return cs.CaptchaActionDelete(ctx, unit, info)
-	case entity.CaptchaActionVote:
-		return cs.CaptchaActionVote(ctx, unit, info)
-
-	}
+	config := cs.getActionConfig(ctx, actionType)
 	//actionType not found
-	return false
-}
-
-func (cs *CaptchaService) CaptchaActionEmail(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	// You need a verification code every time
-	return false
-}
-
-func (cs *CaptchaService) CaptchaActionPassword(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
-	}
-	setNum := 3
-	setTime := int64(60 * 30) //seconds
-	now := time.Now().Unix()
-	if now-actionInfo.LastTime <= setTime && actionInfo.Num >= setNum {
-		return false
-	}
-	if now-actionInfo.LastTime != 0 && now-actionInfo.LastTime > setTime {
-		cs.captchaRepo.SetActionType(ctx, unit, entity.CaptchaActionPassword, "", 0)
-	}
-	return true
-}
-
-func (cs *CaptchaService) CaptchaActionEditUserinfo(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
-	}
-	setNum := 3
-	setTime := int64(60 * 30) //seconds
-	now := time.Now().Unix()
-	if now-actionInfo.LastTime <= setTime && actionInfo.Num >= setNum {
-		return false
-	}
-	if now-actionInfo.LastTime != 0 && now-actionInfo.LastTime > setTime {
-		cs.captchaRepo.SetActionType(ctx, unit, entity.CaptchaActionEditUserinfo, "", 0)
-	}
-	return true
-}
-
-func (cs *CaptchaService) CaptchaActionQuestion(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
-	}
-	setNum := 10
-	setTime := int64(5) //seconds
-	now := time.Now().Unix()
-	if now-actionInfo.LastTime <= setTime || actionInfo.Num >= setNum {
+	if config == nil {
 		return false
 	}
-	return true
-}
-
-func (cs *CaptchaService) CaptchaActionAnswer(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
+	if config.Mode == schema.CaptchaModeNever || cs.isExempted(ctx, unit, config) {
 		return true
 	}
-	setNum := 10
-	setTime := int64(5) //seconds
-	now := time.Now().Unix()
-	if now-actionInfo.LastTime <= setTime || actionInfo.Num >= setNum {
+	if config.Mode == schema.CaptchaModeAlways {
 		return false
 	}
-	return true
-}
-
-func (cs *CaptchaService) CaptchaActionComment(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
-	}
-	setNum := 30
-	setTime := int64(1) //seconds
-	now := time.Now().Unix()
-	if now-actionInfo.LastTime <= setTime || actionInfo.Num >= setNum {
+	info, err := cs.captchaRepo.GetActionType(ctx, unit, actionType)
+	if err != nil {
+		log.Error(err)
 		return false
 	}
-	return true
+	return cs.checkThreshold(ctx, unit, actionType, info, config)
 }
 
-func (cs *CaptchaService) CaptchaActionEdit(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
-	}
-	setNum := 10
-	if actionInfo.Num >= setNum {
-		return false
+// getActionConfig get the thresholds of the action configured by the admin
+func (cs *CaptchaService) getActionConfig(ctx context.Context, actionType string) *schema.CaptchaActionConfig {
+	siteCaptcha, err := cs.siteInfoCommonService.GetSiteCaptcha(ctx)
+	if err != nil {
+		log.Error(err)
+		siteCaptcha = &schema.SiteCaptchaResp{}
+		siteCaptcha.FillDefault()
 	}
-	return true
+	return siteCaptcha.GetActionConfig(actionType)
 }
 
-func (cs *CaptchaService) CaptchaActionInvitationAnswer(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
-	}
-	setNum := 30
-	if actionInfo.Num >= setNum {
+// isExempted whether the reputation of the user reaches the exempt rank of the action,
+// the unit is the user id for the actions of the login user, otherwise it is the ip
+func (cs *CaptchaService) isExempted(ctx context.Context, unit string, config *schema.CaptchaActionConfig) bool {
+	if config.ExemptRank <= 0 || len(unit) == 0 {
 		return false
 	}
-	return true
-}
-
-func (cs *CaptchaService) CaptchaActionSearch(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
-	}
-	now := time.Now().Unix()
-	setNum := 20
-	setTime := int64(60) //seconds
-	if now-int64(actionInfo.LastTime) <= setTime && actionInfo.Num >= setNum {
+	userInfo, exist, err := cs.userRepo.GetByUserID(ctx, unit)
+	if err != nil {
+		log.Error(err)
 		return false
 	}
-	if now-actionInfo.LastTime > setTime {
-		cs.captchaRepo.SetActionType(ctx, unit, entity.CaptchaActionSearch, "", 0)
-	}
-	return true
+	return exist && userInfo.Status == entity.UserStatusAvailable && userInfo.Rank >= config.ExemptRank
 }
 
-func (cs *CaptchaService) CaptchaActionReport(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
+// checkThreshold
+// true pass
+// false the action exceeds the thresholds and needs captcha
+func (cs *CaptchaService) checkThreshold(ctx context.Context, unit, actionType string,
+	actionInfo *entity.ActionRecordInfo, config *schema.CaptchaActionConfig) bool {
 	if actionInfo == nil {
 		return true
 	}
-	setNum := 30
-	setTime := int64(1) //seconds
-	now := time.Now().Unix()
-	if now-actionInfo.LastTime <= setTime || actionInfo.Num >= setNum {
-		return false
-	}
-	return true
-}
-
-func (cs *CaptchaService) CaptchaActionDelete(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
+	elapsed := time.Now().Unix() - actionInfo.LastTime
+	// the action is not performed in the window, so count the attempts from zero
+	if config.Window > 0 && elapsed > config.Window {
+		if err := cs.captchaRepo.SetActionType(ctx, unit, actionType, "", 0); err != nil {
+			log.Error(err)
+		}
 		return true
 	}
-	setNum := 5
-	setTime := int64(5) //seconds
-	now := time.Now().Unix()
-	if now-actionInfo.LastTime <= setTime || actionInfo.Num >= setNum {
+	if config.MinInterval > 0 && elapsed <= config.MinInterval {
 		return false
 	}
-	return true
-}
-
-func (cs *CaptchaService) CaptchaActionVote(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
-	}
-	setNum := 40
-	if actionInfo.Num >= setNum {
+	if config.MaxAttempts > 0 && actionInfo.Num >= config.MaxAttempts {
 		return false
 	}
 	return true
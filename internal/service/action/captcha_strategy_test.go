@@ -0,0 +1,122 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package action
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCaptchaRepo struct {
+	CaptchaRepo
+	reset int
+}
+
+func (r *fakeCaptchaRepo) SetActionType(ctx context.Context, unit, actionType, config string, amount int) error {
+	r.reset++
+	return nil
+}
+
+type fakeSiteInfoService struct {
+	siteinfo_common.SiteInfoCommonService
+	siteCaptcha *schema.SiteCaptchaResp
+}
+
+func (s *fakeSiteInfoService) GetSiteCaptcha(ctx context.Context) (*schema.SiteCaptchaResp, error) {
+	resp := &schema.SiteCaptchaResp{Actions: s.siteCaptcha.Actions}
+	resp.FillDefault()
+	return resp, nil
+}
+
+type fakeUserRepo struct {
+	usercommon.UserRepo
+	users map[string]*entity.User
+}
+
+func (r *fakeUserRepo) GetByUserID(ctx context.Context, userID string) (*entity.User, bool, error) {
+	user, ok := r.users[userID]
+	return user, ok, nil
+}
+
+func newTestCaptchaService(actions ...*schema.CaptchaActionConfig) (*CaptchaService, *fakeCaptchaRepo) {
+	repo := &fakeCaptchaRepo{}
+	siteInfoService := &fakeSiteInfoService{siteCaptcha: &schema.SiteCaptchaResp{Actions: actions}}
+	userRepo := &fakeUserRepo{users: map[string]*entity.User{
+		"1": {ID: "1", Rank: 1, Status: entity.UserStatusAvailable},
+		"2": {ID: "2", Rank: 1000, Status: entity.UserStatusAvailable},
+	}}
+	return NewCaptchaService(repo, siteInfoService, userRepo), repo
+}
+
+func TestCaptchaService_getActionConfig(t *testing.T) {
+	cs, _ := newTestCaptchaService(&schema.CaptchaActionConfig{
+		Action: entity.CaptchaActionVote, Mode: schema.CaptchaModeNever})
+	ctx := context.Background()
+
+	assert.Equal(t, schema.CaptchaModeNever, cs.getActionConfig(ctx, entity.CaptchaActionVote).Mode)
+	// the default thresholds are used if the action is not configured
+	assert.Equal(t, schema.CaptchaModeAlways, cs.getActionConfig(ctx, entity.CaptchaActionEmail).Mode)
+	assert.Equal(t, 3, cs.getActionConfig(ctx, entity.CaptchaActionPassword).MaxAttempts)
+	assert.Nil(t, cs.getActionConfig(ctx, "unknown"))
+}
+
+func TestCaptchaService_isExempted(t *testing.T) {
+	cs, _ := newTestCaptchaService()
+	ctx := context.Background()
+	config := &schema.CaptchaActionConfig{Action: entity.CaptchaActionAnswer, ExemptRank: 100}
+
+	assert.False(t, cs.isExempted(ctx, "1", config))
+	assert.True(t, cs.isExempted(ctx, "2", config))
+	assert.False(t, cs.isExempted(ctx, "127.0.0.1", config))
+	config.ExemptRank = 0
+	assert.False(t, cs.isExempted(ctx, "2", config))
+}
+
+func TestCaptchaService_checkThreshold(t *testing.T) {
+	cs, repo := newTestCaptchaService()
+	ctx := context.Background()
+	now := time.Now().Unix()
+
+	// the attempts in the window
+	config := &schema.CaptchaActionConfig{MaxAttempts: 3, Window: 60}
+	assert.True(t, cs.checkThreshold(ctx, "1", "", nil, config))
+	assert.True(t, cs.checkThreshold(ctx, "1", "", &entity.ActionRecordInfo{LastTime: now, Num: 2}, config))
+	assert.False(t, cs.checkThreshold(ctx, "1", "", &entity.ActionRecordInfo{LastTime: now, Num: 3}, config))
+	// the attempts are reset after the window
+	assert.True(t, cs.checkThreshold(ctx, "1", "", &entity.ActionRecordInfo{LastTime: now - 61, Num: 3}, config))
+	assert.Equal(t, 1, repo.reset)
+
+	// the interval between two actions
+	config = &schema.CaptchaActionConfig{MaxAttempts: 10, MinInterval: 5}
+	assert.False(t, cs.checkThreshold(ctx, "1", "", &entity.ActionRecordInfo{LastTime: now - 3, Num: 1}, config))
+	assert.True(t, cs.checkThreshold(ctx, "1", "", &entity.ActionRecordInfo{LastTime: now - 6, Num: 1}, config))
+	assert.False(t, cs.checkThreshold(ctx, "1", "", &entity.ActionRecordInfo{LastTime: now - 6, Num: 10}, config))
+
+	// no limit
+	config = &schema.CaptchaActionConfig{}
+	assert.True(t, cs.checkThreshold(ctx, "1", "", &entity.ActionRecordInfo{LastTime: now, Num: 1000}, config))
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
+	"github.com/apache/incubator-answer/internal/service/space"
 	"github.com/apache/incubator-answer/internal/service/tag_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/converter"
@@ -60,6 +61,7 @@ type ActivityService struct {
 	revisionService       *revision_common.RevisionService
 	metaService           *metacommon.MetaCommonService
 	configService         *config.ConfigService
+	spaceService          *space.SpaceService
 }
 
 // NewActivityService new activity service
@@ -73,6 +75,7 @@ func NewActivityService(
 	revisionService *revision_common.RevisionService,
 	metaService *metacommon.MetaCommonService,
 	configService *config.ConfigService,
+	spaceService *space.SpaceService,
 ) *ActivityService {
 	return &ActivityService{
 		objectInfoService:     objectInfoService,
@@ -84,6 +87,7 @@ func NewActivityService(
 		revisionService:       revisionService,
 		metaService:           metaService,
 		configService:         configService,
+		spaceService:          spaceService,
 	}
 }
 
@@ -165,6 +169,9 @@ func (as *ActivityService) getTimelineMainObjInfo(ctx context.Context, objectID
 	if err != nil {
 		return nil, err
 	}
+	if err = as.checkObjectAccess(ctx, objInfo); err != nil {
+		return nil, err
+	}
 	resp.Title = objInfo.Title
 	if objInfo.ObjectType == constant.TagObjectType {
 		tag, exist, _ := as.tagCommonService.GetTagByID(ctx, objInfo.TagID)
@@ -255,11 +262,25 @@ func (as *ActivityService) formatTimelineUserInfo(ctx context.Context, timeline
 func (as *ActivityService) GetObjectTimelineDetail(ctx context.Context, req *schema.GetObjectTimelineDetailReq) (
 	resp *schema.GetObjectTimelineDetailResp, err error) {
 	resp = &schema.GetObjectTimelineDetailResp{}
-	resp.OldRevision, _ = as.getOneObjectDetail(ctx, req.OldRevisionID)
-	resp.NewRevision, _ = as.getOneObjectDetail(ctx, req.NewRevisionID)
+	resp.OldRevision, err = as.getOneObjectDetail(ctx, req.OldRevisionID)
+	if err != nil {
+		return nil, err
+	}
+	resp.NewRevision, err = as.getOneObjectDetail(ctx, req.NewRevisionID)
+	if err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
 
+// checkObjectAccess check the user can access the question which the object belongs to, same as the question detail
+func (as *ActivityService) checkObjectAccess(ctx context.Context, objInfo *schema.SimpleObjectInfo) (err error) {
+	if objInfo.ObjectType == constant.TagObjectType {
+		return nil
+	}
+	return as.spaceService.CheckQuestionAccess(ctx, objInfo.QuestionID)
+}
+
 // getOneObjectDetail get object detail
 func (as *ActivityService) getOneObjectDetail(ctx context.Context, revisionID string) (
 	resp *schema.ObjectTimelineDetail, err error) {
@@ -279,6 +300,9 @@ func (as *ActivityService) getOneObjectDetail(ctx context.Context, revisionID st
 	if err != nil {
 		return nil, err
 	}
+	if err = as.checkObjectAccess(ctx, objInfo); err != nil {
+		return nil, err
+	}
 
 	switch objInfo.ObjectType {
 	case constant.QuestionObjectType:
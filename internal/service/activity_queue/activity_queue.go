@@ -22,6 +22,7 @@ package activity_queue
 import (
 	"context"
 
+	"github.com/apache/incubator-answer/internal/base/metrics"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/segmentfault/pacman/log"
 )
@@ -54,6 +55,7 @@ func (ns *activityQueueService) working() {
 				continue
 			}
 			if err := ns.Handler(context.Background(), msg); err != nil {
+				metrics.ObserveQueueHandlerError("activity")
 				log.Error(err)
 			}
 		}
@@ -64,6 +66,7 @@ func (ns *activityQueueService) working() {
 func NewActivityQueueService() ActivityQueueService {
 	ns := &activityQueueService{}
 	ns.Queue = make(chan *schema.ActivityMsg, 128)
+	metrics.RegisterQueue("activity", cap(ns.Queue), func() int { return len(ns.Queue) })
 	ns.working()
 	return ns
 }
@@ -32,6 +32,7 @@ const (
 	AnswerAccept      = "answer.accept"
 	CommentVoteUp     = "comment.vote_up"
 	EditAccepted      = "edit.accepted"
+	ReputationAdjust  = "user.reputation_adjust"
 )
 
 var (
@@ -72,5 +73,6 @@ var (
 		AnswerAccept:      "action_activity_type.accept",
 		CommentVoteUp:     "action_activity_type.upvote",
 		EditAccepted:      "action_activity_type.edit",
+		ReputationAdjust:  "action_activity_type.adjust",
 	}
 )
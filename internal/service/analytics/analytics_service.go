@@ -0,0 +1,517 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/pkg/obj"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// recomputeDays the latest days are computed again in every rollup,
+	// because the accepted answers and the first answers of the questions change over time
+	recomputeDays = 7
+	// backfillBatchDays max days of the history to be backfilled in one rollup
+	backfillBatchDays = 90
+)
+
+// voteActivityKeys activity keys of the votes
+var voteActivityKeys = []string{
+	activity_type.QuestionVoteUp,
+	activity_type.QuestionVoteDown,
+	activity_type.AnswerVoteUp,
+	activity_type.AnswerVoteDown,
+}
+
+// AnalyticsRepo analytics repository
+type AnalyticsRepo interface {
+	GetQuestionsByCreatedTime(ctx context.Context, start, end time.Time) (questions []*entity.Question, err error)
+	GetAnswersByCreatedTime(ctx context.Context, start, end time.Time) (answers []*entity.Answer, err error)
+	GetCommentsByCreatedTime(ctx context.Context, start, end time.Time) (comments []*entity.Comment, err error)
+	GetVotesByCreatedTime(ctx context.Context, start, end time.Time, activityTypes []int) (
+		votes []*entity.Activity, err error)
+	GetUserCountByCreatedTime(ctx context.Context, start, end time.Time) (count int64, err error)
+	GetFirstAnswerTimes(ctx context.Context, questionIDs []string) (mapping map[string]time.Time, err error)
+	GetAnswerQuestionIDs(ctx context.Context, answerIDs []string) (mapping map[string]string, err error)
+	GetQuestionTagIDs(ctx context.Context, questionIDs []string) (mapping map[string][]string, err error)
+	GetEarliestUserCreatedTime(ctx context.Context) (createdAt time.Time, exist bool, err error)
+	SaveAnalyticsDaily(ctx context.Context, date string, list []*entity.AnalyticsDaily) (err error)
+	GetAnalyticsDailyList(ctx context.Context, tagID, startDate, endDate string) (
+		list []*entity.AnalyticsDaily, err error)
+	GetAnalyticsDates(ctx context.Context, startDate, endDate string) (dates []string, err error)
+}
+
+// AnalyticsService community analytics service
+type AnalyticsService struct {
+	analyticsRepo AnalyticsRepo
+	configService *config.ConfigService
+}
+
+// NewAnalyticsService new analytics service
+func NewAnalyticsService(
+	analyticsRepo AnalyticsRepo,
+	configService *config.ConfigService,
+) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		configService: configService,
+	}
+}
+
+// GetAnalytics get the time series of the community statistics
+func (as *AnalyticsService) GetAnalytics(ctx context.Context, req *schema.GetAnalyticsReq) (
+	resp *schema.GetAnalyticsResp, err error) {
+	startDate, endDate, err := parseAnalyticsRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Granularity) == 0 {
+		req.Granularity = schema.AnalyticsGranularityDay
+	}
+	tagID := "0"
+	if len(req.TagID) > 0 {
+		tagID = uid.DeShortID(req.TagID)
+	}
+
+	list, err := as.analyticsRepo.GetAnalyticsDailyList(ctx, tagID,
+		startDate.Format(schema.AnalyticsDateFormat), endDate.Format(schema.AnalyticsDateFormat))
+	if err != nil {
+		return nil, err
+	}
+	return &schema.GetAnalyticsResp{
+		StartDate:   startDate.Format(schema.AnalyticsDateFormat),
+		EndDate:     endDate.Format(schema.AnalyticsDateFormat),
+		Granularity: req.Granularity,
+		TagID:       req.TagID,
+		Series:      buildSeries(list, startDate, endDate, req.Granularity),
+	}, nil
+}
+
+// ExportAnalyticsCSV export the time series of the community statistics as csv
+func (as *AnalyticsService) ExportAnalyticsCSV(ctx context.Context, req *schema.GetAnalyticsReq) (
+	content []byte, err error) {
+	resp, err := as.GetAnalytics(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	_ = w.Write([]string{
+		"date", "question_count", "answer_count", "comment_count", "user_count", "active_user_count",
+		"vote_count", "accept_rate", "unanswered_ratio", "median_first_answer_seconds",
+	})
+	for _, item := range resp.Series {
+		_ = w.Write([]string{
+			item.Date,
+			fmt.Sprintf("%d", item.QuestionCount),
+			fmt.Sprintf("%d", item.AnswerCount),
+			fmt.Sprintf("%d", item.CommentCount),
+			fmt.Sprintf("%d", item.UserCount),
+			fmt.Sprintf("%d", item.ActiveUserCount),
+			fmt.Sprintf("%d", item.VoteCount),
+			fmt.Sprintf("%.4f", item.AcceptRate),
+			fmt.Sprintf("%.4f", item.UnansweredRatio),
+			fmt.Sprintf("%d", item.MedianFirstAnswerSeconds),
+		})
+	}
+	w.Flush()
+	if err = w.Error(); err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return buf.Bytes(), nil
+}
+
+// RollupCron compute the daily statistics of the latest days and backfill the missing history
+func (as *AnalyticsService) RollupCron(ctx context.Context) {
+	today := truncateDay(time.Now())
+	dates := make([]time.Time, 0)
+	for i := recomputeDays - 1; i >= 0; i-- {
+		dates = append(dates, today.AddDate(0, 0, -i))
+	}
+
+	earliest, exist, err := as.analyticsRepo.GetEarliestUserCreatedTime(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if exist {
+		backfillStart := truncateDay(earliest)
+		backfillEnd := today.AddDate(0, 0, -recomputeDays)
+		existDates, err := as.analyticsRepo.GetAnalyticsDates(ctx,
+			backfillStart.Format(schema.AnalyticsDateFormat), backfillEnd.Format(schema.AnalyticsDateFormat))
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		existMapping := make(map[string]bool, len(existDates))
+		for _, date := range existDates {
+			existMapping[date] = true
+		}
+		missing := make([]time.Time, 0)
+		for day := backfillEnd; !day.Before(backfillStart) && len(missing) < backfillBatchDays; day = day.AddDate(0, 0, -1) {
+			if !existMapping[day.Format(schema.AnalyticsDateFormat)] {
+				missing = append(missing, day)
+			}
+		}
+		dates = append(missing, dates...)
+	}
+
+	for _, day := range dates {
+		if err := as.rollupDay(ctx, day); err != nil {
+			log.Errorf("rollup analytics of %s failed: %v", day.Format(schema.AnalyticsDateFormat), err)
+		}
+	}
+}
+
+// dayStat the statistics of the whole site or a tag in one day
+type dayStat struct {
+	questions          int
+	answers            int
+	comments           int
+	votes              int
+	accepted           int
+	unanswered         int
+	activeUsers        map[string]bool
+	firstAnswerSeconds []int64
+}
+
+func newDayStat() *dayStat {
+	return &dayStat{activeUsers: make(map[string]bool)}
+}
+
+func (as *AnalyticsService) rollupDay(ctx context.Context, day time.Time) (err error) {
+	start, end := day, day.AddDate(0, 0, 1)
+	questions, err := as.analyticsRepo.GetQuestionsByCreatedTime(ctx, start, end)
+	if err != nil {
+		return err
+	}
+	answers, err := as.analyticsRepo.GetAnswersByCreatedTime(ctx, start, end)
+	if err != nil {
+		return err
+	}
+	comments, err := as.analyticsRepo.GetCommentsByCreatedTime(ctx, start, end)
+	if err != nil {
+		return err
+	}
+	votes, err := as.getVotes(ctx, start, end)
+	if err != nil {
+		return err
+	}
+	userCount, err := as.analyticsRepo.GetUserCountByCreatedTime(ctx, start, end)
+	if err != nil {
+		return err
+	}
+
+	questionIDs := make([]string, 0, len(questions))
+	for _, question := range questions {
+		questionIDs = append(questionIDs, question.ID)
+	}
+	firstAnswerTimes, err := as.analyticsRepo.GetFirstAnswerTimes(ctx, questionIDs)
+	if err != nil {
+		return err
+	}
+
+	// the question of each vote, the votes of answers are counted to the question of the answer
+	voteAnswerIDs := make([]string, 0)
+	for _, vote := range votes {
+		if objectType, _ := obj.GetObjectTypeStrByObjectID(vote.ObjectID); objectType == constant.AnswerObjectType {
+			voteAnswerIDs = append(voteAnswerIDs, vote.ObjectID)
+		}
+	}
+	answerQuestionIDs, err := as.analyticsRepo.GetAnswerQuestionIDs(ctx, voteAnswerIDs)
+	if err != nil {
+		return err
+	}
+	voteQuestionIDs := make([]string, len(votes))
+	for i, vote := range votes {
+		voteQuestionIDs[i] = vote.ObjectID
+		if questionID, ok := answerQuestionIDs[vote.ObjectID]; ok {
+			voteQuestionIDs[i] = questionID
+		}
+	}
+
+	relatedQuestionIDs := append([]string{}, questionIDs...)
+	for _, answer := range answers {
+		relatedQuestionIDs = append(relatedQuestionIDs, answer.QuestionID)
+	}
+	for _, comment := range comments {
+		relatedQuestionIDs = append(relatedQuestionIDs, comment.QuestionID)
+	}
+	relatedQuestionIDs = append(relatedQuestionIDs, voteQuestionIDs...)
+	questionTagIDs, err := as.analyticsRepo.GetQuestionTagIDs(ctx, relatedQuestionIDs)
+	if err != nil {
+		return err
+	}
+
+	overall := newDayStat()
+	tagStats := make(map[string]*dayStat)
+	statsOf := func(questionID string) []*dayStat {
+		stats := []*dayStat{overall}
+		for _, tagID := range questionTagIDs[questionID] {
+			if tagStats[tagID] == nil {
+				tagStats[tagID] = newDayStat()
+			}
+			stats = append(stats, tagStats[tagID])
+		}
+		return stats
+	}
+
+	for _, question := range questions {
+		firstAnswerAt, answered := firstAnswerTimes[question.ID]
+		for _, stat := range statsOf(question.ID) {
+			stat.questions++
+			stat.activeUsers[question.UserID] = true
+			if question.AcceptedAnswerID != "0" && len(question.AcceptedAnswerID) > 0 {
+				stat.accepted++
+			}
+			if question.AnswerCount == 0 {
+				stat.unanswered++
+			}
+			if answered {
+				stat.firstAnswerSeconds = append(stat.firstAnswerSeconds,
+					int64(firstAnswerAt.Sub(question.CreatedAt).Seconds()))
+			}
+		}
+	}
+	for _, answer := range answers {
+		for _, stat := range statsOf(answer.QuestionID) {
+			stat.answers++
+			stat.activeUsers[answer.UserID] = true
+		}
+	}
+	for _, comment := range comments {
+		for _, stat := range statsOf(comment.QuestionID) {
+			stat.comments++
+			stat.activeUsers[comment.UserID] = true
+		}
+	}
+	for i, vote := range votes {
+		for _, stat := range statsOf(voteQuestionIDs[i]) {
+			stat.votes++
+			stat.activeUsers[fmt.Sprintf("%d", vote.TriggerUserID)] = true
+		}
+	}
+
+	date := day.Format(schema.AnalyticsDateFormat)
+	overallDaily := overall.toEntity(date, "0")
+	overallDaily.UserCount = int(userCount)
+	list := []*entity.AnalyticsDaily{overallDaily}
+	for tagID, stat := range tagStats {
+		list = append(list, stat.toEntity(date, tagID))
+	}
+	return as.analyticsRepo.SaveAnalyticsDaily(ctx, date, list)
+}
+
+// getVotes get the votes in the time range, the activities of the same vote are counted once
+func (as *AnalyticsService) getVotes(ctx context.Context, start, end time.Time) (votes []*entity.Activity, err error) {
+	activityTypes := make([]int, 0, len(voteActivityKeys))
+	for _, key := range voteActivityKeys {
+		cfg, err := as.configService.GetConfigByKey(ctx, key)
+		if err != nil {
+			continue
+		}
+		activityTypes = append(activityTypes, cfg.ID)
+	}
+	activities, err := as.analyticsRepo.GetVotesByCreatedTime(ctx, start, end, activityTypes)
+	if err != nil {
+		return nil, err
+	}
+	votes = make([]*entity.Activity, 0, len(activities))
+	exist := make(map[string]bool, len(activities))
+	for _, act := range activities {
+		key := fmt.Sprintf("%s-%d-%d", act.ObjectID, act.TriggerUserID, act.ActivityType)
+		if exist[key] {
+			continue
+		}
+		exist[key] = true
+		votes = append(votes, act)
+	}
+	return votes, nil
+}
+
+func (s *dayStat) toEntity(date, tagID string) *entity.AnalyticsDaily {
+	delete(s.activeUsers, "")
+	delete(s.activeUsers, "0")
+	return &entity.AnalyticsDaily{
+		StatDate:                 date,
+		TagID:                    tagID,
+		QuestionCount:            s.questions,
+		AnswerCount:              s.answers,
+		CommentCount:             s.comments,
+		ActiveUserCount:          len(s.activeUsers),
+		VoteCount:                s.votes,
+		AcceptedCount:            s.accepted,
+		UnansweredCount:          s.unanswered,
+		FirstAnsweredCount:       len(s.firstAnswerSeconds),
+		MedianFirstAnswerSeconds: int(median(s.firstAnswerSeconds)),
+	}
+}
+
+// periodStat the statistics of a period summed from the days
+type periodStat struct {
+	item        *schema.AnalyticsSeriesItem
+	days        int
+	activeUsers int
+	accepted    int
+	unanswered  int
+	// the daily medians weighted by the answered questions of the day
+	firstAnswerMedians []weightedValue
+}
+
+type weightedValue struct {
+	value  int64
+	weight int
+}
+
+// buildSeries sum the daily statistics up to the periods of the granularity, the periods without data are zero
+func buildSeries(list []*entity.AnalyticsDaily, startDate, endDate time.Time,
+	granularity string) (series []*schema.AnalyticsSeriesItem) {
+	mapping := make(map[string]*entity.AnalyticsDaily, len(list))
+	for _, daily := range list {
+		mapping[daily.StatDate] = daily
+	}
+
+	periods := make([]*periodStat, 0)
+	periodMapping := make(map[string]*periodStat)
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		periodDate := periodStart(day, granularity).Format(schema.AnalyticsDateFormat)
+		period, ok := periodMapping[periodDate]
+		if !ok {
+			period = &periodStat{item: &schema.AnalyticsSeriesItem{Date: periodDate}}
+			periodMapping[periodDate] = period
+			periods = append(periods, period)
+		}
+		period.days++
+		daily, ok := mapping[day.Format(schema.AnalyticsDateFormat)]
+		if !ok {
+			continue
+		}
+		period.item.QuestionCount += daily.QuestionCount
+		period.item.AnswerCount += daily.AnswerCount
+		period.item.CommentCount += daily.CommentCount
+		period.item.UserCount += daily.UserCount
+		period.item.VoteCount += daily.VoteCount
+		period.activeUsers += daily.ActiveUserCount
+		period.accepted += daily.AcceptedCount
+		period.unanswered += daily.UnansweredCount
+		if daily.FirstAnsweredCount > 0 {
+			period.firstAnswerMedians = append(period.firstAnswerMedians, weightedValue{
+				value: int64(daily.MedianFirstAnswerSeconds), weight: daily.FirstAnsweredCount})
+		}
+	}
+
+	series = make([]*schema.AnalyticsSeriesItem, 0, len(periods))
+	for _, period := range periods {
+		period.item.ActiveUserCount = period.activeUsers / period.days
+		if period.item.QuestionCount > 0 {
+			period.item.AcceptRate = float64(period.accepted) / float64(period.item.QuestionCount)
+			period.item.UnansweredRatio = float64(period.unanswered) / float64(period.item.QuestionCount)
+		}
+		period.item.MedianFirstAnswerSeconds = int(weightedMedian(period.firstAnswerMedians))
+		series = append(series, period.item)
+	}
+	return series
+}
+
+// periodStart the first day of the period which the day belongs to, the week starts from monday
+func periodStart(day time.Time, granularity string) time.Time {
+	switch granularity {
+	case schema.AnalyticsGranularityWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case schema.AnalyticsGranularityMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+	}
+	return day
+}
+
+func parseAnalyticsRange(start, end string) (startDate, endDate time.Time, err error) {
+	endDate = truncateDay(time.Now())
+	if len(end) > 0 {
+		endDate, err = time.ParseInLocation(schema.AnalyticsDateFormat, end, time.UTC)
+		if err != nil {
+			return startDate, endDate, errors.BadRequest(reason.AnalyticsRangeInvalid)
+		}
+	}
+	startDate = endDate.AddDate(0, 0, 1-schema.AnalyticsDefaultDays)
+	if len(start) > 0 {
+		startDate, err = time.ParseInLocation(schema.AnalyticsDateFormat, start, time.UTC)
+		if err != nil {
+			return startDate, endDate, errors.BadRequest(reason.AnalyticsRangeInvalid)
+		}
+	}
+	if endDate.Before(startDate) || endDate.Sub(startDate) > schema.AnalyticsMaxDays*24*time.Hour {
+		return startDate, endDate, errors.BadRequest(reason.AnalyticsRangeInvalid)
+	}
+	return startDate, endDate, nil
+}
+
+// truncateDay the analytics days are in UTC
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func median(values []int64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int64{}, values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func weightedMedian(values []weightedValue) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]weightedValue{}, values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].value < sorted[j].value })
+	total := 0
+	for _, v := range sorted {
+		total += v.weight
+	}
+	acc := 0
+	for _, v := range sorted {
+		acc += v.weight
+		if acc*2 >= total {
+			return v.value
+		}
+	}
+	return sorted[len(sorted)-1].value
+}
@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedian(t *testing.T) {
+	assert.Equal(t, int64(0), median(nil))
+	assert.Equal(t, int64(3), median([]int64{5, 1, 3}))
+	assert.Equal(t, int64(2), median([]int64{4, 1, 3, 1}))
+	assert.Equal(t, int64(10), weightedMedian([]weightedValue{{value: 100, weight: 1}, {value: 10, weight: 3}}))
+}
+
+func TestPeriodStart(t *testing.T) {
+	day := time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC) // thursday
+	assert.Equal(t, "2024-05-16", periodStart(day, schema.AnalyticsGranularityDay).Format(schema.AnalyticsDateFormat))
+	assert.Equal(t, "2024-05-13", periodStart(day, schema.AnalyticsGranularityWeek).Format(schema.AnalyticsDateFormat))
+	assert.Equal(t, "2024-05-01", periodStart(day, schema.AnalyticsGranularityMonth).Format(schema.AnalyticsDateFormat))
+	sunday := time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2024-05-13", periodStart(sunday, schema.AnalyticsGranularityWeek).Format(schema.AnalyticsDateFormat))
+}
+
+func TestBuildSeries(t *testing.T) {
+	list := []*entity.AnalyticsDaily{
+		{StatDate: "2024-05-13", QuestionCount: 2, AcceptedCount: 1, UnansweredCount: 1, ActiveUserCount: 4,
+			FirstAnsweredCount: 1, MedianFirstAnswerSeconds: 60},
+		{StatDate: "2024-05-15", QuestionCount: 2, AnswerCount: 3, ActiveUserCount: 10,
+			FirstAnsweredCount: 2, MedianFirstAnswerSeconds: 120},
+	}
+	start := time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)
+
+	series := buildSeries(list, start, end, schema.AnalyticsGranularityDay)
+	assert.Len(t, series, 8)
+	assert.Equal(t, 0, series[1].QuestionCount)
+
+	series = buildSeries(list, start, end, schema.AnalyticsGranularityWeek)
+	if assert.Len(t, series, 2) {
+		assert.Equal(t, "2024-05-13", series[0].Date)
+		assert.Equal(t, 4, series[0].QuestionCount)
+		assert.Equal(t, 3, series[0].AnswerCount)
+		assert.Equal(t, 2, series[0].ActiveUserCount)
+		assert.Equal(t, 0.25, series[0].AcceptRate)
+		assert.Equal(t, 0.25, series[0].UnansweredRatio)
+		assert.Equal(t, 120, series[0].MedianFirstAnswerSeconds)
+		assert.Equal(t, "2024-05-20", series[1].Date)
+	}
+}
+
+func TestParseAnalyticsRange(t *testing.T) {
+	start, end, err := parseAnalyticsRange("2024-01-01", "2024-01-31")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-01", start.Format(schema.AnalyticsDateFormat))
+	assert.Equal(t, "2024-01-31", end.Format(schema.AnalyticsDateFormat))
+
+	start, end, err = parseAnalyticsRange("", "2024-01-31")
+	assert.NoError(t, err)
+	assert.Equal(t, schema.AnalyticsDefaultDays-1, int(end.Sub(start).Hours()/24))
+
+	_, _, err = parseAnalyticsRange("2024-02-01", "2024-01-31")
+	assert.Error(t, err)
+	_, _, err = parseAnalyticsRange("2020-01-01", "2024-01-31")
+	assert.Error(t, err)
+	_, _, err = parseAnalyticsRange("2024/01/01", "")
+	assert.Error(t, err)
+}
@@ -112,6 +112,7 @@ func (as *AnswerCommon) ShowFormat(ctx context.Context, data *entity.Answer) *sc
 	info.UserID = data.UserID
 	info.UpdateUserID = data.LastEditUserID
 	info.Status = data.Status
+	info.Wiki = data.Wiki
 	info.MemberActions = make([]*schema.PermissionMemberAction, 0)
 	return &info
 }
@@ -27,6 +27,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
@@ -60,6 +61,7 @@ type BadgeAwardService struct {
 	userCommon               *usercommon.UserCommon
 	objectInfoService        *object_info.ObjService
 	notificationQueueService notice_queue.NotificationQueueService
+	eventQueueService        event_queue.EventQueueService
 }
 
 func NewBadgeAwardService(
@@ -68,6 +70,7 @@ func NewBadgeAwardService(
 	userCommon *usercommon.UserCommon,
 	objectInfoService *object_info.ObjService,
 	notificationQueueService notice_queue.NotificationQueueService,
+	eventQueueService event_queue.EventQueueService,
 ) *BadgeAwardService {
 	return &BadgeAwardService{
 		badgeAwardRepo:           badgeAwardRepo,
@@ -75,6 +78,7 @@ func NewBadgeAwardService(
 		userCommon:               userCommon,
 		objectInfoService:        objectInfoService,
 		notificationQueueService: notificationQueueService,
+		eventQueueService:        eventQueueService,
 	}
 }
 
@@ -184,6 +188,9 @@ func (bs *BadgeAwardService) Award(ctx context.Context, badgeID string, userID s
 		NotificationAction: constant.NotificationEarnedBadge,
 	}
 	bs.notificationQueueService.Send(ctx, msg)
+	// the badge is awarded in the handler of event queue, so only send the event to plugins
+	bs.eventQueueService.SendToPlugins(ctx, schema.NewEvent(constant.EventBadgeAward, badgeAward.UserID).
+		OID(badgeData.ID).AddExtra("badge_name", badgeData.Name).AddExtra("award_key", badgeAward.AwardKey))
 	return nil
 }
 
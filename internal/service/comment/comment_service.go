@@ -21,13 +21,12 @@ package comment
 
 import (
 	"context"
-	"encoding/json"
 	"time"
 
 	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/external_profile"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
-	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/pager"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/entity"
@@ -39,6 +38,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/space"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/token"
@@ -91,7 +91,8 @@ type CommentService struct {
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
 	activityQueueService             activity_queue.ActivityQueueService
 	eventQueueService                event_queue.EventQueueService
-	data                             *data.Data
+	spaceService                     *space.SpaceService
+	externalProfileService           *external_profile.ExternalProfileService
 }
 
 // NewCommentService new comment service
@@ -107,7 +108,8 @@ func NewCommentService(
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService,
 	activityQueueService activity_queue.ActivityQueueService,
 	eventQueueService event_queue.EventQueueService,
-	data *data.Data,
+	spaceService *space.SpaceService,
+	externalProfileService *external_profile.ExternalProfileService,
 ) *CommentService {
 	return &CommentService{
 		commentRepo:                      commentRepo,
@@ -121,30 +123,11 @@ func NewCommentService(
 		externalNotificationQueueService: externalNotificationQueueService,
 		activityQueueService:             activityQueueService,
 		eventQueueService:                eventQueueService,
-		data:                             data,
+		spaceService:                     spaceService,
+		externalProfileService:           externalProfileService,
 	}
 }
 
-// get all external login info
-func (cs *CommentService) GetAllExternalLoginInfo(ctx context.Context) (externalLoginInfoMap map[string]*entity.MixinUserInfo) {
-	var externalLoginInfos []*entity.UserExternalLogin
-	err := cs.data.DB.Table("user_external_login").Find(&externalLoginInfos)
-	if err != nil {
-		log.Debugf("get external login info failed: %v", err)
-	}
-	externalLoginInfoMap = make(map[string]*entity.MixinUserInfo, len(externalLoginInfos))
-	for _, info := range externalLoginInfos {
-		var mixinUserInfoResponse entity.MixinUserInfoResponse
-		err := json.Unmarshal([]byte(info.MetaInfo), &mixinUserInfoResponse)
-		if err != nil {
-			log.Debugf("unmarshal external login info failed: %v", err)
-		}
-		externalLoginInfoMap[info.UserID] = &mixinUserInfoResponse.Data
-	}
-
-	return externalLoginInfoMap
-}
-
 // AddComment add comment
 func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddCommentReq) (
 	resp *schema.GetCommentResp, err error) {
@@ -164,6 +147,9 @@ func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddComment
 	objInfo.AnswerID = uid.DeShortID(objInfo.AnswerID)
 	if objInfo.ObjectType == constant.QuestionObjectType || objInfo.ObjectType == constant.AnswerObjectType {
 		comment.QuestionID = objInfo.QuestionID
+		if err = cs.spaceService.CheckQuestionAccess(ctx, objInfo.QuestionID); err != nil {
+			return nil, err
+		}
 	}
 
 	if len(req.ReplyCommentID) > 0 {
@@ -329,6 +315,11 @@ func (cs *CommentService) GetComment(ctx context.Context, req *schema.GetComment
 	if !exist {
 		return nil, errors.BadRequest(reason.CommentNotFound)
 	}
+	if len(comment.QuestionID) > 0 {
+		if err = cs.spaceService.CheckQuestionAccess(ctx, comment.QuestionID); err != nil {
+			return nil, err
+		}
+	}
 
 	resp = &schema.GetCommentResp{
 		CommentID:      comment.ID,
@@ -389,10 +380,20 @@ func (cs *CommentService) GetCommentWithPage(ctx context.Context, req *schema.Ge
 	if err != nil {
 		return nil, err
 	}
-	externalLoginInfoMap := cs.GetAllExternalLoginInfo(ctx)
+	// all the comments belong to the same object, so check the question of the first one is enough
+	if len(commentList) > 0 && len(commentList[0].QuestionID) > 0 {
+		if err = cs.spaceService.CheckQuestionAccess(ctx, commentList[0].QuestionID); err != nil {
+			return nil, err
+		}
+	}
+	userIDs := make([]string, 0, len(commentList)*2)
+	for _, comment := range commentList {
+		userIDs = append(userIDs, comment.UserID, comment.GetReplyUserID())
+	}
+	externalProfiles := cs.externalProfileService.BatchGetExternalProfiles(ctx, userIDs)
 	resp := make([]*schema.GetCommentResp, 0)
 	for _, comment := range commentList {
-		commentResp, err := cs.convertCommentEntity2Resp(ctx, req, comment, externalLoginInfoMap)
+		commentResp, err := cs.convertCommentEntity2Resp(ctx, req, comment, externalProfiles)
 		if err != nil {
 			return nil, err
 		}
@@ -414,7 +415,9 @@ func (cs *CommentService) GetCommentWithPage(ctx context.Context, req *schema.Ge
 				return nil, err
 			}
 			if exist && comment.ObjectID == req.ObjectID {
-				commentResp, err := cs.convertCommentEntity2Resp(ctx, req, comment, externalLoginInfoMap)
+				externalProfiles = cs.externalProfileService.BatchGetExternalProfiles(ctx,
+					[]string{comment.UserID, comment.GetReplyUserID()})
+				commentResp, err := cs.convertCommentEntity2Resp(ctx, req, comment, externalProfiles)
 				if err != nil {
 					return nil, err
 				}
@@ -426,7 +429,7 @@ func (cs *CommentService) GetCommentWithPage(ctx context.Context, req *schema.Ge
 }
 
 func (cs *CommentService) convertCommentEntity2Resp(ctx context.Context, req *schema.GetCommentWithPageReq,
-	comment *entity.Comment, externalLoginInfoMap map[string]*entity.MixinUserInfo) (commentResp *schema.GetCommentResp, err error) {
+	comment *entity.Comment, externalProfiles map[string]*schema.ExternalProfile) (commentResp *schema.GetCommentResp, err error) {
 	commentResp = &schema.GetCommentResp{
 		CommentID:      comment.ID,
 		CreatedAt:      comment.CreatedAt.Unix(),
@@ -450,9 +453,7 @@ func (cs *CommentService) convertCommentEntity2Resp(ctx context.Context, req *sc
 			commentResp.UserDisplayName = commentUser.DisplayName
 			commentResp.UserAvatar = commentUser.Avatar
 			commentResp.UserStatus = commentUser.Status
-			if externalLoginInfo, exist := externalLoginInfoMap[commentResp.UserID]; exist {
-				commentResp.UserMemberShip = externalLoginInfo.Membership
-			}
+			commentResp.UserMemberShip = externalProfiles[commentResp.UserID].GetMembership()
 		}
 	}
 
@@ -466,9 +467,7 @@ func (cs *CommentService) convertCommentEntity2Resp(ctx context.Context, req *sc
 			commentResp.ReplyUsername = replyUser.Username
 			commentResp.ReplyUserDisplayName = replyUser.DisplayName
 			commentResp.ReplyUserStatus = replyUser.Status
-			if externalLoginInfo, exist := externalLoginInfoMap[commentResp.ReplyUserID]; exist {
-				commentResp.ReplyUserMemberShip = externalLoginInfo.Membership
-			}
+			commentResp.ReplyUserMemberShip = externalProfiles[commentResp.ReplyUserID].GetMembership()
 		}
 	}
 
@@ -25,9 +25,9 @@ import (
 	"time"
 
 	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/external_profile"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
-	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
@@ -43,6 +43,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/space"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/pkg/htmltext"
@@ -71,7 +72,8 @@ type AnswerService struct {
 	activityQueueService             activity_queue.ActivityQueueService
 	reviewService                    *review.ReviewService
 	eventQueueService                event_queue.EventQueueService
-	data                             *data.Data
+	spaceService                     *space.SpaceService
+	externalProfileService           *external_profile.ExternalProfileService
 }
 
 func NewAnswerService(
@@ -92,7 +94,8 @@ func NewAnswerService(
 	activityQueueService activity_queue.ActivityQueueService,
 	reviewService *review.ReviewService,
 	eventQueueService event_queue.EventQueueService,
-	data *data.Data,
+	spaceService *space.SpaceService,
+	externalProfileService *external_profile.ExternalProfileService,
 ) *AnswerService {
 	return &AnswerService{
 		answerRepo:                       answerRepo,
@@ -112,30 +115,11 @@ func NewAnswerService(
 		activityQueueService:             activityQueueService,
 		reviewService:                    reviewService,
 		eventQueueService:                eventQueueService,
-		data:                             data,
+		spaceService:                     spaceService,
+		externalProfileService:           externalProfileService,
 	}
 }
 
-// get all external login info
-func (as *AnswerService) GetAllExternalLoginInfo(ctx context.Context) (externalLoginInfoMap map[string]*entity.MixinUserInfo) {
-	var externalLoginInfos []*entity.UserExternalLogin
-	err := as.data.DB.Table("user_external_login").Find(&externalLoginInfos)
-	if err != nil {
-		log.Debugf("get external login info failed: %v", err)
-	}
-	externalLoginInfoMap = make(map[string]*entity.MixinUserInfo, len(externalLoginInfos))
-	for _, info := range externalLoginInfos {
-		var mixinUserInfoResponse entity.MixinUserInfoResponse
-		err := json.Unmarshal([]byte(info.MetaInfo), &mixinUserInfoResponse)
-		if err != nil {
-			log.Debugf("unmarshal external login info failed: %v", err)
-		}
-		externalLoginInfoMap[info.UserID] = &mixinUserInfoResponse.Data
-	}
-
-	return externalLoginInfoMap
-}
-
 // RemoveAnswer delete answer
 func (as *AnswerService) RemoveAnswer(ctx context.Context, req *schema.RemoveAnswerReq) (err error) {
 	answerInfo, exist, err := as.answerRepo.GetByID(ctx, req.ID)
@@ -255,6 +239,9 @@ func (as *AnswerService) Insert(ctx context.Context, req *schema.AnswerAddReq) (
 	if !exist {
 		return "", errors.BadRequest(reason.QuestionNotFound)
 	}
+	if err = as.spaceService.CheckQuestionSpaceAccess(ctx, questionInfo.SpaceID); err != nil {
+		return "", err
+	}
 	if questionInfo.Status == entity.QuestionStatusClosed || questionInfo.Status == entity.QuestionStatusDeleted {
 		err = errors.BadRequest(reason.AnswerCannotAddByClosedQuestion)
 		return "", err
@@ -348,6 +335,9 @@ func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq
 	if !exist {
 		return "", errors.BadRequest(reason.QuestionNotFound)
 	}
+	if err = as.spaceService.CheckQuestionSpaceAccess(ctx, questionInfo.SpaceID); err != nil {
+		return "", err
+	}
 
 	answerInfo, exist, err := as.answerRepo.GetByID(ctx, req.ID)
 	if err != nil {
@@ -409,11 +399,15 @@ func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq
 		return insertData.ID, err
 	}
 	if canUpdate {
+		activityTypeKey := constant.ActAnswerEdited
+		if req.IsRollback {
+			activityTypeKey = constant.ActAnswerRollback
+		}
 		as.activityQueueService.Send(ctx, &schema.ActivityMsg{
 			UserID:           req.UserID,
 			ObjectID:         insertData.ID,
 			OriginalObjectID: insertData.ID,
-			ActivityTypeKey:  constant.ActAnswerEdited,
+			ActivityTypeKey:  activityTypeKey,
 			RevisionID:       revisionID,
 		})
 		as.eventQueueService.Send(ctx, schema.NewEvent(constant.EventAnswerUpdate, req.UserID).TID(insertData.ID).
@@ -423,6 +417,22 @@ func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq
 	return insertData.ID, nil
 }
 
+// UpdateAnswerWiki convert the answer to or from community wiki
+func (as *AnswerService) UpdateAnswerWiki(ctx context.Context, req *schema.UpdateAnswerWikiReq) (err error) {
+	answerInfo, exist, err := as.answerRepo.GetAnswer(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist || answerInfo.Status == entity.AnswerStatusDeleted {
+		return errors.BadRequest(reason.AnswerNotFound)
+	}
+	if answerInfo.Wiki == req.Wiki {
+		return nil
+	}
+	answerInfo.Wiki = req.Wiki
+	return as.answerRepo.UpdateAnswer(ctx, answerInfo, []string{"wiki"})
+}
+
 // AcceptAnswer accept answer
 func (as *AnswerService) AcceptAnswer(ctx context.Context, req *schema.AcceptAnswerReq) (err error) {
 	// find question
@@ -609,6 +619,11 @@ func (as *AnswerService) AdminSetAnswerStatus(ctx context.Context, req *schema.A
 
 func (as *AnswerService) SearchList(ctx context.Context, req *schema.AnswerListReq) ([]*schema.AnswerInfo, int64, error) {
 	list := make([]*schema.AnswerInfo, 0)
+	if len(req.QuestionID) > 0 {
+		if err := as.spaceService.CheckQuestionAccess(ctx, req.QuestionID); err != nil {
+			return list, 0, err
+		}
+	}
 	dbSearch := entity.AnswerSearch{}
 	dbSearch.QuestionID = req.QuestionID
 	dbSearch.Page = req.Page
@@ -639,24 +654,19 @@ func (as *AnswerService) SearchFormatInfo(ctx context.Context, answers []*entity
 		userIDs = append(userIDs, info.UserID, info.LastEditUserID)
 	}
 
-	externalLoginInfoMap := as.GetAllExternalLoginInfo(ctx)
-
 	userInfoMap, err := as.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
 	if err != nil {
 		return list, err
 	}
+	externalProfiles := as.externalProfileService.BatchGetExternalProfiles(ctx, userIDs)
 	for _, item := range list {
 		item.UserInfo = userInfoMap[item.UserID]
 		if item.UserInfo != nil {
-			if info, ok := externalLoginInfoMap[item.UserInfo.ID]; ok {
-				item.UserInfo.Membership = info.Membership
-			}
+			item.UserInfo.Membership = externalProfiles[item.UserInfo.ID].GetMembership()
 		}
 		item.UpdateUserInfo = userInfoMap[item.UpdateUserID]
 		if item.UpdateUserInfo != nil {
-			if info, ok := externalLoginInfoMap[item.UpdateUserInfo.ID]; ok {
-				item.UpdateUserInfo.Membership = info.Membership
-			}
+			item.UpdateUserInfo.Membership = externalProfiles[item.UpdateUserInfo.ID].GetMembership()
 		}
 	}
 	if len(req.UserID) == 0 {
@@ -674,9 +684,11 @@ func (as *AnswerService) SearchFormatInfo(ctx context.Context, answers []*entity
 			req.UserID,
 			item.UserID,
 			item.Status,
-			req.CanEdit,
+			req.CanEdit || (item.Wiki && req.CanEditWiki),
 			req.CanDelete,
-			req.CanRecover)
+			req.CanRecover,
+			req.CanWiki && !item.Wiki,
+			req.CanWiki && item.Wiki)
 	}
 	return list, nil
 }
@@ -40,7 +40,7 @@ func (q *QuestionService) RefreshHottestCron(ctx context.Context) {
 			ctx,
 			page, pageSize,
 			[]string{},
-			"", "newest",
+			"", "", "newest",
 			schema.HotInDays,
 			false, false)
 		if err != nil {
@@ -61,7 +61,8 @@ func (q *QuestionService) RefreshHottestCron(ctx context.Context) {
 				aScores = 0
 			}
 
-			score := q.getScore(float64(question.ViewCount), float64(question.AnswerCount), float64(question.VoteCount), aScores, float64(qAgeInHours), float64(qUpdated))
+			// the unique views are not affected by refreshing
+			score := q.getScore(float64(question.UniqueViewCount), float64(question.AnswerCount), float64(question.VoteCount), aScores, float64(qAgeInHours), float64(qUpdated))
 			if score < 0 {
 				score = 0
 			}
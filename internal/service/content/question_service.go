@@ -26,12 +26,13 @@ import (
 	"time"
 
 	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/external_profile"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
-	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/pager"
 	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/tracing"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/base/validator"
 	"github.com/apache/incubator-answer/internal/entity"
@@ -48,10 +49,12 @@ import (
 	"github.com/apache/incubator-answer/internal/service/notification"
 	"github.com/apache/incubator-answer/internal/service/permission"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/question_view"
 	"github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/space"
 	"github.com/apache/incubator-answer/internal/service/tag"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
@@ -92,7 +95,9 @@ type QuestionService struct {
 	reviewService                    *review.ReviewService
 	configService                    *config.ConfigService
 	eventQueueService                event_queue.EventQueueService
-	data                             *data.Data
+	spaceService                     *space.SpaceService
+	questionViewService              *question_view.QuestionViewService
+	externalProfileService           *external_profile.ExternalProfileService
 }
 
 func NewQuestionService(
@@ -118,7 +123,9 @@ func NewQuestionService(
 	reviewService *review.ReviewService,
 	configService *config.ConfigService,
 	eventQueueService event_queue.EventQueueService,
-	data *data.Data,
+	spaceService *space.SpaceService,
+	questionViewService *question_view.QuestionViewService,
+	externalProfileService *external_profile.ExternalProfileService,
 ) *QuestionService {
 	return &QuestionService{
 		activityRepo:                     activityRepo,
@@ -143,29 +150,12 @@ func NewQuestionService(
 		reviewService:                    reviewService,
 		configService:                    configService,
 		eventQueueService:                eventQueueService,
-		data:                             data,
+		spaceService:                     spaceService,
+		questionViewService:              questionViewService,
+		externalProfileService:           externalProfileService,
 	}
 }
 
-func (qs *QuestionService) GetAllExternalLoginInfo(ctx context.Context) (externalLoginInfoMap map[string]*entity.MixinUserInfo) {
-	var externalLoginInfos []*entity.UserExternalLogin
-	err := qs.data.DB.Table("user_external_login").Find(&externalLoginInfos)
-	if err != nil {
-		log.Debugf("get external login info failed: %v", err)
-	}
-	externalLoginInfoMap = make(map[string]*entity.MixinUserInfo, len(externalLoginInfos))
-	for _, info := range externalLoginInfos {
-		var mixinUserInfoResponse entity.MixinUserInfoResponse
-		err := json.Unmarshal([]byte(info.MetaInfo), &mixinUserInfoResponse)
-		if err != nil {
-			log.Debugf("unmarshal external login info failed: %v", err)
-		}
-		externalLoginInfoMap[info.UserID] = &mixinUserInfoResponse.Data
-	}
-
-	return externalLoginInfoMap
-}
-
 func (qs *QuestionService) CloseQuestion(ctx context.Context, req *schema.CloseQuestionReq) error {
 	questionInfo, has, err := qs.questionRepo.GetQuestion(ctx, req.ID)
 	if err != nil {
@@ -346,9 +336,17 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 		}
 	}
 
+	if err = qs.spaceService.CheckCanPostInSpace(ctx, req.SpaceID); err != nil {
+		return nil, err
+	}
+
 	question := &entity.Question{}
 	now := time.Now()
+	if req.PublishAt > 0 && req.PublishAt <= now.Unix() {
+		return nil, errors.BadRequest(reason.QuestionScheduleTimeInvalid)
+	}
 	question.UserID = req.UserID
+	question.SpaceID = req.SpaceID
 	question.Title = req.Title
 	question.OriginalText = req.Content
 	question.ParsedText = req.HTML
@@ -362,12 +360,20 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 	question.PostUpdateTime = now
 	question.Pin = entity.QuestionUnPin
 	question.Show = entity.QuestionShow
+	if req.PublishAt > 0 {
+		question.PublishAt = time.Unix(req.PublishAt, 0)
+	}
 	//question.UpdatedAt = nil
 	err = qs.questionRepo.AddQuestion(ctx, question)
 	if err != nil {
 		return
 	}
 	question.Status = qs.reviewService.AddQuestionReview(ctx, question, req.Tags, req.IP, req.UserAgent)
+	// the question that passed the review stays hidden until the scheduled job publishes it,
+	// the question in the review queue is scheduled when it is approved
+	if question.Status == entity.QuestionStatusAvailable && !question.PublishAt.IsZero() {
+		question.Status = entity.QuestionStatusScheduled
+	}
 	if err := qs.questionRepo.UpdateQuestionStatus(ctx, question.ID, question.Status); err != nil {
 		return nil, err
 	}
@@ -379,7 +385,9 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 	if err != nil {
 		return
 	}
-	_ = qs.questionRepo.UpdateSearch(ctx, question.ID)
+	if question.PublishAt.IsZero() {
+		_ = qs.questionRepo.UpdateSearch(ctx, question.ID)
+	}
 
 	revisionDTO := &schema.AddRevisionDTO{
 		UserID:   question.UserID,
@@ -409,13 +417,16 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 		}
 	}
 
-	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
-		UserID:           question.UserID,
-		ObjectID:         question.ID,
-		OriginalObjectID: question.ID,
-		ActivityTypeKey:  constant.ActQuestionAsked,
-		RevisionID:       revisionID,
-	})
+	// the activity of the scheduled question is added when it is published
+	if question.PublishAt.IsZero() {
+		qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
+			UserID:           question.UserID,
+			ObjectID:         question.ID,
+			OriginalObjectID: question.ID,
+			ActivityTypeKey:  constant.ActQuestionAsked,
+			RevisionID:       revisionID,
+		})
+	}
 
 	userInfo, _, err := qs.userCommon.GetUserBasicInfoByID(ctx, question.UserID)
 	if err != nil {
@@ -424,15 +435,35 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 
 	if question.Status == entity.QuestionStatusAvailable {
 		qs.externalNotificationQueueService.Send(ctx,
-			schema.CreateNewQuestionNotificationMsg(question.ID, question.Title, question.ParsedText, question.UserID, userInfo.DisplayName, tags))
+			schema.CreateNewQuestionNotificationMsg(question.ID, question.Title,
+				converter.Markdown2InlineStyleHTML(question.OriginalText, qs.siteInfoService.GetCodeHighlightTheme(ctx)),
+				question.UserID, userInfo.DisplayName, tags))
+	}
+	if question.PublishAt.IsZero() {
+		qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuestionCreate, req.UserID).TID(question.ID).
+			QID(question.ID, question.UserID))
 	}
-	qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuestionCreate, req.UserID).TID(question.ID).
-		QID(question.ID, question.UserID))
 
 	questionInfo, err = qs.GetQuestion(ctx, question.ID, question.UserID, req.QuestionPermission)
 	return
 }
 
+// UpdateQuestionWiki convert the question to or from community wiki
+func (qs *QuestionService) UpdateQuestionWiki(ctx context.Context, req *schema.UpdateQuestionWikiReq) (err error) {
+	questionInfo, has, err := qs.questionRepo.GetQuestion(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !has || questionInfo.Status == entity.QuestionStatusDeleted {
+		return errors.BadRequest(reason.QuestionNotFound)
+	}
+	if questionInfo.Wiki == req.Wiki {
+		return nil
+	}
+	questionInfo.Wiki = req.Wiki
+	return qs.questionRepo.UpdateQuestion(ctx, questionInfo, []string{"wiki"})
+}
+
 // OperationQuestion
 func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.OperationQuestionReq) (err error) {
 	questionInfo, has, err := qs.questionRepo.GetQuestion(ctx, req.ID)
@@ -473,9 +504,32 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 			return err
 		}
 	case schema.QuestionOperationPin:
+		now := time.Now().Unix()
+		if (req.UnpinAt > 0 && req.UnpinAt <= now) || (req.CloseAt > 0 && req.CloseAt <= now) {
+			return errors.BadRequest(reason.QuestionScheduleTimeInvalid)
+		}
 		questionInfo.Pin = entity.QuestionPin
+		questionInfo.UnpinAt, questionInfo.CloseAt = time.Time{}, time.Time{}
+		if req.UnpinAt > 0 {
+			questionInfo.UnpinAt = time.Unix(req.UnpinAt, 0)
+		}
+		if req.CloseAt > 0 {
+			questionInfo.CloseAt = time.Unix(req.CloseAt, 0)
+			// the question will be closed by the user who schedules it
+			err = qs.metaService.AddOrUpdateMetaByObjectIdAndKey(ctx, uid.DeShortID(questionInfo.ID),
+				entity.QuestionCloseUserKey, func(meta *entity.Meta, exist bool) (*entity.Meta, error) {
+					meta.ObjectID = uid.DeShortID(questionInfo.ID)
+					meta.Key = entity.QuestionCloseUserKey
+					meta.Value = req.UserID
+					return meta, nil
+				})
+			if err != nil {
+				return err
+			}
+		}
 	case schema.QuestionOperationUnPin:
 		questionInfo.Pin = entity.QuestionUnPin
+		questionInfo.UnpinAt, questionInfo.CloseAt = time.Time{}, time.Time{}
 	}
 
 	err = qs.questionRepo.UpdateQuestionOperation(ctx, questionInfo)
@@ -501,6 +555,122 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 	return nil
 }
 
+// QuestionScheduleCron publish the scheduled questions and unpin or close the expired pinned questions
+func (qs *QuestionService) QuestionScheduleCron(ctx context.Context) {
+	if err := qs.publishScheduledQuestions(ctx); err != nil {
+		log.Errorf("publish scheduled questions failed: %v", err)
+	}
+	if err := qs.expirePinnedQuestions(ctx); err != nil {
+		log.Errorf("expire pinned questions failed: %v", err)
+	}
+}
+
+// publishScheduledQuestions publish the scheduled questions whose publish time is up
+func (qs *QuestionService) publishScheduledQuestions(ctx context.Context) (err error) {
+	now := time.Now()
+	questionList, err := qs.questionRepo.GetScheduledQuestions(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, question := range questionList {
+		question.CreatedAt = now
+		question.PostUpdateTime = now
+		// only the instance that publishes the question sends the notifications
+		published, err := qs.questionRepo.PublishScheduledQuestion(ctx, question)
+		if err != nil {
+			log.Errorf("publish scheduled question %s failed: %v", question.ID, err)
+			continue
+		}
+		if !published {
+			continue
+		}
+		qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
+			UserID:           question.UserID,
+			ObjectID:         question.ID,
+			OriginalObjectID: question.ID,
+			ActivityTypeKey:  constant.ActQuestionAsked,
+			RevisionID:       question.RevisionID,
+		})
+
+		userQuestionCount, err := qs.questioncommon.GetUserQuestionCount(ctx, question.UserID)
+		if err != nil {
+			log.Errorf("get user question count error %v", err)
+		} else {
+			err = qs.userCommon.UpdateQuestionCount(ctx, question.UserID, userQuestionCount)
+			if err != nil {
+				log.Errorf("update user question count error %v", err)
+			}
+		}
+
+		tags, err := qs.tagCommon.GetObjectEntityTag(ctx, question.ID)
+		if err != nil {
+			log.Errorf("get question tags failed, err: %v", err)
+		}
+		userInfo, _, err := qs.userCommon.GetUserBasicInfoByID(ctx, question.UserID)
+		if err != nil {
+			log.Errorf("get user basic info by id error %v", err)
+		}
+		qs.externalNotificationQueueService.Send(ctx,
+			schema.CreateNewQuestionNotificationMsg(question.ID, question.Title,
+				converter.Markdown2InlineStyleHTML(question.OriginalText, qs.siteInfoService.GetCodeHighlightTheme(ctx)),
+				question.UserID, userInfo.DisplayName, tags))
+		qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuestionCreate, question.UserID).TID(question.ID).
+			QID(question.ID, question.UserID))
+	}
+	return nil
+}
+
+// expirePinnedQuestions unpin or close the questions whose unpin or close time is up
+func (qs *QuestionService) expirePinnedQuestions(ctx context.Context) (err error) {
+	now := time.Now()
+	questionList, err := qs.questionRepo.GetExpiredPinnedQuestions(ctx, now)
+	if err != nil {
+		return err
+	}
+	closeType, err := qs.configService.GetIDByKey(ctx, constant.ReasonSomething)
+	if err != nil {
+		return err
+	}
+	for _, question := range questionList {
+		if !question.UnpinAt.IsZero() && !question.UnpinAt.After(now) {
+			question.Pin = entity.QuestionUnPin
+			question.UnpinAt = time.Time{}
+		}
+		if !question.CloseAt.IsZero() && !question.CloseAt.After(now) {
+			var closeErr error
+			if question.Status == entity.QuestionStatusAvailable {
+				closeErr = qs.closeScheduledQuestion(ctx, question.ID, closeType)
+			}
+			if closeErr != nil {
+				log.Errorf("close expired question %s failed: %v", question.ID, closeErr)
+			}
+			// the close is retried next time only if the database fails, the others such as the missing
+			// closing user will never succeed, so the close is given up and the unpin is still applied
+			if myErr, ok := closeErr.(*errors.Error); closeErr == nil || !ok || !errors.IsInternalServer(myErr) {
+				question.CloseAt = time.Time{}
+			}
+		}
+		if err = qs.questionRepo.UpdateQuestionOperation(ctx, question); err != nil {
+			log.Errorf("update expired question %s failed: %v", question.ID, err)
+		}
+	}
+	return nil
+}
+
+// closeScheduledQuestion close the question on behalf of the user who schedules it to be closed
+func (qs *QuestionService) closeScheduledQuestion(ctx context.Context, questionID string, closeType int) (err error) {
+	meta, err := qs.metaService.GetMetaByObjectIdAndKey(ctx, uid.DeShortID(questionID), entity.QuestionCloseUserKey)
+	if err != nil {
+		return err
+	}
+	return qs.CloseQuestion(ctx, &schema.CloseQuestionReq{
+		ID:        questionID,
+		CloseType: closeType,
+		CloseMsg:  "Closed automatically at the scheduled time.",
+		UserID:    meta.Value,
+	})
+}
+
 // RemoveQuestion delete question
 func (qs *QuestionService) RemoveQuestion(ctx context.Context, req *schema.RemoveQuestionReq) (err error) {
 	questionInfo, has, err := qs.questionRepo.GetQuestion(ctx, req.ID)
@@ -975,10 +1145,14 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 		return
 	}
 	if canUpdate {
+		activityTypeKey := constant.ActQuestionEdited
+		if req.IsRollback {
+			activityTypeKey = constant.ActQuestionRollback
+		}
 		qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 			UserID:           req.UserID,
 			ObjectID:         question.ID,
-			ActivityTypeKey:  constant.ActQuestionEdited,
+			ActivityTypeKey:  activityTypeKey,
 			RevisionID:       revisionID,
 			OriginalObjectID: question.ID,
 		})
@@ -993,13 +1167,16 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 // GetQuestion get question one
 func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID string,
 	per schema.QuestionPermission) (resp *schema.QuestionInfoResp, err error) {
+	ctx, span := tracing.Start(ctx, "QuestionService.GetQuestion")
+	defer span.End()
 	question, err := qs.questioncommon.Info(ctx, questionID, userID)
 	if err != nil {
 		return
 	}
-	// If the question is deleted or pending, only the administrator and the author can view it
+	// If the question is deleted, pending or scheduled, only the administrator and the author can view it
 	if (question.Status == entity.QuestionStatusDeleted ||
-		question.Status == entity.QuestionStatusPending) && !per.CanReopen && question.UserID != userID {
+		question.Status == entity.QuestionStatusPending ||
+		question.Status == entity.QuestionStatusScheduled) && !per.CanReopen && question.UserID != userID {
 		return nil, errors.NotFound(reason.QuestionNotFound)
 	}
 	if question.Status != entity.QuestionStatusClosed {
@@ -1022,6 +1199,11 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 		per.CanHide = false
 		per.CanPin = false
 	}
+	if question.Wiki {
+		per.CanWiki = false
+	} else {
+		per.CanUnWiki = false
+	}
 
 	if question.Status == entity.QuestionStatusDeleted {
 		operation := &schema.Operation{}
@@ -1035,25 +1217,37 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 		operation.Level = schema.OperationLevelSecondary
 		question.Operation = operation
 	}
+	if question.Status == entity.QuestionStatusScheduled {
+		operation := &schema.Operation{}
+		operation.Msg = translator.Tr(handler.GetLangByCtx(ctx), reason.QuestionScheduled)
+		operation.Level = schema.OperationLevelSecondary
+		question.Operation = operation
+	}
 
 	question.Description = htmltext.FetchExcerpt(question.HTML, "...", 240)
 	question.MemberActions = permission.GetQuestionPermission(ctx, userID, question.UserID, question.Status,
 		per.CanEdit, per.CanDelete,
 		per.CanClose, per.CanReopen, per.CanPin, per.CanHide, per.CanUnPin, per.CanShow,
-		per.CanRecover)
+		per.CanRecover, per.CanWiki, per.CanUnWiki)
 	question.ExtendsActions = permission.GetQuestionExtendsPermission(ctx, per.CanInviteOtherToAnswer)
 	return question, nil
 }
 
-// GetQuestionAndAddPV get question one
-func (qs *QuestionService) GetQuestionAndAddPV(ctx context.Context, questionID, loginUserID string,
+// GetQuestionAndAddPV get question one and record the view of it
+func (qs *QuestionService) GetQuestionAndAddPV(ctx context.Context, questionID, loginUserID, ip, userAgent string,
 	per schema.QuestionPermission) (
 	resp *schema.QuestionInfoResp, err error) {
-	err = qs.questioncommon.UpdatePv(ctx, questionID)
+	resp, err = qs.GetQuestion(ctx, questionID, loginUserID, per)
 	if err != nil {
-		log.Error(err)
+		return nil, err
 	}
-	return qs.GetQuestion(ctx, questionID, loginUserID, per)
+	qs.questionViewService.AddQuestionView(ctx, &schema.AddQuestionViewReq{
+		QuestionID: questionID,
+		UserID:     loginUserID,
+		IP:         ip,
+		UserAgent:  userAgent,
+	})
+	return resp, nil
 }
 
 func (qs *QuestionService) InviteUserInfo(ctx context.Context, questionID string) (inviteList []*schema.UserBasicInfo, err error) {
@@ -1359,11 +1553,11 @@ func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.Ques
 			return nil, 0, err
 		}
 		if exist {
-			synTagIds, err := qs.tagCommon.GetTagIDsByMainTagID(ctx, tagInfo.ID)
+			// browsing the parent tag includes the questions of its descendant tags
+			tagIDs, err = qs.tagCommon.GetTagIDsWithDescendants(ctx, tagInfo.ID)
 			if err != nil {
 				return nil, 0, err
 			}
-			tagIDs = append(synTagIds, tagInfo.ID)
 		}
 	}
 
@@ -1379,12 +1573,25 @@ func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.Ques
 		req.UserIDBeSearched = userinfo.ID
 	}
 
+	// query by space condition
+	spaceID := ""
+	if len(req.Space) > 0 {
+		spaceInfo, exist, err := qs.spaceService.GetSpaceBySlugName(ctx, strings.ToLower(req.Space))
+		if err != nil {
+			return nil, 0, err
+		}
+		if !exist {
+			return questions, 0, nil
+		}
+		spaceID = spaceInfo.ID
+	}
+
 	if req.OrderCond == schema.QuestionOrderCondHot {
 		req.InDays = schema.HotInDays
 	}
 
 	questionList, total, err := qs.questionRepo.GetQuestionPage(ctx, req.Page, req.PageSize,
-		tagIDs, req.UserIDBeSearched, req.OrderCond, req.InDays, showHidden, req.ShowPending)
+		tagIDs, spaceID, req.UserIDBeSearched, req.OrderCond, req.InDays, showHidden, req.ShowPending)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -1528,6 +1735,15 @@ func (qs *QuestionService) AdminQuestionPage(
 		item.CreateTime = info.CreatedAt.Unix()
 		item.UpdateTime = info.PostUpdateTime.Unix()
 		item.EditTime = info.UpdatedAt.Unix()
+		if !info.PublishAt.IsZero() {
+			item.PublishTime = info.PublishAt.Unix()
+		}
+		if !info.UnpinAt.IsZero() {
+			item.UnpinTime = info.UnpinAt.Unix()
+		}
+		if !info.CloseAt.IsZero() {
+			item.CloseTime = info.CloseAt.Unix()
+		}
 		list = append(list, item)
 		userIds = append(userIds, info.UserID)
 	}
@@ -22,6 +22,7 @@ package content
 import (
 	"context"
 	"encoding/json"
+	"sort"
 	"time"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
@@ -40,10 +41,13 @@ import (
 	"github.com/apache/incubator-answer/internal/service/report_common"
 	"github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/internal/service/revision"
+	"github.com/apache/incubator-answer/internal/service/space"
 	"github.com/apache/incubator-answer/internal/service/tag_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/internal/service/tag_moderator"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/diff"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/obj"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -68,6 +72,9 @@ type RevisionService struct {
 	reportRepo               report_common.ReportRepo
 	reviewService            *review.ReviewService
 	reviewActivity           activity.ReviewActivityRepo
+	questionService          *QuestionService
+	spaceService             *space.SpaceService
+	tagModeratorService      *tag_moderator.TagModeratorService
 }
 
 func NewRevisionService(
@@ -85,6 +92,9 @@ func NewRevisionService(
 	reportRepo report_common.ReportRepo,
 	reviewService *review.ReviewService,
 	reviewActivity activity.ReviewActivityRepo,
+	questionService *QuestionService,
+	spaceService *space.SpaceService,
+	tagModeratorService *tag_moderator.TagModeratorService,
 ) *RevisionService {
 	return &RevisionService{
 		revisionRepo:             revisionRepo,
@@ -101,6 +111,9 @@ func NewRevisionService(
 		reportRepo:               reportRepo,
 		reviewService:            reviewService,
 		reviewActivity:           reviewActivity,
+		questionService:          questionService,
+		spaceService:             spaceService,
+		tagModeratorService:      tagModeratorService,
 	}
 }
 
@@ -127,6 +140,16 @@ func (rs *RevisionService) RevisionAudit(ctx context.Context, req *schema.Revisi
 		revisionitem := &schema.GetRevisionResp{}
 		_ = copier.Copy(revisionitem, revisioninfo)
 		rs.parseItem(ctx, revisionitem)
+		// the tag moderator can review the revisions of the questions with the moderated tags and their answers
+		if (objectType == constant.QuestionObjectType && !req.CanReviewQuestion) ||
+			(objectType == constant.AnswerObjectType && !req.CanReviewAnswer) {
+			isTagModerator, err := rs.isRevisionObjectTagModerator(ctx, req.UserID, revisioninfo.ObjectID)
+			if err != nil {
+				return err
+			}
+			req.CanReviewQuestion = req.CanReviewQuestion || isTagModerator
+			req.CanReviewAnswer = req.CanReviewAnswer || isTagModerator
+		}
 		var saveErr error
 		switch objectType {
 		case constant.QuestionObjectType:
@@ -336,11 +359,22 @@ func (rs *RevisionService) revisionAuditTag(ctx context.Context, revisionitem *s
 func (rs *RevisionService) GetUnreviewedRevisionPage(ctx context.Context, req *schema.RevisionSearch) (
 	resp *pager.PageModel, err error) {
 	revisionResp := make([]*schema.GetUnreviewedRevisionResp, 0)
+	// the tag moderator can only review the revisions of the questions with the moderated tags and their answers
+	tagIDs := make([]string, 0)
+	if !req.CanReviewQuestion && !req.CanReviewAnswer && !req.CanReviewTag {
+		tagIDs, err = rs.tagModeratorService.GetUserModeratedTagIDs(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if len(tagIDs) > 0 {
+			req.CanReviewQuestion, req.CanReviewAnswer = true, true
+		}
+	}
 	if len(req.GetCanReviewObjectTypes()) == 0 {
 		return pager.NewPageModel(0, revisionResp), nil
 	}
 	revisionPage, total, err := rs.revisionRepo.GetUnreviewedRevisionPage(
-		ctx, req.Page, 1, req.GetCanReviewObjectTypes())
+		ctx, req.Page, 1, req.GetCanReviewObjectTypes(), tagIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -386,6 +420,9 @@ func (rs *RevisionService) GetRevisionList(ctx context.Context, req *schema.GetR
 	)
 
 	resp = []schema.GetRevisionResp{}
+	if err = rs.checkRevisionObjectAccess(ctx, req.ObjectID); err != nil {
+		return nil, err
+	}
 	_ = copier.Copy(&rev, req)
 
 	revs, err = rs.revisionRepo.GetRevisionList(ctx, &rev)
@@ -416,6 +453,70 @@ func (rs *RevisionService) GetRevisionList(ctx context.Context, req *schema.GetR
 	return
 }
 
+// GetContributors get the users who wrote or edited the question or answer from the visible revisions,
+// the author comes first and the others are sorted by the number of their revisions
+func (rs *RevisionService) GetContributors(ctx context.Context, req *schema.GetContributorsReq) (
+	resp []*schema.ContributorItem, err error) {
+	objectType, err := obj.GetObjectTypeStrByObjectID(req.ObjectID)
+	if err != nil || (objectType != constant.QuestionObjectType && objectType != constant.AnswerObjectType) {
+		return nil, errors.BadRequest(reason.ObjectNotFound)
+	}
+	if err = rs.checkRevisionObjectAccess(ctx, req.ObjectID); err != nil {
+		return nil, err
+	}
+	objInfo, err := rs.objectInfoService.GetInfo(ctx, req.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	revs, err := rs.revisionRepo.GetRevisionList(ctx, &entity.Revision{ObjectID: req.ObjectID})
+	if err != nil {
+		return nil, err
+	}
+
+	contributors := make(map[string]*schema.ContributorItem)
+	userIDs := []string{objInfo.ObjectCreatorUserID}
+	contributors[objInfo.ObjectCreatorUserID] = &schema.ContributorItem{IsAuthor: true}
+	for _, r := range revs {
+		if r.Status != entity.RevisioNnormalStatus && r.Status != entity.RevisionReviewPassStatus {
+			continue
+		}
+		item, ok := contributors[r.UserID]
+		if !ok {
+			item = &schema.ContributorItem{}
+			contributors[r.UserID] = item
+			userIDs = append(userIDs, r.UserID)
+		}
+		item.EditCount++
+		if r.CreatedAt.Unix() > item.LastEditTime {
+			item.LastEditTime = r.CreatedAt.Unix()
+		}
+	}
+	userInfoMapping, err := rs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = make([]*schema.ContributorItem, 0, len(userIDs))
+	for _, userID := range userIDs {
+		item := contributors[userID]
+		item.UserInfo = userInfoMapping[userID]
+		if item.UserInfo == nil {
+			continue
+		}
+		resp = append(resp, item)
+	}
+	sort.SliceStable(resp, func(i, j int) bool {
+		if resp[i].IsAuthor != resp[j].IsAuthor {
+			return resp[i].IsAuthor
+		}
+		if resp[i].EditCount != resp[j].EditCount {
+			return resp[i].EditCount > resp[j].EditCount
+		}
+		return resp[i].LastEditTime > resp[j].LastEditTime
+	})
+	return resp, nil
+}
+
 func (rs *RevisionService) parseItem(ctx context.Context, item *schema.GetRevisionResp) {
 	var (
 		err          error
@@ -539,3 +640,242 @@ func (rs *RevisionService) GetReviewingType(ctx context.Context, req *schema.Get
 	}
 	return resp, nil
 }
+
+// GetRevisionDiff get the diff of title, content and tags between two revisions of the same object
+func (rs *RevisionService) GetRevisionDiff(ctx context.Context, req *schema.GetRevisionDiffReq) (
+	resp *schema.GetRevisionDiffResp, err error) {
+	baseRevision, err := rs.getVisibleRevision(ctx, req.BaseRevisionID)
+	if err != nil {
+		return nil, err
+	}
+	targetRevision, err := rs.getVisibleRevision(ctx, req.TargetRevisionID)
+	if err != nil {
+		return nil, err
+	}
+	if baseRevision.ObjectID != targetRevision.ObjectID {
+		return nil, errors.BadRequest(reason.RevisionObjectMismatch)
+	}
+
+	objectType, err := obj.GetObjectTypeStrByObjectID(baseRevision.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	baseContent, err := parseRevisionDiffContent(objectType, baseRevision.Content)
+	if err != nil {
+		return nil, err
+	}
+	targetContent, err := parseRevisionDiffContent(objectType, targetRevision.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &schema.GetRevisionDiffResp{
+		ObjectID:         baseRevision.ObjectID,
+		ObjectType:       objectType,
+		BaseRevisionID:   baseRevision.ID,
+		TargetRevisionID: targetRevision.ID,
+		Title:            diff.Words(baseContent.title, targetContent.title),
+		Content:          diff.Lines(baseContent.content, targetContent.content),
+	}
+	if objectType == constant.QuestionObjectType {
+		resp.Tags = diffTagSet(baseContent.tags, targetContent.tags)
+	}
+	if objectType != constant.TagObjectType && handler.GetEnableShortID(ctx) {
+		resp.ObjectID = uid.EnShortID(resp.ObjectID)
+	}
+	return resp, nil
+}
+
+// GetRollbackRevision get the revision to rollback, the object must not have any revision under review
+func (rs *RevisionService) GetRollbackRevision(ctx context.Context, revisionID string) (
+	revision *entity.Revision, err error) {
+	revision, err = rs.getVisibleRevision(ctx, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = rs.CheckCanUpdateRevision(ctx, &schema.CheckCanQuestionUpdate{ID: revision.ObjectID})
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// RollbackRevision rollback the object to the revision through the normal edit path,
+// so the rollback creates a new revision and is reviewed if the user has no permission to edit without review
+func (rs *RevisionService) RollbackRevision(ctx context.Context, revision *entity.Revision,
+	req *schema.RevisionRollbackReq) (err error) {
+	objectType, err := obj.GetObjectTypeStrByObjectID(revision.ObjectID)
+	if err != nil {
+		return err
+	}
+	switch objectType {
+	case constant.QuestionObjectType:
+		question := &entity.QuestionWithTagsRevision{}
+		if err = json.Unmarshal([]byte(revision.Content), question); err != nil {
+			return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+		tags := make([]*schema.TagItem, 0, len(question.Tags))
+		for _, tag := range question.Tags {
+			tags = append(tags, &schema.TagItem{SlugName: tag.SlugName, DisplayName: tag.DisplayName})
+		}
+		updateReq := &schema.QuestionUpdate{
+			ID:           revision.ObjectID,
+			Title:        question.Title,
+			Content:      question.OriginalText,
+			HTML:         converter.Markdown2HTML(question.OriginalText),
+			Tags:         tags,
+			EditSummary:  req.EditSummary,
+			UserID:       req.UserID,
+			NoNeedReview: req.NoNeedReview,
+			IsRollback:   true,
+		}
+		updateReq.CanEdit = req.CanEdit
+		updateReq.CanUseReservedTag = req.CanUseReservedTag
+		updateReq.CanAddTag = req.CanAddTag
+		if _, err = rs.questionService.UpdateQuestionCheckTags(ctx, updateReq); err != nil {
+			return err
+		}
+		hasNewTag, err := rs.questionService.HasNewTag(ctx, tags)
+		if err != nil {
+			return err
+		}
+		if hasNewTag && !req.CanAddTag {
+			return errors.Forbidden(reason.RankFailToMeetTheCondition)
+		}
+		_, err = rs.questionService.UpdateQuestion(ctx, updateReq)
+		return err
+	case constant.AnswerObjectType:
+		answer := &entity.Answer{}
+		if err = json.Unmarshal([]byte(revision.Content), answer); err != nil {
+			return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+		_, err = rs.answerService.Update(ctx, &schema.AnswerUpdateReq{
+			ID:           revision.ObjectID,
+			QuestionID:   answer.QuestionID,
+			Content:      answer.OriginalText,
+			HTML:         converter.Markdown2HTML(answer.OriginalText),
+			EditSummary:  req.EditSummary,
+			UserID:       req.UserID,
+			NoNeedReview: req.NoNeedReview,
+			CanEdit:      req.CanEdit,
+			IsRollback:   true,
+		})
+		return err
+	case constant.TagObjectType:
+		tag := &entity.Tag{}
+		if err = json.Unmarshal([]byte(revision.Content), tag); err != nil {
+			return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+		return rs.tagCommon.UpdateTag(ctx, &schema.UpdateTagReq{
+			TagID:        revision.ObjectID,
+			SlugName:     tag.SlugName,
+			DisplayName:  tag.DisplayName,
+			OriginalText: tag.OriginalText,
+			ParsedText:   converter.Markdown2HTML(tag.OriginalText),
+			EditSummary:  req.EditSummary,
+			UserID:       req.UserID,
+			NoNeedReview: req.NoNeedReview,
+			IsRollback:   true,
+		})
+	}
+	return errors.BadRequest(reason.ObjectNotFound)
+}
+
+// getVisibleRevision get the revision that is visible in the revision list
+func (rs *RevisionService) getVisibleRevision(ctx context.Context, revisionID string) (
+	revision *entity.Revision, err error) {
+	revision, exist, err := rs.revisionRepo.GetRevisionByID(ctx, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || (revision.Status != entity.RevisioNnormalStatus && revision.Status != entity.RevisionReviewPassStatus) {
+		return nil, errors.NotFound(reason.RevisionNotFound)
+	}
+	if err = rs.checkRevisionObjectAccess(ctx, revision.ObjectID); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// isRevisionObjectTagModerator whether the user moderates the tags of the question that the revision object belongs to
+func (rs *RevisionService) isRevisionObjectTagModerator(ctx context.Context, userID, objectID string) (bool, error) {
+	objInfo, err := rs.objectInfoService.GetInfo(ctx, objectID)
+	if err != nil {
+		return false, err
+	}
+	return rs.tagModeratorService.IsQuestionTagModerator(ctx, userID, objInfo.QuestionID)
+}
+
+// checkRevisionObjectAccess check the user can access the question which the object belongs to
+func (rs *RevisionService) checkRevisionObjectAccess(ctx context.Context, objectID string) (err error) {
+	objectType, err := obj.GetObjectTypeStrByObjectID(objectID)
+	if err != nil || objectType == constant.TagObjectType {
+		return nil
+	}
+	objInfo, err := rs.objectInfoService.GetInfo(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	return rs.spaceService.CheckQuestionAccess(ctx, objInfo.QuestionID)
+}
+
+// revisionDiffContent the fields of revision content to diff
+type revisionDiffContent struct {
+	title   string
+	content string
+	tags    []string
+}
+
+func parseRevisionDiffContent(objectType, content string) (diffContent *revisionDiffContent, err error) {
+	diffContent = &revisionDiffContent{}
+	switch objectType {
+	case constant.QuestionObjectType:
+		question := &entity.QuestionWithTagsRevision{}
+		err = json.Unmarshal([]byte(content), question)
+		diffContent.title = question.Title
+		diffContent.content = question.OriginalText
+		for _, tag := range question.Tags {
+			diffContent.tags = append(diffContent.tags, tag.SlugName)
+		}
+	case constant.AnswerObjectType:
+		answer := &entity.Answer{}
+		err = json.Unmarshal([]byte(content), answer)
+		diffContent.content = answer.OriginalText
+	case constant.TagObjectType:
+		tag := &entity.Tag{}
+		err = json.Unmarshal([]byte(content), tag)
+		diffContent.title = tag.SlugName
+		diffContent.content = tag.OriginalText
+	}
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return diffContent, nil
+}
+
+func diffTagSet(baseTags, targetTags []string) *schema.RevisionTagDiff {
+	tagDiff := &schema.RevisionTagDiff{
+		Added:     make([]string, 0),
+		Removed:   make([]string, 0),
+		Unchanged: make([]string, 0),
+	}
+	baseMapping := make(map[string]bool, len(baseTags))
+	for _, tag := range baseTags {
+		baseMapping[tag] = true
+	}
+	targetMapping := make(map[string]bool, len(targetTags))
+	for _, tag := range targetTags {
+		targetMapping[tag] = true
+		if baseMapping[tag] {
+			tagDiff.Unchanged = append(tagDiff.Unchanged, tag)
+		} else {
+			tagDiff.Added = append(tagDiff.Added, tag)
+		}
+	}
+	for _, tag := range baseTags {
+		if !targetMapping[tag] {
+			tagDiff.Removed = append(tagDiff.Removed, tag)
+		}
+	}
+	return tagDiff
+}
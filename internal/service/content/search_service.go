@@ -86,13 +86,16 @@ func (ss *SearchService) Search(ctx context.Context, dto *schema.SearchDTO) (res
 func (ss *SearchService) searchByPlugin(ctx context.Context, finder plugin.Search, cond *schema.SearchCondition, dto *schema.SearchDTO) (resp *schema.SearchResp, err error) {
 	var res []plugin.SearchResult
 	resp = &schema.SearchResp{}
-	if cond.SearchAll() {
-		res, resp.Total, err = finder.SearchContents(ctx, cond.Convert2PluginSearchCond(dto.Page, dto.Size, dto.Order))
-	} else if cond.SearchQuestion() {
-		res, resp.Total, err = finder.SearchQuestions(ctx, cond.Convert2PluginSearchCond(dto.Page, dto.Size, dto.Order))
-	} else if cond.SearchAnswer() {
-		res, resp.Total, err = finder.SearchAnswers(ctx, cond.Convert2PluginSearchCond(dto.Page, dto.Size, dto.Order))
-	}
+	_ = plugin.TraceCall(ctx, finder, func(ctx context.Context) error {
+		if cond.SearchAll() {
+			res, resp.Total, err = finder.SearchContents(ctx, cond.Convert2PluginSearchCond(dto.Page, dto.Size, dto.Order))
+		} else if cond.SearchQuestion() {
+			res, resp.Total, err = finder.SearchQuestions(ctx, cond.Convert2PluginSearchCond(dto.Page, dto.Size, dto.Order))
+		} else if cond.SearchAnswer() {
+			res, resp.Total, err = finder.SearchAnswers(ctx, cond.Convert2PluginSearchCond(dto.Page, dto.Size, dto.Order))
+		}
+		return err
+	})
 
 	resp.SearchResults, err = ss.searchRepo.ParseSearchPluginResult(ctx, res, cond.Words)
 	return resp, err
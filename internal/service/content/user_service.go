@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/external_profile"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
@@ -45,6 +46,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/internal/service/user_external_login"
+	"github.com/apache/incubator-answer/internal/service/user_suspension"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/google/uuid"
@@ -68,6 +70,8 @@ type UserService struct {
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
 	questionService               *questioncommon.QuestionCommon
 	eventQueueService             event_queue.EventQueueService
+	externalProfileService        *external_profile.ExternalProfileService
+	userSuspensionService         *user_suspension.UserSuspensionService
 }
 
 func NewUserService(userRepo usercommon.UserRepo,
@@ -83,6 +87,8 @@ func NewUserService(userRepo usercommon.UserRepo,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
 	questionService *questioncommon.QuestionCommon,
 	eventQueueService event_queue.EventQueueService,
+	externalProfileService *external_profile.ExternalProfileService,
+	userSuspensionService *user_suspension.UserSuspensionService,
 ) *UserService {
 	return &UserService{
 		userCommonService:             userCommonService,
@@ -98,6 +104,8 @@ func NewUserService(userRepo usercommon.UserRepo,
 		userNotificationConfigService: userNotificationConfigService,
 		questionService:               questionService,
 		eventQueueService:             eventQueueService,
+		externalProfileService:        externalProfileService,
+		userSuspensionService:         userSuspensionService,
 	}
 }
 
@@ -124,9 +132,22 @@ func (us *UserService) GetUserInfoByUserID(ctx context.Context, token, userID st
 	resp.Avatar = us.siteInfoService.FormatAvatar(ctx, userInfo.Avatar, userInfo.EMail, userInfo.Status)
 	resp.AccessToken = token
 	resp.HavePassword = len(userInfo.Pass) > 0
+	resp.Suspension = us.getUserSuspensionInfo(ctx, userInfo)
 	return resp, nil
 }
 
+// getUserSuspensionInfo get the active suspension shown to the suspended user, nil if the user is not suspended
+func (us *UserService) getUserSuspensionInfo(ctx context.Context, userInfo *entity.User) *schema.UserSuspensionInfo {
+	if userInfo.Status != entity.UserStatusSuspended {
+		return nil
+	}
+	info, err := us.userSuspensionService.GetUserSuspensionInfo(ctx, userInfo.ID)
+	if err != nil {
+		log.Error(err)
+	}
+	return info
+}
+
 func (us *UserService) GetOtherUserInfoByUsername(ctx context.Context, req *schema.GetOtherUserInfoByUsernameReq) (
 	resp *schema.GetOtherUserInfoByUsernameResp, err error) {
 	userInfo, exist, err := us.userRepo.GetByUsername(ctx, req.Username)
@@ -140,21 +161,10 @@ func (us *UserService) GetOtherUserInfoByUsername(ctx context.Context, req *sche
 	resp.ConvertFromUserEntity(userInfo)
 	resp.Avatar = us.siteInfoService.FormatAvatar(ctx, userInfo.Avatar, userInfo.EMail, userInfo.Status).GetURL()
 
-	externalLoginInfoList, err := us.userExternalLoginService.GetExternalLoginUserInfoList(ctx, userInfo.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, info := range externalLoginInfoList {
-		if info.Provider == "basic" {
-			var mixinUserInfoResponse entity.MixinUserInfoResponse
-			err = json.Unmarshal([]byte(info.MetaInfo), &mixinUserInfoResponse)
-			if err != nil {
-				return nil, err
-			}
-			resp.MemberShip = mixinUserInfoResponse.Data.Membership
-			break
-		}
+	resp.MemberShip = us.externalProfileService.GetExternalProfile(ctx, userInfo.ID).GetMembership()
+	// Only the user himself and the administrator can see the suspension
+	if req.UserID == userInfo.ID || req.IsAdmin {
+		resp.Suspension = us.getUserSuspensionInfo(ctx, userInfo)
 	}
 	// Only the user himself and the administrator can see the hidden questions
 	questionCount, err := us.questionService.GetPersonalUserQuestionCount(ctx, req.UserID, userInfo.ID, req.IsAdmin)
@@ -205,6 +215,7 @@ func (us *UserService) EmailLogin(ctx context.Context, req *schema.UserEmailLogi
 	resp = &schema.UserLoginResp{}
 	resp.ConvertFromUserEntity(userInfo)
 	resp.Avatar = us.siteInfoService.FormatAvatar(ctx, userInfo.Avatar, userInfo.EMail, userInfo.Status).GetURL()
+	resp.Suspension = us.getUserSuspensionInfo(ctx, userInfo)
 	userCacheInfo := &entity.UserCacheInfo{
 		UserID:      userInfo.ID,
 		EmailStatus: userInfo.MailStatus,
@@ -460,6 +471,7 @@ func (us *UserService) UserRegisterByEmail(ctx context.Context, registerUserInfo
 	if err != nil {
 		return nil, nil, err
 	}
+	us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserRegister, userInfo.ID).OID(userInfo.ID))
 	if err := us.userNotificationConfigService.SetDefaultUserNotificationConfig(ctx, []string{userInfo.ID}); err != nil {
 		log.Errorf("set default user notification config failed, err: %v", err)
 	}
@@ -931,35 +943,29 @@ func (us *UserService) warpStatRankingResp(
 		Staffs:                     make([]*schema.UserRankingSimpleInfo, 0),
 	}
 
-	allExternalLoginInfo, err := us.userExternalLoginService.ListAllExternalLoginInfo(ctx)
-	if err != nil {
-		log.Debugf("get all external login info failed: %v", err)
+	userIDs := make([]string, 0, len(rankStat)+len(voteStat)+len(userRoleRels))
+	for _, stat := range rankStat {
+		userIDs = append(userIDs, stat.UserID)
 	}
-	allExternalLoginInfoMap := make(map[string]*entity.MixinUserInfo, len(allExternalLoginInfo))
-	for _, info := range allExternalLoginInfo {
-		var mixinUserInfoResponse entity.MixinUserInfoResponse
-		err := json.Unmarshal([]byte(info.MetaInfo), &mixinUserInfoResponse)
-		if err != nil {
-			log.Debugf("unmarshal external login info failed: %v", err)
-		}
-		allExternalLoginInfoMap[info.UserID] = &mixinUserInfoResponse.Data
+	for _, stat := range voteStat {
+		userIDs = append(userIDs, stat.UserID)
 	}
+	for _, rel := range userRoleRels {
+		userIDs = append(userIDs, rel.UserID)
+	}
+	externalProfiles := us.externalProfileService.BatchGetExternalProfiles(ctx, userIDs)
 
 	for _, stat := range rankStat {
 		if stat.Rank <= 0 {
 			continue
 		}
 		if userInfo := userInfoMapping[stat.UserID]; userInfo != nil && userInfo.Status != entity.UserStatusDeleted {
-			membership := entity.Membership{}
-			if mixinUserInfo, ok := allExternalLoginInfoMap[stat.UserID]; ok {
-				membership = mixinUserInfo.Membership
-			}
 			resp.UsersWithTheMostReputation = append(resp.UsersWithTheMostReputation, &schema.UserRankingSimpleInfo{
 				Username:    userInfo.Username,
 				Rank:        stat.Rank,
 				DisplayName: userInfo.DisplayName,
 				Avatar:      userInfo.Avatar,
-				Membership:  membership,
+				Membership:  externalProfiles[stat.UserID].GetMembership(),
 			})
 		}
 	}
@@ -968,31 +974,23 @@ func (us *UserService) warpStatRankingResp(
 			continue
 		}
 		if userInfo := userInfoMapping[stat.UserID]; userInfo != nil && userInfo.Status != entity.UserStatusDeleted {
-			membership := entity.Membership{}
-			if mixinUserInfo, ok := allExternalLoginInfoMap[stat.UserID]; ok {
-				membership = mixinUserInfo.Membership
-			}
 			resp.UsersWithTheMostVote = append(resp.UsersWithTheMostVote, &schema.UserRankingSimpleInfo{
 				Username:    userInfo.Username,
 				VoteCount:   stat.VoteCount,
 				DisplayName: userInfo.DisplayName,
 				Avatar:      userInfo.Avatar,
-				Membership:  membership,
+				Membership:  externalProfiles[stat.UserID].GetMembership(),
 			})
 		}
 	}
 	for _, rel := range userRoleRels {
 		if userInfo := userInfoMapping[rel.UserID]; userInfo != nil && userInfo.Status != entity.UserStatusDeleted {
-			membership := entity.Membership{}
-			if mixinUserInfo, ok := allExternalLoginInfoMap[rel.UserID]; ok {
-				membership = mixinUserInfo.Membership
-			}
 			resp.Staffs = append(resp.Staffs, &schema.UserRankingSimpleInfo{
 				Username:    userInfo.Username,
 				Rank:        userInfo.Rank,
 				DisplayName: userInfo.DisplayName,
 				Avatar:      userInfo.Avatar,
-				Membership:  membership,
+				Membership:  externalProfiles[rel.UserID].GetMembership(),
 			})
 		}
 	}
@@ -0,0 +1,254 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package draft
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/object_info"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/internal/service/revision"
+	"github.com/apache/incubator-answer/internal/service/space"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// DraftRepo draft repository
+type DraftRepo interface {
+	SaveDraft(ctx context.Context, draft *entity.Draft) (err error)
+	GetDraft(ctx context.Context, userID, draftType, objectID string) (draft *entity.Draft, exist bool, err error)
+	RemoveDraft(ctx context.Context, userID, draftType, objectID string) (err error)
+	GetDraftPage(ctx context.Context, userID string, page, pageSize int) (drafts []*entity.Draft, total int64, err error)
+	RemoveExpiredDrafts(ctx context.Context, before time.Time) (count int64, err error)
+}
+
+// DraftService draft service
+type DraftService struct {
+	draftRepo         DraftRepo
+	revisionRepo      revision.RevisionRepo
+	objectInfoService *object_info.ObjService
+	spaceService      *space.SpaceService
+	rankService       *rank.RankService
+}
+
+// NewDraftService new draft service
+func NewDraftService(
+	draftRepo DraftRepo,
+	revisionRepo revision.RevisionRepo,
+	objectInfoService *object_info.ObjService,
+	spaceService *space.SpaceService,
+	rankService *rank.RankService,
+) *DraftService {
+	return &DraftService{
+		draftRepo:         draftRepo,
+		revisionRepo:      revisionRepo,
+		objectInfoService: objectInfoService,
+		spaceService:      spaceService,
+		rankService:       rankService,
+	}
+}
+
+// SaveDraft save the draft of the context, the base revision of the existing draft is kept
+// unless the new one is given
+func (ds *DraftService) SaveDraft(ctx context.Context, req *schema.SaveDraftReq) (resp *schema.DraftInfo, err error) {
+	if err = ds.checkDraftContext(ctx, &req.DraftContext); err != nil {
+		return nil, err
+	}
+	latestRevisionID, err := ds.getLatestRevisionID(ctx, req.DraftType, req.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	draft, exist, err := ds.draftRepo.GetDraft(ctx, req.UserID, req.DraftType, req.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		draft = &entity.Draft{UserID: req.UserID, DraftType: req.DraftType, ObjectID: req.ObjectID}
+	}
+	draft.Title = req.Title
+	draft.OriginalText = req.Content
+	draft.Tags = ""
+	if len(req.Tags) > 0 {
+		tags, _ := json.Marshal(req.Tags)
+		draft.Tags = string(tags)
+	}
+	if len(req.BaseRevisionID) > 0 {
+		draft.BaseRevisionID = req.BaseRevisionID
+	} else if !exist {
+		draft.BaseRevisionID = latestRevisionID
+	}
+	if err = ds.draftRepo.SaveDraft(ctx, draft); err != nil {
+		return nil, err
+	}
+	return ds.formatDraft(ctx, draft, latestRevisionID), nil
+}
+
+// GetDraft get the draft of the context, nil if there is no draft
+func (ds *DraftService) GetDraft(ctx context.Context, req *schema.GetDraftReq) (resp *schema.DraftInfo, err error) {
+	ds.formatDraftContext(&req.DraftContext)
+	draft, exist, err := ds.draftRepo.GetDraft(ctx, req.UserID, req.DraftType, req.ObjectID)
+	if err != nil || !exist {
+		return nil, err
+	}
+	latestRevisionID, err := ds.getLatestRevisionID(ctx, draft.DraftType, draft.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	return ds.formatDraft(ctx, draft, latestRevisionID), nil
+}
+
+// RemoveDraft remove the draft of the context
+func (ds *DraftService) RemoveDraft(ctx context.Context, req *schema.RemoveDraftReq) (err error) {
+	ds.formatDraftContext(&req.DraftContext)
+	return ds.draftRepo.RemoveDraft(ctx, req.UserID, req.DraftType, req.ObjectID)
+}
+
+// RemovePublishedDraft remove the draft after the content is published, the error is only logged
+// because the content has been published successfully
+func (ds *DraftService) RemovePublishedDraft(ctx context.Context, userID, draftType, objectID string) {
+	req := &schema.RemoveDraftReq{
+		DraftContext: schema.DraftContext{UserID: userID, DraftType: draftType, ObjectID: objectID},
+	}
+	if err := ds.RemoveDraft(ctx, req); err != nil {
+		log.Errorf("remove published draft failed: %v", err)
+	}
+}
+
+// GetDraftPage get the drafts of the user, the latest updated first
+func (ds *DraftService) GetDraftPage(ctx context.Context, req *schema.GetDraftPageReq) (
+	pageModel *pager.PageModel, err error) {
+	drafts, total, err := ds.draftRepo.GetDraftPage(ctx, req.UserID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*schema.DraftInfo, 0, len(drafts))
+	for _, draft := range drafts {
+		latestRevisionID, err := ds.getLatestRevisionID(ctx, draft.DraftType, draft.ObjectID)
+		if err != nil {
+			log.Error(err)
+		}
+		list = append(list, ds.formatDraft(ctx, draft, latestRevisionID))
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// RemoveExpiredDrafts remove the drafts that are not updated for a long time
+func (ds *DraftService) RemoveExpiredDrafts(ctx context.Context) {
+	count, err := ds.draftRepo.RemoveExpiredDrafts(ctx, time.Now().Add(-schema.DraftExpireDuration))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if count > 0 {
+		log.Infof("removed %d expired drafts", count)
+	}
+}
+
+func (ds *DraftService) formatDraftContext(draftCtx *schema.DraftContext) {
+	if draftCtx.DraftType == entity.DraftTypeQuestion {
+		draftCtx.ObjectID = "0"
+		return
+	}
+	draftCtx.ObjectID = uid.DeShortID(draftCtx.ObjectID)
+}
+
+// checkDraftContext the answer draft must be for a question, and the edit draft must be for a question or answer.
+// The user must be able to access the question, and be able to edit the post for the edit draft.
+func (ds *DraftService) checkDraftContext(ctx context.Context, draftCtx *schema.DraftContext) (err error) {
+	ds.formatDraftContext(draftCtx)
+	if draftCtx.DraftType == entity.DraftTypeQuestion {
+		return nil
+	}
+	if len(draftCtx.ObjectID) == 0 || draftCtx.ObjectID == "0" {
+		return errors.BadRequest(reason.ObjectNotFound)
+	}
+	objInfo, err := ds.objectInfoService.GetInfo(ctx, draftCtx.ObjectID)
+	if err != nil {
+		return err
+	}
+	if objInfo.IsDeleted() {
+		return errors.BadRequest(reason.ObjectNotFound)
+	}
+	var editAction string
+	switch {
+	case draftCtx.DraftType == entity.DraftTypeAnswer && objInfo.ObjectType == constant.QuestionObjectType:
+	case draftCtx.DraftType == entity.DraftTypeEdit && objInfo.ObjectType == constant.QuestionObjectType:
+		editAction = permission.QuestionEdit
+	case draftCtx.DraftType == entity.DraftTypeEdit && objInfo.ObjectType == constant.AnswerObjectType:
+		editAction = permission.AnswerEdit
+	default:
+		return errors.BadRequest(reason.ObjectNotFound)
+	}
+	// the question in the space that the user can not access is not found, whatever the draft type is
+	if err = ds.spaceService.CheckQuestionAccess(ctx, objInfo.QuestionID); err != nil {
+		return err
+	}
+	if len(editAction) == 0 {
+		return nil
+	}
+	can, err := ds.rankService.CheckOperationPermission(ctx, draftCtx.UserID, editAction, draftCtx.ObjectID)
+	if err != nil {
+		return err
+	}
+	if !can {
+		return errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+	return nil
+}
+
+// getLatestRevisionID the answer draft is based on the question, and the edit draft is based on the edited post
+func (ds *DraftService) getLatestRevisionID(ctx context.Context, draftType, objectID string) (
+	revisionID string, err error) {
+	if draftType == entity.DraftTypeQuestion {
+		return "0", nil
+	}
+	revisionInfo, exist, err := ds.revisionRepo.GetLastRevisionByObjectID(ctx, objectID)
+	if err != nil {
+		return "", err
+	}
+	if !exist {
+		return "0", nil
+	}
+	return revisionInfo.ID, nil
+}
+
+func (ds *DraftService) formatDraft(ctx context.Context, draft *entity.Draft, latestRevisionID string) *schema.DraftInfo {
+	info := &schema.DraftInfo{}
+	info.ConvertFromEntity(draft)
+	info.LatestRevisionID = latestRevisionID
+	info.Conflict = len(latestRevisionID) > 0 && latestRevisionID != "0" && latestRevisionID != draft.BaseRevisionID
+	if draft.DraftType == entity.DraftTypeQuestion {
+		info.ObjectID = ""
+	} else if handler.GetEnableShortID(ctx) {
+		info.ObjectID = uid.EnShortID(info.ObjectID)
+	}
+	return info
+}
@@ -22,6 +22,7 @@ package event_queue
 import (
 	"context"
 
+	"github.com/apache/incubator-answer/internal/base/metrics"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/segmentfault/pacman/log"
 )
@@ -29,17 +30,25 @@ import (
 type EventQueueService interface {
 	Send(ctx context.Context, msg *schema.EventMsg)
 	RegisterHandler(handler func(ctx context.Context, msg *schema.EventMsg) error)
+	// SendToPlugins only delivers the event to the event listener plugins without blocking.
+	// It is used by the handler of the queue, which can't send the event to the queue itself.
+	SendToPlugins(ctx context.Context, msg *schema.EventMsg)
 }
 
 type eventQueueService struct {
-	Queue   chan *schema.EventMsg
-	Handler func(ctx context.Context, msg *schema.EventMsg) error
+	Queue            chan *schema.EventMsg
+	Handler          func(ctx context.Context, msg *schema.EventMsg) error
+	pluginDispatcher *pluginEventDispatcher
 }
 
 func (ns *eventQueueService) Send(ctx context.Context, msg *schema.EventMsg) {
 	ns.Queue <- msg
 }
 
+func (ns *eventQueueService) SendToPlugins(ctx context.Context, msg *schema.EventMsg) {
+	ns.pluginDispatcher.dispatch(msg)
+}
+
 func (ns *eventQueueService) RegisterHandler(
 	handler func(ctx context.Context, msg *schema.EventMsg) error) {
 	ns.Handler = handler
@@ -49,11 +58,13 @@ func (ns *eventQueueService) working() {
 	go func() {
 		for msg := range ns.Queue {
 			log.Debugf("received badge %+v", msg)
+			ns.pluginDispatcher.dispatch(msg)
 			if ns.Handler == nil {
 				log.Warnf("no handler for badge")
 				continue
 			}
 			if err := ns.Handler(context.Background(), msg); err != nil {
+				metrics.ObserveQueueHandlerError("event")
 				log.Error(err)
 			}
 		}
@@ -62,8 +73,9 @@ func (ns *eventQueueService) working() {
 
 // NewEventQueueService create a new badge queue service
 func NewEventQueueService() EventQueueService {
-	ns := &eventQueueService{}
+	ns := &eventQueueService{pluginDispatcher: newPluginEventDispatcher()}
 	ns.Queue = make(chan *schema.EventMsg, 128)
+	metrics.RegisterQueue("event", cap(ns.Queue), func() int { return len(ns.Queue) })
 	ns.working()
 	return ns
 }
@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package event_queue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/metrics"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	pluginEventQueueSize = 128
+	pluginEventTimeout   = 30 * time.Second
+)
+
+// pluginEventDispatcher dispatches the events to the event listener plugins.
+// Each plugin has its own queue and worker, so a slow or panicking plugin can't block the core queue or other plugins.
+type pluginEventDispatcher struct {
+	lock   sync.Mutex
+	queues map[string]chan *plugin.Event
+}
+
+func newPluginEventDispatcher() *pluginEventDispatcher {
+	return &pluginEventDispatcher{queues: make(map[string]chan *plugin.Event)}
+}
+
+// dispatch delivers the event to all enabled event listener plugins, it never blocks.
+// Each plugin receives its own copy of the event, so a plugin modifying the event can't affect the others.
+func (d *pluginEventDispatcher) dispatch(msg *schema.EventMsg) {
+	source := convertToPluginEvent(msg)
+	_ = plugin.CallEventListener(func(listener plugin.EventListener) error {
+		slugName := listener.Info().SlugName
+		event := copyPluginEvent(source)
+		select {
+		case d.getQueue(listener) <- event:
+		default:
+			log.Warnf("event queue of plugin %s is full, drop event %s", slugName, event.Type)
+		}
+		return nil
+	})
+}
+
+func (d *pluginEventDispatcher) getQueue(listener plugin.EventListener) chan *plugin.Event {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	slugName := listener.Info().SlugName
+	if queue, ok := d.queues[slugName]; ok {
+		return queue
+	}
+	queue := make(chan *plugin.Event, pluginEventQueueSize)
+	d.queues[slugName] = queue
+	go func() {
+		for event := range queue {
+			handlePluginEvent(listener, event)
+		}
+	}()
+	return queue
+}
+
+func handlePluginEvent(listener plugin.EventListener, event *plugin.Event) {
+	slugName := listener.Info().SlugName
+	start := time.Now()
+	var err error
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+		if err != nil {
+			log.Errorf("plugin %s handle event %s failed: %v", slugName, event.Type, err)
+		}
+		metrics.ObservePluginCall("EventListener", slugName, time.Since(start), err)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), pluginEventTimeout)
+	defer cancel()
+	err = plugin.TraceCall(ctx, listener, func(ctx context.Context) error {
+		return listener.OnEvent(ctx, event)
+	})
+}
+
+// copyPluginEvent copy the event including its extra map
+func copyPluginEvent(event *plugin.Event) *plugin.Event {
+	copied := *event
+	copied.Extra = make(map[string]string, len(event.Extra))
+	for k, v := range event.Extra {
+		copied.Extra[k] = v
+	}
+	return &copied
+}
+
+func convertToPluginEvent(msg *schema.EventMsg) *plugin.Event {
+	extra := make(map[string]string, len(msg.ExtraInfo))
+	for k, v := range msg.ExtraInfo {
+		extra[k] = v
+	}
+	return &plugin.Event{
+		Type:           plugin.EventType(msg.EventType),
+		UserID:         msg.UserID,
+		ObjectID:       msg.GetObjectID(),
+		QuestionID:     msg.QuestionID,
+		QuestionUserID: msg.QuestionUserID,
+		AnswerID:       msg.AnswerID,
+		AnswerUserID:   msg.AnswerUserID,
+		CommentID:      msg.CommentID,
+		CommentUserID:  msg.CommentUserID,
+		Extra:          extra,
+		CreatedAt:      time.Now(),
+	}
+}
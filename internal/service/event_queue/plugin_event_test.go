@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package event_queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEventListener struct {
+	slugName string
+	events   chan *plugin.Event
+	panic    bool
+}
+
+func (l *testEventListener) Info() plugin.Info {
+	return plugin.Info{SlugName: l.slugName}
+}
+
+func (l *testEventListener) OnEvent(_ context.Context, event *plugin.Event) error {
+	if l.panic {
+		panic("listener panic")
+	}
+	l.events <- event
+	return nil
+}
+
+func TestPluginEventDispatcher(t *testing.T) {
+	panicListener := &testEventListener{slugName: "test_panic_listener", panic: true}
+	listener := &testEventListener{slugName: "test_listener", events: make(chan *plugin.Event, 2)}
+	disabledListener := &testEventListener{slugName: "test_disabled_listener", events: make(chan *plugin.Event, 2)}
+	plugin.Register(panicListener)
+	plugin.Register(listener)
+	plugin.Register(disabledListener)
+	plugin.StatusManager.Enable(panicListener.slugName, true)
+	plugin.StatusManager.Enable(listener.slugName, true)
+
+	d := newPluginEventDispatcher()
+	d.dispatch(schema.NewEvent(constant.EventQuestionCreate, "1").TID("10010000000000001").
+		QID("10010000000000001", "2"))
+	d.dispatch(schema.NewEvent(constant.EventUserSuspend, "1").OID("3"))
+
+	for _, expected := range []struct {
+		eventType plugin.EventType
+		objectID  string
+	}{
+		{plugin.EventQuestionCreate, "10010000000000001"},
+		{plugin.EventUserSuspend, "3"},
+	} {
+		select {
+		case event := <-listener.events:
+			assert.Equal(t, expected.eventType, event.Type)
+			assert.Equal(t, expected.objectID, event.ObjectID)
+			assert.Equal(t, "1", event.UserID)
+		case <-time.After(time.Second):
+			t.Fatalf("event %s not received", expected.eventType)
+		}
+	}
+	assert.Len(t, disabledListener.events, 0)
+}
+
+func TestCopyPluginEvent(t *testing.T) {
+	event := &plugin.Event{Type: plugin.EventQuestionCreate, ObjectID: "1", Extra: map[string]string{"key": "value"}}
+	copied := copyPluginEvent(event)
+	copied.ObjectID = "2"
+	copied.Extra["key"] = "changed"
+	copied.Extra["new"] = "value"
+
+	assert.Equal(t, "1", event.ObjectID)
+	assert.Equal(t, map[string]string{"key": "value"}, event.Extra)
+	assert.Equal(t, plugin.EventQuestionCreate, copied.Type)
+}
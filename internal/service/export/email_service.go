@@ -31,6 +31,7 @@ import (
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/metrics"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/schema"
@@ -148,6 +149,7 @@ func (es *EmailService) Send(ctx context.Context, toEmailAddr, subject, body str
 		d.TLSConfig = &tls.Config{ServerName: d.Host, InsecureSkipVerify: true}
 	}
 	if err := d.DialAndSend(m); err != nil {
+		metrics.ObserveEmailSendFailure()
 		log.Errorf("send email to %s failed: %s", toEmailAddr, err)
 	} else {
 		log.Infof("send email to %s success", toEmailAddr)
@@ -301,6 +303,27 @@ func (es *EmailService) NewCommentTemplate(ctx context.Context, raw *schema.NewC
 	return title, body, nil
 }
 
+// NotificationTemplate the template of the inbox notifications that have no templates of their own
+func (es *EmailService) NotificationTemplate(ctx context.Context, raw *schema.NotificationTemplateRawData) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.NotificationTemplateData{
+		SiteName:       siteInfo.Name,
+		Description:    raw.Description,
+		Title:          raw.Title,
+		Url:            raw.Url,
+		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeyNotificationTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyNotificationBody, templateData)
+	return title, body, nil
+}
+
 // NewQuestionTemplate new question template
 func (es *EmailService) NewQuestionTemplate(ctx context.Context, raw *schema.NewQuestionTemplateRawData) (
 	title, body string, err error) {
@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package external_profile
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/incubator-answer/internal/base/tracing"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/checker"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ExternalProfileRepo external profile repository
+type ExternalProfileRepo interface {
+	GetUserExternalLoginListByUserIDs(ctx context.Context, userIDs []string) (resp []*entity.UserExternalLogin, err error)
+	GetCacheExternalProfile(ctx context.Context, userID string) (profile *schema.ExternalProfile, exist bool, err error)
+	SetCacheExternalProfile(ctx context.Context, userID string, profile *schema.ExternalProfile) (err error)
+}
+
+// ExternalProfileService the profile of users decoded from the meta info of external logins
+type ExternalProfileService struct {
+	externalProfileRepo ExternalProfileRepo
+}
+
+// NewExternalProfileService new external profile service
+func NewExternalProfileService(externalProfileRepo ExternalProfileRepo) *ExternalProfileService {
+	return &ExternalProfileService{
+		externalProfileRepo: externalProfileRepo,
+	}
+}
+
+// BatchGetExternalProfiles get the external profiles of users, the users without any external login are not in the map.
+// The profiles are cached, so only the users that are not in the cache are loaded from the database.
+// The cache is removed by the user external login repository when the external logins are changed.
+func (es *ExternalProfileService) BatchGetExternalProfiles(ctx context.Context, userIDs []string) (
+	profiles map[string]*schema.ExternalProfile) {
+	ctx, span := tracing.Start(ctx, "ExternalProfileService.BatchGetExternalProfiles")
+	defer span.End()
+
+	profiles = make(map[string]*schema.ExternalProfile, len(userIDs))
+	missingIDs := make([]string, 0)
+	handled := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if handled[userID] || !checker.IsNotZeroString(userID) {
+			continue
+		}
+		handled[userID] = true
+		profile, exist, err := es.externalProfileRepo.GetCacheExternalProfile(ctx, userID)
+		if err != nil {
+			log.Errorf("get external profile cache failed: %v", err)
+		}
+		if !exist {
+			missingIDs = append(missingIDs, userID)
+			continue
+		}
+		if len(profile.Providers) > 0 {
+			profiles[userID] = profile
+		}
+	}
+	if len(missingIDs) == 0 {
+		return profiles
+	}
+
+	externalLoginList, err := es.externalProfileRepo.GetUserExternalLoginListByUserIDs(ctx, missingIDs)
+	if err != nil {
+		log.Errorf("get external login info failed: %v", err)
+		return profiles
+	}
+	loaded := make(map[string]*schema.ExternalProfile, len(missingIDs))
+	for _, info := range externalLoginList {
+		profile := loaded[info.UserID]
+		if profile == nil {
+			profile = &schema.ExternalProfile{}
+			loaded[info.UserID] = profile
+		}
+		mergeExternalProfile(profile, info)
+	}
+	// the users without any external login are cached as well, so that they will not be queried again
+	for _, userID := range missingIDs {
+		profile := loaded[userID]
+		if profile == nil {
+			profile = &schema.ExternalProfile{}
+		} else {
+			profiles[userID] = profile
+		}
+		if err := es.externalProfileRepo.SetCacheExternalProfile(ctx, userID, profile); err != nil {
+			log.Errorf("set external profile cache failed: %v", err)
+		}
+	}
+	return profiles
+}
+
+// GetExternalProfile get the external profile of the user, it returns nil if the user has no external login
+func (es *ExternalProfileService) GetExternalProfile(ctx context.Context, userID string) (profile *schema.ExternalProfile) {
+	return es.BatchGetExternalProfiles(ctx, []string{userID})[userID]
+}
+
+// externalProfileMeta the fields of the meta info used to build the profile.
+// Some connectors wrap the user info in data, such as Mixin, so the fields are read from data as well.
+type externalProfileMeta struct {
+	Data       *externalProfileMeta `json:"data"`
+	Membership *entity.Membership   `json:"membership"`
+	IsVerified bool                 `json:"is_verified"`
+	Verified   bool                 `json:"verified"`
+}
+
+// mergeExternalProfile merges the meta info of the external login into the profile
+func mergeExternalProfile(profile *schema.ExternalProfile, info *entity.UserExternalLogin) {
+	profile.Providers = append(profile.Providers, info.Provider)
+	if len(info.MetaInfo) == 0 {
+		return
+	}
+	meta := &externalProfileMeta{}
+	if err := json.Unmarshal([]byte(info.MetaInfo), meta); err != nil {
+		log.Debugf("unmarshal external login meta info of provider %s failed: %v", info.Provider, err)
+	}
+	for ; meta != nil; meta = meta.Data {
+		if meta.Membership != nil && len(meta.Membership.Plan) > 0 && len(profile.Membership.Plan) == 0 {
+			profile.Membership = *meta.Membership
+		}
+		profile.Verified = profile.Verified || meta.IsVerified || meta.Verified
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package external_profile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeExternalProfileRepo struct {
+	logins  []*entity.UserExternalLogin
+	cache   map[string]*schema.ExternalProfile
+	queried [][]string
+}
+
+func (f *fakeExternalProfileRepo) GetUserExternalLoginListByUserIDs(_ context.Context, userIDs []string) (
+	resp []*entity.UserExternalLogin, err error) {
+	f.queried = append(f.queried, userIDs)
+	for _, login := range f.logins {
+		for _, userID := range userIDs {
+			if login.UserID == userID {
+				resp = append(resp, login)
+			}
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeExternalProfileRepo) GetCacheExternalProfile(_ context.Context, userID string) (
+	profile *schema.ExternalProfile, exist bool, err error) {
+	profile, exist = f.cache[userID]
+	return profile, exist, nil
+}
+
+func (f *fakeExternalProfileRepo) SetCacheExternalProfile(_ context.Context, userID string,
+	profile *schema.ExternalProfile) (err error) {
+	f.cache[userID] = profile
+	return nil
+}
+
+func TestBatchGetExternalProfiles(t *testing.T) {
+	repo := &fakeExternalProfileRepo{
+		logins: []*entity.UserExternalLogin{
+			{UserID: "1", Provider: "mixin", MetaInfo: `{"data":{"is_verified":true,"membership":{"plan":"advance"}}}`},
+			{UserID: "2", Provider: "github", MetaInfo: `{"login":"octocat"}`},
+			{UserID: "2", Provider: "custom", MetaInfo: `{"verified":true,"membership":{"plan":"basic"}}`},
+			{UserID: "4", Provider: "broken", MetaInfo: `{`},
+		},
+		cache: make(map[string]*schema.ExternalProfile),
+	}
+	es := NewExternalProfileService(repo)
+
+	profiles := es.BatchGetExternalProfiles(context.TODO(), []string{"1", "2", "3", "4", "1", "0", ""})
+	assert.Len(t, repo.queried, 1)
+	assert.ElementsMatch(t, []string{"1", "2", "3", "4"}, repo.queried[0])
+
+	assert.Equal(t, "advance", profiles["1"].GetMembership().Plan)
+	assert.True(t, profiles["1"].IsVerified())
+	assert.Equal(t, []string{"github", "custom"}, profiles["2"].Providers)
+	assert.Equal(t, "basic", profiles["2"].GetMembership().Plan)
+	assert.True(t, profiles["2"].IsVerified())
+	assert.Equal(t, []string{"broken"}, profiles["4"].Providers)
+	assert.False(t, profiles["4"].IsVerified())
+
+	// the user without any external login is not in the result, but it is cached
+	assert.NotContains(t, profiles, "3")
+	assert.Equal(t, "", profiles["3"].GetMembership().Plan)
+	assert.Contains(t, repo.cache, "3")
+
+	profiles = es.BatchGetExternalProfiles(context.TODO(), []string{"1", "3"})
+	assert.Len(t, repo.queried, 1)
+	assert.Equal(t, "advance", profiles["1"].GetMembership().Plan)
+	assert.NotContains(t, profiles, "3")
+}
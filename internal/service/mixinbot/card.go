@@ -0,0 +1,21 @@
+package mixinbot
+
+import (
+	"context"
+)
+
+// CardObject the object that the notification card is sent for, it is used to handle the reply of the card
+type CardObject struct {
+	QuestionID     string `json:"question_id"`
+	QuestionTitle  string `json:"question_title"`
+	AnswerID       string `json:"answer_id"`
+	CommentID      string `json:"comment_id"`
+	ReceiverUserID string `json:"receiver_user_id"`
+}
+
+type MixinBotRepo interface {
+	SetCardObject(ctx context.Context, messageID string, object *CardObject) (err error)
+	GetCardObject(ctx context.Context, messageID string) (object *CardObject, exist bool, err error)
+	// CheckAndRecordMessage returns true if the message has been recorded before
+	CheckAndRecordMessage(ctx context.Context, key string) (duplicate bool, err error)
+}
@@ -0,0 +1,55 @@
+package mixinbotcommand
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	commandHelp    = "/help"
+	commandStart   = "/start"
+	commandFollow  = "/follow"
+	commandMute    = "/mute"
+	commandDigest  = "/digest"
+	commandSearch  = "/search"
+	commandComment = "/comment"
+)
+
+// command the command sent to the bot, the text that is not starts with "/" is not a command
+type command struct {
+	Name string
+	Args string
+}
+
+func parseCommand(text string) *command {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") {
+		return nil
+	}
+	name, args := text, ""
+	if idx := strings.IndexFunc(text, unicode.IsSpace); idx > 0 {
+		name, args = text[:idx], text[idx:]
+	}
+	return &command{
+		Name: strings.ToLower(name),
+		Args: strings.TrimSpace(args),
+	}
+}
+
+var questionLinkRegexp = regexp.MustCompile(`/questions/([0-9A-Za-z]+)`)
+
+// parseQuestionID get the question id from the question link or the question id itself
+func parseQuestionID(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 {
+		return ""
+	}
+	if matches := questionLinkRegexp.FindStringSubmatch(s); len(matches) == 2 {
+		return matches[1]
+	}
+	if strings.ContainsAny(s, "/ ") {
+		return ""
+	}
+	return s
+}
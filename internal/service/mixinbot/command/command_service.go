@@ -0,0 +1,581 @@
+package mixinbotcommand
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/action"
+	"github.com/apache/incubator-answer/internal/service/comment"
+	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/follow"
+	"github.com/apache/incubator-answer/internal/service/mixinbot"
+	mixinbotlang "github.com/apache/incubator-answer/internal/service/mixinbot/lang"
+	"github.com/apache/incubator-answer/internal/service/notification"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/space"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_external_login"
+	"github.com/apache/incubator-answer/pkg/display"
+	"github.com/apache/incubator-answer/pkg/encryption"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/fox-one/mixin-sdk-go/v2"
+	myErrors "github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	mixinProvider  = "basic"
+	reconnectDelay = 5 * time.Second
+	listSize       = 5
+)
+
+// MixinBotCommandService receives the messages sent to the mixin bot,
+// users can reply the notification cards to post content and send commands to the bot
+type MixinBotCommandService struct {
+	mixinBotService       *mixinbot.MixinBotService
+	userExternalLoginRepo user_external_login.UserExternalLoginRepo
+	userCommon            *usercommon.UserCommon
+	rankService           *rank.RankService
+	captchaService        *action.CaptchaService
+	answerService         *content.AnswerService
+	commentService        *comment.CommentService
+	followService         *follow.FollowService
+	tagCommonService      *tagcommon.TagCommonService
+	questionRepo          questioncommon.QuestionRepo
+	searchService         *content.SearchService
+	notificationService   *notification.NotificationService
+	siteInfoService       siteinfo_common.SiteInfoCommonService
+	spaceService          *space.SpaceService
+	langPicker            *mixinbotlang.LangPicker
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func NewMixinBotCommandService(
+	mixinBotService *mixinbot.MixinBotService,
+	userExternalLoginRepo user_external_login.UserExternalLoginRepo,
+	userCommon *usercommon.UserCommon,
+	rankService *rank.RankService,
+	captchaService *action.CaptchaService,
+	answerService *content.AnswerService,
+	commentService *comment.CommentService,
+	followService *follow.FollowService,
+	tagCommonService *tagcommon.TagCommonService,
+	questionRepo questioncommon.QuestionRepo,
+	searchService *content.SearchService,
+	notificationService *notification.NotificationService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	spaceService *space.SpaceService,
+) *MixinBotCommandService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &MixinBotCommandService{
+		mixinBotService:       mixinBotService,
+		userExternalLoginRepo: userExternalLoginRepo,
+		userCommon:            userCommon,
+		rankService:           rankService,
+		captchaService:        captchaService,
+		answerService:         answerService,
+		commentService:        commentService,
+		followService:         followService,
+		tagCommonService:      tagCommonService,
+		questionRepo:          questionRepo,
+		searchService:         searchService,
+		notificationService:   notificationService,
+		siteInfoService:       siteInfoService,
+		spaceService:          spaceService,
+		langPicker:            mixinbotlang.NewLangPicker(),
+		ctx:                   ctx,
+		cancel:                cancel,
+	}
+}
+
+// Enabled whether the bot should receive the messages
+func (cs *MixinBotCommandService) Enabled() bool {
+	return cs.mixinBotService != nil && cs.mixinBotService.Config.EnableCommand
+}
+
+// Start keeps receiving the messages until Shutdown is called, it reconnects when the connection is broken
+func (cs *MixinBotCommandService) Start() error {
+	log.Info("mixin bot command service started")
+	for {
+		err := cs.mixinBotService.Transport.LoopBlaze(cs.ctx, cs)
+		if err != nil && cs.ctx.Err() == nil {
+			log.Errorf("mixin bot blaze loop failed: %v", err)
+		}
+		select {
+		case <-cs.ctx.Done():
+			return nil
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// Shutdown stops receiving the messages
+func (cs *MixinBotCommandService) Shutdown() error {
+	cs.cancel()
+	return nil
+}
+
+func (cs *MixinBotCommandService) OnAckReceipt(ctx context.Context, msg *mixin.MessageView, userID string) error {
+	return nil
+}
+
+// OnMessage handles the message sent to the bot. The error is only logged, otherwise the blaze loop will be broken
+// and the same message will be delivered again.
+func (cs *MixinBotCommandService) OnMessage(ctx context.Context, msg *mixin.MessageView, userID string) error {
+	if msg.Category != mixin.MessageCategoryPlainText || msg.UserID == cs.mixinBotService.Config.ClientID {
+		return nil
+	}
+	text, err := decodeMessageData(msg)
+	if err != nil {
+		log.Debugf("decode mixin message %s failed: %v", msg.MessageID, err)
+		return nil
+	}
+	reply := cs.HandleMessage(ctx, msg.UserID, msg.MessageID, msg.QuoteMessageID, text)
+	if len(reply) == 0 {
+		return nil
+	}
+	err = cs.mixinBotService.SendText(ctx, msg.ConversationID, msg.UserID, reply, msg.MessageID)
+	if err != nil {
+		log.Errorf("reply mixin message %s failed: %v", msg.MessageID, err)
+	}
+	return nil
+}
+
+// HandleMessage handles the text sent by the mixin user and returns the reply, the reply is empty if the message
+// has been handled before.
+func (cs *MixinBotCommandService) HandleMessage(ctx context.Context, mixinUserID, messageID, quoteMessageID, text string) (
+	reply string) {
+	duplicate, err := cs.mixinBotService.Repo.CheckAndRecordMessage(ctx, "id:"+messageID)
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+	if duplicate {
+		return ""
+	}
+
+	lang := cs.getSiteLanguage(ctx)
+	externalLogin, exist, err := cs.userExternalLoginRepo.GetByExternalID(ctx, mixinProvider, mixinUserID)
+	if err != nil {
+		log.Error(err)
+		return cs.replyError(lang, err)
+	}
+	if !exist {
+		return cs.tr(lang, mixinbotlang.CommandReplyNotBound)
+	}
+	userInfo, exist, err := cs.userCommon.GetUserBasicInfoByID(ctx, externalLogin.UserID)
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	if !exist {
+		return cs.tr(lang, mixinbotlang.CommandReplyNotBound)
+	}
+	if len(userInfo.Language) > 0 && userInfo.Language != translator.DefaultLangOption {
+		lang = i18n.Language(userInfo.Language)
+	}
+	if userInfo.Status != constant.UserNormal {
+		return cs.tr(lang, mixinbotlang.CommandReplyForbidden)
+	}
+	ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, lang)
+	// the bot acts as the user, so the user can only access the spaces as in the api
+	spaceAccess, err := cs.spaceService.GetUserSpaceAccess(ctx, userInfo.ID)
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	ctx = context.WithValue(ctx, constant.SpaceAccessFlag, spaceAccess)
+
+	// the same content sent again in a short time is ignored, just like the duplicate request rejection of the api
+	duplicate, err = cs.mixinBotService.Repo.CheckAndRecordMessage(ctx,
+		encryption.MD5(fmt.Sprintf("%s:%s:%s", userInfo.ID, quoteMessageID, strings.TrimSpace(text))))
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	if duplicate {
+		return cs.tr(lang, mixinbotlang.CommandReplyDuplicate)
+	}
+
+	cmd := parseCommand(text)
+	if len(quoteMessageID) > 0 && (cmd == nil || cmd.Name == commandComment || cmd.Name == commandMute) {
+		card, exist, err := cs.mixinBotService.Repo.GetCardObject(ctx, quoteMessageID)
+		if err != nil {
+			return cs.replyError(lang, err)
+		}
+		if !exist || (len(card.ReceiverUserID) > 0 && card.ReceiverUserID != userInfo.ID) {
+			return cs.tr(lang, mixinbotlang.CommandReplyCardNotFound)
+		}
+		switch {
+		case cmd == nil:
+			return cs.replyCard(ctx, lang, userInfo.ID, card, text, false)
+		case cmd.Name == commandComment:
+			return cs.replyCard(ctx, lang, userInfo.ID, card, cmd.Args, true)
+		case len(cmd.Args) == 0:
+			return cs.mute(ctx, lang, userInfo.ID, card.QuestionID)
+		}
+	}
+	if cmd == nil {
+		return cs.tr(lang, mixinbotlang.CommandReplyHelp)
+	}
+
+	switch cmd.Name {
+	case commandHelp, commandStart:
+		return cs.tr(lang, mixinbotlang.CommandReplyHelp)
+	case commandFollow:
+		return cs.followTag(ctx, lang, userInfo.ID, cmd.Args)
+	case commandMute:
+		return cs.mute(ctx, lang, userInfo.ID, parseQuestionID(cmd.Args))
+	case commandDigest:
+		return cs.digest(ctx, lang, userInfo.ID)
+	case commandSearch:
+		return cs.search(ctx, lang, userInfo.ID, cmd.Args)
+	default:
+		return cs.tr(lang, mixinbotlang.CommandReplyUnknown)
+	}
+}
+
+// replyCard posts the text as the answer of the question, or as the comment of the object of the card
+func (cs *MixinBotCommandService) replyCard(ctx context.Context, lang i18n.Language, userID string,
+	card *mixinbot.CardObject, text string, asComment bool) string {
+	questionID := uid.DeShortID(card.QuestionID)
+	if len(questionID) == 0 {
+		return cs.tr(lang, mixinbotlang.CommandReplyCardNotFound)
+	}
+	if !asComment && len(card.AnswerID) == 0 && len(card.CommentID) == 0 {
+		return cs.addAnswer(ctx, lang, userID, questionID, card.QuestionTitle, text)
+	}
+	answerID := uid.DeShortID(card.AnswerID)
+	req := &schema.AddCommentReq{
+		ObjectID:       questionID,
+		ReplyCommentID: uid.DeShortID(card.CommentID),
+		OriginalText:   text,
+		UserID:         userID,
+	}
+	if len(answerID) > 0 {
+		req.ObjectID = answerID
+	}
+	return cs.addComment(ctx, lang, req, questionID, card.QuestionTitle, answerID)
+}
+
+func (cs *MixinBotCommandService) addAnswer(ctx context.Context, lang i18n.Language,
+	userID, questionID, title, text string) string {
+	req := &schema.AnswerAddReq{
+		QuestionID: questionID,
+		Content:    text,
+		UserID:     userID,
+	}
+	if reply, ok := cs.check(lang, req); !ok {
+		return reply
+	}
+	canList, err := cs.rankService.CheckOperationPermissions(ctx, userID, []string{permission.AnswerAdd})
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	if !canList[0] {
+		return cs.tr(lang, mixinbotlang.CommandReplyNoPermission)
+	}
+	if !cs.captchaService.ValidationStrategy(ctx, userID, entity.CaptchaActionAnswer) {
+		return cs.tr(lang, mixinbotlang.CommandReplyCaptchaRequired, cs.questionURL(ctx, questionID, title))
+	}
+
+	write, err := cs.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	if write.RestrictAnswer {
+		ids, err := cs.answerService.GetCountByUserIDQuestionID(ctx, userID, questionID)
+		if err != nil {
+			return cs.replyError(lang, err)
+		}
+		if len(ids) >= 1 {
+			return cs.replyError(lang, myErrors.Forbidden(reason.AnswerRestrictAnswer))
+		}
+	}
+
+	answerID, err := cs.answerService.Insert(ctx, req)
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	_, _ = cs.captchaService.ActionRecordAdd(ctx, entity.CaptchaActionAnswer, userID)
+
+	answerURL := cs.answerURL(ctx, questionID, title, answerID)
+	info, _, has, err := cs.answerService.Get(ctx, answerID, userID)
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	if has && info.Status == entity.AnswerStatusPending {
+		return cs.tr(lang, mixinbotlang.CommandReplyPendingReview, answerURL)
+	}
+	return cs.tr(lang, mixinbotlang.CommandReplyAnswerPosted, answerURL)
+}
+
+func (cs *MixinBotCommandService) addComment(ctx context.Context, lang i18n.Language,
+	req *schema.AddCommentReq, questionID, title, answerID string) string {
+	if reply, ok := cs.check(lang, req); !ok {
+		return reply
+	}
+	canList, err := cs.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+		permission.CommentAdd,
+		permission.CommentEdit,
+		permission.CommentDelete,
+	})
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	req.CanAdd = canList[0]
+	req.CanEdit = canList[1]
+	req.CanDelete = canList[2]
+	if !req.CanAdd {
+		return cs.tr(lang, mixinbotlang.CommandReplyNoPermission)
+	}
+	if !cs.captchaService.ValidationStrategy(ctx, req.UserID, entity.CaptchaActionComment) {
+		return cs.tr(lang, mixinbotlang.CommandReplyCaptchaRequired, cs.questionURL(ctx, questionID, title))
+	}
+
+	resp, err := cs.commentService.AddComment(ctx, req)
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	_, _ = cs.captchaService.ActionRecordAdd(ctx, entity.CaptchaActionComment, req.UserID)
+
+	siteGeneral, siteSeo, err := cs.getSiteURLInfo(ctx)
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	commentURL := display.CommentURL(siteSeo.Permalink, siteGeneral.SiteUrl,
+		questionID, title, answerID, resp.CommentID)
+	return cs.tr(lang, mixinbotlang.CommandReplyCommentPosted, commentURL)
+}
+
+func (cs *MixinBotCommandService) followTag(ctx context.Context, lang i18n.Language, userID, slugName string) string {
+	slugName = strings.TrimPrefix(strings.TrimSpace(slugName), "#")
+	if len(slugName) == 0 {
+		return cs.tr(lang, mixinbotlang.CommandReplyHelp)
+	}
+	tag, exist, err := cs.tagCommonService.GetTagBySlugName(ctx, slugName)
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	if !exist {
+		return cs.tr(lang, mixinbotlang.CommandReplyTagNotFound, slugName)
+	}
+	_, err = cs.followService.Follow(ctx, &schema.FollowDTO{
+		ObjectID: tag.ID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	return cs.tr(lang, mixinbotlang.CommandReplyFollowed, tag.DisplayName)
+}
+
+func (cs *MixinBotCommandService) mute(ctx context.Context, lang i18n.Language, userID, questionID string) string {
+	questionID = uid.DeShortID(questionID)
+	if len(questionID) == 0 {
+		return cs.tr(lang, mixinbotlang.CommandReplyQuestionNotFound)
+	}
+	question, exist, err := cs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	if !exist || question.Status == entity.QuestionStatusDeleted {
+		return cs.tr(lang, mixinbotlang.CommandReplyQuestionNotFound)
+	}
+	if err = cs.spaceService.CheckQuestionAccess(ctx, question.ID); err != nil {
+		return cs.tr(lang, mixinbotlang.CommandReplyQuestionNotFound)
+	}
+	_, err = cs.followService.Follow(ctx, &schema.FollowDTO{
+		ObjectID: question.ID,
+		IsCancel: true,
+		UserID:   userID,
+	})
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	return cs.tr(lang, mixinbotlang.CommandReplyMuted, question.Title)
+}
+
+func (cs *MixinBotCommandService) digest(ctx context.Context, lang i18n.Language, userID string) string {
+	redDot, err := cs.notificationService.GetRedDot(ctx, &schema.GetRedDot{UserID: userID})
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	if redDot.Inbox == 0 {
+		return cs.tr(lang, mixinbotlang.CommandReplyDigestEmpty)
+	}
+	page, err := cs.notificationService.GetNotificationPage(ctx, &schema.NotificationSearch{
+		Page:     1,
+		PageSize: listSize,
+		TypeStr:  "inbox",
+		UserID:   userID,
+	})
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	siteGeneral, siteSeo, err := cs.getSiteURLInfo(ctx)
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+
+	lines := []string{cs.tr(lang, mixinbotlang.CommandReplyDigest, redDot.Inbox)}
+	notifications, _ := page.List.([]*schema.NotificationContent)
+	for _, item := range notifications {
+		if item.IsRead {
+			continue
+		}
+		line := "- " + item.ObjectInfo.Title
+		if questionID, ok := item.ObjectInfo.ObjectMap["question"]; ok {
+			line += " " + display.QuestionURL(siteSeo.Permalink, siteGeneral.SiteUrl, questionID, item.ObjectInfo.Title)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (cs *MixinBotCommandService) search(ctx context.Context, lang i18n.Language, userID, query string) string {
+	req := &schema.SearchDTO{
+		Query:  query,
+		Page:   1,
+		Size:   listSize,
+		Order:  "relevance",
+		UserID: userID,
+	}
+	if reply, ok := cs.check(lang, req); !ok {
+		return reply
+	}
+	if !cs.captchaService.ValidationStrategy(ctx, userID, entity.CaptchaActionSearch) {
+		return cs.tr(lang, mixinbotlang.CommandReplyCaptchaRequired, cs.siteURL(ctx)+"/search")
+	}
+	resp, err := cs.searchService.Search(ctx, req)
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+	_, _ = cs.captchaService.ActionRecordAdd(ctx, entity.CaptchaActionSearch, userID)
+	if len(resp.SearchResults) == 0 {
+		return cs.tr(lang, mixinbotlang.CommandReplySearchEmpty, query)
+	}
+	siteGeneral, siteSeo, err := cs.getSiteURLInfo(ctx)
+	if err != nil {
+		return cs.replyError(lang, err)
+	}
+
+	lines := []string{cs.tr(lang, mixinbotlang.CommandReplySearch, query)}
+	for _, item := range resp.SearchResults {
+		if item.Object == nil {
+			continue
+		}
+		lines = append(lines, "- "+item.Object.Title+" "+display.QuestionURL(
+			siteSeo.Permalink, siteGeneral.SiteUrl, item.Object.QuestionID, item.Object.Title))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// check validates the request just like the api does, it returns the reply when the request is invalid
+func (cs *MixinBotCommandService) check(lang i18n.Language, req interface{}) (reply string, ok bool) {
+	errFields, err := validator.GetValidatorByLang(lang).Check(req)
+	if err == nil {
+		return "", true
+	}
+	if len(errFields) > 0 {
+		return cs.tr(lang, mixinbotlang.CommandReplyFailed, errFields[0].ErrorMsg), false
+	}
+	return cs.replyError(lang, err), false
+}
+
+func (cs *MixinBotCommandService) replyError(lang i18n.Language, err error) string {
+	var myErr *myErrors.Error
+	if errors.As(err, &myErr) {
+		if myErr.Reason == reason.RankFailToMeetTheCondition {
+			return cs.tr(lang, mixinbotlang.CommandReplyNoPermission)
+		}
+		return cs.tr(lang, mixinbotlang.CommandReplyFailed, handler.NewRespBodyFromError(myErr).TrMsg(lang).Message)
+	}
+	return cs.tr(lang, mixinbotlang.CommandReplyFailed, translator.Tr(lang, reason.UnknownError))
+}
+
+func (cs *MixinBotCommandService) tr(lang i18n.Language, reply mixinbotlang.CommandReply, args ...interface{}) string {
+	tpl := cs.langPicker.Pick(mixinbotlang.GetLanguage(string(lang))).TranslateCommandReply(reply)
+	if len(args) == 0 {
+		return tpl
+	}
+	return fmt.Sprintf(tpl, args...)
+}
+
+func (cs *MixinBotCommandService) getSiteLanguage(ctx context.Context) i18n.Language {
+	if cs.siteInfoService == nil {
+		return i18n.DefaultLanguage
+	}
+	interfaceInfo, err := cs.siteInfoService.GetSiteInterface(ctx)
+	if err != nil || interfaceInfo == nil {
+		return i18n.DefaultLanguage
+	}
+	return i18n.Language(interfaceInfo.Language)
+}
+
+func (cs *MixinBotCommandService) getSiteURLInfo(ctx context.Context) (
+	siteGeneral *schema.SiteGeneralResp, siteSeo *schema.SiteSeoResp, err error) {
+	siteGeneral, err = cs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	siteSeo, err = cs.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return siteGeneral, siteSeo, nil
+}
+
+func (cs *MixinBotCommandService) siteURL(ctx context.Context) string {
+	siteGeneral, err := cs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return ""
+	}
+	return siteGeneral.SiteUrl
+}
+
+func (cs *MixinBotCommandService) questionURL(ctx context.Context, questionID, title string) string {
+	siteGeneral, siteSeo, err := cs.getSiteURLInfo(ctx)
+	if err != nil {
+		return ""
+	}
+	return display.QuestionURL(siteSeo.Permalink, siteGeneral.SiteUrl, questionID, title)
+}
+
+func (cs *MixinBotCommandService) answerURL(ctx context.Context, questionID, title, answerID string) string {
+	siteGeneral, siteSeo, err := cs.getSiteURLInfo(ctx)
+	if err != nil {
+		return ""
+	}
+	return display.AnswerURL(siteSeo.Permalink, siteGeneral.SiteUrl, questionID, title, answerID)
+}
+
+// decodeMessageData the data of the plain text message is encoded by base64
+func decodeMessageData(msg *mixin.MessageView) (string, error) {
+	if len(msg.Data) > 0 {
+		data, err := base64.StdEncoding.DecodeString(msg.Data)
+		if err == nil {
+			return string(data), nil
+		}
+	}
+	data, err := base64.RawURLEncoding.DecodeString(msg.DataBase64)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,291 @@
+package mixinbotcommand
+
+import (
+	"context"
+	"encoding/base64"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/mixinbot"
+	mixinbotlang "github.com/apache/incubator-answer/internal/service/mixinbot/lang"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/space"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_external_login"
+	"github.com/fox-one/mixin-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTransport delivers the queued messages to the listener and records the messages sent by the bot
+type fakeTransport struct {
+	mu       sync.Mutex
+	incoming []*mixin.MessageView
+	sent     []*mixin.MessageRequest
+}
+
+func (t *fakeTransport) SendMessage(ctx context.Context, message *mixin.MessageRequest) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sent = append(t.sent, message)
+	return nil
+}
+
+func (t *fakeTransport) CreateContactConversation(ctx context.Context, userID string) error {
+	return nil
+}
+
+func (t *fakeTransport) LoopBlaze(ctx context.Context, listener mixin.BlazeListener) error {
+	t.mu.Lock()
+	incoming := t.incoming
+	t.incoming = nil
+	t.mu.Unlock()
+	for _, msg := range incoming {
+		if err := listener.OnMessage(ctx, msg, "bot"); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (t *fakeTransport) getSent() []*mixin.MessageRequest {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*mixin.MessageRequest{}, t.sent...)
+}
+
+type fakeMixinBotRepo struct {
+	cards    map[string]*mixinbot.CardObject
+	messages map[string]bool
+}
+
+func (r *fakeMixinBotRepo) SetCardObject(ctx context.Context, messageID string, object *mixinbot.CardObject) error {
+	r.cards[messageID] = object
+	return nil
+}
+
+func (r *fakeMixinBotRepo) GetCardObject(ctx context.Context, messageID string) (*mixinbot.CardObject, bool, error) {
+	object, ok := r.cards[messageID]
+	return object, ok, nil
+}
+
+func (r *fakeMixinBotRepo) CheckAndRecordMessage(ctx context.Context, key string) (bool, error) {
+	if r.messages[key] {
+		return true, nil
+	}
+	r.messages[key] = true
+	return false, nil
+}
+
+type fakeUserExternalLoginRepo struct {
+	user_external_login.UserExternalLoginRepo
+	bindings map[string]string
+}
+
+func (r *fakeUserExternalLoginRepo) GetByExternalID(ctx context.Context, provider, externalID string) (
+	*entity.UserExternalLogin, bool, error) {
+	userID, ok := r.bindings[provider+":"+externalID]
+	if !ok {
+		return nil, false, nil
+	}
+	return &entity.UserExternalLogin{UserID: userID, Provider: provider, ExternalID: externalID}, true, nil
+}
+
+type fakeUserRepo struct {
+	usercommon.UserRepo
+	users map[string]*entity.User
+}
+
+func (r *fakeUserRepo) GetByUserID(ctx context.Context, userID string) (*entity.User, bool, error) {
+	user, ok := r.users[userID]
+	return user, ok, nil
+}
+
+type fakeSiteInfoService struct {
+	siteinfo_common.SiteInfoCommonService
+}
+
+func (s *fakeSiteInfoService) GetSiteInterface(ctx context.Context) (*schema.SiteInterfaceResp, error) {
+	return &schema.SiteInterfaceResp{Language: "en_US"}, nil
+}
+
+func (s *fakeSiteInfoService) FormatAvatar(ctx context.Context, originalAvatarData, email string,
+	userStatus int) *schema.AvatarInfo {
+	return &schema.AvatarInfo{}
+}
+
+type fakeQuestionRepo struct {
+	questioncommon.QuestionRepo
+	questions map[string]*entity.Question
+}
+
+func (r *fakeQuestionRepo) GetQuestion(ctx context.Context, id string) (*entity.Question, bool, error) {
+	question, ok := r.questions[id]
+	return question, ok, nil
+}
+
+type fakeUserRoleRelRepo struct {
+	role.UserRoleRelRepo
+}
+
+func (r *fakeUserRoleRelRepo) GetUserRoleRel(ctx context.Context, userID string) (*entity.UserRoleRel, bool, error) {
+	return nil, false, nil
+}
+
+type fakeSpaceRepo struct {
+	space.SpaceRepo
+	spaces         map[string]*entity.Space
+	questionSpaces map[string]string
+}
+
+func (r *fakeSpaceRepo) GetSpace(ctx context.Context, spaceID string) (*entity.Space, bool, error) {
+	s, ok := r.spaces[spaceID]
+	return s, ok, nil
+}
+
+func (r *fakeSpaceRepo) GetMemberships(ctx context.Context, userID string, roleID int) ([]*entity.SpaceMember, error) {
+	return nil, nil
+}
+
+func (r *fakeSpaceRepo) GetQuestionSpaceID(ctx context.Context, questionID string) (string, bool, error) {
+	spaceID, ok := r.questionSpaces[questionID]
+	return spaceID, ok, nil
+}
+
+func newTestCommandService() (*MixinBotCommandService, *fakeTransport, *fakeMixinBotRepo) {
+	transport := &fakeTransport{}
+	repo := &fakeMixinBotRepo{
+		cards:    make(map[string]*mixinbot.CardObject),
+		messages: make(map[string]bool),
+	}
+	mixinBotService := &mixinbot.MixinBotService{
+		Config:    mixinbot.MixinBotConfig{ClientID: "bot", EnableCommand: true},
+		Transport: transport,
+		Repo:      repo,
+	}
+	externalLoginRepo := &fakeUserExternalLoginRepo{bindings: map[string]string{
+		"basic:mixin-1": "1",
+		"basic:mixin-2": "2",
+	}}
+	userRepo := &fakeUserRepo{users: map[string]*entity.User{
+		"1": {ID: "1", Username: "u1", Language: "en_US",
+			Status: entity.UserStatusAvailable, MailStatus: entity.EmailStatusAvailable},
+		"2": {ID: "2", Username: "u2", Language: "en_US",
+			Status: entity.UserStatusSuspended, MailStatus: entity.EmailStatusAvailable},
+	}}
+	siteInfoService := &fakeSiteInfoService{}
+	questionRepo := &fakeQuestionRepo{questions: map[string]*entity.Question{
+		"10010000000000001": {ID: "10010000000000001", Title: "private question",
+			Status: entity.QuestionStatusAvailable},
+	}}
+	spaceRepo := &fakeSpaceRepo{
+		spaces: map[string]*entity.Space{
+			"1": {ID: "1", Visibility: entity.SpaceVisibilityHidden},
+		},
+		questionSpaces: map[string]string{"10010000000000001": "1"},
+	}
+	spaceService := space.NewSpaceService(spaceRepo, nil,
+		role.NewUserRoleRelService(&fakeUserRoleRelRepo{}, nil), nil)
+	cs := NewMixinBotCommandService(mixinBotService, externalLoginRepo,
+		usercommon.NewUserCommon(userRepo, nil, nil, siteInfoService),
+		nil, nil, nil, nil, nil, nil, questionRepo, nil, nil, siteInfoService, spaceService)
+	return cs, transport, repo
+}
+
+func TestParseCommand(t *testing.T) {
+	assert.Nil(t, parseCommand("hello"))
+	assert.Equal(t, &command{Name: "/help"}, parseCommand(" /help "))
+	assert.Equal(t, &command{Name: "/follow", Args: "golang"}, parseCommand("/Follow golang"))
+	assert.Equal(t, &command{Name: "/comment", Args: "first line\nsecond line"},
+		parseCommand("/comment\nfirst line\nsecond line"))
+}
+
+func TestParseQuestionID(t *testing.T) {
+	assert.Equal(t, "10010000000000001", parseQuestionID("10010000000000001"))
+	assert.Equal(t, "D1I2", parseQuestionID("https://example.com/questions/D1I2/some-title"))
+	assert.Equal(t, "10010000000000001", parseQuestionID("https://example.com/questions/10010000000000001"))
+	assert.Equal(t, "", parseQuestionID("https://example.com/tags/golang"))
+	assert.Equal(t, "", parseQuestionID(""))
+}
+
+func TestHandleMessage(t *testing.T) {
+	ctx := context.Background()
+	cs, _, repo := newTestCommandService()
+	en := mixinbotlang.NewLangPicker().Pick(mixinbotlang.LanguageEnUS)
+
+	// unbound user
+	assert.Equal(t, en.TranslateCommandReply(mixinbotlang.CommandReplyNotBound),
+		cs.HandleMessage(ctx, "mixin-0", "m1", "", "/help"))
+
+	// bound user
+	assert.Equal(t, en.TranslateCommandReply(mixinbotlang.CommandReplyHelp),
+		cs.HandleMessage(ctx, "mixin-1", "m2", "", "/help"))
+	assert.Equal(t, en.TranslateCommandReply(mixinbotlang.CommandReplyUnknown),
+		cs.HandleMessage(ctx, "mixin-1", "m3", "", "/unknown"))
+
+	// the question is in a space that the user is not a member of
+	assert.Equal(t, en.TranslateCommandReply(mixinbotlang.CommandReplyQuestionNotFound),
+		cs.HandleMessage(ctx, "mixin-1", "m8", "", "/mute 10010000000000001"))
+
+	// the same message is delivered again
+	assert.Equal(t, "", cs.HandleMessage(ctx, "mixin-1", "m2", "", "/help"))
+	// the same content is sent again
+	assert.Equal(t, en.TranslateCommandReply(mixinbotlang.CommandReplyDuplicate),
+		cs.HandleMessage(ctx, "mixin-1", "m4", "", "/help"))
+
+	// suspended user
+	assert.Equal(t, en.TranslateCommandReply(mixinbotlang.CommandReplyForbidden),
+		cs.HandleMessage(ctx, "mixin-2", "m5", "", "/help"))
+
+	// the card is expired or sent to another user
+	assert.Equal(t, en.TranslateCommandReply(mixinbotlang.CommandReplyCardNotFound),
+		cs.HandleMessage(ctx, "mixin-1", "m6", "card-0", "a reply to the card"))
+	repo.cards["card-1"] = &mixinbot.CardObject{QuestionID: "10010000000000001", ReceiverUserID: "2"}
+	assert.Equal(t, en.TranslateCommandReply(mixinbotlang.CommandReplyCardNotFound),
+		cs.HandleMessage(ctx, "mixin-1", "m7", "card-1", "a reply to the card"))
+}
+
+func TestStartAndShutdown(t *testing.T) {
+	cs, transport, _ := newTestCommandService()
+	assert.True(t, cs.Enabled())
+	transport.incoming = []*mixin.MessageView{
+		{
+			ConversationID: "c1",
+			UserID:         "mixin-0",
+			MessageID:      "m1",
+			Category:       mixin.MessageCategoryAppCard,
+			Data:           base64.StdEncoding.EncodeToString([]byte("{}")),
+		},
+		{
+			ConversationID: "c1",
+			UserID:         "mixin-0",
+			MessageID:      "m2",
+			Category:       mixin.MessageCategoryPlainText,
+			Data:           base64.StdEncoding.EncodeToString([]byte("/help")),
+		},
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- cs.Start()
+	}()
+	assert.Eventually(t, func() bool {
+		return len(transport.getSent()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.NoError(t, cs.Shutdown())
+	assert.NoError(t, <-done)
+
+	sent := transport.getSent()[0]
+	assert.Equal(t, "c1", sent.ConversationID)
+	assert.Equal(t, "mixin-0", sent.RecipientID)
+	assert.Equal(t, "m2", sent.QuoteMessageID)
+	assert.Equal(t, mixin.MessageCategoryPlainText, sent.Category)
+	text, _ := base64.StdEncoding.DecodeString(sent.Data)
+	en := mixinbotlang.NewLangPicker().Pick(mixinbotlang.LanguageEnUS)
+	assert.Equal(t, en.TranslateCommandReply(mixinbotlang.CommandReplyNotBound), string(text))
+}
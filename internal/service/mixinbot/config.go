@@ -6,4 +6,6 @@ type MixinBotConfig struct {
 	ServerPublicKey   string `json:"server_public_key" mapstructure:"server_public_key" yaml:"server_public_key"`
 	SessionPrivateKey string `json:"session_private_key" mapstructure:"session_private_key" yaml:"session_private_key"`
 	SpendKey          string `json:"spend_key" mapstructure:"spend_key" yaml:"spend_key"`
+	// EnableCommand receives the messages sent to the bot, so that users can reply the cards and send commands
+	EnableCommand bool `json:"enable_command" mapstructure:"enable_command" yaml:"enable_command"`
 }
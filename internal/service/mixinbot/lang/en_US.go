@@ -95,3 +95,73 @@ const (
 	enUsTplNewQuestionDescription            = "%s created a topic"
 	enUsTplNewQuestionFollowedTagDescription = "%s followed the topic"
 )
+
+func (e *EnUS) TranslateCommandReply(reply CommandReply) string {
+	switch reply {
+	case CommandReplyHelp:
+		return enUsReplyHelp
+	case CommandReplyNotBound:
+		return enUsReplyNotBound
+	case CommandReplyForbidden:
+		return enUsReplyForbidden
+	case CommandReplyNoPermission:
+		return enUsReplyNoPermission
+	case CommandReplyCaptchaRequired:
+		return enUsReplyCaptchaRequired
+	case CommandReplyDuplicate:
+		return enUsReplyDuplicate
+	case CommandReplyUnknown:
+		return enUsReplyUnknown
+	case CommandReplyCardNotFound:
+		return enUsReplyCardNotFound
+	case CommandReplyAnswerPosted:
+		return enUsReplyAnswerPosted
+	case CommandReplyCommentPosted:
+		return enUsReplyCommentPosted
+	case CommandReplyPendingReview:
+		return enUsReplyPendingReview
+	case CommandReplyFollowed:
+		return enUsReplyFollowed
+	case CommandReplyTagNotFound:
+		return enUsReplyTagNotFound
+	case CommandReplyMuted:
+		return enUsReplyMuted
+	case CommandReplyQuestionNotFound:
+		return enUsReplyQuestionNotFound
+	case CommandReplyDigestEmpty:
+		return enUsReplyDigestEmpty
+	case CommandReplyDigest:
+		return enUsReplyDigest
+	case CommandReplySearchEmpty:
+		return enUsReplySearchEmpty
+	case CommandReplySearch:
+		return enUsReplySearch
+	case CommandReplyFailed:
+		return enUsReplyFailed
+	default:
+		return ""
+	}
+}
+
+const (
+	enUsReplyHelp             = "You can reply to a notification card to reply the topic or comment, or send the commands:\n/follow <tag> follow the tag\n/mute <topic link> stop receiving notifications of the topic\n/digest show the unread notifications\n/search <terms> search the topics"
+	enUsReplyNotBound         = "Your Mixin account is not bound to any user, please log in to the site with Mixin first"
+	enUsReplyForbidden        = "Your account can not do this right now"
+	enUsReplyNoPermission     = "You do not have enough reputation to do this"
+	enUsReplyCaptchaRequired  = "Please post on the website and pass the captcha first: %s"
+	enUsReplyDuplicate        = "The same message has been handled"
+	enUsReplyUnknown          = "Unknown command, send /help to see the commands"
+	enUsReplyCardNotFound     = "The notification is expired, please reply on the website"
+	enUsReplyAnswerPosted     = "Your reply is posted: %s"
+	enUsReplyCommentPosted    = "Your comment is posted: %s"
+	enUsReplyPendingReview    = "Your post is waiting for review: %s"
+	enUsReplyFollowed         = "You are following the tag %s now"
+	enUsReplyTagNotFound      = "The tag %s is not found"
+	enUsReplyMuted            = "You will not receive notifications of the topic %s anymore"
+	enUsReplyQuestionNotFound = "The topic is not found"
+	enUsReplyDigestEmpty      = "You have no unread notifications"
+	enUsReplyDigest           = "You have %d unread notifications:"
+	enUsReplySearchEmpty      = "Nothing is found for %s"
+	enUsReplySearch           = "The topics found for %s:"
+	enUsReplyFailed           = "Failed: %s"
+)
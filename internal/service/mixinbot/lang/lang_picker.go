@@ -6,6 +6,32 @@ import (
 
 type Lang string
 
+// CommandReply the reply of the command sent to the bot, some of them are templates that should be formatted
+type CommandReply string
+
+const (
+	CommandReplyHelp             CommandReply = "help"
+	CommandReplyNotBound         CommandReply = "not_bound"
+	CommandReplyForbidden        CommandReply = "forbidden"
+	CommandReplyNoPermission     CommandReply = "no_permission"
+	CommandReplyCaptchaRequired  CommandReply = "captcha_required"
+	CommandReplyDuplicate        CommandReply = "duplicate"
+	CommandReplyUnknown          CommandReply = "unknown"
+	CommandReplyCardNotFound     CommandReply = "card_not_found"
+	CommandReplyAnswerPosted     CommandReply = "answer_posted"
+	CommandReplyCommentPosted    CommandReply = "comment_posted"
+	CommandReplyPendingReview    CommandReply = "pending_review"
+	CommandReplyFollowed         CommandReply = "followed"
+	CommandReplyTagNotFound      CommandReply = "tag_not_found"
+	CommandReplyMuted            CommandReply = "muted"
+	CommandReplyQuestionNotFound CommandReply = "question_not_found"
+	CommandReplyDigestEmpty      CommandReply = "digest_empty"
+	CommandReplyDigest           CommandReply = "digest"
+	CommandReplySearchEmpty      CommandReply = "search_empty"
+	CommandReplySearch           CommandReply = "search"
+	CommandReplyFailed           CommandReply = "failed"
+)
+
 func GetLanguage(lang string) Lang {
 	switch lang {
 	case "zh_CN":
@@ -20,6 +46,7 @@ func GetLanguage(lang string) Lang {
 type LangSupportService interface {
 	TranslateGetCardInfo() string
 	TranslateDescription(mixinNotificationMsg *plugin.NotificationMessage) string
+	TranslateCommandReply(reply CommandReply) string
 	GetLangType() Lang
 }
 
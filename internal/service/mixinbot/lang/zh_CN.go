@@ -98,3 +98,73 @@ const (
 	zhCnTplNewQuestionDescription            = "%s 发起了话题"
 	zhCnTplNewQuestionFollowedTagDescription = "%s 关注了话题"
 )
+
+func (z *ZhCN) TranslateCommandReply(reply CommandReply) string {
+	switch reply {
+	case CommandReplyHelp:
+		return zhCnReplyHelp
+	case CommandReplyNotBound:
+		return zhCnReplyNotBound
+	case CommandReplyForbidden:
+		return zhCnReplyForbidden
+	case CommandReplyNoPermission:
+		return zhCnReplyNoPermission
+	case CommandReplyCaptchaRequired:
+		return zhCnReplyCaptchaRequired
+	case CommandReplyDuplicate:
+		return zhCnReplyDuplicate
+	case CommandReplyUnknown:
+		return zhCnReplyUnknown
+	case CommandReplyCardNotFound:
+		return zhCnReplyCardNotFound
+	case CommandReplyAnswerPosted:
+		return zhCnReplyAnswerPosted
+	case CommandReplyCommentPosted:
+		return zhCnReplyCommentPosted
+	case CommandReplyPendingReview:
+		return zhCnReplyPendingReview
+	case CommandReplyFollowed:
+		return zhCnReplyFollowed
+	case CommandReplyTagNotFound:
+		return zhCnReplyTagNotFound
+	case CommandReplyMuted:
+		return zhCnReplyMuted
+	case CommandReplyQuestionNotFound:
+		return zhCnReplyQuestionNotFound
+	case CommandReplyDigestEmpty:
+		return zhCnReplyDigestEmpty
+	case CommandReplyDigest:
+		return zhCnReplyDigest
+	case CommandReplySearchEmpty:
+		return zhCnReplySearchEmpty
+	case CommandReplySearch:
+		return zhCnReplySearch
+	case CommandReplyFailed:
+		return zhCnReplyFailed
+	default:
+		return ""
+	}
+}
+
+const (
+	zhCnReplyHelp             = "你可以回复通知卡片来回复话题或评论，或者发送以下命令：\n/follow <标签> 关注标签\n/mute <话题链接> 不再接收该话题的通知\n/digest 查看未读通知\n/search <关键词> 搜索话题"
+	zhCnReplyNotBound         = "你的 Mixin 账号还没有绑定用户，请先使用 Mixin 登录网站"
+	zhCnReplyForbidden        = "你的账号暂时不能进行此操作"
+	zhCnReplyNoPermission     = "你的声望不足，不能进行此操作"
+	zhCnReplyCaptchaRequired  = "请先在网站上发布并通过验证码：%s"
+	zhCnReplyDuplicate        = "相同的消息已经处理过了"
+	zhCnReplyUnknown          = "未知命令，发送 /help 查看可用命令"
+	zhCnReplyCardNotFound     = "通知已过期，请在网站上回复"
+	zhCnReplyAnswerPosted     = "你的回复已发布：%s"
+	zhCnReplyCommentPosted    = "你的评论已发布：%s"
+	zhCnReplyPendingReview    = "你的内容正在等待审核：%s"
+	zhCnReplyFollowed         = "你已关注标签 %s"
+	zhCnReplyTagNotFound      = "标签 %s 不存在"
+	zhCnReplyMuted            = "你将不再接收话题 %s 的通知"
+	zhCnReplyQuestionNotFound = "话题不存在"
+	zhCnReplyDigestEmpty      = "你没有未读通知"
+	zhCnReplyDigest           = "你有 %d 条未读通知："
+	zhCnReplySearchEmpty      = "没有找到与 %s 相关的内容"
+	zhCnReplySearch           = "与 %s 相关的话题："
+	zhCnReplyFailed           = "操作失败：%s"
+)
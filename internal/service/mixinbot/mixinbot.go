@@ -2,6 +2,7 @@ package mixinbot
 
 import (
 	"context"
+	"encoding/base64"
 	"math/rand"
 	"os"
 	"time"
@@ -15,12 +16,14 @@ type MixinBotService struct {
 	Config   MixinBotConfig
 	SpendKey mixinnet.Key
 
-	User   *mixin.User
-	Client *mixin.Client
+	User      *mixin.User
+	Client    *mixin.Client
+	Transport Transport
+	Repo      MixinBotRepo
 }
 
-func NewMixinBotService(config *MixinBotConfig) (*MixinBotService, error) {
-	mixinBot := &MixinBotService{}
+func NewMixinBotService(config *MixinBotConfig, mixinBotRepo MixinBotRepo) (*MixinBotService, error) {
+	mixinBot := &MixinBotService{Repo: mixinBotRepo}
 
 	client, err := mixin.NewFromKeystore(&mixin.Keystore{
 		ClientID:          config.ClientID,
@@ -53,21 +56,22 @@ func NewMixinBotService(config *MixinBotConfig) (*MixinBotService, error) {
 	mixinBot.Config = *config
 	mixinBot.User = user
 	mixinBot.Client = client
+	mixinBot.Transport = NewClientTransport(client)
 
 	return mixinBot, nil
 }
 
 func (m *MixinBotService) SendMessage(ctx context.Context, message *mixin.MessageRequest) error {
 	sendMessageToUser := func(retry int) error {
-		err := m.Client.SendMessage(ctx, message)
+		err := m.Transport.SendMessage(ctx, message)
 		if err != nil {
 			// try create conversation
-			_, err = m.Client.CreateContactConversation(ctx, message.RecipientID)
+			err = m.Transport.CreateContactConversation(ctx, message.RecipientID)
 			if err != nil {
 				log.Errorf(ctx, "create contact conversation failed: %v, data: %v, retry: %d", err, message, retry)
 				return err
 			}
-			err = m.Client.SendMessage(ctx, message)
+			err = m.Transport.SendMessage(ctx, message)
 			if err != nil {
 				log.Errorf(ctx, "send mixin notification failed: %v, data: %v, retry: %d", err, message, retry)
 				return err
@@ -89,6 +93,29 @@ func (m *MixinBotService) SendMessage(ctx context.Context, message *mixin.Messag
 	return err
 }
 
+// SendCard sends the card and remembers the object of it, so that the user can reply the card to post content
+func (m *MixinBotService) SendCard(ctx context.Context, message *mixin.MessageRequest, object *CardObject) error {
+	if err := m.SendMessage(ctx, message); err != nil {
+		return err
+	}
+	if m.Repo == nil || object == nil {
+		return nil
+	}
+	return m.Repo.SetCardObject(ctx, message.MessageID, object)
+}
+
+// SendText sends the plain text to the conversation, it quotes the message if quoteMessageID is not empty
+func (m *MixinBotService) SendText(ctx context.Context, conversationID, recipientID, text, quoteMessageID string) error {
+	return m.SendMessage(ctx, &mixin.MessageRequest{
+		ConversationID: conversationID,
+		RecipientID:    recipientID,
+		MessageID:      mixin.RandomTraceID(),
+		Category:       mixin.MessageCategoryPlainText,
+		Data:           base64.StdEncoding.EncodeToString([]byte(text)),
+		QuoteMessageID: quoteMessageID,
+	})
+}
+
 var cardColorList = []string{
 	"#7983C2", "#8F7AC5", "#C5595A", "#C97B46", "#76A048", "#3D98D0",
 	"#5979F0", "#8A64D0", "#B76753", "#AA8A46", "#9CAD23", "#6BC0CE",
@@ -0,0 +1,36 @@
+package mixinbot
+
+import (
+	"context"
+
+	"github.com/fox-one/mixin-sdk-go/v2"
+)
+
+// Transport the way the bot talks to Mixin, it is replaced by a fake one in tests
+type Transport interface {
+	SendMessage(ctx context.Context, message *mixin.MessageRequest) error
+	CreateContactConversation(ctx context.Context, userID string) error
+	// LoopBlaze receives the messages sent to the bot and calls the listener until the connection is broken
+	LoopBlaze(ctx context.Context, listener mixin.BlazeListener) error
+}
+
+type clientTransport struct {
+	client *mixin.Client
+}
+
+func NewClientTransport(client *mixin.Client) Transport {
+	return &clientTransport{client: client}
+}
+
+func (t *clientTransport) SendMessage(ctx context.Context, message *mixin.MessageRequest) error {
+	return t.client.SendMessage(ctx, message)
+}
+
+func (t *clientTransport) CreateContactConversation(ctx context.Context, userID string) error {
+	_, err := t.client.CreateContactConversation(ctx, userID)
+	return err
+}
+
+func (t *clientTransport) LoopBlaze(ctx context.Context, listener mixin.BlazeListener) error {
+	return t.client.LoopBlaze(ctx, listener)
+}
@@ -117,6 +117,20 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) FormatListAvatar(ctx, userList
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormatListAvatar", reflect.TypeOf((*MockSiteInfoCommonService)(nil).FormatListAvatar), ctx, userList)
 }
 
+// GetCodeHighlightTheme mocks base method.
+func (m *MockSiteInfoCommonService) GetCodeHighlightTheme(ctx context.Context) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCodeHighlightTheme", ctx)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetCodeHighlightTheme indicates an expected call of GetCodeHighlightTheme.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetCodeHighlightTheme(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeHighlightTheme", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetCodeHighlightTheme), ctx)
+}
+
 // GetSiteBranding mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteBranding(ctx context.Context) (*schema.SiteBrandingResp, error) {
 	m.ctrl.T.Helper()
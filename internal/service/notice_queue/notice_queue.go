@@ -22,6 +22,7 @@ package notice_queue
 import (
 	"context"
 
+	"github.com/apache/incubator-answer/internal/base/metrics"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/segmentfault/pacman/log"
 )
@@ -54,6 +55,7 @@ func (ns *notificationQueueService) working() {
 				continue
 			}
 			if err := ns.Handler(context.Background(), msg); err != nil {
+				metrics.ObserveQueueHandlerError("notification")
 				log.Error(err)
 			}
 		}
@@ -64,6 +66,7 @@ func (ns *notificationQueueService) working() {
 func NewNotificationQueueService() NotificationQueueService {
 	ns := &notificationQueueService{}
 	ns.Queue = make(chan *schema.NotificationMsg, 128)
+	metrics.RegisterQueue("notification", cap(ns.Queue), func() int { return len(ns.Queue) })
 	ns.working()
 	return ns
 }
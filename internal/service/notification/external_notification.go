@@ -22,6 +22,7 @@ package notification
 import (
 	"context"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/schema"
@@ -31,6 +32,8 @@ import (
 	mixinbotlang "github.com/apache/incubator-answer/internal/service/mixinbot/lang"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/space"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/internal/service/user_external_login"
 	"github.com/apache/incubator-answer/internal/service/user_notification_config"
@@ -48,6 +51,8 @@ type ExternalNotificationService struct {
 	userExternalLoginRepo      user_external_login.UserExternalLoginRepo
 	siteInfoService            siteinfo_common.SiteInfoCommonService
 	mixinBotService            *mixinbot.MixinBotService
+	spaceService               *space.SpaceService
+	tagCommon                  *tagcommon.TagCommonService
 	langPicker                 *mixinbotlang.LangPicker
 }
 
@@ -61,6 +66,8 @@ func NewExternalNotificationService(
 	userExternalLoginRepo user_external_login.UserExternalLoginRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	mixinbotService *mixinbot.MixinBotService,
+	spaceService *space.SpaceService,
+	tagCommon *tagcommon.TagCommonService,
 ) *ExternalNotificationService {
 	n := &ExternalNotificationService{
 		data:                       data,
@@ -72,6 +79,8 @@ func NewExternalNotificationService(
 		userExternalLoginRepo:      userExternalLoginRepo,
 		siteInfoService:            siteInfoService,
 		mixinBotService:            mixinbotService,
+		spaceService:               spaceService,
+		tagCommon:                  tagCommon,
 		langPicker:                 mixinbotlang.NewLangPicker(),
 	}
 	notificationQueueService.RegisterHandler(n.Handler)
@@ -90,6 +99,10 @@ func (ns *ExternalNotificationService) Handler(ctx context.Context, msg *schema.
 	if msg.NewQuestionTemplateRawData != nil {
 		return ns.handleNewQuestionNotification(ctx, msg)
 	}
+	if !ns.canReceiverAccessQuestion(ctx, msg) {
+		log.Debugf("receiver %s can not access the question, skip notification", msg.ReceiverUserID)
+		return nil
+	}
 	if msg.NewCommentTemplateRawData != nil {
 		return ns.handleNewCommentNotification(ctx, msg)
 	}
@@ -102,3 +115,37 @@ func (ns *ExternalNotificationService) Handler(ctx context.Context, msg *schema.
 	log.Errorf("unknown notification message: %+v", msg)
 	return nil
 }
+
+// canReceiverAccessQuestion check whether the receiver can access the question that notification is about
+func (ns *ExternalNotificationService) canReceiverAccessQuestion(ctx context.Context,
+	msg *schema.ExternalNotificationMsg) bool {
+	var questionID string
+	switch {
+	case msg.NewCommentTemplateRawData != nil:
+		questionID = msg.NewCommentTemplateRawData.QuestionID
+	case msg.NewAnswerTemplateRawData != nil:
+		questionID = msg.NewAnswerTemplateRawData.QuestionID
+	case msg.NewInviteAnswerTemplateRawData != nil:
+		questionID = msg.NewInviteAnswerTemplateRawData.QuestionID
+	}
+	if len(questionID) == 0 {
+		return true
+	}
+	canAccess, err := ns.spaceService.CanUserAccessQuestion(ctx, msg.ReceiverUserID, questionID)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	return canAccess
+}
+
+// isEmailEnabled check whether the receiver receives the emails of the type of the notifications
+func (ns *ExternalNotificationService) isEmailEnabled(ctx context.Context, userID string,
+	source constant.NotificationSource) (bool, error) {
+	notificationConfigs, err := ns.userNotificationConfigRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	config := schema.NewNotificationConfig(notificationConfigs)
+	return config.IsChannelEnabled(source, constant.EmailChannel), nil
+}
@@ -32,22 +32,12 @@ func (ns *ExternalNotificationService) handleInviteAnswerNotification(ctx contex
 	msg *schema.ExternalNotificationMsg) error {
 	log.Debugf("try to send invite answer notification %+v", msg)
 
-	notificationConfig, exist, err := ns.userNotificationConfigRepo.GetByUserIDAndSource(ctx, msg.ReceiverUserID, constant.InboxSource)
+	emailEnabled, err := ns.isEmailEnabled(ctx, msg.ReceiverUserID, constant.InviteSource)
 	if err != nil {
 		return err
 	}
-	if !exist {
-		return nil
-	}
-	channels := schema.NewNotificationChannelsFormJson(notificationConfig.Channels)
-	for _, channel := range channels {
-		if !channel.Enable {
-			continue
-		}
-		switch channel.Key {
-		case constant.EmailChannel:
-			ns.sendInviteAnswerNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewInviteAnswerTemplateRawData)
-		}
+	if emailEnabled {
+		ns.sendInviteAnswerNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewInviteAnswerTemplateRawData)
 	}
 	return nil
 }
@@ -58,6 +48,7 @@ func (ns *ExternalNotificationService) sendInviteAnswerNotificationEmail(ctx con
 		SourceType: schema.UnsubscribeSourceType,
 		NotificationSources: []constant.NotificationSource{
 			constant.InboxSource,
+			constant.InviteSource,
 		},
 		Email:                    email,
 		UserID:                   userID,
@@ -32,22 +32,12 @@ func (ns *ExternalNotificationService) handleNewAnswerNotification(ctx context.C
 	msg *schema.ExternalNotificationMsg) error {
 	log.Debugf("try to send new comment notification %+v", msg)
 
-	notificationConfig, exist, err := ns.userNotificationConfigRepo.GetByUserIDAndSource(ctx, msg.ReceiverUserID, constant.InboxSource)
+	emailEnabled, err := ns.isEmailEnabled(ctx, msg.ReceiverUserID, constant.AnswerToMyQuestionSource)
 	if err != nil {
 		return err
 	}
-	if !exist {
-		return nil
-	}
-	channels := schema.NewNotificationChannelsFormJson(notificationConfig.Channels)
-	for _, channel := range channels {
-		if !channel.Enable {
-			continue
-		}
-		switch channel.Key {
-		case constant.EmailChannel:
-			ns.sendNewAnswerNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewAnswerTemplateRawData)
-		}
+	if emailEnabled {
+		ns.sendNewAnswerNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewAnswerTemplateRawData)
 	}
 	return nil
 }
@@ -58,6 +48,7 @@ func (ns *ExternalNotificationService) sendNewAnswerNotificationEmail(ctx contex
 		SourceType: schema.UnsubscribeSourceType,
 		NotificationSources: []constant.NotificationSource{
 			constant.InboxSource,
+			constant.AnswerToMyQuestionSource,
 		},
 		Email:                    email,
 		UserID:                   userID,
@@ -32,22 +32,12 @@ func (ns *ExternalNotificationService) handleNewCommentNotification(ctx context.
 	msg *schema.ExternalNotificationMsg) error {
 	log.Debugf("try to send new comment notification %+v", msg)
 
-	notificationConfig, exist, err := ns.userNotificationConfigRepo.GetByUserIDAndSource(ctx, msg.ReceiverUserID, constant.InboxSource)
+	emailEnabled, err := ns.isEmailEnabled(ctx, msg.ReceiverUserID, constant.CommentReplySource)
 	if err != nil {
 		return err
 	}
-	if !exist {
-		return nil
-	}
-	channels := schema.NewNotificationChannelsFormJson(notificationConfig.Channels)
-	for _, channel := range channels {
-		if !channel.Enable {
-			continue
-		}
-		switch channel.Key {
-		case constant.EmailChannel:
-			ns.sendNewCommentNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewCommentTemplateRawData)
-		}
+	if emailEnabled {
+		ns.sendNewCommentNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewCommentTemplateRawData)
 	}
 	return nil
 }
@@ -58,6 +48,7 @@ func (ns *ExternalNotificationService) sendNewCommentNotificationEmail(ctx conte
 		SourceType: schema.UnsubscribeSourceType,
 		NotificationSources: []constant.NotificationSource{
 			constant.InboxSource,
+			constant.CommentReplySource,
 		},
 		Email:                    email,
 		UserID:                   userID,
@@ -28,6 +28,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/schema"
 	mixinbotlang "github.com/apache/incubator-answer/internal/service/mixinbot/lang"
+	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/pkg/display"
 	"github.com/apache/incubator-answer/pkg/token"
 	"github.com/apache/incubator-answer/plugin"
@@ -113,7 +114,7 @@ func (ns *ExternalNotificationService) getNewQuestionSubscribers(ctx context.Con
 	// 1. get all this new question's tags followers
 	tagsFollowerIDs := make([]string, 0)
 	followerMapping := make(map[string]bool)
-	for _, tagID := range msg.NewQuestionTemplateRawData.TagIDs {
+	for _, tagID := range ns.getFollowedTagIDs(ctx, msg.NewQuestionTemplateRawData.TagIDs) {
 		userIDs, err := ns.followRepo.GetFollowUserIDs(ctx, tagID)
 		if err != nil {
 			log.Error(err)
@@ -163,9 +164,17 @@ func (ns *ExternalNotificationService) getNewQuestionSubscribers(ctx context.Con
 		}
 	}
 
-	// 3. remove question owner
+	// 3. remove question owner and the users who can not access the question
 	delete(subscribersMapping, msg.NewQuestionTemplateRawData.QuestionAuthorUserID)
 	for _, subscriber := range subscribersMapping {
+		canAccess, err := ns.spaceService.CanUserAccessQuestion(ctx, subscriber.UserID,
+			msg.NewQuestionTemplateRawData.QuestionID)
+		if err != nil {
+			return nil, err
+		}
+		if !canAccess {
+			continue
+		}
 		subscribers = append(subscribers, subscriber)
 	}
 	log.Debugf("get %d subscribers from all new question config", len(subscribers))
@@ -231,10 +240,10 @@ func (ns *ExternalNotificationService) sendNewQuestionNotificationEmail(ctx cont
 
 func (ns *ExternalNotificationService) syncNewQuestionNotificationToPlugin(ctx context.Context,
 	msg *schema.ExternalNotificationMsg) {
-	_ = plugin.CallNotification(func(fn plugin.Notification) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallNotification, func(ctx context.Context, fn plugin.Notification) error {
 		// 1. get all this new question's tags followers
 		subscribersMapping := make(map[string]plugin.NotificationType)
-		for _, tagID := range msg.NewQuestionTemplateRawData.TagIDs {
+		for _, tagID := range ns.getFollowedTagIDs(ctx, msg.NewQuestionTemplateRawData.TagIDs) {
 			userIDs, err := ns.followRepo.GetFollowUserIDs(ctx, tagID)
 			if err != nil {
 				log.Error(err)
@@ -251,8 +260,15 @@ func (ns *ExternalNotificationService) syncNewQuestionNotificationToPlugin(ctx c
 			subscribersMapping[subscriber] = plugin.NotificationNewQuestion
 		}
 
-		// 3. remove question owner
+		// 3. remove question owner and the users who can not access the question
 		delete(subscribersMapping, msg.NewQuestionTemplateRawData.QuestionAuthorUserID)
+		for subscriberUserID := range subscribersMapping {
+			canAccess, err := ns.spaceService.CanUserAccessQuestion(ctx, subscriberUserID,
+				msg.NewQuestionTemplateRawData.QuestionID)
+			if err != nil || !canAccess {
+				delete(subscribersMapping, subscriberUserID)
+			}
+		}
 
 		pluginNotificationMsg := ns.newPluginQuestionNotification(ctx, msg)
 
@@ -312,3 +328,19 @@ func (ns *ExternalNotificationService) newPluginQuestionNotification(
 		msg.NewQuestionTemplateRawData.QuestionID, msg.NewQuestionTemplateRawData.QuestionTitle)
 	return raw
 }
+
+// getFollowedTagIDs get the question's tag ids and their ancestor tag ids,
+// because following a parent tag implies following its descendant tags
+func (ns *ExternalNotificationService) getFollowedTagIDs(ctx context.Context, tagIDs []string) []string {
+	followedTagIDs := make([]string, 0, len(tagIDs))
+	for _, tagID := range tagIDs {
+		followedTagIDs = append(followedTagIDs, tagID)
+		ancestorIDs, err := ns.tagCommon.GetTagAncestorIDs(ctx, tagID)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		followedTagIDs = append(followedTagIDs, ancestorIDs...)
+	}
+	return converter.UniqueArray(followedTagIDs)
+}
@@ -73,7 +73,11 @@ func (es *ExternalNotificationService) sendMixinNewQuestionMessage(ctx context.C
 		Data:           cardBase64code,
 	}
 
-	es.mixinBotService.SendMessage(ctx, messageRequest)
+	_ = es.mixinBotService.SendCard(ctx, messageRequest, &mixinbot.CardObject{
+		QuestionID:     rawData.QuestionID,
+		QuestionTitle:  rawData.QuestionTitle,
+		ReceiverUserID: msg.ReceiverUserID,
+	})
 }
 
 func (es *ExternalNotificationService) fillCardAction(card *mixin.AppCardMessage, msg *schema.ExternalNotificationMsg, questionUrl string) {
@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification_channel
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/token"
+	"github.com/segmentfault/pacman/i18n"
+)
+
+// emailChannel sends the notification by email. The notifications of the answers, comments and invites are
+// sent with their own templates when the content is posted, so only the other types are sent here.
+type emailChannel struct {
+	emailService *export.EmailService
+	userRepo     usercommon.UserRepo
+}
+
+// NewEmailChannel new email channel
+func NewEmailChannel(emailService *export.EmailService, userRepo usercommon.UserRepo) Channel {
+	return &emailChannel{
+		emailService: emailService,
+		userRepo:     userRepo,
+	}
+}
+
+func (c *emailChannel) Key() constant.NotificationChannelKey {
+	return constant.EmailChannel
+}
+
+func (c *emailChannel) Supports(source constant.NotificationSource) bool {
+	return !schema.HasNotificationEmailTemplate(source)
+}
+
+func (c *emailChannel) Render(ctx context.Context, msg *schema.NotificationChannelMsg) (*RenderedMessage, error) {
+	if len(msg.ReceiverLang) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(msg.ReceiverLang))
+	}
+	code := token.GenerateToken()
+	title, body, err := c.emailService.NotificationTemplate(ctx, &schema.NotificationTemplateRawData{
+		Description:     msg.Description,
+		Title:           msg.QuestionTitle,
+		Url:             getMessageURL(msg),
+		UnsubscribeCode: code,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &RenderedMessage{Title: title, Body: body, Code: code}, nil
+}
+
+func (c *emailChannel) Deliver(ctx context.Context, msg *schema.NotificationChannelMsg, rendered *RenderedMessage) error {
+	userInfo, exist, err := c.userRepo.GetByUserID(ctx, msg.ReceiverUserID)
+	if err != nil {
+		return err
+	}
+	if !exist || userInfo.MailStatus != entity.EmailStatusAvailable || len(userInfo.EMail) == 0 {
+		return ErrReceiverUnreachable
+	}
+	codeContent := &schema.EmailCodeContent{
+		SourceType:               schema.UnsubscribeSourceType,
+		NotificationSources:      []constant.NotificationSource{msg.Source},
+		Email:                    userInfo.EMail,
+		UserID:                   userInfo.ID,
+		SkipValidationLatestCode: true,
+	}
+	c.emailService.SendAndSaveCodeWithTime(ctx, userInfo.ID, userInfo.EMail, rendered.Title, rendered.Body,
+		rendered.Code, codeContent.ToJSONString(), 1*24*time.Hour)
+	return nil
+}
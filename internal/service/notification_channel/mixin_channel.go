@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification_channel
+
+import (
+	"context"
+	"encoding/base64"
+	"regexp"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/mixinbot"
+	mixinbotlang "github.com/apache/incubator-answer/internal/service/mixinbot/lang"
+	"github.com/apache/incubator-answer/internal/service/user_external_login"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/fox-one/mixin-sdk-go/v2"
+	"github.com/goccy/go-json"
+	"github.com/segmentfault/pacman/log"
+)
+
+var htmlTagRegexp = regexp.MustCompile(`<.*?>`)
+
+// mixinChannel sends the notification as the card of the Mixin bot to the user who logins with Mixin
+type mixinChannel struct {
+	mixinBotService       *mixinbot.MixinBotService
+	userExternalLoginRepo user_external_login.UserExternalLoginRepo
+	langPicker            *mixinbotlang.LangPicker
+}
+
+// NewMixinChannel new mixin channel
+func NewMixinChannel(mixinBotService *mixinbot.MixinBotService,
+	userExternalLoginRepo user_external_login.UserExternalLoginRepo) Channel {
+	return &mixinChannel{
+		mixinBotService:       mixinBotService,
+		userExternalLoginRepo: userExternalLoginRepo,
+		langPicker:            mixinbotlang.NewLangPicker(),
+	}
+}
+
+func (c *mixinChannel) Key() constant.NotificationChannelKey {
+	return constant.MixinChannel
+}
+
+func (c *mixinChannel) Supports(source constant.NotificationSource) bool {
+	return source != constant.BadgeSource
+}
+
+func (c *mixinChannel) Render(ctx context.Context, msg *schema.NotificationChannelMsg) (*RenderedMessage, error) {
+	description := c.langPicker.Pick(mixinbotlang.GetLanguage(msg.ReceiverLang)).TranslateDescription(&msg.NotificationMessage)
+	title, content := mixinbot.PrefixTitle+msg.QuestionTitle, msg.Content
+
+	titleRunes := []rune(title)
+	if len(titleRunes) > mixinbot.MaxCardTitleLength {
+		title = string(titleRunes[:mixinbot.MaxCardTitleLength-len([]rune(mixinbot.Ellipsis))]) + mixinbot.Ellipsis
+	}
+
+	content = htmlTagRegexp.ReplaceAllString(content, "")
+	contentRunes := []rune(content)
+	if len(contentRunes) > mixinbot.MaxCardContentLength {
+		content = string(contentRunes[:mixinbot.MaxCardContentLength-len([]rune(mixinbot.Ellipsis))]) + mixinbot.Ellipsis
+		contentRunes = []rune(content)
+	}
+
+	lastContent := "\n\n\n" + description
+	if len(contentRunes)+len([]rune(lastContent)) <= mixinbot.MaxCardContentLength {
+		content += lastContent
+	} else {
+		availableLength := mixinbot.MaxCardContentLength - len([]rune(lastContent)) - len([]rune(mixinbot.Ellipsis))
+		if availableLength < 0 {
+			availableLength = 0
+		}
+		content = string(contentRunes[:availableLength]) + mixinbot.Ellipsis + lastContent
+	}
+	return &RenderedMessage{Title: title, Body: content}, nil
+}
+
+func (c *mixinChannel) Deliver(ctx context.Context, msg *schema.NotificationChannelMsg, rendered *RenderedMessage) error {
+	if c.mixinBotService == nil {
+		log.Debugf("mixinbot service is not initialized")
+		return ErrReceiverUnreachable
+	}
+	externalLogin, exist, err := c.userExternalLoginRepo.GetByUserID(ctx, "basic", msg.ReceiverUserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return ErrReceiverUnreachable
+	}
+
+	card := &mixin.AppCardMessage{
+		AppID:       c.mixinBotService.Config.ClientID,
+		Title:       rendered.Title,
+		Description: rendered.Body,
+		Shareable:   true,
+	}
+	c.fillCardAction(card, msg)
+	cardBytes, err := json.Marshal(card)
+	if err != nil {
+		return err
+	}
+
+	messageRequest := &mixin.MessageRequest{
+		ConversationID: mixin.UniqueConversationID(c.mixinBotService.Config.ClientID, externalLogin.ExternalID),
+		RecipientID:    externalLogin.ExternalID,
+		MessageID:      mixin.RandomTraceID(),
+		Category:       mixin.MessageCategoryAppCard,
+		Data:           base64.StdEncoding.EncodeToString(cardBytes),
+	}
+	return c.mixinBotService.SendCard(ctx, messageRequest, &mixinbot.CardObject{
+		QuestionID:     msg.QuestionID,
+		QuestionTitle:  msg.QuestionTitle,
+		AnswerID:       msg.AnswerID,
+		CommentID:      msg.CommentID,
+		ReceiverUserID: msg.ReceiverUserID,
+	})
+}
+
+func (c *mixinChannel) fillCardAction(card *mixin.AppCardMessage, msg *schema.NotificationChannelMsg) {
+	btnMsg := mixin.AppButtonMessage{
+		Label:  c.langPicker.Pick(mixinbotlang.GetLanguage(msg.ReceiverLang)).TranslateGetCardInfo(),
+		Action: msg.QuestionUrl,
+		Color:  mixinbot.RandomCardColor(),
+	}
+
+	switch msg.Type {
+	case plugin.NotificationUpdateQuestion, plugin.NotificationInvitedYouToAnswer, plugin.NotificationNewQuestion, plugin.NotificationNewQuestionFollowedTag:
+		btnMsg.Action = msg.QuestionUrl
+	case plugin.NotificationAnswerTheQuestion, plugin.NotificationUpdateAnswer, plugin.NotificationAcceptAnswer:
+		btnMsg.Action = msg.AnswerUrl
+	case plugin.NotificationCommentQuestion, plugin.NotificationCommentAnswer, plugin.NotificationReplyToYou, plugin.NotificationMentionYou:
+		btnMsg.Action = msg.CommentUrl
+	default:
+		log.Debugf("this type of notification will be drop, the type is %s", msg.Type)
+	}
+	card.Actions = []mixin.AppButtonMessage{btnMsg}
+}
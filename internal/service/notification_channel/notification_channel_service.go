@@ -0,0 +1,360 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification_channel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/mixinbot"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_external_login"
+	"github.com/apache/incubator-answer/internal/service/user_notification_config"
+	"github.com/apache/incubator-answer/pkg/display"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	maxDeliveryAttempts = 3
+	retryDelay          = 5 * time.Minute
+	dueDeliveryLimit    = 100
+)
+
+// ErrReceiverUnreachable the receiver can not be reached through the channel, such as the user has not bound
+// the account of the channel, the delivery is skipped
+var ErrReceiverUnreachable = errors.New("receiver unreachable")
+
+// RenderedMessage the notification rendered in the format of the channel
+type RenderedMessage struct {
+	Title string
+	Body  string
+	// Code the code delivered with the message, such as the unsubscribe code of the email
+	Code string
+}
+
+// Channel the way the notification is delivered to the user
+type Channel interface {
+	// Key the key of the channel that users choose for the types of the notifications
+	Key() constant.NotificationChannelKey
+	// Supports whether the channel delivers the type of the notifications
+	Supports(source constant.NotificationSource) bool
+	// Render renders the notification in the format of the channel
+	Render(ctx context.Context, msg *schema.NotificationChannelMsg) (*RenderedMessage, error)
+	// Deliver delivers the rendered notification, returns ErrReceiverUnreachable if the receiver can not be reached
+	Deliver(ctx context.Context, msg *schema.NotificationChannelMsg, rendered *RenderedMessage) error
+}
+
+// NotificationDeliveryRepo notification delivery repository
+type NotificationDeliveryRepo interface {
+	AddDelivery(ctx context.Context, delivery *entity.NotificationDelivery) (err error)
+	UpdateDelivery(ctx context.Context, delivery *entity.NotificationDelivery) (err error)
+	GetDueDeliveries(ctx context.Context, before time.Time, limit int) (deliveries []*entity.NotificationDelivery, err error)
+}
+
+// NotificationChannelService routes the inbox notifications to the channels chosen by the receiver
+type NotificationChannelService struct {
+	notificationDeliveryRepo      NotificationDeliveryRepo
+	userNotificationConfigService *user_notification_config.UserNotificationConfigService
+	userCommon                    *usercommon.UserCommon
+	userExternalLoginRepo         user_external_login.UserExternalLoginRepo
+	siteInfoService               siteinfo_common.SiteInfoCommonService
+
+	mu       sync.RWMutex
+	channels []Channel
+}
+
+// NewNotificationChannelService new notification channel service
+func NewNotificationChannelService(
+	notificationDeliveryRepo NotificationDeliveryRepo,
+	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
+	userCommon *usercommon.UserCommon,
+	userExternalLoginRepo user_external_login.UserExternalLoginRepo,
+	userRepo usercommon.UserRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	emailService *export.EmailService,
+	mixinBotService *mixinbot.MixinBotService,
+) *NotificationChannelService {
+	ns := &NotificationChannelService{
+		notificationDeliveryRepo:      notificationDeliveryRepo,
+		userNotificationConfigService: userNotificationConfigService,
+		userCommon:                    userCommon,
+		userExternalLoginRepo:         userExternalLoginRepo,
+		siteInfoService:               siteInfoService,
+	}
+	ns.Register(NewEmailChannel(emailService, userRepo))
+	ns.Register(NewMixinChannel(mixinBotService, userExternalLoginRepo))
+	ns.Register(NewWebhookChannel())
+	ns.Register(NewChatWebhookChannel())
+	return ns
+}
+
+// Register registers the channel, the channel with the same key is replaced
+func (ns *NotificationChannelService) Register(channel Channel) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	for i, c := range ns.channels {
+		if c.Key() == channel.Key() {
+			ns.channels[i] = channel
+			return
+		}
+	}
+	ns.channels = append(ns.channels, channel)
+}
+
+// getChannels the registered channels and the channels of the enabled notification plugins
+func (ns *NotificationChannelService) getChannels() (channels []Channel) {
+	ns.mu.RLock()
+	channels = append(channels, ns.channels...)
+	ns.mu.RUnlock()
+	_ = plugin.CallNotification(func(fn plugin.Notification) error {
+		channels = append(channels, newPluginChannel(fn, ns.userExternalLoginRepo))
+		return nil
+	})
+	return channels
+}
+
+func (ns *NotificationChannelService) getChannel(key constant.NotificationChannelKey) Channel {
+	for _, channel := range ns.getChannels() {
+		if channel.Key() == key {
+			return channel
+		}
+	}
+	return nil
+}
+
+// Route delivers the notification through the channels that the receiver chooses for the type of the notification.
+// The delivery is postponed to the end of the quiet hours of the receiver, and the failed delivery is retried later.
+func (ns *NotificationChannelService) Route(ctx context.Context, notificationID string,
+	objInfo *schema.SimpleObjectInfo, msg *schema.NotificationMsg) {
+	if len(msg.ReceiverUserID) == 0 {
+		return
+	}
+	source := constant.GetNotificationActionSource(msg.NotificationAction)
+	config, err := ns.userNotificationConfigService.GetNotificationConfig(ctx, msg.ReceiverUserID)
+	if err != nil {
+		log.Errorf("get notification config of user %s failed: %v", msg.ReceiverUserID, err)
+		return
+	}
+	channels := make([]Channel, 0)
+	for _, channel := range ns.getChannels() {
+		if channel.Supports(source) && config.IsChannelEnabled(source, channel.Key()) {
+			channels = append(channels, channel)
+		}
+	}
+	if len(channels) == 0 {
+		return
+	}
+
+	channelMsg, err := ns.newChannelMsg(ctx, notificationID, source, objInfo, msg)
+	if err != nil {
+		log.Errorf("build notification channel message failed: %v", err)
+		return
+	}
+	var quietUntil time.Time
+	if interfaceInfo, _ := ns.siteInfoService.GetSiteInterface(ctx); interfaceInfo != nil {
+		quietUntil = config.QuietHours.QuietUntil(time.Now(), interfaceInfo.TimeZone)
+	}
+
+	for _, channel := range channels {
+		m := *channelMsg
+		m.Target = config.GetChannelTarget(channel.Key())
+		delivery := &entity.NotificationDelivery{
+			NotificationID: notificationID,
+			UserID:         msg.ReceiverUserID,
+			Source:         string(source),
+			Channel:        string(channel.Key()),
+			Status:         entity.NotificationDeliveryStatusPending,
+			Message:        m.ToJSONString(),
+			ScheduledAt:    time.Now(),
+		}
+		if !quietUntil.IsZero() {
+			delivery.ScheduledAt = quietUntil
+			if err := ns.notificationDeliveryRepo.AddDelivery(ctx, delivery); err != nil {
+				log.Error(err)
+			}
+			continue
+		}
+		ns.deliver(ctx, channel, &m, delivery)
+		if delivery.Status == entity.NotificationDeliveryStatusSkipped {
+			continue
+		}
+		if err := ns.notificationDeliveryRepo.AddDelivery(ctx, delivery); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// DeliverDueCron delivers the notifications postponed by the quiet hours and retries the failed deliveries
+func (ns *NotificationChannelService) DeliverDueCron(ctx context.Context) {
+	deliveries, err := ns.notificationDeliveryRepo.GetDueDeliveries(ctx, time.Now(), dueDeliveryLimit)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, delivery := range deliveries {
+		msg, err := schema.NewNotificationChannelMsgFromJSON(delivery.Message)
+		channel := ns.getChannel(constant.NotificationChannelKey(delivery.Channel))
+		switch {
+		case err != nil:
+			delivery.Status = entity.NotificationDeliveryStatusFailed
+			delivery.ErrorMsg = truncateErrorMsg(err.Error())
+		case channel == nil:
+			delivery.Status = entity.NotificationDeliveryStatusSkipped
+			delivery.ErrorMsg = "channel is not available"
+		default:
+			ns.deliver(ctx, channel, msg, delivery)
+		}
+		if err := ns.notificationDeliveryRepo.UpdateDelivery(ctx, delivery); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// deliver renders and delivers the message, the result is set to the delivery
+func (ns *NotificationChannelService) deliver(ctx context.Context, channel Channel,
+	msg *schema.NotificationChannelMsg, delivery *entity.NotificationDelivery) {
+	delivery.Attempts++
+	rendered, err := channel.Render(ctx, msg)
+	if err == nil {
+		err = channel.Deliver(ctx, msg, rendered)
+	}
+	switch {
+	case err == nil:
+		delivery.Status = entity.NotificationDeliveryStatusSent
+		delivery.ErrorMsg = ""
+		delivery.DeliveredAt = time.Now()
+	case errors.Is(err, ErrReceiverUnreachable):
+		delivery.Status = entity.NotificationDeliveryStatusSkipped
+		delivery.ErrorMsg = truncateErrorMsg(err.Error())
+	case delivery.Attempts < maxDeliveryAttempts:
+		log.Warnf("deliver notification through %s failed: %v", channel.Key(), err)
+		delivery.Status = entity.NotificationDeliveryStatusPending
+		delivery.ErrorMsg = truncateErrorMsg(err.Error())
+		delivery.ScheduledAt = time.Now().Add(retryDelay * time.Duration(delivery.Attempts))
+	default:
+		log.Errorf("deliver notification through %s failed: %v", channel.Key(), err)
+		delivery.Status = entity.NotificationDeliveryStatusFailed
+		delivery.ErrorMsg = truncateErrorMsg(err.Error())
+	}
+}
+
+// newChannelMsg builds the message that is delivered through the channels
+func (ns *NotificationChannelService) newChannelMsg(ctx context.Context, notificationID string,
+	source constant.NotificationSource, objInfo *schema.SimpleObjectInfo, msg *schema.NotificationMsg) (
+	channelMsg *schema.NotificationChannelMsg, err error) {
+	siteInfo, err := ns.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seoInfo, err := ns.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	interfaceInfo, err := ns.siteInfoService.GetSiteInterface(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	channelMsg = &schema.NotificationChannelMsg{
+		NotificationMessage: plugin.NotificationMessage{
+			Type:           plugin.NotificationType(msg.NotificationAction),
+			ReceiverUserID: msg.ReceiverUserID,
+			TriggerUserID:  msg.TriggerUserID,
+			QuestionTitle:  msg.Title,
+		},
+		NotificationID: notificationID,
+		Source:         source,
+	}
+	if objInfo != nil {
+		channelMsg.QuestionID = uid.DeShortID(objInfo.QuestionID)
+		channelMsg.AnswerID = uid.DeShortID(objInfo.AnswerID)
+		channelMsg.CommentID = objInfo.CommentID
+		channelMsg.QuestionTitle = objInfo.Title
+		channelMsg.Content = objInfo.Content
+	}
+	if len(channelMsg.QuestionID) > 0 {
+		channelMsg.QuestionUrl = display.QuestionURL(
+			seoInfo.Permalink, siteInfo.SiteUrl, channelMsg.QuestionID, channelMsg.QuestionTitle)
+	}
+	if len(channelMsg.AnswerID) > 0 {
+		channelMsg.AnswerUrl = display.AnswerURL(
+			seoInfo.Permalink, siteInfo.SiteUrl, channelMsg.QuestionID, channelMsg.QuestionTitle, channelMsg.AnswerID)
+	}
+	if len(channelMsg.CommentID) > 0 {
+		channelMsg.CommentUrl = display.CommentURL(seoInfo.Permalink, siteInfo.SiteUrl,
+			channelMsg.QuestionID, channelMsg.QuestionTitle, channelMsg.AnswerID, channelMsg.CommentID)
+	}
+	if len(msg.TriggerUserID) > 0 {
+		triggerUser, exist, err := ns.userCommon.GetUserBasicInfoByID(ctx, msg.TriggerUserID)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			channelMsg.TriggerUserID = triggerUser.ID
+			channelMsg.TriggerUserDisplayName = triggerUser.DisplayName
+			channelMsg.TriggerUserUrl = display.UserURL(siteInfo.SiteUrl, triggerUser.Username)
+		}
+	}
+
+	receiver, _, _ := ns.userCommon.GetUserBasicInfoByID(ctx, msg.ReceiverUserID)
+	if receiver != nil {
+		channelMsg.ReceiverLang = receiver.Language
+	}
+	// If receiver not set language, use site default language.
+	if len(channelMsg.ReceiverLang) == 0 || channelMsg.ReceiverLang == translator.DefaultLangOption {
+		channelMsg.ReceiverLang = interfaceInfo.Language
+	}
+
+	lang := i18n.Language(channelMsg.ReceiverLang)
+	// the badge is earned by the receiver, so the description is not started with the trigger user
+	if msg.ObjectType == constant.BadgeAwardObjectType {
+		badgeName := translator.Tr(lang, msg.Title)
+		channelMsg.QuestionTitle = badgeName
+		channelMsg.QuestionUrl = fmt.Sprintf("%s/badges/%s", siteInfo.SiteUrl, msg.ExtraInfo["badge_id"])
+		channelMsg.Description = translator.TrWithData(lang, msg.NotificationAction, struct {
+			BadgeName string
+		}{BadgeName: badgeName})
+		return channelMsg, nil
+	}
+	action := translator.Tr(lang, msg.NotificationAction)
+	channelMsg.Description = strings.TrimSpace(channelMsg.TriggerUserDisplayName + " " + action)
+	return channelMsg, nil
+}
+
+func truncateErrorMsg(msg string) string {
+	runes := []rune(msg)
+	if len(runes) > 500 {
+		return string(runes[:500])
+	}
+	return msg
+}
@@ -0,0 +1,292 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification_channel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_notification_config"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeChannel struct {
+	key       constant.NotificationChannelKey
+	err       error
+	delivered []*schema.NotificationChannelMsg
+}
+
+func (c *fakeChannel) Key() constant.NotificationChannelKey {
+	return c.key
+}
+
+func (c *fakeChannel) Supports(source constant.NotificationSource) bool {
+	return source != constant.BadgeSource
+}
+
+func (c *fakeChannel) Render(ctx context.Context, msg *schema.NotificationChannelMsg) (*RenderedMessage, error) {
+	return &RenderedMessage{Title: msg.QuestionTitle, Body: msg.Description}, nil
+}
+
+func (c *fakeChannel) Deliver(ctx context.Context, msg *schema.NotificationChannelMsg, rendered *RenderedMessage) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.delivered = append(c.delivered, msg)
+	return nil
+}
+
+type fakeNotificationDeliveryRepo struct {
+	deliveries []*entity.NotificationDelivery
+}
+
+func (r *fakeNotificationDeliveryRepo) AddDelivery(ctx context.Context, delivery *entity.NotificationDelivery) error {
+	r.deliveries = append(r.deliveries, delivery)
+	return nil
+}
+
+func (r *fakeNotificationDeliveryRepo) UpdateDelivery(ctx context.Context, delivery *entity.NotificationDelivery) error {
+	return nil
+}
+
+func (r *fakeNotificationDeliveryRepo) GetDueDeliveries(ctx context.Context, before time.Time, limit int) (
+	[]*entity.NotificationDelivery, error) {
+	deliveries := make([]*entity.NotificationDelivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.Status == entity.NotificationDeliveryStatusPending && !delivery.ScheduledAt.After(before) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+type fakeUserNotificationConfigRepo struct {
+	user_notification_config.UserNotificationConfigRepo
+	configs []*entity.UserNotificationConfig
+}
+
+func (r *fakeUserNotificationConfigRepo) GetByUserID(ctx context.Context, userID string) (
+	[]*entity.UserNotificationConfig, error) {
+	return r.configs, nil
+}
+
+func (r *fakeUserNotificationConfigRepo) setConfig(source constant.NotificationSource, value any) {
+	data, _ := json.Marshal(value)
+	r.configs = append(r.configs, &entity.UserNotificationConfig{UserID: "1", Source: string(source), Channels: string(data)})
+}
+
+type fakeUserRepo struct {
+	usercommon.UserRepo
+}
+
+func (r *fakeUserRepo) GetByUserID(ctx context.Context, userID string) (*entity.User, bool, error) {
+	return &entity.User{ID: userID, Username: "u" + userID, DisplayName: "User" + userID}, true, nil
+}
+
+type fakeSiteInfoService struct {
+	siteinfo_common.SiteInfoCommonService
+}
+
+func (s *fakeSiteInfoService) GetSiteGeneral(ctx context.Context) (*schema.SiteGeneralResp, error) {
+	return &schema.SiteGeneralResp{SiteUrl: "https://example.com"}, nil
+}
+
+func (s *fakeSiteInfoService) GetSiteSeo(ctx context.Context) (*schema.SiteSeoResp, error) {
+	return &schema.SiteSeoResp{Permalink: constant.PermalinkQuestionID}, nil
+}
+
+func (s *fakeSiteInfoService) GetSiteInterface(ctx context.Context) (*schema.SiteInterfaceResp, error) {
+	return &schema.SiteInterfaceResp{Language: "en_US", TimeZone: "UTC"}, nil
+}
+
+func (s *fakeSiteInfoService) FormatAvatar(ctx context.Context, originalAvatarData, email string,
+	userStatus int) *schema.AvatarInfo {
+	return &schema.AvatarInfo{}
+}
+
+func newTestChannelService(configRepo *fakeUserNotificationConfigRepo) (
+	*NotificationChannelService, *fakeNotificationDeliveryRepo) {
+	deliveryRepo := &fakeNotificationDeliveryRepo{}
+	siteInfoService := &fakeSiteInfoService{}
+	userRepo := &fakeUserRepo{}
+	ns := &NotificationChannelService{
+		notificationDeliveryRepo:      deliveryRepo,
+		userNotificationConfigService: user_notification_config.NewUserNotificationConfigService(userRepo, configRepo, nil),
+		userCommon:                    usercommon.NewUserCommon(userRepo, nil, nil, siteInfoService),
+		siteInfoService:               siteInfoService,
+	}
+	return ns, deliveryRepo
+}
+
+func newAnswerNotificationMsg() *schema.NotificationMsg {
+	return &schema.NotificationMsg{
+		TriggerUserID:      "2",
+		ReceiverUserID:     "1",
+		Type:               schema.NotificationTypeInbox,
+		NotificationAction: constant.NotificationAnswerTheQuestion,
+	}
+}
+
+func newAnswerObjectInfo() *schema.SimpleObjectInfo {
+	return &schema.SimpleObjectInfo{QuestionID: "10010000000000001", AnswerID: "10020000000000001", Title: "title"}
+}
+
+func TestNotificationChannelService_Route(t *testing.T) {
+	ctx := context.Background()
+	configRepo := &fakeUserNotificationConfigRepo{}
+	configRepo.setConfig(constant.AnswerToMyQuestionSource, schema.NotificationChannels{
+		{Key: constant.WebhookChannel, Enable: true},
+	})
+	configRepo.setConfig(constant.ChannelTargetSource, schema.NotificationChannelTargets{
+		{Key: constant.WebhookChannel, Target: "https://hooks.example.com/1"},
+	})
+	ns, deliveryRepo := newTestChannelService(configRepo)
+	mixin := &fakeChannel{key: constant.MixinChannel}
+	webhook := &fakeChannel{key: constant.WebhookChannel}
+	chat := &fakeChannel{key: constant.ChatWebhookChannel}
+	ns.Register(mixin)
+	ns.Register(webhook)
+	ns.Register(chat)
+
+	ns.Route(ctx, "100", newAnswerObjectInfo(), newAnswerNotificationMsg())
+
+	// mixin is enabled by default, chat webhook is not chosen by the user
+	assert.Len(t, mixin.delivered, 1)
+	assert.Len(t, webhook.delivered, 1)
+	assert.Len(t, chat.delivered, 0)
+	msg := webhook.delivered[0]
+	assert.Equal(t, "https://hooks.example.com/1", msg.Target)
+	assert.Equal(t, constant.AnswerToMyQuestionSource, msg.Source)
+	assert.Equal(t, "https://example.com/questions/10010000000000001/10020000000000001", msg.AnswerUrl)
+	assert.Equal(t, "User2 "+constant.NotificationAnswerTheQuestion, msg.Description)
+	assert.Equal(t, "en_US", msg.ReceiverLang)
+
+	assert.Len(t, deliveryRepo.deliveries, 2)
+	for _, delivery := range deliveryRepo.deliveries {
+		assert.Equal(t, entity.NotificationDeliveryStatusSent, delivery.Status)
+		assert.Equal(t, "100", delivery.NotificationID)
+		assert.Equal(t, 1, delivery.Attempts)
+	}
+}
+
+func TestNotificationChannelService_RouteUnreachable(t *testing.T) {
+	ns, deliveryRepo := newTestChannelService(&fakeUserNotificationConfigRepo{})
+	ns.Register(&fakeChannel{key: constant.MixinChannel, err: ErrReceiverUnreachable})
+
+	ns.Route(context.Background(), "100", newAnswerObjectInfo(), newAnswerNotificationMsg())
+	assert.Len(t, deliveryRepo.deliveries, 0)
+}
+
+func TestNotificationChannelService_RouteQuietHours(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	configRepo := &fakeUserNotificationConfigRepo{}
+	configRepo.setConfig(constant.QuietHoursSource, &schema.NotificationQuietHours{
+		Enable:   true,
+		Start:    now.Add(-time.Hour).Format("15:04"),
+		End:      now.Add(time.Hour).Format("15:04"),
+		TimeZone: "UTC",
+	})
+	ns, deliveryRepo := newTestChannelService(configRepo)
+	mixin := &fakeChannel{key: constant.MixinChannel}
+	ns.Register(mixin)
+
+	ns.Route(ctx, "100", newAnswerObjectInfo(), newAnswerNotificationMsg())
+	assert.Len(t, mixin.delivered, 0)
+	assert.Len(t, deliveryRepo.deliveries, 1)
+	delivery := deliveryRepo.deliveries[0]
+	assert.Equal(t, entity.NotificationDeliveryStatusPending, delivery.Status)
+	assert.True(t, delivery.ScheduledAt.After(now))
+
+	// the delivery is not due until the quiet hours end
+	ns.DeliverDueCron(ctx)
+	assert.Len(t, mixin.delivered, 0)
+
+	delivery.ScheduledAt = now.Add(-time.Minute)
+	ns.DeliverDueCron(ctx)
+	assert.Len(t, mixin.delivered, 1)
+	assert.Equal(t, entity.NotificationDeliveryStatusSent, delivery.Status)
+	assert.Equal(t, "https://example.com/questions/10010000000000001/10020000000000001",
+		mixin.delivered[0].AnswerUrl)
+}
+
+func TestNotificationChannelService_Retry(t *testing.T) {
+	ctx := context.Background()
+	ns, deliveryRepo := newTestChannelService(&fakeUserNotificationConfigRepo{})
+	mixin := &fakeChannel{key: constant.MixinChannel, err: errors.New("network error")}
+	ns.Register(mixin)
+
+	ns.Route(ctx, "100", newAnswerObjectInfo(), newAnswerNotificationMsg())
+	assert.Len(t, deliveryRepo.deliveries, 1)
+	delivery := deliveryRepo.deliveries[0]
+	for i := 1; i < maxDeliveryAttempts; i++ {
+		assert.Equal(t, entity.NotificationDeliveryStatusPending, delivery.Status)
+		assert.Equal(t, i, delivery.Attempts)
+		assert.Equal(t, "network error", delivery.ErrorMsg)
+		delivery.ScheduledAt = time.Now().Add(-time.Minute)
+		ns.DeliverDueCron(ctx)
+	}
+	assert.Equal(t, entity.NotificationDeliveryStatusFailed, delivery.Status)
+	assert.Equal(t, maxDeliveryAttempts, delivery.Attempts)
+}
+
+func TestNotificationChannelService_RouteBadge(t *testing.T) {
+	configRepo := &fakeUserNotificationConfigRepo{}
+	configRepo.setConfig(constant.BadgeSource, schema.NotificationChannels{
+		{Key: constant.WebhookChannel, Enable: true},
+	})
+	ns, _ := newTestChannelService(configRepo)
+	mixin := &fakeChannel{key: constant.MixinChannel}
+	webhook := &allSourcesChannel{fakeChannel{key: constant.WebhookChannel}}
+	ns.Register(mixin)
+	ns.Register(webhook)
+
+	ns.Route(context.Background(), "100", nil, &schema.NotificationMsg{
+		TriggerUserID:      "1",
+		ReceiverUserID:     "1",
+		Type:               schema.NotificationTypeAchievement,
+		ObjectType:         constant.BadgeAwardObjectType,
+		Title:              "Nice Question",
+		ExtraInfo:          map[string]string{"badge_id": "5"},
+		NotificationAction: constant.NotificationEarnedBadge,
+	})
+	assert.Len(t, mixin.delivered, 0)
+	assert.Len(t, webhook.delivered, 1)
+	msg := webhook.delivered[0]
+	assert.Equal(t, constant.BadgeSource, msg.Source)
+	assert.Equal(t, "Nice Question", msg.QuestionTitle)
+	assert.Equal(t, "https://example.com/badges/5", msg.QuestionUrl)
+}
+
+type allSourcesChannel struct {
+	fakeChannel
+}
+
+func (c *allSourcesChannel) Supports(source constant.NotificationSource) bool {
+	return true
+}
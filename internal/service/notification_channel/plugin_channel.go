@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification_channel
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/user_external_login"
+	"github.com/apache/incubator-answer/plugin"
+)
+
+// pluginChannel sends the notification to the notification plugin, the plugin renders the message itself
+type pluginChannel struct {
+	fn                    plugin.Notification
+	userExternalLoginRepo user_external_login.UserExternalLoginRepo
+}
+
+func newPluginChannel(fn plugin.Notification,
+	userExternalLoginRepo user_external_login.UserExternalLoginRepo) Channel {
+	return &pluginChannel{fn: fn, userExternalLoginRepo: userExternalLoginRepo}
+}
+
+func (c *pluginChannel) Key() constant.NotificationChannelKey {
+	return constant.NewPluginChannelKey(c.fn.Info().SlugName)
+}
+
+func (c *pluginChannel) Supports(source constant.NotificationSource) bool {
+	return source != constant.BadgeSource
+}
+
+func (c *pluginChannel) Render(ctx context.Context, msg *schema.NotificationChannelMsg) (*RenderedMessage, error) {
+	return &RenderedMessage{Title: msg.QuestionTitle, Body: msg.Description}, nil
+}
+
+func (c *pluginChannel) Deliver(ctx context.Context, msg *schema.NotificationChannelMsg, rendered *RenderedMessage) error {
+	pluginMsg := msg.NotificationMessage
+	pluginMsg.Content = ""
+	pluginMsg.ReceiverExternalID = ""
+	userInfo, exist, err := c.userExternalLoginRepo.GetByUserID(ctx, c.fn.Info().SlugName, msg.ReceiverUserID)
+	if err != nil {
+		return err
+	}
+	if exist {
+		pluginMsg.ReceiverExternalID = userInfo.ExternalID
+	}
+	return plugin.TraceCall(ctx, c.fn, func(_ context.Context) error {
+		c.fn.Notify(pluginMsg)
+		return nil
+	})
+}
@@ -0,0 +1,193 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification_channel
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/goccy/go-json"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// webhookPayload the payload posted to the webhook
+type webhookPayload struct {
+	NotificationID string `json:"notification_id"`
+	Type           string `json:"type"`
+	Source         string `json:"source"`
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	Url            string `json:"url"`
+	TriggerUser    string `json:"trigger_user"`
+	TriggerUserUrl string `json:"trigger_user_url"`
+	QuestionUrl    string `json:"question_url"`
+	AnswerUrl      string `json:"answer_url"`
+	CommentUrl     string `json:"comment_url"`
+}
+
+// chatWebhookPayload the payload of the incoming webhooks of Slack and the Matrix bridges
+type chatWebhookPayload struct {
+	Text string `json:"text"`
+}
+
+// webhookChannel posts the notification to the webhook set by the user.
+// If chat is true, the notification is posted as the text message that Slack and Matrix incoming webhooks accept.
+type webhookChannel struct {
+	chat   bool
+	client *http.Client
+}
+
+// NewWebhookChannel new webhook channel
+func NewWebhookChannel() Channel {
+	return &webhookChannel{client: newWebhookClient()}
+}
+
+// NewChatWebhookChannel new chat webhook channel that is compatible with the incoming webhooks of Slack and Matrix
+func NewChatWebhookChannel() Channel {
+	return &webhookChannel{chat: true, client: newWebhookClient()}
+}
+
+func (c *webhookChannel) Key() constant.NotificationChannelKey {
+	if c.chat {
+		return constant.ChatWebhookChannel
+	}
+	return constant.WebhookChannel
+}
+
+func (c *webhookChannel) Supports(source constant.NotificationSource) bool {
+	return true
+}
+
+func (c *webhookChannel) Render(ctx context.Context, msg *schema.NotificationChannelMsg) (*RenderedMessage, error) {
+	url := getMessageURL(msg)
+	var (
+		body []byte
+		err  error
+	)
+	if c.chat {
+		text := msg.Description
+		if len(msg.QuestionTitle) > 0 {
+			text += "\n" + msg.QuestionTitle
+		}
+		if len(url) > 0 {
+			text += "\n" + url
+		}
+		body, err = json.Marshal(&chatWebhookPayload{Text: strings.TrimSpace(text)})
+	} else {
+		body, err = json.Marshal(&webhookPayload{
+			NotificationID: msg.NotificationID,
+			Type:           string(msg.Type),
+			Source:         string(msg.Source),
+			Title:          msg.QuestionTitle,
+			Description:    msg.Description,
+			Url:            url,
+			TriggerUser:    msg.TriggerUserDisplayName,
+			TriggerUserUrl: msg.TriggerUserUrl,
+			QuestionUrl:    msg.QuestionUrl,
+			AnswerUrl:      msg.AnswerUrl,
+			CommentUrl:     msg.CommentUrl,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &RenderedMessage{Title: msg.QuestionTitle, Body: string(body)}, nil
+}
+
+func (c *webhookChannel) Deliver(ctx context.Context, msg *schema.NotificationChannelMsg, rendered *RenderedMessage) error {
+	if len(msg.Target) == 0 {
+		return ErrReceiverUnreachable
+	}
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, msg.Target, bytes.NewBufferString(rendered.Body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// getMessageURL the url of the most specific object of the notification
+func getMessageURL(msg *schema.NotificationChannelMsg) string {
+	switch {
+	case len(msg.CommentUrl) > 0:
+		return msg.CommentUrl
+	case len(msg.AnswerUrl) > 0:
+		return msg.AnswerUrl
+	default:
+		return msg.QuestionUrl
+	}
+}
+
+// newWebhookClient the client refuses to connect to the loopback, private and link-local addresses,
+// so that the webhooks set by users can not reach the internal network
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("webhook address %s is not allowed", host)
+			}
+			return nil
+		},
+	}
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: webhookTimeout,
+		MaxIdleConns:        10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   webhookTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
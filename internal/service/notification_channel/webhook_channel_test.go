@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification_channel
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	assert.True(t, isPublicIP(net.ParseIP("8.8.8.8")))
+	assert.True(t, isPublicIP(net.ParseIP("2001:4860:4860::8888")))
+	assert.False(t, isPublicIP(net.ParseIP("127.0.0.1")))
+	assert.False(t, isPublicIP(net.ParseIP("10.0.0.1")))
+	assert.False(t, isPublicIP(net.ParseIP("192.168.1.1")))
+	assert.False(t, isPublicIP(net.ParseIP("169.254.169.254")))
+	assert.False(t, isPublicIP(net.ParseIP("::1")))
+	assert.False(t, isPublicIP(net.ParseIP("0.0.0.0")))
+}
+
+func TestWebhookChannel(t *testing.T) {
+	ctx := context.Background()
+	msg := &schema.NotificationChannelMsg{
+		NotificationMessage: plugin.NotificationMessage{
+			QuestionTitle: "title",
+			QuestionUrl:   "https://example.com/questions/1",
+			CommentUrl:    "https://example.com/questions/1?commentId=2",
+		},
+		Description: "someone commented on your post",
+	}
+
+	rendered, err := NewChatWebhookChannel().Render(ctx, msg)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"text":"someone commented on your post\ntitle\nhttps://example.com/questions/1?commentId=2"}`,
+		rendered.Body)
+
+	// the webhook without target is skipped
+	channel := NewWebhookChannel()
+	rendered, err = channel.Render(ctx, msg)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, channel.Deliver(ctx, msg, rendered), ErrReceiverUnreachable)
+
+	// the webhook in the internal network is refused
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+	msg.Target = server.URL
+	assert.Error(t, channel.Deliver(ctx, msg, rendered))
+	assert.False(t, called)
+}
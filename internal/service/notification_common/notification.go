@@ -24,12 +24,8 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/apache/incubator-answer/internal/base/translator"
-	"github.com/apache/incubator-answer/internal/service/mixinbot"
-	mixinbotlang "github.com/apache/incubator-answer/internal/service/mixinbot/lang"
-	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
-	"github.com/apache/incubator-answer/internal/service/user_external_login"
-	"github.com/apache/incubator-answer/pkg/display"
+	"github.com/apache/incubator-answer/internal/service/notification_channel"
+	"github.com/apache/incubator-answer/internal/service/space"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/data"
@@ -60,17 +56,15 @@ type NotificationRepo interface {
 }
 
 type NotificationCommon struct {
-	data                     *data.Data
-	notificationRepo         NotificationRepo
-	activityRepo             activity_common.ActivityRepo
-	followRepo               activity_common.FollowRepo
-	userCommon               *usercommon.UserCommon
-	objectInfoService        *object_info.ObjService
-	notificationQueueService notice_queue.NotificationQueueService
-	userExternalLoginRepo    user_external_login.UserExternalLoginRepo
-	siteInfoService          siteinfo_common.SiteInfoCommonService
-	mixinbotService          *mixinbot.MixinBotService
-	langPicker               *mixinbotlang.LangPicker
+	data                       *data.Data
+	notificationRepo           NotificationRepo
+	activityRepo               activity_common.ActivityRepo
+	followRepo                 activity_common.FollowRepo
+	userCommon                 *usercommon.UserCommon
+	objectInfoService          *object_info.ObjService
+	notificationQueueService   notice_queue.NotificationQueueService
+	notificationChannelService *notification_channel.NotificationChannelService
+	spaceService               *space.SpaceService
 }
 
 func NewNotificationCommon(
@@ -81,22 +75,19 @@ func NewNotificationCommon(
 	followRepo activity_common.FollowRepo,
 	objectInfoService *object_info.ObjService,
 	notificationQueueService notice_queue.NotificationQueueService,
-	userExternalLoginRepo user_external_login.UserExternalLoginRepo,
-	siteInfoService siteinfo_common.SiteInfoCommonService,
-	mixinbotService *mixinbot.MixinBotService,
+	notificationChannelService *notification_channel.NotificationChannelService,
+	spaceService *space.SpaceService,
 ) *NotificationCommon {
 	notification := &NotificationCommon{
-		data:                     data,
-		notificationRepo:         notificationRepo,
-		activityRepo:             activityRepo,
-		followRepo:               followRepo,
-		userCommon:               userCommon,
-		objectInfoService:        objectInfoService,
-		notificationQueueService: notificationQueueService,
-		userExternalLoginRepo:    userExternalLoginRepo,
-		siteInfoService:          siteInfoService,
-		mixinbotService:          mixinbotService,
-		langPicker:               mixinbotlang.NewLangPicker(),
+		data:                       data,
+		notificationRepo:           notificationRepo,
+		activityRepo:               activityRepo,
+		followRepo:                 followRepo,
+		userCommon:                 userCommon,
+		objectInfoService:          objectInfoService,
+		notificationQueueService:   notificationQueueService,
+		notificationChannelService: notificationChannelService,
+		spaceService:               spaceService,
 	}
 	notificationQueueService.RegisterHandler(notification.AddNotification)
 	return notification
@@ -148,6 +139,18 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 		}
 	}
 
+	// the receiver who can not access the question in restricted space should not be notified
+	if len(questionID) > 0 && len(req.ReceiverUserID) > 0 {
+		canAccess, err := ns.spaceService.CanUserAccessQuestion(ctx, req.ReceiverUserID, questionID)
+		if err != nil {
+			return fmt.Errorf("check user space access error: %w", err)
+		}
+		if !canAccess {
+			go ns.SendNotificationToAllFollower(ctx, msg, questionID)
+			return nil
+		}
+	}
+
 	if msg.Type == schema.NotificationTypeAchievement {
 		notificationInfo, exist, err := ns.notificationRepo.GetByUserIdObjectIdTypeId(ctx, req.ReceiverUserID, req.ObjectInfo.ObjectID, req.Type)
 		if err != nil {
@@ -214,9 +217,8 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 
 	go ns.SendNotificationToAllFollower(ctx, msg, questionID)
 
-	if msg.Type == schema.NotificationTypeInbox {
-		go ns.syncNotificationToMixin(ctx, objInfo, msg)
-		ns.syncNotificationToPlugin(ctx, objInfo, msg)
+	if msg.Type == schema.NotificationTypeInbox || msg.ObjectType == constant.BadgeAwardObjectType {
+		go ns.notificationChannelService.Route(ctx, info.ID, objInfo, msg)
 	}
 	return nil
 }
@@ -357,190 +359,3 @@ func (ns *NotificationCommon) SendNotificationToAllFollower(ctx context.Context,
 		ns.notificationQueueService.Send(ctx, t)
 	}
 }
-
-func (ns *NotificationCommon) syncNotificationToMixin(ctx context.Context, objInfo *schema.SimpleObjectInfo,
-	msg *schema.NotificationMsg) {
-	if ns.mixinbotService == nil {
-		log.Debugf("mixinbot service is not initialized")
-		return
-	}
-
-	mixinNotificationMsg, err := ns.getMixinNotificationMsg(ctx, objInfo, msg)
-	if err != nil {
-		log.Errorf("get mixin notification msg failed: %v", err)
-		return
-	}
-
-	if mixinNotificationMsg == nil {
-		log.Debugf("mixin notification msg is nil")
-		return
-	}
-
-	if len(mixinNotificationMsg.ReceiverExternalID) == 0 {
-		log.Debugf("receiver external id is empty")
-		return
-	}
-
-	description := ns.langPicker.Pick(mixinbotlang.GetLanguage(mixinNotificationMsg.ReceiverLang)).TranslateDescription(mixinNotificationMsg)
-	ns.sendMixinNotification(description, mixinNotificationMsg)
-}
-
-func (ns *NotificationCommon) getMixinNotificationMsg(ctx context.Context, objInfo *schema.SimpleObjectInfo,
-	msg *schema.NotificationMsg) (*plugin.NotificationMessage, error) {
-	siteInfo, err := ns.siteInfoService.GetSiteGeneral(ctx)
-	if err != nil {
-		log.Errorf("get site general info failed: %v", err)
-		return nil, err
-	}
-	seoInfo, err := ns.siteInfoService.GetSiteSeo(ctx)
-	if err != nil {
-		log.Errorf("get site seo info failed: %v", err)
-		return nil, err
-	}
-	interfaceInfo, err := ns.siteInfoService.GetSiteInterface(ctx)
-	if err != nil {
-		log.Errorf("get site interface info failed: %v", err)
-		return nil, err
-	}
-
-	objInfo.QuestionID = uid.DeShortID(objInfo.QuestionID)
-	objInfo.AnswerID = uid.DeShortID(objInfo.AnswerID)
-	pluginNotificationMsg := plugin.NotificationMessage{
-		Type:           plugin.NotificationType(msg.NotificationAction),
-		ReceiverUserID: msg.ReceiverUserID,
-		TriggerUserID:  msg.TriggerUserID,
-		QuestionTitle:  objInfo.Title,
-		Content:        objInfo.Content,
-	}
-
-	if len(objInfo.QuestionID) > 0 {
-		pluginNotificationMsg.QuestionUrl =
-			display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, objInfo.QuestionID, objInfo.Title)
-	}
-	if len(objInfo.AnswerID) > 0 {
-		pluginNotificationMsg.AnswerUrl =
-			display.AnswerURL(seoInfo.Permalink, siteInfo.SiteUrl, objInfo.QuestionID, objInfo.Title, objInfo.AnswerID)
-	}
-	if len(objInfo.CommentID) > 0 {
-		pluginNotificationMsg.CommentUrl =
-			display.CommentURL(seoInfo.Permalink, siteInfo.SiteUrl, objInfo.QuestionID, objInfo.Title, objInfo.AnswerID, objInfo.CommentID)
-	}
-
-	if len(msg.TriggerUserID) > 0 {
-		triggerUser, exist, err := ns.userCommon.GetUserBasicInfoByID(ctx, msg.TriggerUserID)
-		if err != nil {
-			log.Errorf("get trigger user basic info failed: %v", err)
-			return nil, err
-		}
-		if exist {
-			pluginNotificationMsg.TriggerUserID = triggerUser.ID
-			pluginNotificationMsg.TriggerUserDisplayName = triggerUser.DisplayName
-			pluginNotificationMsg.TriggerUserUrl = display.UserURL(siteInfo.SiteUrl, triggerUser.Username)
-		}
-
-	}
-
-	if len(pluginNotificationMsg.ReceiverUserID) > 0 {
-		receiverUserExternalLogin, exist, err := ns.userExternalLoginRepo.GetByUserID(ctx, "basic", msg.ReceiverUserID)
-		if err != nil {
-			log.Errorf("get user external login info failed: %v", err)
-			return nil, err
-		}
-
-		if !exist {
-			return nil, err
-		}
-		pluginNotificationMsg.ReceiverExternalID = receiverUserExternalLogin.ExternalID
-	}
-
-	if len(pluginNotificationMsg.ReceiverLang) == 0 && len(msg.ReceiverUserID) > 0 {
-		userInfo, _, _ := ns.userCommon.GetUserBasicInfoByID(ctx, msg.ReceiverUserID)
-		if userInfo != nil {
-			pluginNotificationMsg.ReceiverLang = userInfo.Language
-		}
-		// If receiver not set language, use site default language.
-		if len(pluginNotificationMsg.ReceiverLang) == 0 || pluginNotificationMsg.ReceiverLang == translator.DefaultLangOption {
-			pluginNotificationMsg.ReceiverLang = interfaceInfo.Language
-		}
-	}
-
-	return &pluginNotificationMsg, nil
-}
-
-func (ns *NotificationCommon) syncNotificationToPlugin(ctx context.Context, objInfo *schema.SimpleObjectInfo,
-	msg *schema.NotificationMsg) {
-	siteInfo, err := ns.siteInfoService.GetSiteGeneral(ctx)
-	if err != nil {
-		log.Errorf("get site general info failed: %v", err)
-		return
-	}
-	seoInfo, err := ns.siteInfoService.GetSiteSeo(ctx)
-	if err != nil {
-		log.Errorf("get site seo info failed: %v", err)
-		return
-	}
-	interfaceInfo, err := ns.siteInfoService.GetSiteInterface(ctx)
-	if err != nil {
-		log.Errorf("get site interface info failed: %v", err)
-		return
-	}
-
-	objInfo.QuestionID = uid.DeShortID(objInfo.QuestionID)
-	objInfo.AnswerID = uid.DeShortID(objInfo.AnswerID)
-	pluginNotificationMsg := plugin.NotificationMessage{
-		Type:           plugin.NotificationType(msg.NotificationAction),
-		ReceiverUserID: msg.ReceiverUserID,
-		TriggerUserID:  msg.TriggerUserID,
-		QuestionTitle:  objInfo.Title,
-	}
-
-	if len(objInfo.QuestionID) > 0 {
-		pluginNotificationMsg.QuestionUrl =
-			display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, objInfo.QuestionID, objInfo.Title)
-	}
-	if len(objInfo.AnswerID) > 0 {
-		pluginNotificationMsg.AnswerUrl =
-			display.AnswerURL(seoInfo.Permalink, siteInfo.SiteUrl, objInfo.QuestionID, objInfo.Title, objInfo.AnswerID)
-	}
-	if len(objInfo.CommentID) > 0 {
-		pluginNotificationMsg.CommentUrl =
-			display.CommentURL(seoInfo.Permalink, siteInfo.SiteUrl, objInfo.QuestionID, objInfo.Title, objInfo.AnswerID, objInfo.CommentID)
-	}
-
-	if len(msg.TriggerUserID) > 0 {
-		triggerUser, exist, err := ns.userCommon.GetUserBasicInfoByID(ctx, msg.TriggerUserID)
-		if err != nil {
-			log.Errorf("get trigger user basic info failed: %v", err)
-			return
-		}
-		if exist {
-			pluginNotificationMsg.TriggerUserID = triggerUser.ID
-			pluginNotificationMsg.TriggerUserDisplayName = triggerUser.DisplayName
-			pluginNotificationMsg.TriggerUserUrl = display.UserURL(siteInfo.SiteUrl, triggerUser.Username)
-		}
-	}
-
-	if len(pluginNotificationMsg.ReceiverLang) == 0 && len(msg.ReceiverUserID) > 0 {
-		userInfo, _, _ := ns.userCommon.GetUserBasicInfoByID(ctx, msg.ReceiverUserID)
-		if userInfo != nil {
-			pluginNotificationMsg.ReceiverLang = userInfo.Language
-		}
-		// If receiver not set language, use site default language.
-		if len(pluginNotificationMsg.ReceiverLang) == 0 || pluginNotificationMsg.ReceiverLang == translator.DefaultLangOption {
-			pluginNotificationMsg.ReceiverLang = interfaceInfo.Language
-		}
-	}
-
-	_ = plugin.CallNotification(func(fn plugin.Notification) error {
-		userInfo, exist, err := ns.userExternalLoginRepo.GetByUserID(ctx, fn.Info().SlugName, msg.ReceiverUserID)
-		if err != nil {
-			log.Errorf("get user external login info failed: %v", err)
-			return nil
-		}
-		if exist {
-			pluginNotificationMsg.ReceiverExternalID = userInfo.ExternalID
-		}
-		fn.Notify(pluginNotificationMsg)
-		return nil
-	})
-}
@@ -30,7 +30,7 @@ import (
 
 // GetAnswerPermission get answer permission
 func GetAnswerPermission(ctx context.Context, userID, creatorUserID string,
-	status int, canEdit, canDelete, canRecover bool) (
+	status int, canEdit, canDelete, canRecover, canWiki, canUnWiki bool) (
 	actions []*schema.PermissionMemberAction) {
 	lang := handler.GetLangByCtx(ctx)
 	actions = make([]*schema.PermissionMemberAction, 0)
@@ -49,6 +49,22 @@ func GetAnswerPermission(ctx context.Context, userID, creatorUserID string,
 		})
 	}
 
+	if canWiki {
+		actions = append(actions, &schema.PermissionMemberAction{
+			Action: "wiki",
+			Name:   translator.Tr(lang, wikiActionName),
+			Type:   "confirm",
+		})
+	}
+
+	if canUnWiki {
+		actions = append(actions, &schema.PermissionMemberAction{
+			Action: "unwiki",
+			Name:   translator.Tr(lang, unwikiActionName),
+			Type:   "confirm",
+		})
+	}
+
 	if (canDelete || userID == creatorUserID) && status != entity.AnswerStatusDeleted {
 		actions = append(actions, &schema.PermissionMemberAction{
 			Action: "delete",
@@ -62,6 +62,10 @@ const (
 	AnswerUnDelete              = "answer.undeleted"
 	QuestionUnDelete            = "question.undeleted"
 	TagUnDelete                 = "tag.undeleted"
+	WikiEdit                    = "wiki.edit"
+	QuestionWiki                = "question.wiki"
+	AnswerWiki                  = "answer.wiki"
+	QuestionSchedule            = "question.schedule"
 )
 
 const (
@@ -76,4 +80,6 @@ const (
 	hideActionName                  = "action.hide"
 	showActionName                  = "action.show"
 	inviteSomeoneToAnswerActionName = "action.invite_someone_to_answer"
+	wikiActionName                  = "action.wiki"
+	unwikiActionName                = "action.unwiki"
 )
@@ -30,7 +30,7 @@ import (
 
 // GetQuestionPermission get question permission
 func GetQuestionPermission(ctx context.Context, userID string, creatorUserID string, status int,
-	canEdit, canDelete, canClose, canReopen, canPin, canHide, canUnPin, canShow, canRecover bool) (
+	canEdit, canDelete, canClose, canReopen, canPin, canHide, canUnPin, canShow, canRecover, canWiki, canUnWiki bool) (
 	actions []*schema.PermissionMemberAction) {
 	lang := handler.GetLangByCtx(ctx)
 	actions = make([]*schema.PermissionMemberAction, 0)
@@ -93,6 +93,22 @@ func GetQuestionPermission(ctx context.Context, userID string, creatorUserID str
 		})
 	}
 
+	if canWiki {
+		actions = append(actions, &schema.PermissionMemberAction{
+			Action: "wiki",
+			Name:   translator.Tr(lang, wikiActionName),
+			Type:   "confirm",
+		})
+	}
+
+	if canUnWiki {
+		actions = append(actions, &schema.PermissionMemberAction{
+			Action: "unwiki",
+			Name:   translator.Tr(lang, unwikiActionName),
+			Type:   "confirm",
+		})
+	}
+
 	if (canDelete || userID == creatorUserID) && status != entity.QuestionStatusDeleted {
 		actions = append(actions, &schema.PermissionMemberAction{
 			Action: "delete",
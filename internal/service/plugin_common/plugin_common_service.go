@@ -34,6 +34,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/plugin"
 )
 
@@ -93,7 +94,7 @@ func (ps *PluginCommonService) UpdatePluginConfig(ctx context.Context, req *sche
 		return err
 	}
 
-	_ = plugin.CallSearch(func(search plugin.Search) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallSearch, func(ctx context.Context, search plugin.Search) error {
 		if search.Info().SlugName == req.PluginSlugName {
 			search.RegisterSyncer(ctx, search_sync.NewPluginSyncer(ps.data))
 		}
@@ -154,11 +155,14 @@ func (ps *PluginCommonService) initPluginData() {
 		}
 
 		_ = plugin.CallCache(func(cache plugin.Cache) error {
-			ps.data.Cache = cache
+			ps.data.Cache = plugin.NewTracedCache(cache)
 			return nil
 		})
 	}
 
+	// init markdown extensions of plugins
+	converter.RegisterMarkdownExtensionsFunc(plugin.GetMarkdownExtenders)
+
 	// init plugin user config
 	plugin.RegisterGetPluginUserConfigFunc(func(userID, pluginSlugName string) []byte {
 		pluginUserConfig, exist, err := ps.pluginUserConfigRepo.GetPluginUserConfig(context.Background(), userID, pluginSlugName)
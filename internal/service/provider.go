@@ -24,6 +24,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity"
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	"github.com/apache/incubator-answer/internal/service/analytics"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
 	"github.com/apache/incubator-answer/internal/service/auth"
 	"github.com/apache/incubator-answer/internal/service/badge"
@@ -34,35 +35,46 @@ import (
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/content"
 	"github.com/apache/incubator-answer/internal/service/dashboard"
+	"github.com/apache/incubator-answer/internal/service/draft"
 	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/external_profile"
 	"github.com/apache/incubator-answer/internal/service/follow"
 	"github.com/apache/incubator-answer/internal/service/meta"
 	metacommon "github.com/apache/incubator-answer/internal/service/meta_common"
 	"github.com/apache/incubator-answer/internal/service/mixinbot"
+	mixinbotcommand "github.com/apache/incubator-answer/internal/service/mixinbot/command"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/notification"
+	"github.com/apache/incubator-answer/internal/service/notification_channel"
 	notficationcommon "github.com/apache/incubator-answer/internal/service/notification_common"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	"github.com/apache/incubator-answer/internal/service/plugin_common"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/question_view"
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/internal/service/reason"
 	"github.com/apache/incubator-answer/internal/service/report"
 	"github.com/apache/incubator-answer/internal/service/report_handle"
+	"github.com/apache/incubator-answer/internal/service/reputation"
 	"github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/scim"
 	"github.com/apache/incubator-answer/internal/service/search_parser"
 	"github.com/apache/incubator-answer/internal/service/siteinfo"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/space"
 	"github.com/apache/incubator-answer/internal/service/tag"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/internal/service/tag_moderator"
 	"github.com/apache/incubator-answer/internal/service/uploader"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_data"
 	"github.com/apache/incubator-answer/internal/service/user_external_login"
 	"github.com/apache/incubator-answer/internal/service/user_notification_config"
+	"github.com/apache/incubator-answer/internal/service/user_suspension"
 	"github.com/google/wire"
 )
 
@@ -97,10 +109,14 @@ var ProviderSetService = wire.NewSet(
 	object_info.NewObjService,
 	report_handle.NewReportHandle,
 	user_admin.NewUserAdminService,
+	user_suspension.NewUserSuspensionService,
+	reputation.NewReputationService,
+	draft.NewDraftService,
 	reason.NewReasonService,
 	siteinfo_common.NewSiteInfoCommonService,
 	siteinfo.NewSiteInfoService,
 	notficationcommon.NewNotificationCommon,
+	notification_channel.NewNotificationChannelService,
 	notification.NewNotificationService,
 	activity.NewAnswerActivityService,
 	dashboard.NewDashboardService,
@@ -111,6 +127,7 @@ var ProviderSetService = wire.NewSet(
 	role.NewRolePowerRelService,
 	user_external_login.NewUserExternalLoginService,
 	user_external_login.NewUserCenterLoginService,
+	external_profile.NewExternalProfileService,
 	plugin_common.NewPluginCommonService,
 	config.NewConfigService,
 	notice_queue.NewNotificationQueueService,
@@ -126,4 +143,11 @@ var ProviderSetService = wire.NewSet(
 	badge.NewBadgeAwardService,
 	badge.NewBadgeGroupService,
 	mixinbot.NewMixinBotService,
+	mixinbotcommand.NewMixinBotCommandService,
+	scim.NewScimService,
+	user_data.NewUserDataService,
+	space.NewSpaceService,
+	tag_moderator.NewTagModeratorService,
+	question_view.NewQuestionViewService,
+	analytics.NewAnalyticsService,
 )
@@ -32,8 +32,10 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/external_profile"
 	metacommon "github.com/apache/incubator-answer/internal/service/meta_common"
 	"github.com/apache/incubator-answer/internal/service/revision"
+	"github.com/apache/incubator-answer/internal/service/space"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -55,15 +57,17 @@ type QuestionRepo interface {
 	UpdateQuestion(ctx context.Context, question *entity.Question, Cols []string) (err error)
 	GetQuestion(ctx context.Context, id string) (question *entity.Question, exist bool, err error)
 	GetQuestionList(ctx context.Context, question *entity.Question) (questions []*entity.Question, err error)
-	GetQuestionPage(ctx context.Context, page, pageSize int, tagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) (
+	GetQuestionPage(ctx context.Context, page, pageSize int, tagIDs []string, spaceID, userID, orderCond string, inDays int, showHidden, showPending bool) (
 		questionList []*entity.Question, total int64, err error)
 	GetRecommendQuestionPageByTags(ctx context.Context, userID string, tagIDs, followedQuestionIDs []string, page, pageSize int) (questionList []*entity.Question, total int64, err error)
 	UpdateQuestionStatus(ctx context.Context, questionID string, status int) (err error)
 	UpdateQuestionStatusWithOutUpdateTime(ctx context.Context, question *entity.Question) (err error)
 	RecoverQuestion(ctx context.Context, questionID string) (err error)
 	UpdateQuestionOperation(ctx context.Context, question *entity.Question) (err error)
+	PublishScheduledQuestion(ctx context.Context, question *entity.Question) (published bool, err error)
+	GetScheduledQuestions(ctx context.Context, before time.Time) (questionList []*entity.Question, err error)
+	GetExpiredPinnedQuestions(ctx context.Context, before time.Time) (questionList []*entity.Question, err error)
 	GetQuestionsByTitle(ctx context.Context, title string, pageSize int) (questionList []*entity.Question, err error)
-	UpdatePvCount(ctx context.Context, questionID string) (err error)
 	UpdateAnswerCount(ctx context.Context, questionID string, num int) (err error)
 	UpdateCollectionCount(ctx context.Context, questionID string) (count int64, err error)
 	UpdateAccepted(ctx context.Context, question *entity.Question) (err error)
@@ -79,19 +83,21 @@ type QuestionRepo interface {
 
 // QuestionCommon user service
 type QuestionCommon struct {
-	questionRepo         QuestionRepo
-	answerRepo           answercommon.AnswerRepo
-	voteRepo             activity_common.VoteRepo
-	followCommon         activity_common.FollowRepo
-	tagCommon            *tagcommon.TagCommonService
-	userCommon           *usercommon.UserCommon
-	collectionCommon     *collectioncommon.CollectionCommon
-	AnswerCommon         *answercommon.AnswerCommon
-	metaCommonService    *metacommon.MetaCommonService
-	configService        *config.ConfigService
-	activityQueueService activity_queue.ActivityQueueService
-	revisionRepo         revision.RevisionRepo
-	data                 *data.Data
+	questionRepo           QuestionRepo
+	answerRepo             answercommon.AnswerRepo
+	voteRepo               activity_common.VoteRepo
+	followCommon           activity_common.FollowRepo
+	tagCommon              *tagcommon.TagCommonService
+	userCommon             *usercommon.UserCommon
+	collectionCommon       *collectioncommon.CollectionCommon
+	AnswerCommon           *answercommon.AnswerCommon
+	metaCommonService      *metacommon.MetaCommonService
+	configService          *config.ConfigService
+	activityQueueService   activity_queue.ActivityQueueService
+	revisionRepo           revision.RevisionRepo
+	spaceService           *space.SpaceService
+	data                   *data.Data
+	externalProfileService *external_profile.ExternalProfileService
 }
 
 func NewQuestionCommon(questionRepo QuestionRepo,
@@ -106,43 +112,29 @@ func NewQuestionCommon(questionRepo QuestionRepo,
 	configService *config.ConfigService,
 	activityQueueService activity_queue.ActivityQueueService,
 	revisionRepo revision.RevisionRepo,
+	spaceService *space.SpaceService,
 	data *data.Data,
+	externalProfileService *external_profile.ExternalProfileService,
 ) *QuestionCommon {
 	return &QuestionCommon{
-		questionRepo:         questionRepo,
-		answerRepo:           answerRepo,
-		voteRepo:             voteRepo,
-		followCommon:         followCommon,
-		tagCommon:            tagCommon,
-		userCommon:           userCommon,
-		collectionCommon:     collectionCommon,
-		AnswerCommon:         answerCommon,
-		metaCommonService:    metaCommonService,
-		configService:        configService,
-		activityQueueService: activityQueueService,
-		revisionRepo:         revisionRepo,
-		data:                 data,
+		questionRepo:           questionRepo,
+		answerRepo:             answerRepo,
+		voteRepo:               voteRepo,
+		followCommon:           followCommon,
+		tagCommon:              tagCommon,
+		userCommon:             userCommon,
+		collectionCommon:       collectionCommon,
+		AnswerCommon:           answerCommon,
+		metaCommonService:      metaCommonService,
+		configService:          configService,
+		activityQueueService:   activityQueueService,
+		revisionRepo:           revisionRepo,
+		spaceService:           spaceService,
+		data:                   data,
+		externalProfileService: externalProfileService,
 	}
 }
 
-func (qs *QuestionCommon) GetAllExternalLoginInfo(ctx context.Context) (externalLoginInfoMap map[string]*entity.MixinUserInfo) {
-	var externalLoginInfos []*entity.UserExternalLogin
-	err := qs.data.DB.Table("user_external_login").Find(&externalLoginInfos)
-	if err != nil {
-		log.Debugf("get external login info failed: %v", err)
-	}
-	externalLoginInfoMap = make(map[string]*entity.MixinUserInfo, len(externalLoginInfos))
-	for _, info := range externalLoginInfos {
-		var mixinUserInfoResponse entity.MixinUserInfoResponse
-		err := json.Unmarshal([]byte(info.MetaInfo), &mixinUserInfoResponse)
-		if err != nil {
-			log.Debugf("unmarshal external login info failed: %v", err)
-		}
-		externalLoginInfoMap[info.UserID] = &mixinUserInfoResponse.Data
-	}
-	return externalLoginInfoMap
-}
-
 func (qs *QuestionCommon) GetUserQuestionCount(ctx context.Context, userID string) (count int64, err error) {
 	return qs.questionRepo.GetUserQuestionCount(ctx, userID, 0)
 }
@@ -155,10 +147,6 @@ func (qs *QuestionCommon) GetPersonalUserQuestionCount(ctx context.Context, logi
 	return qs.questionRepo.GetUserQuestionCount(ctx, userID, show)
 }
 
-func (qs *QuestionCommon) UpdatePv(ctx context.Context, questionID string) error {
-	return qs.questionRepo.UpdatePvCount(ctx, questionID)
-}
-
 func (qs *QuestionCommon) UpdateAnswerCount(ctx context.Context, questionID string) error {
 	count, err := qs.answerRepo.GetCountByQuestionID(ctx, questionID)
 	if err != nil {
@@ -261,6 +249,9 @@ func (qs *QuestionCommon) Info(ctx context.Context, questionID string, loginUser
 	if !has {
 		return resp, errors.NotFound(reason.QuestionNotFound)
 	}
+	if err = qs.spaceService.CheckQuestionSpaceAccess(ctx, questionInfo.SpaceID); err != nil {
+		return resp, err
+	}
 	resp = qs.ShowFormat(ctx, questionInfo)
 	if resp.Status == entity.QuestionStatusClosed {
 		metaInfo, err := qs.metaCommonService.GetMetaByObjectIdAndKey(ctx, questionInfo.ID, entity.QuestionCloseReasonKey)
@@ -337,15 +328,15 @@ func (qs *QuestionCommon) Info(ctx context.Context, questionID string, loginUser
 	resp.UpdateUserInfo = userInfoMap[questionInfo.LastEditUserID]
 	resp.LastAnsweredUserInfo = userInfoMap[resp.LastAnsweredUserID]
 
-	externalLoginInfoMap := qs.GetAllExternalLoginInfo(ctx)
-	if mixinUserInfo, ok := externalLoginInfoMap[resp.UserID]; ok {
-		resp.UserInfo.Membership = mixinUserInfo.Membership
+	externalProfiles := qs.externalProfileService.BatchGetExternalProfiles(ctx, userIds)
+	if profile, ok := externalProfiles[resp.UserID]; ok {
+		resp.UserInfo.Membership = profile.GetMembership()
 	}
-	if mixinUserInfo, ok := externalLoginInfoMap[resp.LastEditUserID]; ok {
-		resp.UpdateUserInfo.Membership = mixinUserInfo.Membership
+	if profile, ok := externalProfiles[resp.LastEditUserID]; ok {
+		resp.UpdateUserInfo.Membership = profile.GetMembership()
 	}
-	if mixinUserInfo, ok := externalLoginInfoMap[resp.LastAnsweredUserID]; ok {
-		resp.LastAnsweredUserInfo.Membership = mixinUserInfo.Membership
+	if profile, ok := externalProfiles[resp.LastAnsweredUserID]; ok {
+		resp.LastAnsweredUserInfo.Membership = profile.GetMembership()
 	}
 	if len(loginUserID) == 0 {
 		return resp, nil
@@ -379,20 +370,6 @@ func (qs *QuestionCommon) FormatQuestionsPage(
 	formattedQuestions = make([]*schema.QuestionPageResp, 0)
 	questionIDs := make([]string, 0)
 	userIDs := make([]string, 0)
-	var externalLoginInfos []*entity.UserExternalLogin
-	err = qs.data.DB.Table("user_external_login").Find(&externalLoginInfos)
-	if err != nil {
-		log.Debugf("get external login info failed: %v", err)
-	}
-	externalLoginInfoMap := make(map[string]*entity.MixinUserInfo, len(externalLoginInfos))
-	for _, info := range externalLoginInfos {
-		var mixinUserInfoResponse entity.MixinUserInfoResponse
-		err := json.Unmarshal([]byte(info.MetaInfo), &mixinUserInfoResponse)
-		if err != nil {
-			log.Debugf("unmarshal external login info failed: %v", err)
-		}
-		externalLoginInfoMap[info.UserID] = &mixinUserInfoResponse.Data
-	}
 
 	for _, questionInfo := range questionList {
 		t := &schema.QuestionPageResp{
@@ -440,32 +417,20 @@ func (qs *QuestionCommon) FormatQuestionsPage(
 		if orderCond == schema.QuestionOrderCondNewest || (!haveEdited && !haveAnswered) {
 			t.OperationType = schema.QuestionPageRespOperationTypeAsked
 			t.OperatedAt = questionInfo.CreatedAt.Unix()
-			membership := entity.Membership{}
-			if mixinUserInfo, ok := externalLoginInfoMap[questionInfo.UserID]; ok {
-				membership = mixinUserInfo.Membership
-			}
-			t.Operator = &schema.QuestionPageRespOperator{ID: questionInfo.UserID, Membership: membership}
+			t.Operator = &schema.QuestionPageRespOperator{ID: questionInfo.UserID}
 		} else {
 			// if no one
 			if haveEdited {
 				t.OperationType = schema.QuestionPageRespOperationTypeModified
 				t.OperatedAt = questionInfo.UpdatedAt.Unix()
-				membership := entity.Membership{}
-				if mixinUserInfo, ok := externalLoginInfoMap[questionInfo.LastEditUserID]; ok {
-					membership = mixinUserInfo.Membership
-				}
-				t.Operator = &schema.QuestionPageRespOperator{ID: questionInfo.LastEditUserID, Membership: membership}
+				t.Operator = &schema.QuestionPageRespOperator{ID: questionInfo.LastEditUserID}
 			}
 
 			if haveAnswered {
 				if t.LastAnsweredAt.Unix() > t.OperatedAt {
 					t.OperationType = schema.QuestionPageRespOperationTypeAnswered
 					t.OperatedAt = t.LastAnsweredAt.Unix()
-					membership := entity.Membership{}
-					if mixinUserInfo, ok := externalLoginInfoMap[t.LastAnsweredUserID]; ok {
-						membership = mixinUserInfo.Membership
-					}
-					t.Operator = &schema.QuestionPageRespOperator{ID: t.LastAnsweredUserID, Membership: membership}
+					t.Operator = &schema.QuestionPageRespOperator{ID: t.LastAnsweredUserID}
 				}
 			}
 		}
@@ -480,6 +445,7 @@ func (qs *QuestionCommon) FormatQuestionsPage(
 	if err != nil {
 		return formattedQuestions, err
 	}
+	externalProfiles := qs.externalProfileService.BatchGetExternalProfiles(ctx, userIDs)
 
 	for _, item := range formattedQuestions {
 		tags, ok := tagsMap[item.ID]
@@ -497,6 +463,7 @@ func (qs *QuestionCommon) FormatQuestionsPage(
 				item.Operator.Status = userInfo.Status
 			}
 		}
+		item.Operator.Membership = externalProfiles[item.Operator.ID].GetMembership()
 
 	}
 	return formattedQuestions, nil
@@ -685,6 +652,7 @@ func (qs *QuestionCommon) ShowFormat(ctx context.Context, data *entity.Question)
 	}
 	info.Title = data.Title
 	info.UrlTitle = htmltext.UrlTitle(data.Title)
+	info.SpaceID = data.SpaceID
 	info.Content = data.OriginalText
 	info.HTML = data.ParsedText
 	info.ViewCount = data.ViewCount
@@ -708,6 +676,16 @@ func (qs *QuestionCommon) ShowFormat(ctx context.Context, data *entity.Question)
 	info.Status = data.Status
 	info.Pin = data.Pin
 	info.Show = data.Show
+	info.Wiki = data.Wiki
+	if !data.PublishAt.IsZero() {
+		info.PublishAt = data.PublishAt.Unix()
+	}
+	if !data.UnpinAt.IsZero() {
+		info.UnpinAt = data.UnpinAt.Unix()
+	}
+	if !data.CloseAt.IsZero() {
+		info.CloseAt = data.CloseAt.Unix()
+	}
 	info.UserID = data.UserID
 	info.LastEditUserID = data.LastEditUserID
 	if data.LastAnswerID != "0" {
@@ -0,0 +1,179 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_view
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/pkg/checker"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// QuestionViewRepo question view repository
+type QuestionViewRepo interface {
+	RecordView(ctx context.Context, date, questionID, fingerprint string) (newView, newUniqueView bool, err error)
+	AddPendingView(ctx context.Context, date, questionID string, viewCount, uniqueViewCount int64) (err error)
+	TakePendingView(ctx context.Context, date, questionID string) (viewCount, uniqueViewCount int64, err error)
+	GetPendingViewDailyList(ctx context.Context) (list []*entity.QuestionViewDaily, err error)
+	SaveQuestionViews(ctx context.Context, date, questionID string, viewCount, uniqueViewCount int) (err error)
+	GetQuestionViewDailyList(ctx context.Context, questionID, startDate, endDate string) (
+		list []*entity.QuestionViewDaily, err error)
+}
+
+// QuestionViewService question view service
+type QuestionViewService struct {
+	questionRepo     questioncommon.QuestionRepo
+	questionViewRepo QuestionViewRepo
+}
+
+// NewQuestionViewService new question view service
+func NewQuestionViewService(
+	questionRepo questioncommon.QuestionRepo,
+	questionViewRepo QuestionViewRepo,
+) *QuestionViewService {
+	return &QuestionViewService{
+		questionRepo:     questionRepo,
+		questionViewRepo: questionViewRepo,
+	}
+}
+
+// AddQuestionView record a view of the question. Bots are ignored, repeated views of the same viewer
+// are only counted once in a window, and the counts are kept in cache until FlushQuestionViews.
+func (qs *QuestionViewService) AddQuestionView(ctx context.Context, req *schema.AddQuestionViewReq) {
+	if checker.IsBotUserAgent(req.UserAgent) {
+		return
+	}
+	questionID := uid.DeShortID(req.QuestionID)
+	date := time.Now().Format(schema.QuestionViewDateFormat)
+	newView, newUniqueView, err := qs.questionViewRepo.RecordView(ctx, date, questionID, viewerFingerprint(req))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !newView && !newUniqueView {
+		return
+	}
+	var viewCount, uniqueViewCount int64
+	if newView {
+		viewCount = 1
+	}
+	if newUniqueView {
+		uniqueViewCount = 1
+	}
+	if err = qs.questionViewRepo.AddPendingView(ctx, date, questionID, viewCount, uniqueViewCount); err != nil {
+		log.Error(err)
+	}
+}
+
+// FlushQuestionViews write the question views in cache to the database. The pending daily buckets are
+// stored in the database, so the views recorded by any instance or before restarting are flushed.
+func (qs *QuestionViewService) FlushQuestionViews(ctx context.Context) {
+	pendingList, err := qs.questionViewRepo.GetPendingViewDailyList(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, pending := range pendingList {
+		viewCount, uniqueViewCount, err := qs.questionViewRepo.TakePendingView(ctx, pending.ViewDate, pending.QuestionID)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		if viewCount == 0 && uniqueViewCount == 0 {
+			continue
+		}
+		err = qs.questionViewRepo.SaveQuestionViews(ctx, pending.ViewDate, pending.QuestionID,
+			int(viewCount), int(uniqueViewCount))
+		if err != nil {
+			log.Errorf("save question %s view count failed: %v", pending.QuestionID, err)
+			// put the views back, they are flushed next time
+			err = qs.questionViewRepo.AddPendingView(ctx, pending.ViewDate, pending.QuestionID, viewCount, uniqueViewCount)
+			if err != nil {
+				log.Errorf("restore question %s pending view count failed: %v", pending.QuestionID, err)
+			}
+			continue
+		}
+		_ = qs.questionRepo.UpdateSearch(ctx, pending.QuestionID)
+	}
+}
+
+// GetQuestionViewTrend get the daily views of the question, only the author and admin can see it
+func (qs *QuestionViewService) GetQuestionViewTrend(ctx context.Context, req *schema.GetQuestionViewTrendReq) (
+	resp *schema.GetQuestionViewTrendResp, err error) {
+	questionID := uid.DeShortID(req.QuestionID)
+	question, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || question.Status == entity.QuestionStatusDeleted {
+		return nil, errors.NotFound(reason.QuestionNotFound)
+	}
+	if !req.IsAdmin && question.UserID != req.UserID {
+		return nil, errors.Forbidden(reason.ForbiddenError)
+	}
+
+	if req.Days <= 0 {
+		req.Days = schema.QuestionViewTrendDefaultDays
+	}
+	endDate := time.Now()
+	startDate := endDate.AddDate(0, 0, 1-req.Days)
+	list, err := qs.questionViewRepo.GetQuestionViewDailyList(ctx, questionID,
+		startDate.Format(schema.QuestionViewDateFormat), endDate.Format(schema.QuestionViewDateFormat))
+	if err != nil {
+		return nil, err
+	}
+	mapping := make(map[string]*entity.QuestionViewDaily, len(list))
+	for _, item := range list {
+		mapping[item.ViewDate] = item
+	}
+
+	resp = &schema.GetQuestionViewTrendResp{
+		ViewCount:       question.ViewCount,
+		UniqueViewCount: question.UniqueViewCount,
+		Days:            make([]*schema.QuestionViewDailyItem, 0, req.Days),
+	}
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		item := &schema.QuestionViewDailyItem{Date: day.Format(schema.QuestionViewDateFormat)}
+		if daily, ok := mapping[item.Date]; ok {
+			item.ViewCount = daily.ViewCount
+			item.UniqueViewCount = daily.UniqueViewCount
+		}
+		resp.Days = append(resp.Days, item)
+	}
+	return resp, nil
+}
+
+// viewerFingerprint the login user is identified by user id, the anonymous viewer by ip and user agent
+func viewerFingerprint(req *schema.AddQuestionViewReq) string {
+	if len(req.UserID) > 0 {
+		return "u" + req.UserID
+	}
+	sum := md5.Sum([]byte(req.IP + "|" + req.UserAgent))
+	return "a" + hex.EncodeToString(sum[:])
+}
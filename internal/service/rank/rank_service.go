@@ -34,6 +34,8 @@ import (
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	"github.com/apache/incubator-answer/internal/service/permission"
 	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/space"
+	"github.com/apache/incubator-answer/internal/service/tag_moderator"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -47,6 +49,36 @@ const (
 	PermissionPrefix = "rank."
 )
 
+// tagModeratorPowers the powers of tag moderator on the questions with the moderated tags and their answers
+var tagModeratorPowers = []string{
+	permission.QuestionEdit,
+	permission.QuestionEditWithoutReview,
+	permission.QuestionDelete,
+	permission.QuestionUnDelete,
+	permission.QuestionClose,
+	permission.QuestionReopen,
+	permission.QuestionPin,
+	permission.QuestionUnPin,
+	permission.QuestionHide,
+	permission.QuestionShow,
+	permission.QuestionAudit,
+	permission.AnswerEdit,
+	permission.AnswerEditWithoutReview,
+	permission.AnswerDelete,
+	permission.AnswerUnDelete,
+	permission.AnswerAudit,
+	permission.CommentEdit,
+	permission.CommentDelete,
+	permission.QuestionWiki,
+	permission.AnswerWiki,
+}
+
+// wikiEditPowers the powers on the community wiki post of the users who meet the rank of editing wiki
+var wikiEditPowers = map[string][]string{
+	constant.QuestionObjectType: {permission.QuestionEdit, permission.QuestionEditWithoutReview},
+	constant.AnswerObjectType:   {permission.AnswerEdit, permission.AnswerEditWithoutReview},
+}
+
 type UserRankRepo interface {
 	GetMaxDailyRank(ctx context.Context) (maxDailyRank int, err error)
 	CheckReachLimit(ctx context.Context, session *xorm.Session, userID string, maxDailyRank int) (reach bool, err error)
@@ -58,12 +90,14 @@ type UserRankRepo interface {
 
 // RankService rank service
 type RankService struct {
-	userCommon        *usercommon.UserCommon
-	configService     *config.ConfigService
-	userRankRepo      UserRankRepo
-	objectInfoService *object_info.ObjService
-	roleService       *role.UserRoleRelService
-	rolePowerService  *role.RolePowerRelService
+	userCommon          *usercommon.UserCommon
+	configService       *config.ConfigService
+	userRankRepo        UserRankRepo
+	objectInfoService   *object_info.ObjService
+	roleService         *role.UserRoleRelService
+	rolePowerService    *role.RolePowerRelService
+	spaceService        *space.SpaceService
+	tagModeratorService *tag_moderator.TagModeratorService
 }
 
 // NewRankService new rank service
@@ -73,14 +107,18 @@ func NewRankService(
 	objectInfoService *object_info.ObjService,
 	roleService *role.UserRoleRelService,
 	rolePowerService *role.RolePowerRelService,
+	spaceService *space.SpaceService,
+	tagModeratorService *tag_moderator.TagModeratorService,
 	configService *config.ConfigService) *RankService {
 	return &RankService{
-		userCommon:        userCommon,
-		configService:     configService,
-		userRankRepo:      userRankRepo,
-		objectInfoService: objectInfoService,
-		roleService:       roleService,
-		rolePowerService:  rolePowerService,
+		userCommon:          userCommon,
+		configService:       configService,
+		userRankRepo:        userRankRepo,
+		objectInfoService:   objectInfoService,
+		roleService:         roleService,
+		rolePowerService:    rolePowerService,
+		spaceService:        spaceService,
+		tagModeratorService: tagModeratorService,
 	}
 }
 
@@ -114,6 +152,12 @@ func (rs *RankService) CheckOperationPermission(ctx context.Context, userID stri
 			objectInfo.ObjectCreatorUserID == userID {
 			return true, nil
 		}
+		if objectInfo != nil && rs.getObjectScopedPowerMapping(ctx, userID, objectInfo)[action] {
+			return true, nil
+		}
+		if objectInfo != nil && rs.getObjectWikiPowerMapping(ctx, userInfo.ID, userInfo.Rank, objectInfo)[action] {
+			return true, nil
+		}
 	}
 
 	can, _ = rs.checkUserRank(ctx, userInfo.ID, userInfo.Rank, PermissionPrefix+action)
@@ -158,6 +202,47 @@ func (rs *RankService) CheckOperationPermissions(ctx context.Context, userID str
 	return can, err
 }
 
+// CheckOperationObjectPermissionsForRanks verify that the user has permission to operate the object.
+// Unlike CheckOperationPermission, the object creator is not granted automatically.
+func (rs *RankService) CheckOperationObjectPermissionsForRanks(ctx context.Context, userID, objectID string,
+	actions []string) (can []bool, requireRanks []int, err error) {
+	can, requireRanks, err = rs.CheckOperationPermissionsForRanks(ctx, userID, actions)
+	if err != nil || len(userID) == 0 || len(objectID) == 0 {
+		return can, requireRanks, err
+	}
+	objectInfo, err := rs.objectInfoService.GetInfo(ctx, uid.DeShortID(objectID))
+	if err != nil {
+		// the object not found should be handled by the caller
+		log.Debugf("get object %s info failed: %v", objectID, err)
+		return can, requireRanks, nil
+	}
+	scopedPowerMapping := rs.getObjectScopedPowerMapping(ctx, userID, objectInfo)
+	if objectInfo.Wiki {
+		userInfo, exist, err := rs.userCommon.GetUserBasicInfoByID(ctx, userID)
+		if err != nil {
+			return can, requireRanks, err
+		}
+		if exist {
+			for power := range rs.getObjectWikiPowerMapping(ctx, userInfo.ID, userInfo.Rank, objectInfo) {
+				scopedPowerMapping[power] = true
+			}
+		}
+	}
+	for idx, action := range actions {
+		if scopedPowerMapping[action] {
+			can[idx] = true
+		}
+	}
+	return can, requireRanks, nil
+}
+
+// CheckOperationObjectPermissions verify that the user has permission to operate the object
+func (rs *RankService) CheckOperationObjectPermissions(ctx context.Context, userID, objectID string,
+	actions []string) (can []bool, err error) {
+	can, _, err = rs.CheckOperationObjectPermissionsForRanks(ctx, userID, objectID, actions)
+	return can, err
+}
+
 // CheckOperationObjectOwner check operation object owner
 func (rs *RankService) CheckOperationObjectOwner(ctx context.Context, userID, objectID string) bool {
 	objectID = uid.DeShortID(objectID)
@@ -242,6 +327,62 @@ func (rs *RankService) getUserPowerMapping(ctx context.Context, userID string) (
 	return powerMapping
 }
 
+// getObjectScopedPowerMapping get the powers that user has only on this object,
+// the moderator of the space which the object belongs to has the moderator powers on it.
+func (rs *RankService) getObjectScopedPowerMapping(ctx context.Context, userID string,
+	objectInfo *schema.SimpleObjectInfo) (powerMapping map[string]bool) {
+	powerMapping = make(map[string]bool, 0)
+	if len(objectInfo.QuestionID) == 0 {
+		return powerMapping
+	}
+	isModerator, err := rs.spaceService.IsQuestionSpaceModerator(ctx, userID, objectInfo.QuestionID)
+	if err != nil {
+		log.Error(err)
+		return powerMapping
+	}
+	if isModerator {
+		powers, err := rs.rolePowerService.GetRolePowerList(ctx, role.RoleModeratorID)
+		if err != nil {
+			log.Error(err)
+			return powerMapping
+		}
+		for _, power := range powers {
+			powerMapping[power] = true
+		}
+		return powerMapping
+	}
+
+	// the tag moderator only has the powers on the questions with the moderated tags
+	isTagModerator, err := rs.tagModeratorService.IsQuestionTagModerator(ctx, userID, objectInfo.QuestionID)
+	if err != nil {
+		log.Error(err)
+		return powerMapping
+	}
+	if isTagModerator {
+		for _, power := range tagModeratorPowers {
+			powerMapping[power] = true
+		}
+	}
+	return powerMapping
+}
+
+// getObjectWikiPowerMapping get the powers that user has on the community wiki post,
+// anyone who meets the rank of editing wiki can edit the wiki post without review.
+func (rs *RankService) getObjectWikiPowerMapping(ctx context.Context, userID string, userRank int,
+	objectInfo *schema.SimpleObjectInfo) (powerMapping map[string]bool) {
+	powerMapping = make(map[string]bool, 0)
+	if !objectInfo.Wiki || objectInfo.IsDeleted() {
+		return powerMapping
+	}
+	if can, _ := rs.checkUserRank(ctx, userID, userRank, PermissionPrefix+permission.WikiEdit); !can {
+		return powerMapping
+	}
+	for _, power := range wikiEditPowers[objectInfo.ObjectType] {
+		powerMapping[power] = true
+	}
+	return powerMapping
+}
+
 // checkUserRank verify that the user meets the prestige criteria
 func (rs *RankService) checkUserRank(ctx context.Context, userID string, userRank int, action string) (
 	can bool, rank int) {
@@ -218,14 +218,19 @@ func (rs *ReportService) ReviewReport(ctx context.Context, req *schema.ReviewRep
 
 	// ignore this report
 	if req.OperationType == constant.ReportOperationIgnoreReport {
-		return rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusIgnore)
+		err = rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusIgnore)
+	} else {
+		if err = rs.reportHandle.UpdateReportedObject(ctx, report, req); err != nil {
+			return
+		}
+		err = rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusCompleted)
 	}
-
-	if err = rs.reportHandle.UpdateReportedObject(ctx, report, req); err != nil {
-		return
+	if err != nil {
+		return err
 	}
-
-	return rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusCompleted)
+	rs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventReportHandle, req.UserID).OID(report.ID).
+		AddExtra("object_id", report.ObjectID).AddExtra("operation_type", req.OperationType))
+	return nil
 }
 
 func (rs *ReportService) sendEvent(ctx context.Context,
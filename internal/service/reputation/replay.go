@@ -0,0 +1,229 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package reputation
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/encryption"
+)
+
+const (
+	// UserActivatedKey the config key of the activity that gives the user the first point when activated
+	UserActivatedKey = "user.activated"
+
+	dailyRankLimitKey        = "daily_rank_limit"
+	dailyRankLimitExcludeKey = "daily_rank_limit.exclude"
+)
+
+// the notes explain why the replayed points are different from the points of the rule
+const (
+	NoteDailyLimit = "daily_limit"
+	NoteMinRank    = "min_rank"
+	NoteAdjustment = "adjustment"
+	NoteWiki       = "wiki"
+)
+
+// votedKeys the activities of the votes received by the author of the post
+var votedKeys = map[string]bool{
+	activity_type.QuestionVotedUp:   true,
+	activity_type.QuestionVotedDown: true,
+	activity_type.AnswerVotedUp:     true,
+	activity_type.AnswerVotedDown:   true,
+}
+
+// ReplayRules the current reputation rules that activities are replayed under
+type ReplayRules struct {
+	// Points the points of each activity type, the activity type is the id of the config
+	Points map[int]int
+	// Keys the config key of each activity type
+	Keys map[int]string
+	// DailyLimit the max points that can be earned in one day, 0 means no limit
+	DailyLimit int
+	// DailyLimitExclude the activity types that are not limited by the daily limit
+	DailyLimitExclude map[int]bool
+	// AdjustType the activity type of the manual reputation corrections
+	AdjustType int
+	// ActivatedType the activity type of the user activation
+	ActivatedType int
+	// VotedTypes the activity types of the votes received by the author of the post
+	VotedTypes map[int]bool
+	// WikiObjects the community wiki posts, the votes on them award no reputation
+	WikiObjects map[string]bool
+}
+
+// NewReplayRules builds the rules from the config rows
+func NewReplayRules(configs []*entity.Config) *ReplayRules {
+	rules := &ReplayRules{
+		Points:            make(map[int]int, len(configs)),
+		Keys:              make(map[int]string, len(configs)),
+		DailyLimitExclude: make(map[int]bool),
+		VotedTypes:        make(map[int]bool),
+		WikiObjects:       make(map[string]bool),
+	}
+	idMapping := make(map[string]int, len(configs))
+	for _, cfg := range configs {
+		idMapping[cfg.Key] = cfg.ID
+		rules.Keys[cfg.ID] = cfg.Key
+	}
+	for _, cfg := range configs {
+		switch cfg.Key {
+		case dailyRankLimitKey:
+			rules.DailyLimit = cfg.GetIntValue()
+		case dailyRankLimitExcludeKey:
+			for _, key := range cfg.GetArrayStringValue() {
+				if id, ok := idMapping[key]; ok {
+					rules.DailyLimitExclude[id] = true
+				}
+			}
+		case activity_type.ReputationAdjust:
+			rules.AdjustType = cfg.ID
+		default:
+			rules.Points[cfg.ID] = converter.StringToInt(cfg.Value)
+		}
+		if cfg.Key == UserActivatedKey {
+			rules.ActivatedType = cfg.ID
+		}
+		if votedKeys[cfg.Key] {
+			rules.VotedTypes[cfg.ID] = true
+		}
+	}
+	return rules
+}
+
+// SetWikiObjects set the community wiki posts
+func (r *ReplayRules) SetWikiObjects(objectIDs []string) {
+	for _, objectID := range objectIDs {
+		r.WikiObjects[objectID] = true
+	}
+}
+
+// Version the hash of the rules, the ledger replayed under the other rules is stale
+func (r *ReplayRules) Version() string {
+	// the keys of the maps are sorted by json, so the same rules always have the same version
+	data, _ := json.Marshal(r)
+	return encryption.MD5(string(data))
+}
+
+// ReplayEntry one activity of the replayed ledger
+type ReplayEntry struct {
+	Activity *entity.Activity
+	// Points the points of this activity under the current rules
+	Points int
+	// Balance the reputation after this activity
+	Balance int
+	// Note explains why the points are different from the points of the rule
+	Note string
+}
+
+// ReplayResult the result of replaying the activities of one user
+type ReplayResult struct {
+	// Opening the reputation before the first activity
+	Opening int
+	// Expected the reputation after all activities
+	Expected int
+	Entries  []*ReplayEntry
+}
+
+// Replay replays the available activities of one user under the rules, in the order they took effect.
+// The daily limit and the minimum reputation of 1 are applied the same way as the rank repository does.
+// The manual corrections are listed in the ledger but not replayed, because they only record how
+// the stored reputation was moved to the replayed one. The votes on the community wiki posts that
+// were recorded without reputation are not replayed either, the ones before the conversion are kept.
+func (r *ReplayRules) Replay(user *entity.User, activities []*entity.Activity) *ReplayResult {
+	available := make([]*entity.Activity, 0, len(activities))
+	activated := false
+	for _, act := range activities {
+		if act.Cancelled != entity.ActivityAvailable {
+			continue
+		}
+		if act.ActivityType == r.ActivatedType {
+			activated = true
+		}
+		available = append(available, act)
+	}
+	sort.SliceStable(available, func(i, j int) bool {
+		if !available[i].UpdatedAt.Equal(available[j].UpdatedAt) {
+			return available[i].UpdatedAt.Before(available[j].UpdatedAt)
+		}
+		return available[i].CreatedAt.Before(available[j].CreatedAt)
+	})
+
+	result := &ReplayResult{Entries: make([]*ReplayEntry, 0, len(available))}
+	// the users that are created without activation, such as the admin, start with 1 reputation
+	if !activated && user.MailStatus == entity.EmailStatusAvailable {
+		result.Opening = 1
+	}
+	balance := result.Opening
+	earned := make(map[string]int)
+	for _, act := range available {
+		entry := &ReplayEntry{Activity: act}
+		if act.ActivityType == r.AdjustType {
+			entry.Note = NoteAdjustment
+			entry.Balance = balance
+			result.Entries = append(result.Entries, entry)
+			continue
+		}
+		if r.VotedTypes[act.ActivityType] && r.WikiObjects[act.ObjectID] && act.HasRank == 0 {
+			entry.Note = NoteWiki
+			entry.Balance = balance
+			result.Entries = append(result.Entries, entry)
+			continue
+		}
+		day := act.UpdatedAt.In(time.Local).Format(time.DateOnly)
+		points := r.Points[act.ActivityType]
+		if points > 0 && r.DailyLimit > 0 && !r.DailyLimitExclude[act.ActivityType] && earned[day] >= r.DailyLimit {
+			points, entry.Note = 0, NoteDailyLimit
+		}
+		if points < 0 && balance+points < 1 {
+			points, entry.Note = 1-balance, NoteMinRank
+		}
+		earned[day] += points
+		balance += points
+		entry.Points, entry.Balance = points, balance
+		result.Entries = append(result.Entries, entry)
+	}
+	result.Expected = balance
+	return result
+}
+
+// Drift returns how much the stored reputation is more than the replayed one
+func (res *ReplayResult) Drift(user *entity.User) int {
+	return user.Rank - res.Expected
+}
+
+// NewAdjustActivity builds the activity that records the correction from the stored reputation to the replayed one
+func (r *ReplayRules) NewAdjustActivity(userID string, operatorUserID int64, delta int) *entity.Activity {
+	return &entity.Activity{
+		UserID:           userID,
+		TriggerUserID:    operatorUserID,
+		ObjectID:         "0",
+		OriginalObjectID: "0",
+		ActivityType:     r.AdjustType,
+		Cancelled:        entity.ActivityAvailable,
+		Rank:             delta,
+		HasRank:          1,
+	}
+}
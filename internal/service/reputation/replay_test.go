@@ -0,0 +1,138 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package reputation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRules() *ReplayRules {
+	return NewReplayRules([]*entity.Config{
+		{ID: 1, Key: "answer.accepted", Value: `15`},
+		{ID: 2, Key: "answer.voted_up", Value: `10`},
+		{ID: 10, Key: "user.activated", Value: `1`},
+		{ID: 14, Key: "answer.voted_down", Value: `-2`},
+		{ID: 22, Key: "daily_rank_limit", Value: `20`},
+		{ID: 23, Key: "daily_rank_limit.exclude", Value: `["answer.accepted"]`},
+		{ID: 136, Key: "user.reputation_adjust", Value: `0`},
+	})
+}
+
+func newTestActivity(activityType, rank int, at time.Time) *entity.Activity {
+	return &entity.Activity{ActivityType: activityType, Rank: rank, CreatedAt: at, UpdatedAt: at}
+}
+
+func TestNewReplayRules(t *testing.T) {
+	rules := newTestRules()
+	assert.Equal(t, 15, rules.Points[1])
+	assert.Equal(t, 1, rules.Points[10])
+	assert.Equal(t, 20, rules.DailyLimit)
+	assert.True(t, rules.DailyLimitExclude[1])
+	assert.Equal(t, 136, rules.AdjustType)
+	assert.Equal(t, 10, rules.ActivatedType)
+	assert.Equal(t, "answer.voted_up", rules.Keys[2])
+}
+
+func TestReplay(t *testing.T) {
+	rules := newTestRules()
+	day := time.Date(2024, 1, 1, 10, 0, 0, 0, time.Local)
+	user := &entity.User{ID: "1", Rank: 50, MailStatus: entity.EmailStatusAvailable}
+	activities := []*entity.Activity{
+		newTestActivity(10, 1, day),
+		// the stored points are awarded under the old rules, they are replayed under the current rules
+		newTestActivity(2, 5, day.Add(time.Minute)),
+		newTestActivity(2, 5, day.Add(2*time.Minute)),
+		// reach the daily limit
+		newTestActivity(2, 5, day.Add(3*time.Minute)),
+		// excluded from the daily limit
+		newTestActivity(1, 15, day.Add(4*time.Minute)),
+		// the cancelled activity is not replayed
+		{ActivityType: 2, Rank: 10, Cancelled: entity.ActivityCancelled, CreatedAt: day, UpdatedAt: day},
+		// the adjustment is listed but not replayed
+		newTestActivity(136, 30, day.Add(5*time.Minute)),
+		// the next day is not limited
+		newTestActivity(2, 10, day.Add(24*time.Hour)),
+	}
+	result := rules.Replay(user, activities)
+	assert.Equal(t, 0, result.Opening)
+	assert.Len(t, result.Entries, 7)
+	assert.Equal(t, 0, result.Entries[3].Points)
+	assert.Equal(t, NoteDailyLimit, result.Entries[3].Note)
+	assert.Equal(t, 15, result.Entries[4].Points)
+	assert.Equal(t, 0, result.Entries[5].Points)
+	assert.Equal(t, NoteAdjustment, result.Entries[5].Note)
+	assert.Equal(t, 1+10+10+15+10, result.Expected)
+	assert.Equal(t, 50-46, result.Drift(user))
+}
+
+func TestReplay_MinRank(t *testing.T) {
+	rules := newTestRules()
+	day := time.Date(2024, 1, 1, 10, 0, 0, 0, time.Local)
+	// the user is created without activation, so the reputation starts with 1
+	user := &entity.User{ID: "1", Rank: 1, MailStatus: entity.EmailStatusAvailable}
+	result := rules.Replay(user, []*entity.Activity{
+		newTestActivity(14, -2, day),
+		newTestActivity(2, 10, day.Add(time.Minute)),
+	})
+	assert.Equal(t, 1, result.Opening)
+	assert.Equal(t, 0, result.Entries[0].Points)
+	assert.Equal(t, NoteMinRank, result.Entries[0].Note)
+	assert.Equal(t, 11, result.Expected)
+	assert.Equal(t, -10, result.Drift(user))
+
+	adjust := rules.NewAdjustActivity(user.ID, 2, -result.Drift(user))
+	assert.Equal(t, 136, adjust.ActivityType)
+	assert.Equal(t, 10, adjust.Rank)
+}
+
+func TestReplay_Wiki(t *testing.T) {
+	rules := newTestRules()
+	rules.SetWikiObjects([]string{"20"})
+	assert.True(t, rules.VotedTypes[2])
+	assert.False(t, rules.VotedTypes[1])
+
+	day := time.Date(2024, 1, 1, 10, 0, 0, 0, time.Local)
+	user := &entity.User{ID: "1", Rank: 11, MailStatus: entity.EmailStatusAvailable}
+	voted := func(objectID string, rank int, at time.Time) *entity.Activity {
+		act := newTestActivity(2, rank, at)
+		act.ObjectID = objectID
+		if rank != 0 {
+			act.HasRank = 1
+		}
+		return act
+	}
+	result := rules.Replay(user, []*entity.Activity{
+		// the vote before the post is converted to wiki keeps the reputation
+		voted("20", 10, day),
+		// the vote on the wiki post awards no reputation
+		voted("20", 0, day.Add(time.Minute)),
+		// the vote limited by the daily limit on other post is replayed
+		voted("21", 0, day.Add(24*time.Hour)),
+	})
+	assert.Equal(t, 10, result.Entries[0].Points)
+	assert.Equal(t, 0, result.Entries[1].Points)
+	assert.Equal(t, NoteWiki, result.Entries[1].Note)
+	assert.Equal(t, 10, result.Entries[2].Points)
+	assert.Equal(t, 1+10+10, result.Expected)
+}
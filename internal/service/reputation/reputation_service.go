@@ -0,0 +1,312 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package reputation
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/internal/service/object_info"
+	"github.com/apache/incubator-answer/internal/service/space"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ReputationRepo reputation repository
+type ReputationRepo interface {
+	GetRuleConfigs(ctx context.Context) (configs []*entity.Config, err error)
+	GetUser(ctx context.Context, userID string) (user *entity.User, exist bool, err error)
+	GetUserPage(ctx context.Context, page, pageSize int) (users []*entity.User, total int64, err error)
+	GetUserActivities(ctx context.Context, userIDs []string) (activities []*entity.Activity, err error)
+	GetWikiObjectIDs(ctx context.Context) (objectIDs []string, err error)
+	AdjustUserRank(ctx context.Context, user *entity.User, expected int, activity *entity.Activity) (
+		adjusted bool, err error)
+	SetLedgerCache(ctx context.Context, userID string, ledger *LedgerCache) (err error)
+	GetLedgerCache(ctx context.Context, userID string) (ledger *LedgerCache, exist bool, err error)
+}
+
+// LedgerCache the replayed ledger of the user, it is only used while neither the reputation of the user
+// nor the rules are changed
+type LedgerCache struct {
+	Reputation   int           `json:"reputation"`
+	RulesVersion string        `json:"rules_version"`
+	Result       *ReplayResult `json:"result"`
+}
+
+// ReputationService reputation service
+type ReputationService struct {
+	reputationRepo    ReputationRepo
+	userCommon        *usercommon.UserCommon
+	objectInfoService *object_info.ObjService
+	spaceService      *space.SpaceService
+}
+
+// NewReputationService new reputation service
+func NewReputationService(
+	reputationRepo ReputationRepo,
+	userCommon *usercommon.UserCommon,
+	objectInfoService *object_info.ObjService,
+	spaceService *space.SpaceService,
+) *ReputationService {
+	return &ReputationService{
+		reputationRepo:    reputationRepo,
+		userCommon:        userCommon,
+		objectInfoService: objectInfoService,
+		spaceService:      spaceService,
+	}
+}
+
+// GetReputationLedger get the ledger that explains every point of the user reputation
+func (rs *ReputationService) GetReputationLedger(ctx context.Context, req *schema.GetReputationLedgerReq) (
+	resp *schema.GetReputationLedgerResp, err error) {
+	if len(req.Username) > 0 {
+		userInfo, exist, err := rs.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, errors.BadRequest(reason.UserNotFound)
+		}
+		req.UserID = userInfo.ID
+	}
+	if len(req.UserID) == 0 {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	user, exist, err := rs.reputationRepo.GetUser(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	resp = &schema.GetReputationLedgerResp{
+		UserInfo:   rs.userCommon.FormatUserBasicInfo(ctx, user),
+		Reputation: user.Rank,
+		List:       make([]*schema.ReputationLedgerItem, 0),
+	}
+	// the reputation is managed by the user center, there is no ledger in this site
+	if plugin.RankAgentEnabled() {
+		return resp, nil
+	}
+
+	rules, err := rs.getReplayRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := rs.getLedger(ctx, rules, user)
+	if err != nil {
+		return nil, err
+	}
+	resp.Opening = result.Opening
+	resp.Expected = result.Expected
+	resp.Drift = result.Drift(user)
+	resp.Count = int64(len(result.Entries))
+
+	page, pageSize := req.Page, req.PageSize
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	lang := handler.GetLangByCtx(ctx)
+	// the newest activity first
+	for i := len(result.Entries) - 1 - (page-1)*pageSize; i >= 0 && len(resp.List) < pageSize; i-- {
+		resp.List = append(resp.List, rs.formatLedgerItem(ctx, lang, rules, result.Entries[i]))
+	}
+	return resp, nil
+}
+
+// getLedger get the replayed ledger of the user from cache,
+// replays the activities if the reputation or the rules are changed.
+// So the pages of the ledger do not replay the whole history each time.
+func (rs *ReputationService) getLedger(ctx context.Context, rules *ReplayRules, user *entity.User) (
+	result *ReplayResult, err error) {
+	ledger, exist, err := rs.reputationRepo.GetLedgerCache(ctx, user.ID)
+	if err != nil {
+		log.Error(err)
+	}
+	rulesVersion := rules.Version()
+	if exist && ledger.Reputation == user.Rank && ledger.RulesVersion == rulesVersion && ledger.Result != nil {
+		return ledger.Result, nil
+	}
+
+	activities, err := rs.reputationRepo.GetUserActivities(ctx, []string{user.ID})
+	if err != nil {
+		return nil, err
+	}
+	result = rules.Replay(user, activities)
+	err = rs.reputationRepo.SetLedgerCache(ctx, user.ID, &LedgerCache{
+		Reputation:   user.Rank,
+		RulesVersion: rulesVersion,
+		Result:       result,
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return result, nil
+}
+
+func (rs *ReputationService) formatLedgerItem(ctx context.Context, lang i18n.Language, rules *ReplayRules,
+	entry *ReplayEntry) *schema.ReputationLedgerItem {
+	key := rules.Keys[entry.Activity.ActivityType]
+	item := &schema.ReputationLedgerItem{
+		ActivityID:   entry.Activity.ID,
+		CreatedAt:    entry.Activity.CreatedAt.Unix(),
+		ActivityType: key,
+		RankType:     translator.Tr(lang, activity_type.ActivityTypeFlagMapping[key]),
+		ObjectID:     entry.Activity.ObjectID,
+		Awarded:      entry.Activity.Rank,
+		Points:       entry.Points,
+		Balance:      entry.Balance,
+		Note:         entry.Note,
+	}
+	if len(item.ObjectID) == 0 || item.ObjectID == "0" {
+		item.ObjectID = ""
+		return item
+	}
+	objInfo, err := rs.objectInfoService.GetInfo(ctx, item.ObjectID)
+	if err != nil {
+		log.Error(err)
+		return item
+	}
+	// the points are kept in the ledger, but the object in the space that the viewer can not access is hidden
+	if objInfo.ObjectType != constant.TagObjectType {
+		if err = rs.spaceService.CheckQuestionAccess(ctx, objInfo.QuestionID); err != nil {
+			item.ObjectID = ""
+			return item
+		}
+	}
+	item.ObjectType = objInfo.ObjectType
+	item.Title = objInfo.Title
+	if objInfo.QuestionStatus == entity.QuestionStatusDeleted {
+		item.Title = translator.Tr(lang, constant.DeletedQuestionTitleTrKey)
+	}
+	item.UrlTitle = htmltext.UrlTitle(item.Title)
+	return item
+}
+
+// RecomputeReputation replay the activities of the users under the current rules and report the drift,
+// the corrections are applied as adjustment activities if required
+func (rs *ReputationService) RecomputeReputation(ctx context.Context, req *schema.RecomputeReputationReq) (
+	resp *schema.RecomputeReputationResp, err error) {
+	if plugin.RankAgentEnabled() {
+		return nil, errors.BadRequest(reason.ReputationManagedByUserCenter)
+	}
+	rules, err := rs.getReplayRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*entity.User
+	resp = &schema.RecomputeReputationResp{List: make([]*schema.ReputationDriftItem, 0)}
+	if len(req.UserID) > 0 {
+		user, exist, err := rs.reputationRepo.GetUser(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, errors.BadRequest(reason.UserNotFound)
+		}
+		users, resp.Total = []*entity.User{user}, 1
+	} else {
+		if req.Page <= 0 {
+			req.Page = 1
+		}
+		if req.PageSize <= 0 {
+			req.PageSize = 100
+		}
+		users, resp.Total, err = rs.reputationRepo.GetUserPage(ctx, req.Page, req.PageSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(users) == 0 {
+		return resp, nil
+	}
+
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+	activities, err := rs.reputationRepo.GetUserActivities(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	activityMapping := make(map[string][]*entity.Activity, len(users))
+	for _, act := range activities {
+		activityMapping[act.UserID] = append(activityMapping[act.UserID], act)
+	}
+
+	for _, user := range users {
+		resp.Checked++
+		result := rules.Replay(user, activityMapping[user.ID])
+		drift := result.Drift(user)
+		if drift == 0 {
+			continue
+		}
+		resp.Drifted++
+		item := &schema.ReputationDriftItem{
+			UserID:     user.ID,
+			Username:   user.Username,
+			Reputation: user.Rank,
+			Expected:   result.Expected,
+			Drift:      drift,
+		}
+		resp.List = append(resp.List, item)
+		if !req.Apply {
+			continue
+		}
+		act := rules.NewAdjustActivity(user.ID, converter.StringToInt64(req.LoginUserID), -drift)
+		item.Applied, err = rs.reputationRepo.AdjustUserRank(ctx, user, result.Expected, act)
+		if err != nil {
+			return nil, err
+		}
+		if item.Applied {
+			resp.Applied++
+		}
+	}
+	return resp, nil
+}
+
+func (rs *ReputationService) getReplayRules(ctx context.Context) (rules *ReplayRules, err error) {
+	configs, err := rs.reputationRepo.GetRuleConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules = NewReplayRules(configs)
+	objectIDs, err := rs.reputationRepo.GetWikiObjectIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules.SetWikiObjects(objectIDs)
+	return rules, nil
+}
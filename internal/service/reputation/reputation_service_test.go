@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package reputation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeReputationRepo struct {
+	ReputationRepo
+	activities []*entity.Activity
+	loaded     int
+	ledgers    map[string]*LedgerCache
+}
+
+func (r *fakeReputationRepo) GetUserActivities(ctx context.Context, userIDs []string) (
+	[]*entity.Activity, error) {
+	r.loaded++
+	return r.activities, nil
+}
+
+func (r *fakeReputationRepo) SetLedgerCache(ctx context.Context, userID string, ledger *LedgerCache) error {
+	r.ledgers[userID] = ledger
+	return nil
+}
+
+func (r *fakeReputationRepo) GetLedgerCache(ctx context.Context, userID string) (*LedgerCache, bool, error) {
+	ledger, ok := r.ledgers[userID]
+	return ledger, ok, nil
+}
+
+func TestGetLedger(t *testing.T) {
+	day := time.Date(2024, 1, 1, 10, 0, 0, 0, time.Local)
+	repo := &fakeReputationRepo{
+		activities: []*entity.Activity{newTestActivity(10, 1, day), newTestActivity(2, 10, day)},
+		ledgers:    make(map[string]*LedgerCache),
+	}
+	rs := NewReputationService(repo, nil, nil, nil)
+	rules := newTestRules()
+	user := &entity.User{ID: "1", Rank: 11, MailStatus: entity.EmailStatusAvailable}
+
+	result, err := rs.getLedger(context.TODO(), rules, user)
+	assert.NoError(t, err)
+	assert.Equal(t, 11, result.Expected)
+	assert.Equal(t, 1, repo.loaded)
+
+	// the other pages use the cached ledger
+	result, err = rs.getLedger(context.TODO(), rules, user)
+	assert.NoError(t, err)
+	assert.Len(t, result.Entries, 2)
+	assert.Equal(t, 1, repo.loaded)
+
+	// the reputation is changed by the new activity
+	repo.activities = append(repo.activities, newTestActivity(2, 10, day.Add(time.Minute)))
+	user.Rank = 21
+	result, err = rs.getLedger(context.TODO(), rules, user)
+	assert.NoError(t, err)
+	assert.Equal(t, 21, result.Expected)
+	assert.Equal(t, 2, repo.loaded)
+
+	// the rules are changed by the admin, the cached ledger is replayed under the new rules
+	rules = newTestRules()
+	rules.Points[2] = 5
+	result, err = rs.getLedger(context.TODO(), rules, user)
+	assert.NoError(t, err)
+	assert.Equal(t, 11, result.Expected)
+	assert.Equal(t, 3, repo.loaded)
+}
@@ -21,6 +21,8 @@ package review
 
 import (
 	"context"
+	"html"
+	"strconv"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/pager"
@@ -28,13 +30,18 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/internal/service/tag_moderator"
+	"github.com/apache/incubator-answer/internal/service/user_admin"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_suspension"
+	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/token"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -50,7 +57,8 @@ type ReviewRepo interface {
 	UpdateReviewStatus(ctx context.Context, reviewID int, reviewerUserID string, status int) (err error)
 	GetReview(ctx context.Context, reviewID int) (review *entity.Review, exist bool, err error)
 	GetReviewCount(ctx context.Context, status int) (count int64, err error)
-	GetReviewPage(ctx context.Context, page, pageSize int, cond *entity.Review) (reviewList []*entity.Review, total int64, err error)
+	GetReviewPage(ctx context.Context, page, pageSize int, cond *entity.Review, tagIDs []string) (
+		reviewList []*entity.Review, total int64, err error)
 }
 
 // ReviewService user service
@@ -67,6 +75,10 @@ type ReviewService struct {
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
 	notificationQueueService         notice_queue.NotificationQueueService
 	siteInfoService                  siteinfo_common.SiteInfoCommonService
+	tagModeratorService              *tag_moderator.TagModeratorService
+	eventQueueService                event_queue.EventQueueService
+	userSuspensionService            *user_suspension.UserSuspensionService
+	userAdminService                 *user_admin.UserAdminService
 }
 
 // NewReviewService new review service
@@ -83,6 +95,10 @@ func NewReviewService(
 	questionCommon *questioncommon.QuestionCommon,
 	notificationQueueService notice_queue.NotificationQueueService,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	tagModeratorService *tag_moderator.TagModeratorService,
+	eventQueueService event_queue.EventQueueService,
+	userSuspensionService *user_suspension.UserSuspensionService,
+	userAdminService *user_admin.UserAdminService,
 ) *ReviewService {
 	return &ReviewService{
 		reviewRepo:                       reviewRepo,
@@ -97,6 +113,10 @@ func NewReviewService(
 		questionCommon:                   questionCommon,
 		notificationQueueService:         notificationQueueService,
 		siteInfoService:                  siteInfoService,
+		tagModeratorService:              tagModeratorService,
+		eventQueueService:                eventQueueService,
+		userSuspensionService:            userSuspensionService,
+		userAdminService:                 userAdminService,
 	}
 }
 
@@ -188,7 +208,7 @@ func (cs *ReviewService) callPluginToReview(ctx context.Context, userID, objectI
 		reviewContent.Language = siteInterface.Language
 	}
 
-	_ = plugin.CallReviewer(func(reviewer plugin.Reviewer) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallReviewer, func(ctx context.Context, reviewer plugin.Reviewer) error {
 		// If one of the reviewer plugin return false, then the review is not approved
 		if reviewStatus != plugin.ReviewStatusApproved {
 			return nil
@@ -209,6 +229,20 @@ func (cs *ReviewService) callPluginToReview(ctx context.Context, userID, objectI
 	return reviewStatus
 }
 
+// AddSuspensionAppeal add the appeal of the suspended user to the review queue
+func (cs *ReviewService) AddSuspensionAppeal(ctx context.Context, req *schema.AddSuspensionAppealReq) (err error) {
+	r := &entity.Review{
+		UserID:         req.UserID,
+		ObjectID:       req.UserID,
+		ObjectType:     constant.ObjectTypeStrMapping[constant.UserObjectType],
+		ReviewerUserID: "0",
+		Reason:         req.Content,
+		Status:         entity.ReviewStatusPending,
+	}
+	_, err = cs.userSuspensionService.RecordAppeal(ctx, req.UserID, req.Content, r)
+	return err
+}
+
 // UpdateReview update review
 func (cs *ReviewService) UpdateReview(ctx context.Context, req *schema.UpdateReviewReq) (err error) {
 	review, exist, err := cs.reviewRepo.GetReview(ctx, req.ReviewID)
@@ -221,23 +255,53 @@ func (cs *ReviewService) UpdateReview(ctx context.Context, req *schema.UpdateRev
 	if review.Status != entity.ReviewStatusPending {
 		return nil
 	}
+	// only the admin and moderator can handle the appeal of the suspension
+	if !req.IsAdmin && review.ObjectType == constant.ObjectTypeStrMapping[constant.UserObjectType] {
+		return errors.Forbidden(reason.ForbiddenError)
+	}
+	if !req.IsAdmin {
+		can, err := cs.isReviewObjectTagModerator(ctx, req.UserID, review.ObjectID)
+		if err != nil {
+			return err
+		}
+		if !can {
+			return errors.Forbidden(reason.ForbiddenError)
+		}
+	}
 
-	if err = cs.updateObjectStatus(ctx, review, req.IsApprove()); err != nil {
+	if err = cs.updateObjectStatus(ctx, review, req.UserID, req.IsApprove()); err != nil {
 		return err
 	}
 
+	eventType := constant.EventReviewReject
 	if req.IsApprove() {
+		eventType = constant.EventReviewApprove
 		err = cs.reviewRepo.UpdateReviewStatus(ctx, req.ReviewID, req.UserID, entity.ReviewStatusApproved)
 	} else {
 		err = cs.reviewRepo.UpdateReviewStatus(ctx, req.ReviewID, req.UserID, entity.ReviewStatusRejected)
 	}
-	return
+	if err != nil {
+		return err
+	}
+	cs.eventQueueService.Send(ctx, schema.NewEvent(eventType, req.UserID).OID(strconv.Itoa(review.ID)).
+		AddExtra("object_id", review.ObjectID))
+	return nil
 }
 
 // update object status
-func (cs *ReviewService) updateObjectStatus(ctx context.Context, review *entity.Review, isApprove bool) (err error) {
+func (cs *ReviewService) updateObjectStatus(ctx context.Context, review *entity.Review, reviewerUserID string,
+	isApprove bool) (err error) {
 	objectType := constant.ObjectTypeNumberMapping[review.ObjectType]
 	switch objectType {
+	case constant.UserObjectType:
+		suspension, err := cs.userSuspensionService.ResolveAppeal(ctx, review.ID, reviewerUserID, isApprove)
+		if err != nil {
+			return err
+		}
+		if isApprove && suspension.Status == entity.UserSuspensionStatusReversed {
+			return cs.userAdminService.ReinstateSuspendedUser(ctx, suspension.UserID, reviewerUserID,
+				entity.UserSuspensionStatusReversed)
+		}
 	case constant.QuestionObjectType:
 		questionInfo, exist, err := cs.questionRepo.GetQuestion(ctx, review.ObjectID)
 		if err != nil {
@@ -246,15 +310,18 @@ func (cs *ReviewService) updateObjectStatus(ctx context.Context, review *entity.
 		if !exist {
 			return errors.BadRequest(reason.ObjectNotFound)
 		}
-		if isApprove {
-			questionInfo.Status = entity.QuestionStatusAvailable
-		} else {
+		if !isApprove {
 			questionInfo.Status = entity.QuestionStatusDeleted
+		} else if !questionInfo.PublishAt.IsZero() {
+			// the question with the publish time is published and notified by the scheduled job
+			questionInfo.Status = entity.QuestionStatusScheduled
+		} else {
+			questionInfo.Status = entity.QuestionStatusAvailable
 		}
 		if err := cs.questionRepo.UpdateQuestionStatus(ctx, questionInfo.ID, questionInfo.Status); err != nil {
 			return err
 		}
-		if isApprove {
+		if questionInfo.Status == entity.QuestionStatusAvailable {
 			tags, err := cs.tagCommon.GetObjectEntityTag(ctx, questionInfo.ID)
 			if err != nil {
 				log.Errorf("get question tags failed, err: %v", err)
@@ -264,7 +331,9 @@ func (cs *ReviewService) updateObjectStatus(ctx context.Context, review *entity.
 				log.Errorf("get user basic info by id error %v", err)
 			}
 			cs.externalNotificationQueueService.Send(ctx,
-				schema.CreateNewQuestionNotificationMsg(questionInfo.ID, questionInfo.Title, questionInfo.ParsedText, questionInfo.UserID, userInfo.DisplayName, tags))
+				schema.CreateNewQuestionNotificationMsg(questionInfo.ID, questionInfo.Title,
+					converter.Markdown2InlineStyleHTML(questionInfo.OriginalText, cs.siteInfoService.GetCodeHighlightTheme(ctx)),
+					questionInfo.UserID, userInfo.DisplayName, tags))
 		}
 		userQuestionCount, err := cs.questionRepo.GetUserQuestionCount(ctx, questionInfo.UserID, 0)
 		if err != nil {
@@ -375,20 +444,34 @@ func (cs *ReviewService) GetReviewPendingCount(ctx context.Context) (count int64
 // GetUnreviewedPostPage get review page
 func (cs *ReviewService) GetUnreviewedPostPage(ctx context.Context, req *schema.GetUnreviewedPostPageReq) (
 	pageModel *pager.PageModel, err error) {
+	// the tag moderator can only review the posts with the moderated tags
+	tagIDs := make([]string, 0)
 	if !req.IsAdmin {
-		return pager.NewPageModel(0, make([]*schema.GetUnreviewedPostPageResp, 0)), nil
+		tagIDs, err = cs.tagModeratorService.GetUserModeratedTagIDs(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if len(tagIDs) == 0 {
+			return pager.NewPageModel(0, make([]*schema.GetUnreviewedPostPageResp, 0)), nil
+		}
 	}
 	cond := &entity.Review{
 		ObjectID: req.ObjectID,
 		Status:   entity.ReviewStatusPending,
 	}
-	reviewList, total, err := cs.reviewRepo.GetReviewPage(ctx, req.Page, 1, cond)
+	reviewList, total, err := cs.reviewRepo.GetReviewPage(ctx, req.Page, 1, cond, tagIDs)
 	if err != nil {
 		return
 	}
 
 	resp := make([]*schema.GetUnreviewedPostPageResp, 0)
 	for _, review := range reviewList {
+		if review.ObjectType == constant.ObjectTypeStrMapping[constant.UserObjectType] {
+			if r := cs.formatSuspensionAppealReview(ctx, req, review); r != nil {
+				resp = append(resp, r)
+			}
+			continue
+		}
 		info, err := cs.objectInfoService.GetUnreviewedRevisionInfo(ctx, review.ObjectID)
 		if err != nil {
 			log.Errorf("GetUnreviewedRevisionInfo failed, err: %v", err)
@@ -427,3 +510,46 @@ func (cs *ReviewService) GetUnreviewedPostPage(ctx context.Context, req *schema.
 	}
 	return pager.NewPageModel(total, resp), nil
 }
+
+// formatSuspensionAppealReview format the review of the suspension appeal, the object of the review is the user
+func (cs *ReviewService) formatSuspensionAppealReview(ctx context.Context, req *schema.GetUnreviewedPostPageReq,
+	review *entity.Review) *schema.GetUnreviewedPostPageResp {
+	suspension, exist, err := cs.userSuspensionService.GetAppealedSuspensionInfo(ctx, review.ID)
+	if err != nil {
+		log.Errorf("get appealed suspension failed, err: %v", err)
+		return nil
+	}
+	if !exist {
+		log.Errorf("suspension not found by review id: %d", review.ID)
+		return nil
+	}
+	r := &schema.GetUnreviewedPostPageResp{
+		ReviewID:             review.ID,
+		CreatedAt:            review.CreatedAt.Unix(),
+		ObjectID:             review.ObjectID,
+		ObjectType:           constant.UserObjectType,
+		OriginalText:         review.Reason,
+		ParsedText:           html.EscapeString(review.Reason),
+		SubmitAt:             review.CreatedAt.Unix(),
+		SubmitterDisplayName: req.ReviewerMapping[review.Submitter],
+		Reason:               review.Reason,
+		Suspension:           suspension,
+	}
+	userInfo, exists, err := cs.userCommon.GetUserBasicInfoByID(ctx, review.UserID)
+	if err != nil {
+		log.Errorf("user not found by id: %s, err: %v", review.UserID, err)
+	}
+	if exists {
+		_ = copier.Copy(&r.AuthorUserInfo, userInfo)
+	}
+	return r
+}
+
+// isReviewObjectTagModerator whether the user moderates the tags of the question that the review object belongs to
+func (cs *ReviewService) isReviewObjectTagModerator(ctx context.Context, userID, objectID string) (bool, error) {
+	info, err := cs.objectInfoService.GetUnreviewedRevisionInfo(ctx, objectID)
+	if err != nil {
+		return false, err
+	}
+	return cs.tagModeratorService.IsQuestionTagModerator(ctx, userID, info.QuestionID)
+}
@@ -0,0 +1,405 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter operators defined in RFC 7644 3.4.2.2
+const (
+	FilterOpEq = "eq"
+	FilterOpNe = "ne"
+	FilterOpCo = "co"
+	FilterOpSw = "sw"
+	FilterOpEw = "ew"
+	FilterOpPr = "pr"
+	FilterOpGt = "gt"
+	FilterOpLt = "lt"
+	FilterOpGe = "ge"
+	FilterOpLe = "le"
+
+	FilterOpAnd = "and"
+	FilterOpOr  = "or"
+	FilterOpNot = "not"
+)
+
+var compareOps = map[string]bool{
+	FilterOpEq: true, FilterOpNe: true, FilterOpCo: true, FilterOpSw: true, FilterOpEw: true,
+	FilterOpGt: true, FilterOpLt: true, FilterOpGe: true, FilterOpLe: true,
+}
+
+// Filter is the parsed expression of scim filter query parameter.
+// For logical expression (and, or, not), Left and Right are the operands (not only has Left).
+// For attribute expression, Attr is the lower-case attribute path without schema urn,
+// sub-attribute of value path filter such as `emails[value eq "x"]` is flattened as `emails.value`.
+type Filter struct {
+	Op    string
+	Attr  string
+	Value interface{}
+	Left  *Filter
+	Right *Filter
+}
+
+// IsLogical returns true if filter is a logical expression
+func (f *Filter) IsLogical() bool {
+	return f.Op == FilterOpAnd || f.Op == FilterOpOr || f.Op == FilterOpNot
+}
+
+// ValueString returns the compare value as string
+func (f *Filter) ValueString() string {
+	switch v := f.Value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Match evaluates the filter by attribute values, the key of attrs must be lower-case attribute path.
+// All comparisons are case-insensitive, which is enough for the attributes we supported.
+func (f *Filter) Match(attrs map[string][]string) bool {
+	switch f.Op {
+	case FilterOpAnd:
+		return f.Left.Match(attrs) && f.Right.Match(attrs)
+	case FilterOpOr:
+		return f.Left.Match(attrs) || f.Right.Match(attrs)
+	case FilterOpNot:
+		return !f.Left.Match(attrs)
+	case FilterOpPr:
+		for _, v := range attrs[f.Attr] {
+			if len(v) > 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	values := attrs[f.Attr]
+	if f.Op == FilterOpNe {
+		for _, v := range values {
+			if strings.EqualFold(v, f.ValueString()) {
+				return false
+			}
+		}
+		return true
+	}
+	expected := strings.ToLower(f.ValueString())
+	for _, v := range values {
+		v = strings.ToLower(v)
+		var matched bool
+		switch f.Op {
+		case FilterOpEq:
+			matched = v == expected
+		case FilterOpCo:
+			matched = strings.Contains(v, expected)
+		case FilterOpSw:
+			matched = strings.HasPrefix(v, expected)
+		case FilterOpEw:
+			matched = strings.HasSuffix(v, expected)
+		case FilterOpGt:
+			matched = v > expected
+		case FilterOpLt:
+			matched = v < expected
+		case FilterOpGe:
+			matched = v >= expected
+		case FilterOpLe:
+			matched = v <= expected
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseFilter parse scim filter expression, empty filter returns nil
+func ParseFilter(filter string) (f *Filter, err error) {
+	if len(strings.TrimSpace(filter)) == 0 {
+		return nil, nil
+	}
+	tokens, err := tokenizeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	f, err = p.parseOr("")
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q", p.tokens[p.pos].text)
+	}
+	return f, nil
+}
+
+const (
+	tokenWord = iota
+	tokenString
+	tokenLeftParen
+	tokenRightParen
+	tokenLeftBracket
+	tokenRightBracket
+)
+
+type filterToken struct {
+	kind int
+	text string
+}
+
+func tokenizeFilter(filter string) (tokens []*filterToken, err error) {
+	runes := []rune(filter)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, &filterToken{kind: tokenLeftParen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, &filterToken{kind: tokenRightParen, text: ")"})
+			i++
+		case r == '[':
+			tokens = append(tokens, &filterToken{kind: tokenLeftBracket, text: "["})
+			i++
+		case r == ']':
+			tokens = append(tokens, &filterToken{kind: tokenRightBracket, text: "]"})
+			i++
+		case r == '"':
+			j := i + 1
+			for ; j < len(runes); j++ {
+				if runes[j] == '\\' {
+					j++
+					continue
+				}
+				if runes[j] == '"' {
+					break
+				}
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated string in filter")
+			}
+			var value string
+			if err = json.Unmarshal([]byte(string(runes[i:j+1])), &value); err != nil {
+				return nil, fmt.Errorf("invalid string %s in filter", string(runes[i:j+1]))
+			}
+			tokens = append(tokens, &filterToken{kind: tokenString, text: value})
+			i = j + 1
+		default:
+			j := i
+			for ; j < len(runes); j++ {
+				c := runes[j]
+				if unicode.IsSpace(c) || c == '(' || c == ')' || c == '[' || c == ']' || c == '"' {
+					break
+				}
+			}
+			tokens = append(tokens, &filterToken{kind: tokenWord, text: string(runes[i:j])})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []*filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() *filterToken {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() *filterToken {
+	t := p.peek()
+	if t != nil {
+		p.pos++
+	}
+	return t
+}
+
+func (p *filterParser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t != nil && t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+// parseOr parse `or` expression which has the lowest precedence.
+// prefix is the parent attribute of value path filter.
+func (p *filterParser) parseOr(prefix string) (*Filter, error) {
+	left, err := p.parseAnd(prefix)
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(FilterOpOr) {
+		p.next()
+		right, err := p.parseAnd(prefix)
+		if err != nil {
+			return nil, err
+		}
+		left = &Filter{Op: FilterOpOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd(prefix string) (*Filter, error) {
+	left, err := p.parseUnary(prefix)
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(FilterOpAnd) {
+		p.next()
+		right, err := p.parseUnary(prefix)
+		if err != nil {
+			return nil, err
+		}
+		left = &Filter{Op: FilterOpAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary(prefix string) (*Filter, error) {
+	if p.isKeyword(FilterOpNot) {
+		p.next()
+		if t := p.next(); t == nil || t.kind != tokenLeftParen {
+			return nil, fmt.Errorf("expected ( after not")
+		}
+		inner, err := p.parseOr(prefix)
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t == nil || t.kind != tokenRightParen {
+			return nil, fmt.Errorf("expected )")
+		}
+		return &Filter{Op: FilterOpNot, Left: inner}, nil
+	}
+
+	t := p.next()
+	if t == nil {
+		return nil, fmt.Errorf("unexpected end of filter")
+	}
+	if t.kind == tokenLeftParen {
+		inner, err := p.parseOr(prefix)
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t == nil || t.kind != tokenRightParen {
+			return nil, fmt.Errorf("expected )")
+		}
+		return inner, nil
+	}
+	if t.kind != tokenWord {
+		return nil, fmt.Errorf("unexpected token %q", t.text)
+	}
+
+	attr := normalizeAttrPath(t.text)
+	if len(prefix) > 0 {
+		attr = prefix + "." + attr
+	}
+
+	// value path, such as emails[type eq "work" and value co "@example.com"]
+	if next := p.peek(); next != nil && next.kind == tokenLeftBracket {
+		p.next()
+		inner, err := p.parseOr(attr)
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t == nil || t.kind != tokenRightBracket {
+			return nil, fmt.Errorf("expected ]")
+		}
+		// sub attribute after value path, such as emails[type eq "work"].value eq "x"
+		if next := p.peek(); next != nil && next.kind == tokenWord && strings.HasPrefix(next.text, ".") {
+			p.next()
+			sub, err := p.parseAttrExpr(attr + normalizeAttrPath(next.text))
+			if err != nil {
+				return nil, err
+			}
+			return &Filter{Op: FilterOpAnd, Left: inner, Right: sub}, nil
+		}
+		return inner, nil
+	}
+	return p.parseAttrExpr(attr)
+}
+
+// parseAttrExpr parse the operator and compare value of attribute expression
+func (p *filterParser) parseAttrExpr(attr string) (*Filter, error) {
+	opToken := p.next()
+	if opToken == nil || opToken.kind != tokenWord {
+		return nil, fmt.Errorf("expected operator after %s", attr)
+	}
+	op := strings.ToLower(opToken.text)
+	if op == FilterOpPr {
+		return &Filter{Op: FilterOpPr, Attr: attr}, nil
+	}
+	if !compareOps[op] {
+		return nil, fmt.Errorf("unsupported operator %s", opToken.text)
+	}
+
+	valueToken := p.next()
+	if valueToken == nil {
+		return nil, fmt.Errorf("expected value after %s", opToken.text)
+	}
+	f := &Filter{Op: op, Attr: attr}
+	if valueToken.kind == tokenString {
+		f.Value = valueToken.text
+		return f, nil
+	}
+	if valueToken.kind != tokenWord {
+		return nil, fmt.Errorf("unexpected token %q", valueToken.text)
+	}
+	switch strings.ToLower(valueToken.text) {
+	case "true":
+		f.Value = true
+	case "false":
+		f.Value = false
+	case "null":
+		f.Value = nil
+	default:
+		number, err := strconv.ParseFloat(valueToken.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %s", valueToken.text)
+		}
+		f.Value = number
+	}
+	return f, nil
+}
+
+// normalizeAttrPath remove the schema urn prefix and convert to lower case,
+// e.g. urn:ietf:params:scim:schemas:core:2.0:User:userName -> username
+func normalizeAttrPath(attr string) string {
+	if strings.HasPrefix(strings.ToLower(attr), "urn:") {
+		if idx := strings.LastIndex(attr, ":"); idx >= 0 {
+			attr = attr[idx+1:]
+		}
+	}
+	return strings.ToLower(attr)
+}
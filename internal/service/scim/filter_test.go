@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter(`userName eq "bjensen"`)
+	assert.NoError(t, err)
+	assert.Equal(t, &Filter{Op: FilterOpEq, Attr: "username", Value: "bjensen"}, f)
+
+	f, err = ParseFilter(`urn:ietf:params:scim:schemas:core:2.0:User:userName sw "J"`)
+	assert.NoError(t, err)
+	assert.Equal(t, &Filter{Op: FilterOpSw, Attr: "username", Value: "J"}, f)
+
+	f, err = ParseFilter(`active eq true and (emails co "example.com" or not (displayName pr))`)
+	assert.NoError(t, err)
+	assert.Equal(t, FilterOpAnd, f.Op)
+	assert.Equal(t, &Filter{Op: FilterOpEq, Attr: "active", Value: true}, f.Left)
+	assert.Equal(t, FilterOpOr, f.Right.Op)
+	assert.Equal(t, FilterOpNot, f.Right.Right.Op)
+	assert.Equal(t, &Filter{Op: FilterOpPr, Attr: "displayname"}, f.Right.Right.Left)
+
+	f, err = ParseFilter(`emails[type eq "work" and value co "@example.com"]`)
+	assert.NoError(t, err)
+	assert.Equal(t, FilterOpAnd, f.Op)
+	assert.Equal(t, "emails.type", f.Left.Attr)
+	assert.Equal(t, "emails.value", f.Right.Attr)
+
+	f, err = ParseFilter(`emails[type eq "work"].value eq "a\"b@example.com"`)
+	assert.NoError(t, err)
+	assert.Equal(t, &Filter{Op: FilterOpEq, Attr: "emails.value", Value: `a"b@example.com`}, f.Right)
+
+	f, err = ParseFilter("  ")
+	assert.NoError(t, err)
+	assert.Nil(t, f)
+
+	for _, invalid := range []string{
+		`userName`,
+		`userName xx "a"`,
+		`userName eq`,
+		`userName eq "a`,
+		`(userName eq "a"`,
+		`userName eq "a" extra`,
+		`emails[value eq "a"`,
+	} {
+		_, err = ParseFilter(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	attrs := map[string][]string{
+		"id":            {"2"},
+		"displayname":   {"Admin"},
+		"members.value": {"10", "11"},
+	}
+	cases := map[string]bool{
+		`displayName eq "admin"`:                   true,
+		`displayName ne "admin"`:                   false,
+		`displayName sw "Ad" and id eq "2"`:        true,
+		`displayName ew "x" or members eq "11"`:    false,
+		`members[value eq "11"]`:                   true,
+		`not (members.value eq "12")`:              true,
+		`externalId pr`:                            false,
+		`displayName co "dmi" and not (id eq "3")`: true,
+	}
+	for filter, expected := range cases {
+		f, err := ParseFilter(filter)
+		assert.NoError(t, err, filter)
+		assert.Equal(t, expected, f.Match(attrs), filter)
+	}
+}
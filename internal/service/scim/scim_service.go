@@ -0,0 +1,826 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/auth"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/user_admin"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_external_login"
+	"github.com/apache/incubator-answer/pkg/checker"
+	"github.com/apache/incubator-answer/pkg/random"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ExternalLoginProvider the provider name of user_external_login that saves the scim externalId
+const ExternalLoginProvider = "scim"
+
+// ScimRepo scim repository
+type ScimRepo interface {
+	GetUserPage(ctx context.Context, filter *Filter, offset, limit int) (users []*entity.User, total int64, err error)
+	GetExternalIDMapping(ctx context.Context, userIDs []string) (mapping map[string]string, err error)
+}
+
+// ScimService scim 2.0 provisioning service, users are mapped to answer users and groups are mapped to roles.
+type ScimService struct {
+	scimRepo              ScimRepo
+	userAdminRepo         user_admin.UserAdminRepo
+	userAdminService      *user_admin.UserAdminService
+	userCommon            *usercommon.UserCommon
+	userExternalLoginRepo user_external_login.UserExternalLoginRepo
+	roleService           *role.RoleService
+	userRoleRelService    *role.UserRoleRelService
+	authService           *auth.AuthService
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+}
+
+// NewScimService new scim service
+func NewScimService(
+	scimRepo ScimRepo,
+	userAdminRepo user_admin.UserAdminRepo,
+	userAdminService *user_admin.UserAdminService,
+	userCommon *usercommon.UserCommon,
+	userExternalLoginRepo user_external_login.UserExternalLoginRepo,
+	roleService *role.RoleService,
+	userRoleRelService *role.UserRoleRelService,
+	authService *auth.AuthService,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+) *ScimService {
+	return &ScimService{
+		scimRepo:              scimRepo,
+		userAdminRepo:         userAdminRepo,
+		userAdminService:      userAdminService,
+		userCommon:            userCommon,
+		userExternalLoginRepo: userExternalLoginRepo,
+		roleService:           roleService,
+		userRoleRelService:    userRoleRelService,
+		authService:           authService,
+		siteInfoCommonService: siteInfoCommonService,
+	}
+}
+
+// GetServiceProviderConfig get service provider config
+func (ss *ScimService) GetServiceProviderConfig() *schema.ScimServiceProviderConfigResp {
+	return &schema.ScimServiceProviderConfigResp{
+		Schemas:        []string{schema.ScimSchemaServiceProviderConfig},
+		Patch:          schema.ScimSupported{Supported: true},
+		Bulk:           schema.ScimBulkSupported{Supported: false},
+		Filter:         schema.ScimFilterSupported{Supported: true, MaxResults: schema.ScimMaxCount},
+		ChangePassword: schema.ScimSupported{Supported: true},
+		Sort:           schema.ScimSupported{Supported: false},
+		Etag:           schema.ScimSupported{Supported: false},
+		AuthenticationSchemes: []*schema.ScimAuthenticationScheme{
+			{
+				Type:        "oauthbearertoken",
+				Name:        "OAuth Bearer Token",
+				Description: "Authentication scheme using the bearer token generated in admin settings",
+				Primary:     true,
+			},
+		},
+	}
+}
+
+// ListUsers list users by filter
+func (ss *ScimService) ListUsers(ctx context.Context, req *schema.ScimListReq) (resp *schema.ScimListResp, err error) {
+	req.Format()
+	filter, err := ParseFilter(req.Filter)
+	if err != nil {
+		return nil, errors.BadRequest(reason.ScimInvalidFilter).WithError(err)
+	}
+	users, total, err := ss.scimRepo.GetUserPage(ctx, filter, req.StartIndex-1, *req.Count)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := ss.formatUsers(ctx, users)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.ScimListResp{
+		Schemas:      []string{schema.ScimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   req.StartIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+// GetUser get user by id
+func (ss *ScimService) GetUser(ctx context.Context, userID string) (resp *schema.ScimUser, err error) {
+	userInfo, err := ss.getAvailableUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := ss.formatUsers(ctx, []*entity.User{userInfo})
+	if err != nil {
+		return nil, err
+	}
+	return resources[0], nil
+}
+
+// CreateUser create user
+func (ss *ScimService) CreateUser(ctx context.Context, req *schema.ScimUser) (resp *schema.ScimUser, err error) {
+	email := req.GetPrimaryEmail()
+	if !isValidEmail(email) {
+		return nil, errors.BadRequest(reason.ScimInvalidValue)
+	}
+	_, exist, err := ss.userAdminRepo.GetUserInfoByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errors.Conflict(reason.EmailDuplicate)
+	}
+	if len(req.ExternalID) > 0 {
+		_, exist, err = ss.userExternalLoginRepo.GetByExternalID(ctx, ExternalLoginProvider, req.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			return nil, errors.Conflict(reason.ScimExternalIDDuplicate)
+		}
+	}
+
+	userInfo := &entity.User{
+		EMail:       email,
+		DisplayName: formatDisplayName(req.GetDisplayName()),
+		MailStatus:  entity.EmailStatusAvailable,
+		Status:      entity.UserStatusAvailable,
+		Rank:        1,
+	}
+	userInfo.Username, err = ss.makeUsername(ctx, req.UserName, userInfo.DisplayName)
+	if err != nil {
+		return nil, err
+	}
+	// users provisioned without password can only login via the identity provider
+	if len(req.Password) > 0 {
+		hashPwd, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		userInfo.Pass = string(hashPwd)
+	}
+	if err = ss.userAdminRepo.AddUser(ctx, userInfo); err != nil {
+		return nil, err
+	}
+
+	if err = ss.saveExternalID(ctx, userInfo.ID, req.ExternalID); err != nil {
+		return nil, err
+	}
+	if !req.IsActive() {
+		if err = ss.updateUserStatus(ctx, userInfo.ID, schemaUserStatus(false)); err != nil {
+			return nil, err
+		}
+	}
+	return ss.GetUser(ctx, userInfo.ID)
+}
+
+// ReplaceUser replace user attributes
+func (ss *ScimService) ReplaceUser(ctx context.Context, userID string, req *schema.ScimUser) (
+	resp *schema.ScimUser, err error) {
+	userInfo, err := ss.getAvailableUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	email := req.GetPrimaryEmail()
+	if len(email) > 0 && !isValidEmail(email) {
+		return nil, errors.BadRequest(reason.ScimInvalidValue)
+	}
+	if len(email) > 0 && !strings.EqualFold(email, userInfo.EMail) {
+		other, exist, err := ss.userCommon.GetByEmail(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+		if exist && other.ID != userInfo.ID {
+			return nil, errors.Conflict(reason.EmailDuplicate)
+		}
+		// the email changed by the identity provider is trusted, the unchanged one keeps its status
+		userInfo.EMail = email
+		userInfo.MailStatus = entity.EmailStatusAvailable
+	}
+	if len(req.UserName) > 0 && req.UserName != userInfo.Username && isAnswerUsername(req.UserName) {
+		other, exist, err := ss.userCommon.GetByUsername(ctx, req.UserName)
+		if err != nil {
+			return nil, err
+		}
+		if exist && other.ID != userInfo.ID {
+			return nil, errors.Conflict(reason.UsernameDuplicate)
+		}
+		userInfo.Username = req.UserName
+	}
+	if displayName := formatDisplayName(req.GetDisplayName()); len(displayName) > 0 {
+		userInfo.DisplayName = displayName
+	}
+	if err = ss.userCommon.UpdateUserProfile(ctx, userInfo); err != nil {
+		return nil, err
+	}
+
+	if len(req.Password) > 0 {
+		hashPwd, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		if err = ss.userAdminRepo.UpdateUserPassword(ctx, userInfo.ID, string(hashPwd)); err != nil {
+			return nil, err
+		}
+		ss.authService.RemoveUserAllTokens(ctx, userInfo.ID)
+	}
+
+	if err = ss.saveExternalID(ctx, userInfo.ID, req.ExternalID); err != nil {
+		return nil, err
+	}
+	if req.IsActive() != (userInfo.Status == entity.UserStatusAvailable) {
+		if err = ss.updateUserStatus(ctx, userInfo.ID, schemaUserStatus(req.IsActive())); err != nil {
+			return nil, err
+		}
+	}
+	return ss.GetUser(ctx, userInfo.ID)
+}
+
+// PatchUser apply patch operations to user, unknown attributes are ignored
+func (ss *ScimService) PatchUser(ctx context.Context, userID string, req *schema.ScimPatchReq) (
+	resp *schema.ScimUser, err error) {
+	current, err := ss.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// password is write-only, so it should be applied only if it appears in patch operations.
+	current.Password = ""
+	patched := make(map[string]bool)
+	for _, operation := range req.Operations {
+		if err = applyUserPatch(current, operation, patched); err != nil {
+			return nil, err
+		}
+	}
+	// the name parts are used as display name only if the display name is not set explicitly
+	if (patched["name.givenname"] || patched["name.familyname"]) && !patched["displayname"] {
+		if name := strings.TrimSpace(current.Name.GivenName + " " + current.Name.FamilyName); len(name) > 0 {
+			current.DisplayName = name
+		}
+	}
+	return ss.ReplaceUser(ctx, userID, current)
+}
+
+// DeleteUser mark user as deleted, the user content will be kept
+func (ss *ScimService) DeleteUser(ctx context.Context, userID string) (err error) {
+	if _, err = ss.getAvailableUser(ctx, userID); err != nil {
+		return err
+	}
+	if err = ss.updateUserStatus(ctx, userID, constant.UserDeleted); err != nil {
+		return err
+	}
+	binding, exist, err := ss.userExternalLoginRepo.GetByUserID(ctx, ExternalLoginProvider, userID)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return ss.userExternalLoginRepo.DeleteUserExternalLogin(ctx, userID, binding.ExternalID)
+	}
+	return nil
+}
+
+// ListGroups list groups, only the roles except the default user role are treated as groups,
+// because every user who is not in any group belongs to the default user role.
+func (ss *ScimService) ListGroups(ctx context.Context, req *schema.ScimListReq) (resp *schema.ScimListResp, err error) {
+	req.Format()
+	filter, err := ParseFilter(req.Filter)
+	if err != nil {
+		return nil, errors.BadRequest(reason.ScimInvalidFilter).WithError(err)
+	}
+	groups, err := ss.getAllGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]*schema.ScimGroup, 0)
+	for _, group := range groups {
+		if filter == nil || filter.Match(groupFilterAttrs(group)) {
+			matched = append(matched, group)
+		}
+	}
+	resources := make([]*schema.ScimGroup, 0)
+	for i := req.StartIndex - 1; i < len(matched) && len(resources) < *req.Count; i++ {
+		if req.ExcludeMembers() {
+			matched[i].Members = nil
+		}
+		resources = append(resources, matched[i])
+	}
+	return &schema.ScimListResp{
+		Schemas:      []string{schema.ScimSchemaListResponse},
+		TotalResults: int64(len(matched)),
+		StartIndex:   req.StartIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+// GetGroup get group by id
+func (ss *ScimService) GetGroup(ctx context.Context, groupID string) (resp *schema.ScimGroup, err error) {
+	groups, err := ss.getAllGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if group.ID == groupID {
+			return group, nil
+		}
+	}
+	return nil, errors.NotFound(reason.ObjectNotFound)
+}
+
+// ReplaceGroup replace group members
+func (ss *ScimService) ReplaceGroup(ctx context.Context, groupID string, req *schema.ScimGroup) (
+	resp *schema.ScimGroup, err error) {
+	group, err := ss.GetGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.DisplayName) > 0 && req.DisplayName != group.DisplayName {
+		return nil, errors.BadRequest(reason.ScimGroupImmutable)
+	}
+	members := make([]string, 0, len(req.Members))
+	for _, member := range req.Members {
+		members = append(members, member.Value)
+	}
+	if err = ss.setGroupMembers(ctx, group, members); err != nil {
+		return nil, err
+	}
+	return ss.GetGroup(ctx, groupID)
+}
+
+// PatchGroup add or remove group members
+func (ss *ScimService) PatchGroup(ctx context.Context, groupID string, req *schema.ScimPatchReq) (
+	resp *schema.ScimGroup, err error) {
+	group, err := ss.GetGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[string]bool)
+	for _, member := range group.Members {
+		members[member.Value] = true
+	}
+
+	for _, operation := range req.Operations {
+		op := strings.ToLower(operation.Op)
+		path := strings.ToLower(strings.TrimSpace(operation.Path))
+		switch {
+		case len(path) == 0:
+			// {"op": "replace", "value": {"displayName": "x", "members": [...]}}
+			values, ok := operation.Value.(map[string]interface{})
+			if !ok {
+				return nil, errors.BadRequest(reason.ScimInvalidValue)
+			}
+			for key, value := range values {
+				switch strings.ToLower(key) {
+				case "displayname":
+					if fmt.Sprint(value) != group.DisplayName {
+						return nil, errors.BadRequest(reason.ScimGroupImmutable)
+					}
+				case "members":
+					if op == "replace" {
+						members = make(map[string]bool)
+					}
+					for _, memberID := range patchMemberValues(value) {
+						members[memberID] = op != "remove"
+					}
+				}
+			}
+		case path == "displayname":
+			if op == "remove" || fmt.Sprint(operation.Value) != group.DisplayName {
+				return nil, errors.BadRequest(reason.ScimGroupImmutable)
+			}
+		case path == "members":
+			if op == "replace" || (op == "remove" && operation.Value == nil) {
+				members = make(map[string]bool)
+			}
+			for _, memberID := range patchMemberValues(operation.Value) {
+				members[memberID] = op != "remove"
+			}
+		case strings.HasPrefix(path, "members["):
+			// {"op": "remove", "path": "members[value eq \"2819c223\"]"}
+			filter, err := ParseFilter(operation.Path)
+			if err != nil {
+				return nil, errors.BadRequest(reason.ScimInvalidFilter).WithError(err)
+			}
+			for memberID, in := range members {
+				if in && filter.Match(map[string][]string{"members.value": {memberID}}) && op == "remove" {
+					members[memberID] = false
+				}
+			}
+		default:
+			return nil, errors.BadRequest(reason.ScimInvalidValue)
+		}
+	}
+
+	memberIDs := make([]string, 0, len(members))
+	for memberID, in := range members {
+		if in {
+			memberIDs = append(memberIDs, memberID)
+		}
+	}
+	if err = ss.setGroupMembers(ctx, group, memberIDs); err != nil {
+		return nil, err
+	}
+	return ss.GetGroup(ctx, groupID)
+}
+
+func (ss *ScimService) getAvailableUser(ctx context.Context, userID string) (userInfo *entity.User, err error) {
+	userInfo, exist, err := ss.userAdminRepo.GetUserInfo(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted {
+		return nil, errors.NotFound(reason.UserNotFound)
+	}
+	return userInfo, nil
+}
+
+func (ss *ScimService) formatUsers(ctx context.Context, users []*entity.User) (resp []*schema.ScimUser, err error) {
+	resp = make([]*schema.ScimUser, 0, len(users))
+	if len(users) == 0 {
+		return resp, nil
+	}
+	userIDs := make([]string, 0, len(users))
+	for _, u := range users {
+		userIDs = append(userIDs, u.ID)
+	}
+	externalIDMapping, err := ss.scimRepo.GetExternalIDMapping(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	userRoleMapping, err := ss.userRoleRelService.GetUserRoleMapping(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	siteURL := ss.getSiteURL(ctx)
+
+	for _, u := range users {
+		active := u.Status == entity.UserStatusAvailable
+		user := &schema.ScimUser{
+			Schemas:     []string{schema.ScimSchemaUser},
+			ID:          u.ID,
+			ExternalID:  externalIDMapping[u.ID],
+			UserName:    u.Username,
+			Name:        &schema.ScimName{Formatted: u.DisplayName},
+			DisplayName: u.DisplayName,
+			Active:      &active,
+			Emails: []*schema.ScimMultiValued{
+				{Value: u.EMail, Type: "work", Primary: true},
+			},
+			Groups: make([]*schema.ScimMultiValued, 0),
+			Meta: &schema.ScimMeta{
+				ResourceType: schema.ScimResourceTypeUser,
+				Created:      formatScimTime(u.CreatedAt),
+				LastModified: formatScimTime(u.UpdatedAt),
+				Location:     fmt.Sprintf("%s/scim/v2/Users/%s", siteURL, u.ID),
+			},
+		}
+		if r := userRoleMapping[u.ID]; r != nil && r.ID != role.RoleUserID {
+			user.Groups = append(user.Groups, &schema.ScimMultiValued{
+				Value:   strconv.Itoa(r.ID),
+				Display: r.Name,
+				Ref:     fmt.Sprintf("%s/scim/v2/Groups/%d", siteURL, r.ID),
+			})
+		}
+		resp = append(resp, user)
+	}
+	return resp, nil
+}
+
+func (ss *ScimService) getAllGroups(ctx context.Context) (groups []*schema.ScimGroup, err error) {
+	roles, err := ss.roleService.GetRoleList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	siteURL := ss.getSiteURL(ctx)
+	groups = make([]*schema.ScimGroup, 0)
+	for _, r := range roles {
+		if r.ID == role.RoleUserID {
+			continue
+		}
+		rels, err := ss.userRoleRelService.GetUserByRoleID(ctx, []int{r.ID})
+		if err != nil {
+			return nil, err
+		}
+		group := &schema.ScimGroup{
+			Schemas:     []string{schema.ScimSchemaGroup},
+			ID:          strconv.Itoa(r.ID),
+			DisplayName: r.Name,
+			Members:     make([]*schema.ScimMultiValued, 0, len(rels)),
+			Meta: &schema.ScimMeta{
+				ResourceType: schema.ScimResourceTypeGroup,
+				Location:     fmt.Sprintf("%s/scim/v2/Groups/%d", siteURL, r.ID),
+			},
+		}
+		for _, rel := range rels {
+			group.Members = append(group.Members, &schema.ScimMultiValued{
+				Value: rel.UserID,
+				Ref:   fmt.Sprintf("%s/scim/v2/Users/%s", siteURL, rel.UserID),
+			})
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// setGroupMembers users removed from the group fall back to the default user role
+func (ss *ScimService) setGroupMembers(ctx context.Context, group *schema.ScimGroup, memberIDs []string) (err error) {
+	roleID, _ := strconv.Atoi(group.ID)
+	newMembers := make(map[string]bool, len(memberIDs))
+	for _, memberID := range memberIDs {
+		newMembers[memberID] = true
+	}
+	for _, member := range group.Members {
+		if newMembers[member.Value] {
+			delete(newMembers, member.Value)
+			continue
+		}
+		if err = ss.userRoleRelService.SaveUserRole(ctx, member.Value, role.RoleUserID); err != nil {
+			return err
+		}
+		ss.authService.RemoveUserAllTokens(ctx, member.Value)
+	}
+	for memberID := range newMembers {
+		if _, err = ss.getAvailableUser(ctx, memberID); err != nil {
+			return errors.BadRequest(reason.ScimInvalidValue).WithError(err)
+		}
+		if err = ss.userRoleRelService.SaveUserRole(ctx, memberID, roleID); err != nil {
+			return err
+		}
+		ss.authService.RemoveUserAllTokens(ctx, memberID)
+	}
+	return nil
+}
+
+func (ss *ScimService) saveExternalID(ctx context.Context, userID, externalID string) (err error) {
+	binding, exist, err := ss.userExternalLoginRepo.GetByUserID(ctx, ExternalLoginProvider, userID)
+	if err != nil {
+		return err
+	}
+	if exist && binding.ExternalID == externalID {
+		return nil
+	}
+	if len(externalID) > 0 {
+		other, otherExist, err := ss.userExternalLoginRepo.GetByExternalID(ctx, ExternalLoginProvider, externalID)
+		if err != nil {
+			return err
+		}
+		if otherExist && other.UserID != userID {
+			return errors.Conflict(reason.ScimExternalIDDuplicate)
+		}
+	}
+	if exist {
+		if err = ss.userExternalLoginRepo.DeleteUserExternalLogin(ctx, userID, binding.ExternalID); err != nil {
+			return err
+		}
+	}
+	if len(externalID) == 0 {
+		return nil
+	}
+	return ss.userExternalLoginRepo.AddUserExternalLogin(ctx, &entity.UserExternalLogin{
+		UserID:     userID,
+		Provider:   ExternalLoginProvider,
+		ExternalID: externalID,
+	})
+}
+
+func (ss *ScimService) updateUserStatus(ctx context.Context, userID, status string) (err error) {
+	return ss.userAdminService.UpdateUserStatus(ctx, &schema.UpdateUserStatusReq{
+		UserID: userID,
+		Status: status,
+	})
+}
+
+// makeUsername use the scim userName if it is a valid username, otherwise generate by display name
+func (ss *ScimService) makeUsername(ctx context.Context, userName, displayName string) (username string, err error) {
+	if isAnswerUsername(userName) {
+		_, exist, err := ss.userCommon.GetByUsername(ctx, userName)
+		if err != nil {
+			return "", err
+		}
+		if !exist {
+			return userName, nil
+		}
+	}
+	username, err = ss.userCommon.MakeUsername(ctx, displayName)
+	if err != nil {
+		return ss.userCommon.MakeUsername(ctx, random.Username())
+	}
+	return username, nil
+}
+
+func (ss *ScimService) getSiteURL(ctx context.Context) string {
+	general, err := ss.siteInfoCommonService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+	return general.SiteUrl
+}
+
+func schemaUserStatus(active bool) string {
+	if active {
+		return constant.UserNormal
+	}
+	return constant.UserSuspended
+}
+
+func isValidEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email && len(email) <= 500
+}
+
+func isAnswerUsername(username string) bool {
+	return len(username) > 3 && len(username) <= 30 &&
+		!checker.IsInvalidUsername(username) && !checker.IsReservedUsername(username) && !checker.IsUsersIgnorePath(username)
+}
+
+// formatDisplayName display name is limited to 30 characters
+func formatDisplayName(displayName string) string {
+	runes := []rune(strings.TrimSpace(displayName))
+	if len(runes) > 30 {
+		runes = runes[:30]
+	}
+	return string(runes)
+}
+
+func formatScimTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func groupFilterAttrs(group *schema.ScimGroup) map[string][]string {
+	attrs := map[string][]string{
+		"id":          {group.ID},
+		"displayname": {group.DisplayName},
+	}
+	for _, member := range group.Members {
+		attrs["members"] = append(attrs["members"], member.Value)
+		attrs["members.value"] = append(attrs["members.value"], member.Value)
+	}
+	return attrs
+}
+
+// patchMemberValues get member ids from patch value, such as [{"value": "1"}, {"value": "2"}]
+func patchMemberValues(value interface{}) (memberIDs []string) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			memberIDs = append(memberIDs, patchMemberValues(item)...)
+		}
+	case map[string]interface{}:
+		if id, ok := v["value"]; ok {
+			memberIDs = append(memberIDs, fmt.Sprint(id))
+		}
+	case string:
+		memberIDs = append(memberIDs, v)
+	}
+	return memberIDs
+}
+
+// applyUserPatch apply one patch operation on user resource, the patched attributes are recorded in patched
+func applyUserPatch(user *schema.ScimUser, operation *schema.ScimPatchOperate, patched map[string]bool) (err error) {
+	op := strings.ToLower(operation.Op)
+	if op != "add" && op != "replace" && op != "remove" {
+		return errors.BadRequest(reason.ScimInvalidValue)
+	}
+	path := strings.TrimSpace(operation.Path)
+	if len(path) == 0 {
+		// Azure AD style: {"op": "replace", "value": {"active": false, "name.givenName": "x"}}
+		values, ok := operation.Value.(map[string]interface{})
+		if !ok {
+			return errors.BadRequest(reason.ScimInvalidValue)
+		}
+		for key, value := range values {
+			err = applyUserPatch(user, &schema.ScimPatchOperate{Op: op, Path: key, Value: value}, patched)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if op == "remove" {
+		// the required attributes can not be removed, and the other attributes are ignored
+		return nil
+	}
+
+	// emails[type eq "work"].value -> emails
+	attr := normalizeAttrPath(path)
+	if idx := strings.Index(attr, "["); idx > 0 {
+		attr = attr[:idx]
+	}
+	switch attr {
+	case "active":
+		active, ok := parsePatchBool(operation.Value)
+		if !ok {
+			return errors.BadRequest(reason.ScimInvalidValue)
+		}
+		user.Active = &active
+	case "username":
+		user.UserName = fmt.Sprint(operation.Value)
+	case "displayname", "name.formatted":
+		user.DisplayName = fmt.Sprint(operation.Value)
+		patched["displayname"] = true
+	case "name.givenname", "name.familyname":
+		if user.Name == nil {
+			user.Name = &schema.ScimName{}
+		}
+		if attr == "name.givenname" {
+			user.Name.GivenName = fmt.Sprint(operation.Value)
+		} else {
+			user.Name.FamilyName = fmt.Sprint(operation.Value)
+		}
+		patched[attr] = true
+	case "name":
+		values, ok := operation.Value.(map[string]interface{})
+		if !ok {
+			return errors.BadRequest(reason.ScimInvalidValue)
+		}
+		for key, value := range values {
+			err = applyUserPatch(user, &schema.ScimPatchOperate{Op: op, Path: "name." + key, Value: value}, patched)
+			if err != nil {
+				return err
+			}
+		}
+	case "emails", "emails.value":
+		email := patchEmailValue(operation.Value)
+		if len(email) == 0 {
+			return errors.BadRequest(reason.ScimInvalidValue)
+		}
+		user.Emails = []*schema.ScimMultiValued{{Value: email, Type: "work", Primary: true}}
+	case "externalid":
+		user.ExternalID = fmt.Sprint(operation.Value)
+	case "password":
+		user.Password = fmt.Sprint(operation.Value)
+	}
+	return nil
+}
+
+// parsePatchBool some identity providers send boolean as string, such as "False"
+func parsePatchBool(value interface{}) (b bool, ok bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(strings.ToLower(v))
+		return b, err == nil
+	}
+	return false, false
+}
+
+// patchEmailValue get the primary email from patch value
+func patchEmailValue(value interface{}) (email string) {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if e, ok := v["value"]; ok {
+			return fmt.Sprint(e)
+		}
+	case []interface{}:
+		for _, item := range v {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if primary, _ := m["primary"].(bool); primary || len(email) == 0 {
+				email = patchEmailValue(m)
+			}
+		}
+	}
+	return email
+}
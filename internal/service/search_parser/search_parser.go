@@ -130,12 +130,11 @@ func (sp *SearchParser) parseTags(ctx context.Context, query *string) (tags [][]
 		if tag.MainTagID > 0 {
 			tagGroup = append(tagGroup, fmt.Sprintf("%d", tag.MainTagID))
 		}
-		synIDs, err := sp.tagCommonService.GetTagIDsByMainTagID(ctx, tag.ID)
-		if err != nil || !exists {
+		descendantIDs, err := sp.tagCommonService.GetTagIDsWithDescendants(ctx, tag.ID)
+		if err != nil {
 			continue
 		}
-		tagGroup = append(tagGroup, tag.ID)
-		tagGroup = append(tagGroup, synIDs...)
+		tagGroup = append(tagGroup, descendantIDs...)
 		tagGroup = converter.UniqueArray(tagGroup)
 		tags = append(tags, tagGroup)
 	}
@@ -36,6 +36,8 @@ import (
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/random"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/jinzhu/copier"
 	"github.com/segmentfault/pacman/errors"
@@ -89,6 +91,11 @@ func (s *SiteInfoService) GetSiteInterface(ctx context.Context) (resp *schema.Si
 	return s.siteInfoCommonService.GetSiteInterface(ctx)
 }
 
+// GetCodeHighlightCss get the css of code highlight theme
+func (s *SiteInfoService) GetCodeHighlightCss(ctx context.Context) (css string) {
+	return converter.CodeHighlightCSS(s.siteInfoCommonService.GetCodeHighlightTheme(ctx))
+}
+
 // GetSiteBranding get site info branding
 func (s *SiteInfoService) GetSiteBranding(ctx context.Context) (resp *schema.SiteBrandingResp, err error) {
 	return s.siteInfoCommonService.GetSiteBranding(ctx)
@@ -159,6 +166,10 @@ func (s *SiteInfoService) SaveSiteInterface(ctx context.Context, req schema.Site
 		err = errors.BadRequest(reason.LangNotFound)
 		return
 	}
+	if len(req.CodeHighlightTheme) > 0 && !converter.IsCodeHighlightTheme(req.CodeHighlightTheme) {
+		err = errors.BadRequest(reason.CodeHighlightThemeNotFound)
+		return
+	}
 
 	content, _ := json.Marshal(req)
 	data := entity.SiteInfo{
@@ -269,6 +280,66 @@ func (s *SiteInfoService) SaveSiteUsers(ctx context.Context, req *schema.SiteUse
 	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeUsers, data)
 }
 
+// GetSiteScim get site scim provisioning config
+func (s *SiteInfoService) GetSiteScim(ctx context.Context) (resp *schema.SiteScimResp, err error) {
+	return s.siteInfoCommonService.GetSiteScim(ctx)
+}
+
+// SaveSiteScim save site scim provisioning config, the bearer token will be generated when it is not set
+func (s *SiteInfoService) SaveSiteScim(ctx context.Context, req *schema.SiteScimReq) (resp *schema.SiteScimResp, err error) {
+	resp, err = s.siteInfoCommonService.GetSiteScim(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp.Enable = req.Enable
+	if len(resp.Token) == 0 || req.ResetToken {
+		resp.Token = random.SecretToken()
+	}
+
+	content, _ := json.Marshal(resp)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeScim,
+		Content: string(content),
+		Status:  1,
+	}
+	if err = s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeScim, data); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteCaptcha get site captcha thresholds
+func (s *SiteInfoService) GetSiteCaptcha(ctx context.Context) (resp *schema.SiteCaptchaResp, err error) {
+	return s.siteInfoCommonService.GetSiteCaptcha(ctx)
+}
+
+// SaveSiteCaptcha save site captcha thresholds, if the action is configured more than once the last one is used
+func (s *SiteInfoService) SaveSiteCaptcha(ctx context.Context, req *schema.SiteCaptchaReq) (
+	resp *schema.SiteCaptchaResp, err error) {
+	resp = &schema.SiteCaptchaResp{}
+	configured := make(map[string]int)
+	for _, config := range req.Actions {
+		if idx, ok := configured[config.Action]; ok {
+			resp.Actions[idx] = config
+			continue
+		}
+		configured[config.Action] = len(resp.Actions)
+		resp.Actions = append(resp.Actions, config)
+	}
+	resp.FillDefault()
+
+	content, _ := json.Marshal(resp)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeCaptcha,
+		Content: string(content),
+		Status:  1,
+	}
+	if err = s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeCaptcha, data); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetSMTPConfig get smtp config
 func (s *SiteInfoService) GetSMTPConfig(ctx context.Context) (resp *schema.GetSMTPConfigResp, err error) {
 	emailConfig, err := s.emailService.GetEmailConfig(ctx)
@@ -27,6 +27,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/pkg/gravatar"
 	"github.com/segmentfault/pacman/log"
 )
@@ -45,6 +46,7 @@ type siteInfoCommonService struct {
 type SiteInfoCommonService interface {
 	GetSiteGeneral(ctx context.Context) (resp *schema.SiteGeneralResp, err error)
 	GetSiteInterface(ctx context.Context) (resp *schema.SiteInterfaceResp, err error)
+	GetCodeHighlightTheme(ctx context.Context) (theme string)
 	GetSiteBranding(ctx context.Context) (resp *schema.SiteBrandingResp, err error)
 	GetSiteUsers(ctx context.Context) (resp *schema.SiteUsersResp, err error)
 	FormatAvatar(ctx context.Context, originalAvatarData, email string, userStatus int) *schema.AvatarInfo
@@ -55,6 +57,8 @@ type SiteInfoCommonService interface {
 	GetSiteCustomCssHTML(ctx context.Context) (resp *schema.SiteCustomCssHTMLResp, err error)
 	GetSiteTheme(ctx context.Context) (resp *schema.SiteThemeResp, err error)
 	GetSiteSeo(ctx context.Context) (resp *schema.SiteSeoResp, err error)
+	GetSiteScim(ctx context.Context) (resp *schema.SiteScimResp, err error)
+	GetSiteCaptcha(ctx context.Context) (resp *schema.SiteCaptchaResp, err error)
 	GetSiteInfoByType(ctx context.Context, siteType string, resp interface{}) (err error)
 }
 
@@ -84,6 +88,19 @@ func (s *siteInfoCommonService) GetSiteInterface(ctx context.Context) (resp *sch
 	return resp, nil
 }
 
+// GetCodeHighlightTheme get the theme of code highlight, it falls back to the default theme
+func (s *siteInfoCommonService) GetCodeHighlightTheme(ctx context.Context) (theme string) {
+	siteInterface, err := s.GetSiteInterface(ctx)
+	if err != nil {
+		log.Error(err)
+		return converter.DefaultCodeHighlightTheme
+	}
+	if len(siteInterface.CodeHighlightTheme) == 0 {
+		return converter.DefaultCodeHighlightTheme
+	}
+	return siteInterface.CodeHighlightTheme
+}
+
 // GetSiteBranding get site info branding
 func (s *siteInfoCommonService) GetSiteBranding(ctx context.Context) (resp *schema.SiteBrandingResp, err error) {
 	resp = &schema.SiteBrandingResp{}
@@ -213,6 +230,25 @@ func (s *siteInfoCommonService) GetSiteSeo(ctx context.Context) (resp *schema.Si
 	return resp, nil
 }
 
+// GetSiteScim get site scim provisioning config
+func (s *siteInfoCommonService) GetSiteScim(ctx context.Context) (resp *schema.SiteScimResp, err error) {
+	resp = &schema.SiteScimResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeScim, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteCaptcha get the captcha thresholds of the actions, the default thresholds are used if not configured
+func (s *siteInfoCommonService) GetSiteCaptcha(ctx context.Context) (resp *schema.SiteCaptchaResp, err error) {
+	resp = &schema.SiteCaptchaResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeCaptcha, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
 func (s *siteInfoCommonService) EnableShortID(ctx context.Context) (enabled bool) {
 	siteSeo, err := s.GetSiteSeo(ctx)
 	if err != nil {
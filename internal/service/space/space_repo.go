@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package space
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/entity"
+)
+
+// SpaceRepo space repository
+type SpaceRepo interface {
+	AddSpace(ctx context.Context, space *entity.Space) (err error)
+	UpdateSpace(ctx context.Context, space *entity.Space) (err error)
+	RemoveSpace(ctx context.Context, spaceID string) (err error)
+	GetSpace(ctx context.Context, spaceID string) (space *entity.Space, exist bool, err error)
+	GetSpaceBySlugName(ctx context.Context, slugName string) (space *entity.Space, exist bool, err error)
+	GetSpaceList(ctx context.Context) (spaces []*entity.Space, err error)
+	GetSpaceQuestionCount(ctx context.Context, spaceID string) (count int64, err error)
+
+	AddSpaceMember(ctx context.Context, member *entity.SpaceMember) (err error)
+	UpdateSpaceMember(ctx context.Context, member *entity.SpaceMember) (err error)
+	RemoveSpaceMember(ctx context.Context, spaceID string, memberType int, memberID string) (err error)
+	GetSpaceMember(ctx context.Context, spaceID string, memberType int, memberID string) (
+		member *entity.SpaceMember, exist bool, err error)
+	GetSpaceMembers(ctx context.Context, spaceID string) (members []*entity.SpaceMember, err error)
+	GetMemberships(ctx context.Context, userID string, roleID int) (members []*entity.SpaceMember, err error)
+
+	GetQuestionSpaceID(ctx context.Context, questionID string) (spaceID string, exist bool, err error)
+}
@@ -0,0 +1,425 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package space
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/role"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// SpaceService space service
+type SpaceService struct {
+	spaceRepo          SpaceRepo
+	userCommon         *usercommon.UserCommon
+	userRoleRelService *role.UserRoleRelService
+	roleService        *role.RoleService
+}
+
+// NewSpaceService new space service
+func NewSpaceService(
+	spaceRepo SpaceRepo,
+	userCommon *usercommon.UserCommon,
+	userRoleRelService *role.UserRoleRelService,
+	roleService *role.RoleService,
+) *SpaceService {
+	return &SpaceService{
+		spaceRepo:          spaceRepo,
+		userCommon:         userCommon,
+		userRoleRelService: userRoleRelService,
+		roleService:        roleService,
+	}
+}
+
+// GetUserSpaceAccess get the spaces that user can access
+func (ss *SpaceService) GetUserSpaceAccess(ctx context.Context, userID string) (
+	access *handler.SpaceAccess, err error) {
+	access = &handler.SpaceAccess{}
+	if len(userID) == 0 {
+		return access, nil
+	}
+	roleID, err := ss.userRoleRelService.GetUserRole(ctx, userID)
+	if err != nil {
+		return access, err
+	}
+	if roleID == role.RoleAdminID || roleID == role.RoleModeratorID {
+		access.AllSpaces = true
+		return access, nil
+	}
+	members, err := ss.spaceRepo.GetMemberships(ctx, userID, roleID)
+	if err != nil {
+		return access, err
+	}
+	for _, member := range members {
+		access.MemberSpaceIDs = append(access.MemberSpaceIDs, member.SpaceID)
+		if member.IsModerator {
+			access.ModeratorSpaceIDs = append(access.ModeratorSpaceIDs, member.SpaceID)
+		}
+	}
+	return access, nil
+}
+
+// CheckQuestionAccess check whether the login user in context can access the question.
+// If not, return question not found error to avoid leaking the existence of the question.
+func (ss *SpaceService) CheckQuestionAccess(ctx context.Context, questionID string) (err error) {
+	ok, err := ss.canAccessQuestion(ctx, handler.GetSpaceAccess(ctx), questionID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.NotFound(reason.QuestionNotFound)
+	}
+	return nil
+}
+
+// CheckQuestionSpaceAccess check whether the login user in context can access the questions in the space
+func (ss *SpaceService) CheckQuestionSpaceAccess(ctx context.Context, spaceID string) (err error) {
+	ok, err := ss.canAccessSpaceID(ctx, handler.GetSpaceAccess(ctx), spaceID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.NotFound(reason.QuestionNotFound)
+	}
+	return nil
+}
+
+// CanUserAccessQuestion check whether the user can access the question, used when the user is not the login user
+func (ss *SpaceService) CanUserAccessQuestion(ctx context.Context, userID, questionID string) (ok bool, err error) {
+	access, err := ss.GetUserSpaceAccess(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return ss.canAccessQuestion(ctx, access, questionID)
+}
+
+// IsQuestionSpaceModerator check whether the user is the moderator of the space that question belongs to
+func (ss *SpaceService) IsQuestionSpaceModerator(ctx context.Context, userID, questionID string) (
+	ok bool, err error) {
+	if len(userID) == 0 || len(questionID) == 0 {
+		return false, nil
+	}
+	spaceID, exist, err := ss.spaceRepo.GetQuestionSpaceID(ctx, questionID)
+	if err != nil || !exist || spaceID == entity.NoSpaceID {
+		return false, err
+	}
+	access, err := ss.GetUserSpaceAccess(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return containsID(access.ModeratorSpaceIDs, spaceID), nil
+}
+
+// CheckCanPostInSpace check whether the login user in context can post questions in the space
+func (ss *SpaceService) CheckCanPostInSpace(ctx context.Context, spaceID string) (err error) {
+	if len(spaceID) == 0 || spaceID == entity.NoSpaceID {
+		return nil
+	}
+	space, exist, err := ss.spaceRepo.GetSpace(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.SpaceNotFound)
+	}
+	if !canAccessSpace(handler.GetSpaceAccess(ctx), space) {
+		return errors.Forbidden(reason.SpacePostNotPermitted)
+	}
+	return nil
+}
+
+// GetSpaceBySlugName get space by slug name, the space that login user can not see is treated as not exist
+func (ss *SpaceService) GetSpaceBySlugName(ctx context.Context, slugName string) (
+	space *entity.Space, exist bool, err error) {
+	space, exist, err = ss.spaceRepo.GetSpaceBySlugName(ctx, slugName)
+	if err != nil || !exist {
+		return nil, false, err
+	}
+	if space.Visibility == entity.SpaceVisibilityHidden && !canAccessSpace(handler.GetSpaceAccess(ctx), space) {
+		return nil, false, nil
+	}
+	return space, true, nil
+}
+
+// GetSpaceList get the spaces that login user can see
+func (ss *SpaceService) GetSpaceList(ctx context.Context) (resp []*schema.GetSpaceResp, err error) {
+	resp = make([]*schema.GetSpaceResp, 0)
+	spaces, err := ss.spaceRepo.GetSpaceList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	access := handler.GetSpaceAccess(ctx)
+	for _, space := range spaces {
+		isMember := containsID(access.MemberSpaceIDs, space.ID)
+		canPost := canAccessSpace(access, space)
+		if space.Visibility == entity.SpaceVisibilityHidden && !canPost {
+			continue
+		}
+		resp = append(resp, &schema.GetSpaceResp{
+			ID:          space.ID,
+			SlugName:    space.SlugName,
+			DisplayName: space.DisplayName,
+			Description: space.Description,
+			Visibility:  space.Visibility,
+			IsMember:    isMember,
+			CanPost:     canPost,
+		})
+	}
+	return resp, nil
+}
+
+// GetAdminSpaceList get all spaces for admin
+func (ss *SpaceService) GetAdminSpaceList(ctx context.Context) (resp []*schema.GetSpaceResp, err error) {
+	resp = make([]*schema.GetSpaceResp, 0)
+	spaces, err := ss.spaceRepo.GetSpaceList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, space := range spaces {
+		resp = append(resp, &schema.GetSpaceResp{
+			ID:          space.ID,
+			SlugName:    space.SlugName,
+			DisplayName: space.DisplayName,
+			Description: space.Description,
+			Visibility:  space.Visibility,
+			CanPost:     true,
+		})
+	}
+	return resp, nil
+}
+
+// AddSpace add space
+func (ss *SpaceService) AddSpace(ctx context.Context, req *schema.AddSpaceReq) (resp *schema.AddSpaceResp, err error) {
+	_, exist, err := ss.spaceRepo.GetSpaceBySlugName(ctx, req.SlugName)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errors.BadRequest(reason.SpaceSlugNameDuplicate)
+	}
+	space := &entity.Space{
+		SlugName:    req.SlugName,
+		DisplayName: req.DisplayName,
+		Description: req.Description,
+		Visibility:  req.Visibility,
+	}
+	if err = ss.spaceRepo.AddSpace(ctx, space); err != nil {
+		return nil, err
+	}
+	return &schema.AddSpaceResp{ID: space.ID}, nil
+}
+
+// UpdateSpace update space
+func (ss *SpaceService) UpdateSpace(ctx context.Context, req *schema.UpdateSpaceReq) (err error) {
+	space, exist, err := ss.spaceRepo.GetSpace(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.SpaceNotFound)
+	}
+	if space.SlugName != req.SlugName {
+		_, exist, err = ss.spaceRepo.GetSpaceBySlugName(ctx, req.SlugName)
+		if err != nil {
+			return err
+		}
+		if exist {
+			return errors.BadRequest(reason.SpaceSlugNameDuplicate)
+		}
+	}
+	space.SlugName = req.SlugName
+	space.DisplayName = req.DisplayName
+	space.Description = req.Description
+	space.Visibility = req.Visibility
+	return ss.spaceRepo.UpdateSpace(ctx, space)
+}
+
+// RemoveSpace remove space, only the space without any questions can be removed
+func (ss *SpaceService) RemoveSpace(ctx context.Context, req *schema.RemoveSpaceReq) (err error) {
+	_, exist, err := ss.spaceRepo.GetSpace(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.SpaceNotFound)
+	}
+	count, err := ss.spaceRepo.GetSpaceQuestionCount(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.BadRequest(reason.SpaceNotEmpty)
+	}
+	return ss.spaceRepo.RemoveSpace(ctx, req.ID)
+}
+
+// GetSpaceMembers get space members
+func (ss *SpaceService) GetSpaceMembers(ctx context.Context, req *schema.GetSpaceMembersReq) (
+	resp []*schema.GetSpaceMemberResp, err error) {
+	resp = make([]*schema.GetSpaceMemberResp, 0)
+	members, err := ss.spaceRepo.GetSpaceMembers(ctx, req.SpaceID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0)
+	for _, member := range members {
+		if member.MemberType == entity.SpaceMemberTypeUser {
+			userIDs = append(userIDs, member.MemberID)
+		}
+	}
+	userMapping, err := ss.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	roleMapping, err := ss.roleService.GetRoleMapping(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		item := &schema.GetSpaceMemberResp{
+			MemberType:  member.MemberType,
+			MemberID:    member.MemberID,
+			IsModerator: member.IsModerator,
+		}
+		if member.MemberType == entity.SpaceMemberTypeUser {
+			if user := userMapping[member.MemberID]; user != nil {
+				item.DisplayName = user.Username
+			}
+		} else {
+			roleID, _ := strconv.Atoi(member.MemberID)
+			if r := roleMapping[roleID]; r != nil {
+				item.DisplayName = r.Name
+			}
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+// SaveSpaceMember add space member or update the member if exist
+func (ss *SpaceService) SaveSpaceMember(ctx context.Context, req *schema.SpaceMemberReq) (err error) {
+	_, exist, err := ss.spaceRepo.GetSpace(ctx, req.SpaceID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.SpaceNotFound)
+	}
+	if req.MemberType == entity.SpaceMemberTypeUser {
+		_, exist, err = ss.userCommon.GetUserBasicInfoByID(ctx, req.MemberID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.UserNotFound)
+		}
+	} else {
+		roleID, _ := strconv.Atoi(req.MemberID)
+		roleMapping, err := ss.roleService.GetRoleMapping(ctx)
+		if err != nil {
+			return err
+		}
+		if roleMapping[roleID] == nil {
+			return errors.BadRequest(reason.RequestFormatError)
+		}
+	}
+
+	member, exist, err := ss.spaceRepo.GetSpaceMember(ctx, req.SpaceID, req.MemberType, req.MemberID)
+	if err != nil {
+		return err
+	}
+	if exist {
+		member.IsModerator = req.IsModerator
+		return ss.spaceRepo.UpdateSpaceMember(ctx, member)
+	}
+	return ss.spaceRepo.AddSpaceMember(ctx, &entity.SpaceMember{
+		SpaceID:     req.SpaceID,
+		MemberType:  req.MemberType,
+		MemberID:    req.MemberID,
+		IsModerator: req.IsModerator,
+	})
+}
+
+// RemoveSpaceMember remove space member
+func (ss *SpaceService) RemoveSpaceMember(ctx context.Context, req *schema.RemoveSpaceMemberReq) (err error) {
+	_, exist, err := ss.spaceRepo.GetSpaceMember(ctx, req.SpaceID, req.MemberType, req.MemberID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.SpaceMemberNotFound)
+	}
+	return ss.spaceRepo.RemoveSpaceMember(ctx, req.SpaceID, req.MemberType, req.MemberID)
+}
+
+func (ss *SpaceService) canAccessQuestion(ctx context.Context, access *handler.SpaceAccess, questionID string) (
+	ok bool, err error) {
+	if access.AllSpaces {
+		return true, nil
+	}
+	spaceID, exist, err := ss.spaceRepo.GetQuestionSpaceID(ctx, questionID)
+	if err != nil {
+		return false, err
+	}
+	// let the caller decide how to handle the question not found
+	if !exist {
+		return true, nil
+	}
+	return ss.canAccessSpaceID(ctx, access, spaceID)
+}
+
+func (ss *SpaceService) canAccessSpaceID(ctx context.Context, access *handler.SpaceAccess, spaceID string) (
+	ok bool, err error) {
+	if access.AllSpaces || len(spaceID) == 0 || spaceID == entity.NoSpaceID {
+		return true, nil
+	}
+	space, exist, err := ss.spaceRepo.GetSpace(ctx, spaceID)
+	if err != nil {
+		return false, err
+	}
+	if !exist {
+		return true, nil
+	}
+	return canAccessSpace(access, space), nil
+}
+
+// canAccessSpace whether the user with the access can see and post questions in the space
+func canAccessSpace(access *handler.SpaceAccess, space *entity.Space) bool {
+	if access.AllSpaces || space.Visibility == entity.SpaceVisibilityPublic {
+		return true
+	}
+	return containsID(access.MemberSpaceIDs, space.ID)
+}
+
+func containsID(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package space
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanAccessSpace(t *testing.T) {
+	public := &entity.Space{ID: "1", Visibility: entity.SpaceVisibilityPublic}
+	members := &entity.Space{ID: "2", Visibility: entity.SpaceVisibilityMembers}
+	hidden := &entity.Space{ID: "3", Visibility: entity.SpaceVisibilityHidden}
+
+	anonymous := &handler.SpaceAccess{}
+	assert.True(t, canAccessSpace(anonymous, public))
+	assert.False(t, canAccessSpace(anonymous, members))
+	assert.False(t, canAccessSpace(anonymous, hidden))
+
+	member := &handler.SpaceAccess{MemberSpaceIDs: []string{"3"}}
+	assert.False(t, canAccessSpace(member, members))
+	assert.True(t, canAccessSpace(member, hidden))
+
+	admin := &handler.SpaceAccess{AllSpaces: true}
+	assert.True(t, canAccessSpace(admin, members))
+	assert.True(t, canAccessSpace(admin, hidden))
+}
@@ -43,6 +43,16 @@ import (
 	"github.com/segmentfault/pacman/log"
 )
 
+// TagCategoryRepo tag category repository
+type TagCategoryRepo interface {
+	AddTagCategory(ctx context.Context, category *entity.TagCategory) (err error)
+	UpdateTagCategory(ctx context.Context, category *entity.TagCategory) (err error)
+	RemoveTagCategory(ctx context.Context, categoryID string) (err error)
+	GetTagCategory(ctx context.Context, categoryID string) (category *entity.TagCategory, exist bool, err error)
+	GetTagCategoryBySlugName(ctx context.Context, slugName string) (category *entity.TagCategory, exist bool, err error)
+	GetTagCategoryList(ctx context.Context) (categories []*entity.TagCategory, err error)
+}
+
 // TagService user service
 type TagService struct {
 	tagRepo              tagcommonser.TagRepo
@@ -51,6 +61,7 @@ type TagService struct {
 	followCommon         activity_common.FollowRepo
 	siteInfoService      siteinfo_common.SiteInfoCommonService
 	activityQueueService activity_queue.ActivityQueueService
+	tagCategoryRepo      TagCategoryRepo
 }
 
 // NewTagService new tag service
@@ -61,6 +72,7 @@ func NewTagService(
 	followCommon activity_common.FollowRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	activityQueueService activity_queue.ActivityQueueService,
+	tagCategoryRepo TagCategoryRepo,
 ) *TagService {
 	return &TagService{
 		tagRepo:              tagRepo,
@@ -69,6 +81,7 @@ func NewTagService(
 		followCommon:         followCommon,
 		siteInfoService:      siteInfoService,
 		activityQueueService: activityQueueService,
+		tagCategoryRepo:      tagCategoryRepo,
 	}
 }
 
@@ -184,6 +197,19 @@ func (ts *TagService) GetTagInfo(ctx context.Context, req *schema.GetTagInfoReq)
 	resp.QuestionCount = tagInfo.QuestionCount
 	resp.Recommend = tagInfo.Recommend
 	resp.Reserved = tagInfo.Reserved
+	if tagInfo.ParentTagID > 0 {
+		resp.ParentTagID = converter.IntToString(tagInfo.ParentTagID)
+		parentTag, exist, err := ts.tagCommonService.GetTagByID(ctx, resp.ParentTagID)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			resp.ParentTagSlugName = parentTag.SlugName
+		}
+	}
+	if tagInfo.CategoryID > 0 {
+		resp.CategoryID = converter.IntToString(tagInfo.CategoryID)
+	}
 	resp.IsFollower = ts.checkTagIsFollow(ctx, req.UserID, tagInfo.ID)
 	resp.Status = entity.TagStatusDisplayMapping[tagInfo.Status]
 	resp.MemberActions = permission.GetTagPermission(ctx, tagInfo.Status, req.CanEdit, req.CanDelete, req.CanRecover)
@@ -404,6 +430,7 @@ func (ts *TagService) GetTagWithPage(ctx context.Context, req *schema.GetTagWith
 	tag := &entity.Tag{}
 	_ = copier.Copy(tag, req)
 	tag.UserID = ""
+	tag.CategoryID = converter.StringToInt64(req.CategoryID)
 
 	page := req.Page
 	pageSize := req.PageSize
@@ -430,6 +457,12 @@ func (ts *TagService) GetTagWithPage(ctx context.Context, req *schema.GetTagWith
 			Recommend:     tag.Recommend,
 			Reserved:      tag.Reserved,
 		}
+		if tag.ParentTagID > 0 {
+			item.ParentTagID = converter.IntToString(tag.ParentTagID)
+		}
+		if tag.CategoryID > 0 {
+			item.CategoryID = converter.IntToString(tag.CategoryID)
+		}
 		item.GetExcerpt()
 		resp = append(resp, item)
 
@@ -448,3 +481,175 @@ func (ts *TagService) checkTagIsFollow(ctx context.Context, userID, tagID string
 	}
 	return followed
 }
+
+// UpdateTagHierarchy update the parent tag and the category of the tag
+func (ts *TagService) UpdateTagHierarchy(ctx context.Context, req *schema.UpdateTagHierarchyReq) (err error) {
+	_, existUnreviewed, err := ts.revisionService.ExistUnreviewedByObjectID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	if existUnreviewed {
+		return errors.BadRequest(reason.TagCannotUpdate)
+	}
+
+	tagInfo, exist, err := ts.tagCommonService.GetTagByID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	// the synonym always follows the hierarchy of its main tag
+	if tagInfo.MainTagID > 0 {
+		return errors.BadRequest(reason.TagParentInvalid)
+	}
+
+	var parentTagID int64
+	if len(req.ParentTagID) > 0 {
+		parentTagID, err = ts.checkParentTag(ctx, tagInfo.ID, req.ParentTagID)
+		if err != nil {
+			return err
+		}
+	}
+	var categoryID int64
+	if len(req.CategoryID) > 0 {
+		_, exist, err = ts.tagCategoryRepo.GetTagCategory(ctx, req.CategoryID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.TagCategoryNotFound)
+		}
+		categoryID = converter.StringToInt64(req.CategoryID)
+	}
+	if tagInfo.ParentTagID == parentTagID && tagInfo.CategoryID == categoryID {
+		return nil
+	}
+
+	if err = ts.tagRepo.UpdateTagHierarchy(ctx, tagInfo.ID, parentTagID, categoryID); err != nil {
+		return err
+	}
+	tagInfo.ParentTagID = parentTagID
+	tagInfo.CategoryID = categoryID
+
+	revisionDTO := &schema.AddRevisionDTO{
+		UserID:   req.UserID,
+		ObjectID: tagInfo.ID,
+		Title:    tagInfo.SlugName,
+		Status:   entity.RevisionReviewPassStatus,
+	}
+	tagInfoJson, _ := json.Marshal(tagInfo)
+	revisionDTO.Content = string(tagInfoJson)
+	revisionID, err := ts.revisionService.AddRevision(ctx, revisionDTO, true)
+	if err != nil {
+		return err
+	}
+	ts.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           req.UserID,
+		ObjectID:         tagInfo.ID,
+		OriginalObjectID: tagInfo.ID,
+		ActivityTypeKey:  constant.ActTagEdited,
+		RevisionID:       revisionID,
+	})
+	return nil
+}
+
+// checkParentTag check the parent tag is not the tag itself, its descendants or a synonym
+func (ts *TagService) checkParentTag(ctx context.Context, tagID, parentTagID string) (id int64, err error) {
+	if parentTagID == tagID {
+		return 0, errors.BadRequest(reason.TagParentInvalid)
+	}
+	parentTag, exist, err := ts.tagCommonService.GetTagByID(ctx, parentTagID)
+	if err != nil {
+		return 0, err
+	}
+	if !exist {
+		return 0, errors.BadRequest(reason.TagNotFound)
+	}
+	if parentTag.MainTagID > 0 {
+		return 0, errors.BadRequest(reason.TagParentInvalid)
+	}
+	ancestorIDs, err := ts.tagCommonService.GetTagAncestorIDs(ctx, parentTag.ID)
+	if err != nil {
+		return 0, err
+	}
+	for _, ancestorID := range ancestorIDs {
+		if ancestorID == tagID {
+			return 0, errors.BadRequest(reason.TagParentInvalid)
+		}
+	}
+	return converter.StringToInt64(parentTag.ID), nil
+}
+
+// GetTagCategoryList get all tag categories
+func (ts *TagService) GetTagCategoryList(ctx context.Context) (resp []*schema.GetTagCategoryResp, err error) {
+	categories, err := ts.tagCategoryRepo.GetTagCategoryList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetTagCategoryResp, 0, len(categories))
+	for _, category := range categories {
+		resp = append(resp, &schema.GetTagCategoryResp{
+			ID:          category.ID,
+			SlugName:    category.SlugName,
+			DisplayName: category.DisplayName,
+			SortOrder:   category.SortOrder,
+		})
+	}
+	return resp, nil
+}
+
+// AddTagCategory add tag category
+func (ts *TagService) AddTagCategory(ctx context.Context, req *schema.AddTagCategoryReq) (err error) {
+	slugName := strings.ToLower(strings.ReplaceAll(req.SlugName, " ", "-"))
+	_, exist, err := ts.tagCategoryRepo.GetTagCategoryBySlugName(ctx, slugName)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return errors.BadRequest(reason.TagCategorySlugNameDuplicate)
+	}
+	return ts.tagCategoryRepo.AddTagCategory(ctx, &entity.TagCategory{
+		SlugName:    slugName,
+		DisplayName: req.DisplayName,
+		SortOrder:   req.SortOrder,
+	})
+}
+
+// UpdateTagCategory update tag category
+func (ts *TagService) UpdateTagCategory(ctx context.Context, req *schema.UpdateTagCategoryReq) (err error) {
+	category, exist, err := ts.tagCategoryRepo.GetTagCategory(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagCategoryNotFound)
+	}
+	slugName := strings.ToLower(strings.ReplaceAll(req.SlugName, " ", "-"))
+	sameSlugCategory, exist, err := ts.tagCategoryRepo.GetTagCategoryBySlugName(ctx, slugName)
+	if err != nil {
+		return err
+	}
+	if exist && sameSlugCategory.ID != category.ID {
+		return errors.BadRequest(reason.TagCategorySlugNameDuplicate)
+	}
+	category.SlugName = slugName
+	category.DisplayName = req.DisplayName
+	category.SortOrder = req.SortOrder
+	return ts.tagCategoryRepo.UpdateTagCategory(ctx, category)
+}
+
+// RemoveTagCategory remove tag category, the tags in it become uncategorized
+func (ts *TagService) RemoveTagCategory(ctx context.Context, req *schema.RemoveTagCategoryReq) (err error) {
+	_, exist, err := ts.tagCategoryRepo.GetTagCategory(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagCategoryNotFound)
+	}
+	if err = ts.tagRepo.ClearTagCategory(ctx, converter.StringToInt64(req.ID)); err != nil {
+		return err
+	}
+	return ts.tagCategoryRepo.RemoveTagCategory(ctx, req.ID)
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/tracing"
 	"github.com/apache/incubator-answer/internal/base/validator"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
@@ -62,6 +63,9 @@ type TagRepo interface {
 	GetTagSynonymCount(ctx context.Context, tagID string) (count int64, err error)
 	GetIDsByMainTagId(ctx context.Context, mainTagID string) (tagIDs []string, err error)
 	GetTagList(ctx context.Context, tag *entity.Tag) (tagList []*entity.Tag, err error)
+	UpdateTagHierarchy(ctx context.Context, tagID string, parentTagID, categoryID int64) (err error)
+	GetChildTagIDs(ctx context.Context, parentTagIDs []string) (tagIDs []string, err error)
+	ClearTagCategory(ctx context.Context, categoryID int64) (err error)
 }
 
 type TagRelRepo interface {
@@ -316,6 +320,8 @@ func (ts *TagCommonService) HasNewTag(ctx context.Context, tags []*schema.TagIte
 
 // GetObjectTag get object tag
 func (ts *TagCommonService) GetObjectTag(ctx context.Context, objectId string) (objTags []*schema.TagResp, err error) {
+	ctx, span := tracing.Start(ctx, "TagCommonService.GetObjectTag")
+	defer span.End()
 	tagsInfoList, err := ts.GetObjectEntityTag(ctx, objectId)
 	if err != nil {
 		return nil, err
@@ -382,6 +388,74 @@ func (ts *TagCommonService) GetTagIDsByMainTagID(ctx context.Context, tagID stri
 	return
 }
 
+// GetTagIDsWithDescendants get the ids of the tag, its descendant tags and the synonyms of all of them
+func (ts *TagCommonService) GetTagIDsWithDescendants(ctx context.Context, tagID string) (tagIDs []string, err error) {
+	visited := map[string]bool{tagID: true}
+	tagIDs = []string{tagID}
+	levelTagIDs := []string{tagID}
+	for len(levelTagIDs) > 0 {
+		childTagIDs, err := ts.tagRepo.GetChildTagIDs(ctx, levelTagIDs)
+		if err != nil {
+			return nil, err
+		}
+		levelTagIDs = make([]string, 0)
+		for _, childTagID := range childTagIDs {
+			if visited[childTagID] {
+				continue
+			}
+			visited[childTagID] = true
+			levelTagIDs = append(levelTagIDs, childTagID)
+			tagIDs = append(tagIDs, childTagID)
+		}
+	}
+
+	allTagIDs := make([]string, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		synonymIDs, err := ts.tagRepo.GetIDsByMainTagId(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		allTagIDs = append(allTagIDs, id)
+		allTagIDs = append(allTagIDs, synonymIDs...)
+	}
+	return converter.UniqueArray(allTagIDs), nil
+}
+
+// GetTagAncestorIDs get the ids of the ancestor tags from the parent to the root
+func (ts *TagCommonService) GetTagAncestorIDs(ctx context.Context, tagID string) (ancestorIDs []string, err error) {
+	ancestorIDs = make([]string, 0)
+	visited := map[string]bool{tagID: true}
+	for {
+		tag, exist, err := ts.tagCommonRepo.GetTagByID(ctx, tagID, false)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return ancestorIDs, nil
+		}
+		// the synonym follows the hierarchy of its main tag
+		if tag.MainTagID != 0 {
+			mainTagID := converter.IntToString(tag.MainTagID)
+			if visited[mainTagID] {
+				return ancestorIDs, nil
+			}
+			visited[mainTagID] = true
+			ancestorIDs = append(ancestorIDs, mainTagID)
+			tagID = mainTagID
+			continue
+		}
+		if tag.ParentTagID == 0 {
+			return ancestorIDs, nil
+		}
+		tagID = converter.IntToString(tag.ParentTagID)
+		if visited[tagID] {
+			return ancestorIDs, nil
+		}
+		visited[tagID] = true
+		ancestorIDs = append(ancestorIDs, tagID)
+	}
+}
+
 // GetTagBySlugName get object tag
 func (ts *TagCommonService) GetTagBySlugName(ctx context.Context, slugName string) (tag *entity.Tag, exist bool, err error) {
 	tag, exist, err = ts.tagCommonRepo.GetTagBySlugName(ctx, slugName)
@@ -475,6 +549,8 @@ func (ts *TagCommonService) tagFormatRecommendAndReserved(ctx context.Context, t
 
 // BatchGetObjectTag batch get object tag
 func (ts *TagCommonService) BatchGetObjectTag(ctx context.Context, objectIds []string) (map[string][]*schema.TagResp, error) {
+	ctx, span := tracing.Start(ctx, "TagCommonService.BatchGetObjectTag")
+	defer span.End()
 	objectIDTagMap := make(map[string][]*schema.TagResp)
 	if len(objectIds) == 0 {
 		return objectIDTagMap, nil
@@ -905,11 +981,15 @@ func (ts *TagCommonService) UpdateTag(ctx context.Context, req *schema.UpdateTag
 		return err
 	}
 	if canUpdate {
+		activityTypeKey := constant.ActTagEdited
+		if req.IsRollback {
+			activityTypeKey = constant.ActTagRollback
+		}
 		ts.activityQueueService.Send(ctx, &schema.ActivityMsg{
 			UserID:           req.UserID,
 			ObjectID:         tagInfo.ID,
 			OriginalObjectID: tagInfo.ID,
-			ActivityTypeKey:  constant.ActTagEdited,
+			ActivityTypeKey:  activityTypeKey,
 			RevisionID:       revisionID,
 		})
 	}
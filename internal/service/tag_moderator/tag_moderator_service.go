@@ -0,0 +1,198 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_moderator
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// TagModeratorRepo tag moderator repository
+type TagModeratorRepo interface {
+	AddTagModerator(ctx context.Context, moderator *entity.TagModerator) (err error)
+	RemoveTagModerator(ctx context.Context, tagID, userID string) (err error)
+	GetTagModerator(ctx context.Context, tagID, userID string) (moderator *entity.TagModerator, exist bool, err error)
+	GetTagModerators(ctx context.Context, tagID string) (moderators []*entity.TagModerator, err error)
+	GetModeratedTagIDs(ctx context.Context, userID string) (tagIDs []string, err error)
+}
+
+// TagModeratorService tag moderator service
+type TagModeratorService struct {
+	tagModeratorRepo TagModeratorRepo
+	tagCommon        *tagcommon.TagCommonService
+	userCommon       *usercommon.UserCommon
+}
+
+// NewTagModeratorService new tag moderator service
+func NewTagModeratorService(
+	tagModeratorRepo TagModeratorRepo,
+	tagCommon *tagcommon.TagCommonService,
+	userCommon *usercommon.UserCommon,
+) *TagModeratorService {
+	return &TagModeratorService{
+		tagModeratorRepo: tagModeratorRepo,
+		tagCommon:        tagCommon,
+		userCommon:       userCommon,
+	}
+}
+
+// GetTagModerators get the moderators of the tag, the moderators of the main tag are returned for the synonym
+func (ts *TagModeratorService) GetTagModerators(ctx context.Context, req *schema.GetTagModeratorsReq) (
+	resp []*schema.GetTagModeratorResp, err error) {
+	tagID, err := ts.getMainTagID(ctx, req.TagID)
+	if err != nil {
+		return nil, err
+	}
+	moderators, err := ts.tagModeratorRepo.GetTagModerators(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(moderators))
+	for _, moderator := range moderators {
+		userIDs = append(userIDs, moderator.UserID)
+	}
+	userInfoMapping, err := ts.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = make([]*schema.GetTagModeratorResp, 0, len(moderators))
+	for _, moderator := range moderators {
+		userInfo, ok := userInfoMapping[moderator.UserID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, &schema.GetTagModeratorResp{
+			UserInfo:  userInfo,
+			CreatedAt: moderator.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// AddTagModerator add tag moderator, the moderator of synonym is added to its main tag
+func (ts *TagModeratorService) AddTagModerator(ctx context.Context, req *schema.AddTagModeratorReq) (err error) {
+	tagID, err := ts.getMainTagID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	userInfo, exist, err := ts.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	_, exist, err = ts.tagModeratorRepo.GetTagModerator(ctx, tagID, userInfo.ID)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return errors.BadRequest(reason.TagModeratorAlreadyExist)
+	}
+	return ts.tagModeratorRepo.AddTagModerator(ctx, &entity.TagModerator{
+		TagID:  tagID,
+		UserID: userInfo.ID,
+	})
+}
+
+// RemoveTagModerator remove tag moderator
+func (ts *TagModeratorService) RemoveTagModerator(ctx context.Context, req *schema.RemoveTagModeratorReq) (err error) {
+	tagID, err := ts.getMainTagID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	_, exist, err := ts.tagModeratorRepo.GetTagModerator(ctx, tagID, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagModeratorNotFound)
+	}
+	return ts.tagModeratorRepo.RemoveTagModerator(ctx, tagID, req.UserID)
+}
+
+// GetUserModeratedTagIDs get the ids of tags moderated by the user, including the synonyms of the tags
+func (ts *TagModeratorService) GetUserModeratedTagIDs(ctx context.Context, userID string) (tagIDs []string, err error) {
+	tagIDs = make([]string, 0)
+	if len(userID) == 0 {
+		return tagIDs, nil
+	}
+	mainTagIDs, err := ts.tagModeratorRepo.GetModeratedTagIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, mainTagID := range mainTagIDs {
+		synonymIDs, err := ts.tagCommon.GetTagIDsByMainTagID(ctx, mainTagID)
+		if err != nil {
+			return nil, err
+		}
+		tagIDs = append(tagIDs, mainTagID)
+		tagIDs = append(tagIDs, synonymIDs...)
+	}
+	return tagIDs, nil
+}
+
+// IsQuestionTagModerator whether the user moderates any tag of the question
+func (ts *TagModeratorService) IsQuestionTagModerator(ctx context.Context, userID, questionID string) (
+	is bool, err error) {
+	moderatedTagIDs, err := ts.GetUserModeratedTagIDs(ctx, userID)
+	if err != nil || len(moderatedTagIDs) == 0 {
+		return false, err
+	}
+	tags, err := ts.tagCommon.GetObjectEntityTag(ctx, questionID)
+	if err != nil {
+		return false, err
+	}
+	return containsAnyTag(moderatedTagIDs, tags), nil
+}
+
+// getMainTagID get the id of main tag if the tag is a synonym
+func (ts *TagModeratorService) getMainTagID(ctx context.Context, tagID string) (mainTagID string, err error) {
+	tag, exist, err := ts.tagCommon.GetTagByID(ctx, tagID)
+	if err != nil {
+		return "", err
+	}
+	if !exist {
+		return "", errors.BadRequest(reason.TagNotFound)
+	}
+	if tag.MainTagID != 0 {
+		return converter.IntToString(tag.MainTagID), nil
+	}
+	return tag.ID, nil
+}
+
+func containsAnyTag(tagIDs []string, tags []*entity.Tag) bool {
+	for _, tag := range tags {
+		for _, tagID := range tagIDs {
+			if tag.ID == tagID {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_moderator
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainsAnyTag(t *testing.T) {
+	tags := []*entity.Tag{{ID: "10"}, {ID: "11"}}
+	assert.True(t, containsAnyTag([]string{"1", "11"}, tags))
+	assert.False(t, containsAnyTag([]string{"1", "2"}, tags))
+	assert.False(t, containsAnyTag(nil, tags))
+	assert.False(t, containsAnyTag([]string{"10"}, nil))
+}
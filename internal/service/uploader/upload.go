@@ -21,6 +21,7 @@ package uploader
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -350,7 +351,7 @@ func (us *uploaderService) newSession() *session.Session {
 
 func (us *uploaderService) tryToUploadByPlugin(ctx *gin.Context, source plugin.UploadSource) (
 	url string, err error) {
-	_ = plugin.CallStorage(func(fn plugin.Storage) error {
+	_ = plugin.CallWithContext(ctx, plugin.CallStorage, func(_ context.Context, fn plugin.Storage) error {
 		resp := fn.UploadFile(ctx, source)
 		if resp.OriginalError != nil {
 			log.Errorf("upload file by plugin failed, err: %v", resp.OriginalError)
@@ -28,6 +28,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/validator"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
 	"github.com/apache/incubator-answer/internal/service/comment_common"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/export"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	"github.com/google/uuid"
@@ -45,6 +46,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_suspension"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/jinzhu/copier"
 	"github.com/segmentfault/pacman/errors"
@@ -76,6 +78,8 @@ type UserAdminService struct {
 	questionCommonRepo    questioncommon.QuestionRepo
 	answerCommonRepo      answercommon.AnswerRepo
 	commentCommonRepo     comment_common.CommentCommonRepo
+	eventQueueService     event_queue.EventQueueService
+	userSuspensionService *user_suspension.UserSuspensionService
 }
 
 // NewUserAdminService new user admin service
@@ -90,6 +94,8 @@ func NewUserAdminService(
 	questionCommonRepo questioncommon.QuestionRepo,
 	answerCommonRepo answercommon.AnswerRepo,
 	commentCommonRepo comment_common.CommentCommonRepo,
+	eventQueueService event_queue.EventQueueService,
+	userSuspensionService *user_suspension.UserSuspensionService,
 ) *UserAdminService {
 	return &UserAdminService{
 		userRepo:              userRepo,
@@ -102,6 +108,8 @@ func NewUserAdminService(
 		questionCommonRepo:    questionCommonRepo,
 		answerCommonRepo:      answerCommonRepo,
 		commentCommonRepo:     commentCommonRepo,
+		eventQueueService:     eventQueueService,
+		userSuspensionService: userSuspensionService,
 	}
 }
 
@@ -138,11 +146,16 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 		userInfo.MailStatus = entity.EmailStatusAvailable
 	}
 
+	if err = us.updateUserSuspension(ctx, req); err != nil {
+		return err
+	}
 	err = us.userRepo.UpdateUserStatus(ctx, userInfo.ID, userInfo.Status, userInfo.MailStatus, userInfo.EMail)
 	if err != nil {
 		return err
 	}
 
+	us.sendUserStatusEvent(ctx, req, userInfo.ID)
+
 	// remove all content that user created, such as question, answer, comment, etc.
 	if req.RemoveAllContent {
 		us.removeAllUserCreatedContent(ctx, userInfo.ID)
@@ -155,6 +168,95 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 	return nil
 }
 
+// updateUserSuspension record the suspension when suspending the user, and lift it when the user is restored or deleted
+func (us *UserAdminService) updateUserSuspension(ctx context.Context, req *schema.UpdateUserStatusReq) (err error) {
+	switch {
+	case req.IsSuspended():
+		var endAt time.Time
+		if req.SuspendedUntil > 0 {
+			endAt = time.Unix(req.SuspendedUntil, 0)
+		}
+		return us.userSuspensionService.Suspend(ctx, req.UserID, req.LoginUserID,
+			req.SuspendReasonType, req.SuspendMessage, endAt)
+	case req.IsNormal(), req.IsDeleted():
+		return us.userSuspensionService.Lift(ctx, req.UserID, req.LoginUserID, entity.UserSuspensionStatusLifted)
+	}
+	return nil
+}
+
+// ReinstateSuspendedUser restore the suspended user and end the active suspension with the status
+func (us *UserAdminService) ReinstateSuspendedUser(ctx context.Context, userID, operatorUserID string,
+	suspensionStatus int) (err error) {
+	userInfo, exist, err := us.userRepo.GetUserInfo(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	if err = us.userSuspensionService.Lift(ctx, userID, operatorUserID, suspensionStatus); err != nil {
+		return err
+	}
+	// the user may be restored or deleted by the admin already
+	if userInfo.Status != entity.UserStatusSuspended {
+		return nil
+	}
+	err = us.userRepo.UpdateUserStatus(ctx, userInfo.ID, entity.UserStatusAvailable, userInfo.MailStatus, userInfo.EMail)
+	if err != nil {
+		return err
+	}
+	us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserActivate, operatorUserID).OID(userInfo.ID))
+	return nil
+}
+
+// ReinstateExpiredSuspensionsCron restore the users whose suspensions have ended
+func (us *UserAdminService) ReinstateExpiredSuspensionsCron(ctx context.Context) {
+	const batchSize = 100
+	for {
+		suspensions, err := us.userSuspensionService.GetExpiredSuspensions(ctx, batchSize)
+		if err != nil {
+			log.Errorf("get expired suspensions failed: %v", err)
+			return
+		}
+		for _, suspension := range suspensions {
+			err = us.ReinstateSuspendedUser(ctx, suspension.UserID, "0", entity.UserSuspensionStatusExpired)
+			if err != nil {
+				log.Errorf("reinstate user %s failed: %v", suspension.UserID, err)
+				// make sure the suspension is not picked again
+				if err = us.userSuspensionService.Expire(ctx, suspension); err != nil {
+					log.Errorf("expire suspension %s failed: %v", suspension.ID, err)
+					return
+				}
+			}
+		}
+		if len(suspensions) < batchSize {
+			return
+		}
+	}
+}
+
+// GetUserSuspensionHistory get all suspensions of the user
+func (us *UserAdminService) GetUserSuspensionHistory(ctx context.Context, req *schema.GetUserSuspensionHistoryReq) (
+	resp []*schema.UserSuspensionHistoryItem, err error) {
+	return us.userSuspensionService.GetSuspensionHistory(ctx, req.UserID)
+}
+
+// sendUserStatusEvent send the event of user status changed by admin
+func (us *UserAdminService) sendUserStatusEvent(ctx context.Context, req *schema.UpdateUserStatusReq, userID string) {
+	var eventType constant.EventType
+	switch {
+	case req.IsDeleted():
+		eventType = constant.EventUserDelete
+	case req.IsSuspended():
+		eventType = constant.EventUserSuspend
+	case req.IsNormal():
+		eventType = constant.EventUserActivate
+	default:
+		return
+	}
+	us.eventQueueService.Send(ctx, schema.NewEvent(eventType, req.LoginUserID).OID(userID))
+}
+
 // removeAllUserCreatedContent remove all user created content
 func (us *UserAdminService) removeAllUserCreatedContent(ctx context.Context, userID string) {
 	if err := us.questionCommonRepo.RemoveAllUserQuestion(ctx, userID); err != nil {
@@ -216,6 +318,7 @@ func (us *UserAdminService) AddUser(ctx context.Context, req *schema.AddUserReq)
 	if err != nil {
 		return err
 	}
+	us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserRegister, req.LoginUserID).OID(userInfo.ID))
 	return
 }
 
@@ -28,6 +28,7 @@ import (
 
 	"github.com/Chain-Zhang/pinyin"
 	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/tracing"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/auth"
@@ -86,6 +87,8 @@ func NewUserCommon(
 
 func (us *UserCommon) GetUserBasicInfoByID(ctx context.Context, ID string) (
 	userBasicInfo *schema.UserBasicInfo, exist bool, err error) {
+	ctx, span := tracing.Start(ctx, "UserCommon.GetUserBasicInfoByID")
+	defer span.End()
 	userInfo, exist, err := us.userRepo.GetByUserID(ctx, ID)
 	if err != nil {
 		return nil, exist, err
@@ -141,6 +144,8 @@ func (us *UserCommon) UpdateQuestionCount(ctx context.Context, userID string, nu
 }
 
 func (us *UserCommon) BatchUserBasicInfoByID(ctx context.Context, userIDs []string) (map[string]*schema.UserBasicInfo, error) {
+	ctx, span := tracing.Start(ctx, "UserCommon.BatchUserBasicInfoByID")
+	defer span.End()
 	userMap := make(map[string]*schema.UserBasicInfo)
 	if len(userIDs) == 0 {
 		return userMap, nil
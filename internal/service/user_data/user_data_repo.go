@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_data
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+)
+
+// PersonalData all the personal data of user
+type PersonalData struct {
+	Questions     []*entity.Question
+	Answers       []*entity.Answer
+	Comments      []*entity.Comment
+	Votes         []*entity.Activity
+	Collections   []*entity.Collection
+	BadgeAwards   []*entity.BadgeAward
+	Notifications []*entity.Notification
+	Drafts        []*entity.Draft
+}
+
+// UserDataRepo user data export and deletion repository
+type UserDataRepo interface {
+	AddExport(ctx context.Context, export *entity.UserDataExport) (err error)
+	UpdateExport(ctx context.Context, export *entity.UserDataExport) (err error)
+	RemoveExport(ctx context.Context, id int) (err error)
+	GetLatestExport(ctx context.Context, userID string) (export *entity.UserDataExport, exist bool, err error)
+	GetExportByToken(ctx context.Context, token string) (export *entity.UserDataExport, exist bool, err error)
+	GetExpiredExports(ctx context.Context, before time.Time) (exports []*entity.UserDataExport, err error)
+	GetPendingExports(ctx context.Context) (exports []*entity.UserDataExport, err error)
+
+	AddDeletionRequest(ctx context.Context, req *entity.UserDeletionRequest) (err error)
+	UpdateDeletionRequestStatus(ctx context.Context, id, status int) (err error)
+	GetPendingDeletionRequest(ctx context.Context, userID string) (req *entity.UserDeletionRequest, exist bool, err error)
+	GetDueDeletionRequests(ctx context.Context, before time.Time) (reqs []*entity.UserDeletionRequest, err error)
+
+	GetPersonalData(ctx context.Context, userID string, voteActivityTypes []int) (data *PersonalData, err error)
+	ReassignUserContent(ctx context.Context, fromUserID, toUserID string) (err error)
+	GetUserByUsernameAndEmail(ctx context.Context, username, email string) (user *entity.User, exist bool, err error)
+	ErasePersonalData(ctx context.Context, userID string) (err error)
+}
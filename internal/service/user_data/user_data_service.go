@@ -0,0 +1,460 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_data
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/internal/service/auth"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/service_config"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/user_admin"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/dir"
+	"github.com/apache/incubator-answer/pkg/random"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// exportExpiration the download link of export file is available in this duration
+	exportExpiration = 24 * time.Hour
+	exportSubPath    = "exports"
+)
+
+var (
+	exportStatusMapping = map[int]string{
+		entity.UserDataExportStatusPending:   "pending",
+		entity.UserDataExportStatusCompleted: "completed",
+		entity.UserDataExportStatusFailed:    "failed",
+	}
+	exportVoteActivityTypes = []string{
+		activity_type.QuestionVoteUp,
+		activity_type.QuestionVoteDown,
+		activity_type.AnswerVoteUp,
+		activity_type.AnswerVoteDown,
+		activity_type.CommentVoteUp,
+	}
+	uploadFileRegexp = regexp.MustCompile(`/uploads/((?:post|avatar|branding)/[A-Za-z0-9_\-]+\.[A-Za-z0-9]+)`)
+)
+
+// UserDataService user personal data export and self-service account deletion
+type UserDataService struct {
+	userDataRepo          UserDataRepo
+	userRepo              usercommon.UserRepo
+	userCommon            *usercommon.UserCommon
+	userAdminRepo         user_admin.UserAdminRepo
+	userAdminService      *user_admin.UserAdminService
+	userRoleRelService    *role.UserRoleRelService
+	authService           *auth.AuthService
+	configService         *config.ConfigService
+	serviceConfig         *service_config.ServiceConfig
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+}
+
+// NewUserDataService new user data service
+func NewUserDataService(
+	userDataRepo UserDataRepo,
+	userRepo usercommon.UserRepo,
+	userCommon *usercommon.UserCommon,
+	userAdminRepo user_admin.UserAdminRepo,
+	userAdminService *user_admin.UserAdminService,
+	userRoleRelService *role.UserRoleRelService,
+	authService *auth.AuthService,
+	configService *config.ConfigService,
+	serviceConfig *service_config.ServiceConfig,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+) *UserDataService {
+	return &UserDataService{
+		userDataRepo:          userDataRepo,
+		userRepo:              userRepo,
+		userCommon:            userCommon,
+		userAdminRepo:         userAdminRepo,
+		userAdminService:      userAdminService,
+		userRoleRelService:    userRoleRelService,
+		authService:           authService,
+		configService:         configService,
+		serviceConfig:         serviceConfig,
+		siteInfoCommonService: siteInfoCommonService,
+	}
+}
+
+// RequestExport request to export personal data, the export file will be generated asynchronously.
+// If there is an export in progress or an available export file, it will be returned directly.
+func (us *UserDataService) RequestExport(ctx context.Context, req *schema.UserDataExportReq) (
+	resp *schema.UserDataExportResp, err error) {
+	latest, exist, err := us.userDataRepo.GetLatestExport(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if exist && latest.ExpiredAt.After(time.Now()) && latest.Status != entity.UserDataExportStatusFailed {
+		return us.formatExport(ctx, latest), nil
+	}
+
+	export := &entity.UserDataExport{
+		UserID:    req.UserID,
+		Status:    entity.UserDataExportStatusPending,
+		ExpiredAt: time.Now().Add(exportExpiration),
+	}
+	if err = us.userDataRepo.AddExport(ctx, export); err != nil {
+		return nil, err
+	}
+	return us.formatExport(ctx, export), nil
+}
+
+// GetExport get the latest personal data export
+func (us *UserDataService) GetExport(ctx context.Context, req *schema.GetUserDataExportReq) (
+	resp *schema.UserDataExportResp, err error) {
+	latest, exist, err := us.userDataRepo.GetLatestExport(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || latest.ExpiredAt.Before(time.Now()) {
+		return nil, nil
+	}
+	return us.formatExport(ctx, latest), nil
+}
+
+// GetExportFilePath get the export file path by download token
+func (us *UserDataService) GetExportFilePath(ctx context.Context, req *schema.DownloadUserDataExportReq) (
+	filePath string, err error) {
+	export, exist, err := us.userDataRepo.GetExportByToken(ctx, req.Token)
+	if err != nil {
+		return "", err
+	}
+	if !exist || export.Status != entity.UserDataExportStatusCompleted {
+		return "", errors.NotFound(reason.ObjectNotFound)
+	}
+	if export.ExpiredAt.Before(time.Now()) {
+		return "", errors.BadRequest(reason.UserDataExportExpired)
+	}
+	return filepath.Join(us.exportDir(), export.FileName), nil
+}
+
+// ProcessPendingExports generate the export files of the pending export jobs.
+// The jobs are stored in database, so the jobs that are not finished before restart will be picked up again.
+func (us *UserDataService) ProcessPendingExports(ctx context.Context) {
+	exports, err := us.userDataRepo.GetPendingExports(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, export := range exports {
+		us.generateExport(ctx, export)
+	}
+}
+
+// CleanExpiredExports remove the expired export files
+func (us *UserDataService) CleanExpiredExports(ctx context.Context) {
+	exports, err := us.userDataRepo.GetExpiredExports(ctx, time.Now())
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, export := range exports {
+		if len(export.FileName) > 0 {
+			if err := os.Remove(filepath.Join(us.exportDir(), export.FileName)); err != nil && !os.IsNotExist(err) {
+				log.Errorf("remove export file %s failed: %v", export.FileName, err)
+				continue
+			}
+		}
+		if err := us.userDataRepo.RemoveExport(ctx, export.ID); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+func (us *UserDataService) formatExport(ctx context.Context, export *entity.UserDataExport) *schema.UserDataExportResp {
+	resp := &schema.UserDataExportResp{
+		Status:    exportStatusMapping[export.Status],
+		CreatedAt: export.CreatedAt.Unix(),
+		ExpiredAt: export.ExpiredAt.Unix(),
+	}
+	if export.Status == entity.UserDataExportStatusCompleted {
+		siteGeneral, err := us.siteInfoCommonService.GetSiteGeneral(ctx)
+		if err != nil {
+			log.Error(err)
+		} else {
+			resp.DownloadURL = fmt.Sprintf("%s/answer/api/v1/user/data/export/download?token=%s",
+				siteGeneral.SiteUrl, export.DownloadToken)
+		}
+	}
+	return resp
+}
+
+func (us *UserDataService) generateExport(ctx context.Context, export *entity.UserDataExport) {
+	fileName := fmt.Sprintf("%s-%s.zip", export.UserID, uid.IDStr12())
+	err := us.writeExportFile(ctx, export.UserID, filepath.Join(us.exportDir(), fileName))
+	if err != nil {
+		log.Errorf("generate user %s data export failed: %v", export.UserID, err)
+		export.Status = entity.UserDataExportStatusFailed
+	} else {
+		export.Status = entity.UserDataExportStatusCompleted
+		export.FileName = fileName
+		export.DownloadToken = random.SecretToken()
+		export.ExpiredAt = time.Now().Add(exportExpiration)
+	}
+	if err = us.userDataRepo.UpdateExport(ctx, export); err != nil {
+		log.Error(err)
+	}
+}
+
+func (us *UserDataService) writeExportFile(ctx context.Context, userID, filePath string) (err error) {
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.NotFound(reason.UserNotFound)
+	}
+	voteActivityTypes, voteTypeMapping := us.getVoteActivityTypes(ctx)
+	personalData, err := us.userDataRepo.GetPersonalData(ctx, userID, voteActivityTypes)
+	if err != nil {
+		return err
+	}
+
+	if err = dir.CreateDirIfNotExist(filepath.Dir(filePath)); err != nil {
+		return err
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	zw := zip.NewWriter(file)
+
+	contents := []string{userInfo.Avatar}
+	files := map[string]interface{}{
+		"profile.json": &schema.UserDataExportProfile{
+			ID:            userInfo.ID,
+			Username:      userInfo.Username,
+			DisplayName:   userInfo.DisplayName,
+			Email:         userInfo.EMail,
+			Avatar:        userInfo.Avatar,
+			Bio:           userInfo.Bio,
+			Website:       userInfo.Website,
+			Location:      userInfo.Location,
+			Language:      userInfo.Language,
+			Rank:          userInfo.Rank,
+			CreatedAt:     userInfo.CreatedAt.Unix(),
+			LastLoginDate: userInfo.LastLoginDate.Unix(),
+		},
+	}
+
+	questions := make([]*schema.UserDataExportQuestion, 0, len(personalData.Questions))
+	for _, q := range personalData.Questions {
+		questions = append(questions, &schema.UserDataExportQuestion{
+			ID:          q.ID,
+			Title:       q.Title,
+			Content:     q.OriginalText,
+			Status:      entity.AdminQuestionSearchStatusIntToString[q.Status],
+			ViewCount:   q.ViewCount,
+			VoteCount:   q.VoteCount,
+			AnswerCount: q.AnswerCount,
+			CreatedAt:   q.CreatedAt.Unix(),
+			UpdatedAt:   q.UpdatedAt.Unix(),
+		})
+		contents = append(contents, q.OriginalText)
+		if err = writeZipFile(zw, fmt.Sprintf("questions/%s.md", q.ID),
+			[]byte(fmt.Sprintf("# %s\n\n%s\n", q.Title, q.OriginalText))); err != nil {
+			return err
+		}
+	}
+	files["questions.json"] = questions
+
+	answers := make([]*schema.UserDataExportAnswer, 0, len(personalData.Answers))
+	for _, a := range personalData.Answers {
+		answers = append(answers, &schema.UserDataExportAnswer{
+			ID:         a.ID,
+			QuestionID: a.QuestionID,
+			Content:    a.OriginalText,
+			Accepted:   a.Accepted == schema.AnswerAcceptedEnable,
+			VoteCount:  a.VoteCount,
+			CreatedAt:  a.CreatedAt.Unix(),
+			UpdatedAt:  a.UpdatedAt.Unix(),
+		})
+		contents = append(contents, a.OriginalText)
+		if err = writeZipFile(zw, fmt.Sprintf("answers/%s.md", a.ID), []byte(a.OriginalText+"\n")); err != nil {
+			return err
+		}
+	}
+	files["answers.json"] = answers
+
+	comments := make([]*schema.UserDataExportComment, 0, len(personalData.Comments))
+	for _, c := range personalData.Comments {
+		comments = append(comments, &schema.UserDataExportComment{
+			ID:        c.ID,
+			ObjectID:  c.ObjectID,
+			Content:   c.OriginalText,
+			VoteCount: c.VoteCount,
+			CreatedAt: c.CreatedAt.Unix(),
+		})
+		contents = append(contents, c.OriginalText)
+	}
+	files["comments.json"] = comments
+
+	votes := make([]*schema.UserDataExportVote, 0, len(personalData.Votes))
+	for _, v := range personalData.Votes {
+		votes = append(votes, &schema.UserDataExportVote{
+			ObjectID:  v.ObjectID,
+			Type:      voteTypeMapping[v.ActivityType],
+			CreatedAt: v.CreatedAt.Unix(),
+		})
+	}
+	files["votes.json"] = votes
+
+	collections := make([]*schema.UserDataExportCollection, 0, len(personalData.Collections))
+	for _, c := range personalData.Collections {
+		collections = append(collections, &schema.UserDataExportCollection{
+			ObjectID:  c.ObjectID,
+			CreatedAt: c.CreatedAt.Unix(),
+		})
+	}
+	files["collections.json"] = collections
+
+	badges := make([]*schema.UserDataExportBadge, 0, len(personalData.BadgeAwards))
+	for _, b := range personalData.BadgeAwards {
+		badges = append(badges, &schema.UserDataExportBadge{
+			BadgeID:   b.BadgeID,
+			AwardKey:  b.AwardKey,
+			CreatedAt: b.CreatedAt.Unix(),
+		})
+	}
+	files["badges.json"] = badges
+
+	notifications := make([]*schema.UserDataExportNotification, 0, len(personalData.Notifications))
+	for _, n := range personalData.Notifications {
+		notifications = append(notifications, &schema.UserDataExportNotification{
+			ID:        n.ID,
+			ObjectID:  n.ObjectID,
+			Content:   n.Content,
+			IsRead:    n.IsRead == schema.NotificationRead,
+			CreatedAt: n.CreatedAt.Unix(),
+		})
+	}
+	files["notifications.json"] = notifications
+
+	drafts := make([]*schema.UserDataExportDraft, 0, len(personalData.Drafts))
+	for _, d := range personalData.Drafts {
+		drafts = append(drafts, &schema.UserDataExportDraft{
+			DraftType: d.DraftType,
+			ObjectID:  d.ObjectID,
+			Title:     d.Title,
+			Content:   d.OriginalText,
+			CreatedAt: d.CreatedAt.Unix(),
+			UpdatedAt: d.UpdatedAt.Unix(),
+		})
+		contents = append(contents, d.OriginalText)
+	}
+	files["drafts.json"] = drafts
+
+	for name, value := range files {
+		content, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err = writeZipFile(zw, name, content); err != nil {
+			return err
+		}
+	}
+	if err = us.writeUploadedFiles(zw, contents); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// writeUploadedFiles copy the local uploaded files referenced by user content into the export file
+func (us *UserDataService) writeUploadedFiles(zw *zip.Writer, contents []string) (err error) {
+	written := make(map[string]bool)
+	for _, content := range contents {
+		for _, match := range uploadFileRegexp.FindAllStringSubmatch(content, -1) {
+			subPath := match[1]
+			if written[subPath] {
+				continue
+			}
+			written[subPath] = true
+			if err = copyToZip(zw, filepath.Join(us.serviceConfig.UploadPath, subPath), "files/"+subPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (us *UserDataService) getVoteActivityTypes(ctx context.Context) (activityTypes []int, mapping map[int]string) {
+	mapping = make(map[int]string)
+	for _, key := range exportVoteActivityTypes {
+		id, err := us.configService.GetIDByKey(ctx, key)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		activityTypes = append(activityTypes, id)
+		mapping[id] = key
+	}
+	return activityTypes, mapping
+}
+
+// exportDir the export files must not be placed in upload path, because the upload path is public.
+func (us *UserDataService) exportDir() string {
+	return filepath.Join(filepath.Dir(filepath.Clean(us.serviceConfig.UploadPath)), exportSubPath)
+}
+
+func writeZipFile(zw *zip.Writer, name string, content []byte) (err error) {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(content)
+	return err
+}
+
+// copyToZip copy local file into zip, the missing file will be skipped
+func copyToZip(zw *zip.Writer, filePath, name string) (err error) {
+	src, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer src.Close()
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, src)
+	return err
+}
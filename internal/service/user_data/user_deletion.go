@@ -0,0 +1,201 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_data
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// deletionGracePeriod the account will be deleted after this period, user can cancel the request before that
+	deletionGracePeriod = 14 * 24 * time.Hour
+
+	// ghostUsername does not match the username pattern, so it can not be registered or changed to by anyone
+	ghostUsername        = "~ghost"
+	ghostUserEmail       = "ghost@answer.invalid"
+	ghostUserDisplayName = "Ghost"
+)
+
+// RequestDeletion request to delete account after the grace period
+func (us *UserDataService) RequestDeletion(ctx context.Context, req *schema.UserDeletionReq) (
+	resp *schema.UserDeletionResp, err error) {
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	// users who login by external login plugin may have no password
+	if len(userInfo.Pass) > 0 && bcrypt.CompareHashAndPassword([]byte(userInfo.Pass), []byte(req.Pass)) != nil {
+		return nil, errors.BadRequest(reason.UserDeletionPasswordIncorrect)
+	}
+	roleID, err := us.userRoleRelService.GetUserRole(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if roleID == role.RoleAdminID {
+		return nil, errors.BadRequest(reason.UserDeletionAdminForbidden)
+	}
+	_, exist, err = us.userDataRepo.GetPendingDeletionRequest(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errors.BadRequest(reason.UserDeletionRequestExist)
+	}
+
+	deletion := &entity.UserDeletionRequest{
+		UserID:      req.UserID,
+		Mode:        req.Mode,
+		Status:      entity.UserDeletionStatusPending,
+		ScheduledAt: time.Now().Add(deletionGracePeriod),
+	}
+	if err = us.userDataRepo.AddDeletionRequest(ctx, deletion); err != nil {
+		return nil, err
+	}
+	return formatDeletion(deletion), nil
+}
+
+// GetDeletion get the pending deletion request
+func (us *UserDataService) GetDeletion(ctx context.Context, req *schema.GetUserDeletionReq) (
+	resp *schema.UserDeletionResp, err error) {
+	deletion, exist, err := us.userDataRepo.GetPendingDeletionRequest(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, nil
+	}
+	return formatDeletion(deletion), nil
+}
+
+// CancelDeletion cancel the pending deletion request
+func (us *UserDataService) CancelDeletion(ctx context.Context, req *schema.CancelUserDeletionReq) (err error) {
+	deletion, exist, err := us.userDataRepo.GetPendingDeletionRequest(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.ObjectNotFound)
+	}
+	return us.userDataRepo.UpdateDeletionRequestStatus(ctx, deletion.ID, entity.UserDeletionStatusCanceled)
+}
+
+// ProcessDueDeletions delete the accounts whose grace period is over
+func (us *UserDataService) ProcessDueDeletions(ctx context.Context) {
+	deletions, err := us.userDataRepo.GetDueDeletionRequests(ctx, time.Now())
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, deletion := range deletions {
+		if err := us.deleteUser(ctx, deletion); err != nil {
+			log.Errorf("delete user %s failed: %v", deletion.UserID, err)
+			continue
+		}
+		err = us.userDataRepo.UpdateDeletionRequestStatus(ctx, deletion.ID, entity.UserDeletionStatusCompleted)
+		if err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+func (us *UserDataService) deleteUser(ctx context.Context, deletion *entity.UserDeletionRequest) (err error) {
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, deletion.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted {
+		return nil
+	}
+
+	if deletion.Mode == entity.UserDeletionModeAnonymize {
+		ghost, err := us.getOrCreateGhostUser(ctx)
+		if err != nil {
+			return err
+		}
+		if err = us.userDataRepo.ReassignUserContent(ctx, userInfo.ID, ghost.ID); err != nil {
+			return err
+		}
+		if err = us.userCommon.UpdateQuestionCount(ctx, ghost.ID, int64(ghost.QuestionCount+userInfo.QuestionCount)); err != nil {
+			log.Error(err)
+		}
+		if err = us.userCommon.UpdateAnswerCount(ctx, ghost.ID, ghost.AnswerCount+userInfo.AnswerCount); err != nil {
+			log.Error(err)
+		}
+	}
+
+	err = us.userAdminService.UpdateUserStatus(ctx, &schema.UpdateUserStatusReq{
+		UserID:           userInfo.ID,
+		Status:           constant.UserDeleted,
+		RemoveAllContent: deletion.Mode == entity.UserDeletionModeRemove,
+	})
+	if err != nil {
+		return err
+	}
+	if err = us.userDataRepo.ErasePersonalData(ctx, userInfo.ID); err != nil {
+		return err
+	}
+	us.authService.RemoveUserAllTokens(ctx, userInfo.ID)
+	return nil
+}
+
+// getOrCreateGhostUser the ghost user owns the content of anonymized users, it has no password and can not login.
+// It is matched by both the reserved username and the email of the invalid domain which can not be verified.
+func (us *UserDataService) getOrCreateGhostUser(ctx context.Context) (ghost *entity.User, err error) {
+	ghost, exist, err := us.userDataRepo.GetUserByUsernameAndEmail(ctx, ghostUsername, ghostUserEmail)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return ghost, nil
+	}
+	ghost = &entity.User{
+		Username:    ghostUsername,
+		EMail:       ghostUserEmail,
+		DisplayName: ghostUserDisplayName,
+		MailStatus:  entity.EmailStatusAvailable,
+		Status:      entity.UserStatusAvailable,
+		Rank:        1,
+	}
+	if err = us.userAdminRepo.AddUser(ctx, ghost); err != nil {
+		return nil, err
+	}
+	return ghost, nil
+}
+
+func formatDeletion(deletion *entity.UserDeletionRequest) *schema.UserDeletionResp {
+	return &schema.UserDeletionResp{
+		Mode:        deletion.Mode,
+		CreatedAt:   deletion.CreatedAt.Unix(),
+		ScheduledAt: deletion.ScheduledAt.Unix(),
+	}
+}
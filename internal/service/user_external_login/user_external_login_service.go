@@ -32,6 +32,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
@@ -46,7 +47,6 @@ import (
 )
 
 type UserExternalLoginRepo interface {
-	ListAllExternalLoginInfo(ctx context.Context) (resp []*entity.UserExternalLogin, err error)
 	AddUserExternalLogin(ctx context.Context, user *entity.UserExternalLogin) (err error)
 	UpdateInfo(ctx context.Context, userInfo *entity.UserExternalLogin) (err error)
 	GetByExternalID(ctx context.Context, provider, externalID string) (userInfo *entity.UserExternalLogin, exist bool, err error)
@@ -66,6 +66,7 @@ type UserExternalLoginService struct {
 	siteInfoCommonService         siteinfo_common.SiteInfoCommonService
 	userActivity                  activity.UserActiveActivityRepo
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
+	eventQueueService             event_queue.EventQueueService
 }
 
 // NewUserExternalLoginService new user external login service
@@ -77,6 +78,7 @@ func NewUserExternalLoginService(
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	userActivity activity.UserActiveActivityRepo,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
+	eventQueueService event_queue.EventQueueService,
 ) *UserExternalLoginService {
 	return &UserExternalLoginService{
 		userRepo:                      userRepo,
@@ -86,14 +88,10 @@ func NewUserExternalLoginService(
 		siteInfoCommonService:         siteInfoCommonService,
 		userActivity:                  userActivity,
 		userNotificationConfigService: userNotificationConfigService,
+		eventQueueService:             eventQueueService,
 	}
 }
 
-// Get all ExternalLogin info
-func (us *UserExternalLoginService) ListAllExternalLoginInfo(ctx context.Context) (resp []*entity.UserExternalLogin, err error) {
-	return us.userExternalLoginRepo.ListAllExternalLoginInfo(ctx)
-}
-
 // ExternalLogin if user is already a member logged in
 func (us *UserExternalLoginService) ExternalLogin(
 	ctx context.Context, externalUserInfo *schema.ExternalLoginUserInfoCache) (
@@ -231,6 +229,7 @@ func (us *UserExternalLoginService) registerNewUser(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserRegister, userInfo.ID).OID(userInfo.ID))
 	return userInfo, nil
 }
 
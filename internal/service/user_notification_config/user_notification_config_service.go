@@ -21,12 +21,14 @@ package user_notification_config
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/plugin"
 )
 
 type UserNotificationConfigRepo interface {
@@ -67,9 +69,44 @@ func (us *UserNotificationConfigService) GetUserNotificationConfig(ctx context.C
 	resp = &schema.GetUserNotificationConfigResp{}
 	resp.NotificationConfig = schema.NewNotificationConfig(notificationConfigs)
 	resp.Format()
+	if resp.ChannelTargets == nil {
+		resp.ChannelTargets = make(schema.NotificationChannelTargets, 0)
+	}
+	if resp.QuietHours == nil {
+		resp.QuietHours = &schema.NotificationQuietHours{}
+	}
+	resp.AvailableChannels = us.getAvailableChannels()
 	return resp, nil
 }
 
+// GetNotificationConfig get the notification config of user, the channels and quiet hours are used to route
+// the notifications
+func (us *UserNotificationConfigService) GetNotificationConfig(ctx context.Context, userID string) (
+	nc schema.NotificationConfig, err error) {
+	notificationConfigs, err := us.userNotificationConfigRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nc, err
+	}
+	nc = schema.NewNotificationConfig(notificationConfigs)
+	nc.Format()
+	return nc, nil
+}
+
+// getAvailableChannels the built-in channels and the channels of the enabled notification plugins
+func (us *UserNotificationConfigService) getAvailableChannels() (channels []constant.NotificationChannelKey) {
+	channels = []constant.NotificationChannelKey{
+		constant.EmailChannel,
+		constant.MixinChannel,
+		constant.WebhookChannel,
+		constant.ChatWebhookChannel,
+	}
+	_ = plugin.CallNotification(func(fn plugin.Notification) error {
+		channels = append(channels, constant.NewPluginChannelKey(fn.Info().SlugName))
+		return nil
+	})
+	return channels
+}
+
 func (us *UserNotificationConfigService) UpdateUserNotificationConfig(
 	ctx context.Context, req *schema.UpdateUserNotificationConfigReq) (err error) {
 	req.NotificationConfig.Format()
@@ -90,6 +127,37 @@ func (us *UserNotificationConfigService) UpdateUserNotificationConfig(
 	if err != nil {
 		return err
 	}
+	for source, channels := range req.NotificationConfig.Types {
+		err = us.userNotificationConfigRepo.Save(ctx, us.convertChannelsToEntity(req.UserID, source, channels))
+		if err != nil {
+			return err
+		}
+	}
+	// the channel targets and the quiet hours are only saved when they are in the request
+	if req.NotificationConfig.ChannelTargets != nil {
+		targets, _ := json.Marshal(req.NotificationConfig.ChannelTargets)
+		err = us.userNotificationConfigRepo.Save(ctx, &entity.UserNotificationConfig{
+			UserID:   req.UserID,
+			Source:   string(constant.ChannelTargetSource),
+			Channels: string(targets),
+			Enabled:  len(req.NotificationConfig.ChannelTargets) > 0,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if req.NotificationConfig.QuietHours != nil {
+		quietHours, _ := json.Marshal(req.NotificationConfig.QuietHours)
+		err = us.userNotificationConfigRepo.Save(ctx, &entity.UserNotificationConfig{
+			UserID:   req.UserID,
+			Source:   string(constant.QuietHoursSource),
+			Channels: string(quietHours),
+			Enabled:  req.NotificationConfig.QuietHours.Enable,
+		})
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -107,6 +175,11 @@ func (us *UserNotificationConfigService) convertToEntity(ctx context.Context, us
 	source constant.NotificationSource, channel schema.NotificationChannelConfig) (c *entity.UserNotificationConfig) {
 	var channels schema.NotificationChannels
 	channels = append(channels, &channel)
+	return us.convertChannelsToEntity(userID, source, channels)
+}
+
+func (us *UserNotificationConfigService) convertChannelsToEntity(userID string,
+	source constant.NotificationSource, channels schema.NotificationChannels) (c *entity.UserNotificationConfig) {
 	c = &entity.UserNotificationConfig{
 		UserID:   userID,
 		Source:   string(source),
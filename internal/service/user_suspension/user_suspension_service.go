@@ -0,0 +1,273 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_suspension
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/config"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// suspendReasonsKey the config key of the reasons that can be chosen when suspending the user
+const suspendReasonsKey = "user.suspend.reasons"
+
+// UserSuspensionRepo user suspension repository
+type UserSuspensionRepo interface {
+	AddSuspension(ctx context.Context, suspension *entity.UserSuspension) (err error)
+	UpdateSuspension(ctx context.Context, suspension *entity.UserSuspension, cols ...string) (err error)
+	UpdateAppeal(ctx context.Context, suspension *entity.UserSuspension, review *entity.Review) (updated bool, err error)
+	GetSuspensionByAppealReviewID(ctx context.Context, reviewID int) (
+		suspension *entity.UserSuspension, exist bool, err error)
+	GetActiveSuspension(ctx context.Context, userID string) (suspension *entity.UserSuspension, exist bool, err error)
+	GetSuspensionList(ctx context.Context, userID string) (suspensions []*entity.UserSuspension, err error)
+	GetExpiredSuspensions(ctx context.Context, before time.Time, limit int) (
+		suspensions []*entity.UserSuspension, err error)
+}
+
+// UserSuspensionService user suspension service
+type UserSuspensionService struct {
+	userSuspensionRepo UserSuspensionRepo
+	configService      *config.ConfigService
+	userCommon         *usercommon.UserCommon
+}
+
+// NewUserSuspensionService new user suspension service
+func NewUserSuspensionService(
+	userSuspensionRepo UserSuspensionRepo,
+	configService *config.ConfigService,
+	userCommon *usercommon.UserCommon,
+) *UserSuspensionService {
+	return &UserSuspensionService{
+		userSuspensionRepo: userSuspensionRepo,
+		configService:      configService,
+		userCommon:         userCommon,
+	}
+}
+
+// Suspend record the suspension of the user, the active suspension of the user is replaced by the new one.
+// The reasonType is optional, if it is set, it must be one of the user suspend reasons.
+// The endAt is zero if the suspension is lifted manually only.
+func (us *UserSuspensionService) Suspend(ctx context.Context, userID, operatorUserID string,
+	reasonType int, message string, endAt time.Time) (err error) {
+	if !endAt.IsZero() && !endAt.After(time.Now()) {
+		return errors.BadRequest(reason.UserSuspensionEndTimeInvalid)
+	}
+	if reasonType > 0 {
+		if err = us.checkReasonType(ctx, reasonType); err != nil {
+			return err
+		}
+	}
+	if err = us.Lift(ctx, userID, operatorUserID, entity.UserSuspensionStatusLifted); err != nil {
+		return err
+	}
+	return us.userSuspensionRepo.AddSuspension(ctx, &entity.UserSuspension{
+		UserID:         userID,
+		OperatorUserID: operatorUserID,
+		ReasonType:     reasonType,
+		Message:        message,
+		EndAt:          endAt,
+		Status:         entity.UserSuspensionStatusActive,
+	})
+}
+
+func (us *UserSuspensionService) checkReasonType(ctx context.Context, reasonType int) (err error) {
+	cfg, err := us.configService.GetConfigByID(ctx, reasonType)
+	if err != nil || cfg == nil {
+		return errors.BadRequest(reason.UserSuspensionReasonInvalid)
+	}
+	reasonKeys, err := us.configService.GetArrayStringValue(ctx, suspendReasonsKey)
+	if err != nil {
+		return err
+	}
+	for _, key := range reasonKeys {
+		if key == cfg.Key {
+			return nil
+		}
+	}
+	return errors.BadRequest(reason.UserSuspensionReasonInvalid)
+}
+
+// Lift end the active suspension of the user with the status, nothing to do if the user has no active suspension
+func (us *UserSuspensionService) Lift(ctx context.Context, userID, operatorUserID string, status int) (err error) {
+	suspension, exist, err := us.userSuspensionRepo.GetActiveSuspension(ctx, userID)
+	if err != nil || !exist {
+		return err
+	}
+	return us.end(ctx, suspension, operatorUserID, status)
+}
+
+func (us *UserSuspensionService) end(ctx context.Context, suspension *entity.UserSuspension,
+	operatorUserID string, status int) (err error) {
+	suspension.Status = status
+	suspension.LiftedAt = time.Now()
+	suspension.LiftedUserID = operatorUserID
+	return us.userSuspensionRepo.UpdateSuspension(ctx, suspension, "status", "lifted_at", "lifted_user_id")
+}
+
+// GetExpiredSuspensions get the active suspensions that should be ended now
+func (us *UserSuspensionService) GetExpiredSuspensions(ctx context.Context, limit int) (
+	suspensions []*entity.UserSuspension, err error) {
+	return us.userSuspensionRepo.GetExpiredSuspensions(ctx, time.Now(), limit)
+}
+
+// Expire mark the suspension as expired
+func (us *UserSuspensionService) Expire(ctx context.Context, suspension *entity.UserSuspension) (err error) {
+	return us.end(ctx, suspension, "0", entity.UserSuspensionStatusExpired)
+}
+
+// GetUserSuspensionInfo get the active suspension of the user, nil if the user is not suspended
+func (us *UserSuspensionService) GetUserSuspensionInfo(ctx context.Context, userID string) (
+	info *schema.UserSuspensionInfo, err error) {
+	suspension, exist, err := us.userSuspensionRepo.GetActiveSuspension(ctx, userID)
+	if err != nil || !exist {
+		return nil, err
+	}
+	return us.formatSuspensionInfo(ctx, suspension), nil
+}
+
+// GetAppealedSuspensionInfo get the suspension whose appeal is handled by the review
+func (us *UserSuspensionService) GetAppealedSuspensionInfo(ctx context.Context, reviewID int) (
+	info *schema.UserSuspensionInfo, exist bool, err error) {
+	suspension, exist, err := us.userSuspensionRepo.GetSuspensionByAppealReviewID(ctx, reviewID)
+	if err != nil || !exist {
+		return nil, exist, err
+	}
+	return us.formatSuspensionInfo(ctx, suspension), true, nil
+}
+
+// GetSuspensionHistory get all suspensions of the user, the latest one first
+func (us *UserSuspensionService) GetSuspensionHistory(ctx context.Context, userID string) (
+	resp []*schema.UserSuspensionHistoryItem, err error) {
+	suspensions, err := us.userSuspensionRepo.GetSuspensionList(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0)
+	for _, suspension := range suspensions {
+		userIDs = append(userIDs, suspension.OperatorUserID, suspension.LiftedUserID)
+	}
+	userInfoMapping, err := us.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = make([]*schema.UserSuspensionHistoryItem, 0, len(suspensions))
+	for _, suspension := range suspensions {
+		item := &schema.UserSuspensionHistoryItem{
+			UserSuspensionInfo: us.formatSuspensionInfo(ctx, suspension),
+			OperatorUserInfo:   userInfoMapping[suspension.OperatorUserID],
+			LiftedUserInfo:     userInfoMapping[suspension.LiftedUserID],
+		}
+		if !suspension.LiftedAt.IsZero() {
+			item.LiftedAt = suspension.LiftedAt.Unix()
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+func (us *UserSuspensionService) formatSuspensionInfo(ctx context.Context, suspension *entity.UserSuspension) (
+	info *schema.UserSuspensionInfo) {
+	info = &schema.UserSuspensionInfo{}
+	info.ConvertFromEntity(suspension)
+	if suspension.ReasonType <= 0 {
+		return info
+	}
+	cfg, err := us.configService.GetConfigByID(ctx, suspension.ReasonType)
+	if err != nil {
+		log.Error(err)
+		return info
+	}
+	info.Reason = &schema.ReasonItem{ReasonKey: cfg.Key, ReasonType: suspension.ReasonType}
+	if err = json.Unmarshal([]byte(cfg.Value), info.Reason); err != nil {
+		log.Error(err)
+	}
+	info.Reason.Translate(cfg.Key, handler.GetLangByCtx(ctx))
+	return info
+}
+
+// RecordAppeal record the appeal of the active suspension of the user with the review that handles it,
+// only one appeal is allowed for each suspension
+func (us *UserSuspensionService) RecordAppeal(ctx context.Context, userID, content string, review *entity.Review) (
+	suspension *entity.UserSuspension, err error) {
+	suspension, exist, err := us.userSuspensionRepo.GetActiveSuspension(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotSuspended)
+	}
+	if suspension.AppealStatus != entity.UserSuspensionAppealStatusNone {
+		return nil, errors.BadRequest(reason.UserSuspensionAlreadyAppealed)
+	}
+	suspension.AppealStatus = entity.UserSuspensionAppealStatusPending
+	suspension.AppealContent = content
+	suspension.AppealedAt = time.Now()
+	updated, err := us.userSuspensionRepo.UpdateAppeal(ctx, suspension, review)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, errors.BadRequest(reason.UserSuspensionAlreadyAppealed)
+	}
+	return suspension, nil
+}
+
+// ResolveAppeal record the result of the appeal handled by the review,
+// the suspension is reversed if the appeal is accepted
+func (us *UserSuspensionService) ResolveAppeal(ctx context.Context, reviewID int, operatorUserID string,
+	accepted bool) (suspension *entity.UserSuspension, err error) {
+	suspension, exist, err := us.userSuspensionRepo.GetSuspensionByAppealReviewID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.ObjectNotFound)
+	}
+	if suspension.AppealStatus != entity.UserSuspensionAppealStatusPending {
+		return suspension, nil
+	}
+	cols := []string{"appeal_status"}
+	if accepted {
+		suspension.AppealStatus = entity.UserSuspensionAppealStatusAccepted
+		// the suspension may be lifted or expired before the appeal is handled
+		if suspension.Status == entity.UserSuspensionStatusActive {
+			suspension.Status = entity.UserSuspensionStatusReversed
+			suspension.LiftedAt = time.Now()
+			suspension.LiftedUserID = operatorUserID
+			cols = append(cols, "status", "lifted_at", "lifted_user_id")
+		}
+	} else {
+		suspension.AppealStatus = entity.UserSuspensionAppealStatusRejected
+	}
+	if err = us.userSuspensionRepo.UpdateSuspension(ctx, suspension, cols...); err != nil {
+		return nil, err
+	}
+	return suspension, nil
+}
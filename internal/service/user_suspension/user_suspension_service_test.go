@@ -0,0 +1,216 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_suspension
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeUserSuspensionRepo struct {
+	suspensions []*entity.UserSuspension
+}
+
+func (r *fakeUserSuspensionRepo) AddSuspension(ctx context.Context, suspension *entity.UserSuspension) error {
+	suspension.ID = strconv.Itoa(len(r.suspensions) + 1)
+	suspension.CreatedAt = time.Now()
+	r.suspensions = append(r.suspensions, suspension)
+	return nil
+}
+
+func (r *fakeUserSuspensionRepo) UpdateSuspension(ctx context.Context, suspension *entity.UserSuspension,
+	cols ...string) error {
+	return nil
+}
+
+func (r *fakeUserSuspensionRepo) UpdateAppeal(ctx context.Context, suspension *entity.UserSuspension,
+	review *entity.Review) (bool, error) {
+	suspension.AppealReviewID = review.ID
+	return true, nil
+}
+
+func (r *fakeUserSuspensionRepo) GetSuspensionByAppealReviewID(ctx context.Context, reviewID int) (
+	*entity.UserSuspension, bool, error) {
+	for _, suspension := range r.suspensions {
+		if suspension.AppealReviewID == reviewID {
+			return suspension, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (r *fakeUserSuspensionRepo) GetActiveSuspension(ctx context.Context, userID string) (
+	*entity.UserSuspension, bool, error) {
+	for _, suspension := range r.suspensions {
+		if suspension.UserID == userID && suspension.Status == entity.UserSuspensionStatusActive {
+			return suspension, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (r *fakeUserSuspensionRepo) GetSuspensionList(ctx context.Context, userID string) (
+	[]*entity.UserSuspension, error) {
+	return r.suspensions, nil
+}
+
+func (r *fakeUserSuspensionRepo) GetExpiredSuspensions(ctx context.Context, before time.Time, limit int) (
+	suspensions []*entity.UserSuspension, err error) {
+	for _, suspension := range r.suspensions {
+		if suspension.Status == entity.UserSuspensionStatusActive &&
+			!suspension.EndAt.IsZero() && !suspension.EndAt.After(before) {
+			suspensions = append(suspensions, suspension)
+		}
+	}
+	return suspensions, nil
+}
+
+type fakeConfigRepo struct {
+	configs []*entity.Config
+}
+
+func (r *fakeConfigRepo) GetConfigByID(ctx context.Context, id int) (*entity.Config, error) {
+	for _, c := range r.configs {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("config %d not found", id)
+}
+
+func (r *fakeConfigRepo) GetConfigByKey(ctx context.Context, key string) (*entity.Config, error) {
+	for _, c := range r.configs {
+		if c.Key == key {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("config %s not found", key)
+}
+
+func (r *fakeConfigRepo) UpdateConfig(ctx context.Context, key, value string) error {
+	return nil
+}
+
+func newTestUserSuspensionService() (*UserSuspensionService, *fakeUserSuspensionRepo) {
+	repo := &fakeUserSuspensionRepo{}
+	configRepo := &fakeConfigRepo{configs: []*entity.Config{
+		{ID: 70, Key: "reason.suspended", Value: `{"name":"suspended"}`},
+		{ID: 131, Key: "reason.suspend_spam", Value: `{"name":"spam","description":"Posting spam."}`},
+		{ID: 135, Key: suspendReasonsKey, Value: `["reason.suspend_spam"]`},
+	}}
+	return NewUserSuspensionService(repo, config.NewConfigService(configRepo), nil), repo
+}
+
+func TestUserSuspensionService_Suspend(t *testing.T) {
+	ctx := context.Background()
+	us, repo := newTestUserSuspensionService()
+
+	// the reason must be one of the suspend reasons
+	assert.Error(t, us.Suspend(ctx, "1", "100", 70, "", time.Time{}))
+	assert.Error(t, us.Suspend(ctx, "1", "100", 999, "", time.Time{}))
+	// the end time must be in the future
+	assert.Error(t, us.Suspend(ctx, "1", "100", 131, "", time.Now().Add(-time.Hour)))
+	assert.Empty(t, repo.suspensions)
+
+	assert.NoError(t, us.Suspend(ctx, "1", "100", 131, "spam links", time.Now().Add(time.Hour)))
+	info, err := us.GetUserSuspensionInfo(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "spam links", info.Message)
+	assert.Equal(t, "reason.suspend_spam", info.Reason.ReasonKey)
+	assert.Equal(t, "spam", info.Reason.Name)
+	assert.Equal(t, "active", info.Status)
+	assert.True(t, info.CanAppeal)
+
+	// the new suspension replaces the active one
+	assert.NoError(t, us.Suspend(ctx, "1", "100", 0, "", time.Time{}))
+	assert.Len(t, repo.suspensions, 2)
+	assert.Equal(t, entity.UserSuspensionStatusLifted, repo.suspensions[0].Status)
+	info, err = us.GetUserSuspensionInfo(ctx, "1")
+	assert.NoError(t, err)
+	assert.Nil(t, info.Reason)
+	assert.Zero(t, info.EndAt)
+
+	assert.NoError(t, us.Lift(ctx, "1", "100", entity.UserSuspensionStatusLifted))
+	info, err = us.GetUserSuspensionInfo(ctx, "1")
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+}
+
+func TestUserSuspensionService_Appeal(t *testing.T) {
+	ctx := context.Background()
+	us, repo := newTestUserSuspensionService()
+
+	_, err := us.RecordAppeal(ctx, "1", "it was a mistake", &entity.Review{ID: 10})
+	assert.Error(t, err)
+
+	assert.NoError(t, us.Suspend(ctx, "1", "100", 131, "", time.Time{}))
+	suspension, err := us.RecordAppeal(ctx, "1", "it was a mistake", &entity.Review{ID: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 10, suspension.AppealReviewID)
+	// only one appeal is allowed for each suspension
+	_, err = us.RecordAppeal(ctx, "1", "please", &entity.Review{ID: 12})
+	assert.Error(t, err)
+
+	info, exist, err := us.GetAppealedSuspensionInfo(ctx, 10)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "pending", info.AppealStatus)
+	assert.False(t, info.CanAppeal)
+
+	suspension, err = us.ResolveAppeal(ctx, 10, "100", true)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.UserSuspensionStatusReversed, suspension.Status)
+	assert.Equal(t, entity.UserSuspensionAppealStatusAccepted, repo.suspensions[0].AppealStatus)
+
+	// the appeal is rejected
+	assert.NoError(t, us.Suspend(ctx, "1", "100", 131, "", time.Time{}))
+	_, err = us.RecordAppeal(ctx, "1", "it was a mistake again", &entity.Review{ID: 11})
+	assert.NoError(t, err)
+	suspension, err = us.ResolveAppeal(ctx, 11, "100", false)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.UserSuspensionStatusActive, suspension.Status)
+	assert.Equal(t, entity.UserSuspensionAppealStatusRejected, suspension.AppealStatus)
+}
+
+func TestUserSuspensionService_Expire(t *testing.T) {
+	ctx := context.Background()
+	us, repo := newTestUserSuspensionService()
+
+	assert.NoError(t, us.Suspend(ctx, "1", "100", 131, "", time.Now().Add(time.Hour)))
+	assert.NoError(t, us.Suspend(ctx, "2", "100", 131, "", time.Time{}))
+	suspensions, err := us.GetExpiredSuspensions(ctx, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, suspensions)
+
+	repo.suspensions[0].EndAt = time.Now().Add(-time.Minute)
+	suspensions, err = us.GetExpiredSuspensions(ctx, 10)
+	assert.NoError(t, err)
+	assert.Len(t, suspensions, 1)
+	assert.NoError(t, us.Expire(ctx, suspensions[0]))
+	assert.Equal(t, entity.UserSuspensionStatusExpired, repo.suspensions[0].Status)
+	assert.Equal(t, "0", repo.suspensions[0].LiftedUserID)
+}
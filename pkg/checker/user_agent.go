@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package checker
+
+import "strings"
+
+// botUserAgentKeywords lower case keywords of crawlers, link previewers and http libraries
+var botUserAgentKeywords = []string{
+	"bot", "crawl", "spider", "slurp", "archiver", "facebookexternalhit", "embedly",
+	"preview", "mediapartners", "lighthouse", "headless", "phantomjs",
+	"curl", "wget", "python-requests", "python-urllib", "go-http-client", "okhttp",
+	"java/", "libwww", "httpclient", "axios", "node-fetch",
+}
+
+// IsBotUserAgent checks whether the user agent is empty or belongs to a known bot
+func IsBotUserAgent(userAgent string) bool {
+	userAgent = strings.ToLower(strings.TrimSpace(userAgent))
+	if len(userAgent) == 0 {
+		return true
+	}
+	for _, keyword := range botUserAgentKeywords {
+		if strings.Contains(userAgent, keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package converter
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/segmentfault/pacman/log"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// DefaultCodeHighlightTheme is the default theme of code highlight
+const DefaultCodeHighlightTheme = "github"
+
+var (
+	codeBlockHighlightLinesRegexp = regexp.MustCompile(`\{([\d\s,-]+)\}`)
+	codeBlockTitleRegexp          = regexp.MustCompile(`(?:title|filename)=(?:"([^"]*)"|(\S+))`)
+	// codeHighlightClassRegexp matches only the class names written by the highlighter, such as "line hl", "kd"
+	codeHighlightClassRegexp = newCodeHighlightClassRegexp()
+	// codeLanguageClassRegexp matches the language class of code, such as "language-go"
+	codeLanguageClassRegexp = regexp.MustCompile(`^language-[\w\-+#]{1,32}$`)
+)
+
+// newCodeHighlightClassRegexp returns the regexp matching the space separated token class names of chroma
+func newCodeHighlightClassRegexp() *regexp.Regexp {
+	classNames := make([]string, 0, len(chroma.StandardTypes))
+	for _, className := range chroma.StandardTypes {
+		if len(className) > 0 {
+			classNames = append(classNames, regexp.QuoteMeta(className))
+		}
+	}
+	sort.Strings(classNames)
+	className := "(?:" + strings.Join(classNames, "|") + ")"
+	return regexp.MustCompile(`^` + className + `(?: ` + className + `)*$`)
+}
+
+// CodeBlockInfo is the metadata of fenced code block. e.g.
+// ```go {2,4-6} title="main.go" linenos
+type CodeBlockInfo struct {
+	Language       string
+	Title          string
+	LineNumbers    bool
+	HighlightLines [][2]int
+}
+
+// ParseCodeBlockInfo parse the info string of fenced code block
+func ParseCodeBlockInfo(info string) (codeBlockInfo *CodeBlockInfo) {
+	codeBlockInfo = &CodeBlockInfo{}
+	info = strings.TrimSpace(info)
+	if len(info) > 0 && info[0] != '{' {
+		language, rest, _ := strings.Cut(info, " ")
+		codeBlockInfo.Language = language
+		info = rest
+	}
+	if matches := codeBlockTitleRegexp.FindStringSubmatch(info); len(matches) > 0 {
+		codeBlockInfo.Title = matches[1] + matches[2]
+		info = strings.Replace(info, matches[0], "", 1)
+	}
+	if matches := codeBlockHighlightLinesRegexp.FindStringSubmatch(info); len(matches) > 0 {
+		codeBlockInfo.HighlightLines = parseHighlightLines(matches[1])
+		info = strings.Replace(info, matches[0], "", 1)
+	}
+	for _, field := range strings.Fields(info) {
+		if field == "linenos" {
+			codeBlockInfo.LineNumbers = true
+		}
+	}
+	return codeBlockInfo
+}
+
+// parseHighlightLines parse the highlight lines like 2,4-6
+func parseHighlightLines(s string) (ranges [][2]int) {
+	for _, part := range strings.Split(s, ",") {
+		start, end, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		from, err := strconv.Atoi(strings.TrimSpace(start))
+		if err != nil || from <= 0 {
+			continue
+		}
+		to := from
+		if isRange {
+			to, err = strconv.Atoi(strings.TrimSpace(end))
+			if err != nil || to < from {
+				continue
+			}
+		}
+		ranges = append(ranges, [2]int{from, to})
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	return ranges
+}
+
+// IsCodeHighlightTheme check whether the theme of code highlight exists
+func IsCodeHighlightTheme(theme string) bool {
+	_, ok := styles.Registry[theme]
+	return ok
+}
+
+// CodeHighlightCSS returns the css of the code highlight theme
+func CodeHighlightCSS(theme string) string {
+	if !IsCodeHighlightTheme(theme) {
+		theme = DefaultCodeHighlightTheme
+	}
+	var buf bytes.Buffer
+	if err := chromahtml.New(chromahtml.WithClasses(true)).WriteCSS(&buf, styles.Get(theme)); err != nil {
+		log.Error(err)
+		return ""
+	}
+	return buf.String()
+}
+
+// CodeHighlightExtension highlights the fenced code blocks when rendering markdown.
+// The highlighted html uses the class names, so the theme can be changed without rendering again.
+type CodeHighlightExtension struct {
+	// InlineStyles writes the styles of the Theme into the html instead of the class names,
+	// it is used for the html read without the css of code highlight, such as emails and feeds.
+	InlineStyles bool
+	Theme        string
+}
+
+func (e *CodeHighlightExtension) Extend(m goldmark.Markdown) {
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(&CodeHighlightRenderer{InlineStyles: e.InlineStyles, Theme: e.Theme}, 200),
+	))
+}
+
+// SanitizerAllowList implements SanitizerAllowListExtender, the inline styles written by the highlighter are allowed
+func (e *CodeHighlightExtension) SanitizerAllowList() *SanitizerAllowList {
+	if !e.InlineStyles {
+		return nil
+	}
+	return &SanitizerAllowList{
+		Styles: map[string][]string{
+			"pre": {"color", "background-color", "tab-size"},
+			"span": {"color", "background-color", "font-weight", "font-style", "text-decoration",
+				"display", "width", "white-space", "user-select", "margin-right", "padding"},
+		},
+	}
+}
+
+type CodeHighlightRenderer struct {
+	InlineStyles bool
+	Theme        string
+}
+
+// RegisterFuncs implements renderer.NodeRenderer.RegisterFuncs.
+func (r *CodeHighlightRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderFencedCodeBlock)
+}
+
+func (r *CodeHighlightRenderer) renderFencedCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.FencedCodeBlock)
+	var info string
+	if n.Info != nil {
+		info = string(n.Info.Segment.Value(source))
+	}
+	codeBlockInfo := ParseCodeBlockInfo(info)
+
+	var code bytes.Buffer
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		code.Write(line.Value(source))
+	}
+
+	lexer := lexers.Get(codeBlockInfo.Language)
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code.String())
+	if err != nil {
+		return ast.WalkStop, err
+	}
+	formatter := chromahtml.New(
+		chromahtml.WithClasses(!r.InlineStyles),
+		chromahtml.WithLineNumbers(codeBlockInfo.LineNumbers),
+		chromahtml.HighlightLines(codeBlockInfo.HighlightLines),
+		chromahtml.WithPreWrapper(&codePreWrapper{language: codeBlockInfo.Language}),
+	)
+
+	if len(codeBlockInfo.Title) > 0 {
+		_, _ = w.WriteString(`<figure class="code-block"><figcaption class="code-block-title">`)
+		_, _ = w.Write(util.EscapeHTML([]byte(codeBlockInfo.Title)))
+		_, _ = w.WriteString(`</figcaption>`)
+	}
+	theme := DefaultCodeHighlightTheme
+	if r.InlineStyles && IsCodeHighlightTheme(r.Theme) {
+		theme = r.Theme
+	}
+	if err = formatter.Format(w, styles.Get(theme), iterator); err != nil {
+		return ast.WalkStop, err
+	}
+	if len(codeBlockInfo.Title) > 0 {
+		_, _ = w.WriteString(`</figure>`)
+	}
+	_ = w.WriteByte('\n')
+	return ast.WalkContinue, nil
+}
+
+// codePreWrapper keeps the language class of code, so the front end can still recognize the language.
+type codePreWrapper struct {
+	language string
+}
+
+func (p *codePreWrapper) Start(code bool, styleAttr string) string {
+	if !code {
+		return fmt.Sprintf(`<pre%s>`, styleAttr)
+	}
+	if len(p.language) == 0 {
+		return fmt.Sprintf(`<pre%s><code>`, styleAttr)
+	}
+	return fmt.Sprintf(`<pre%s><code class="language-%s">`, styleAttr, util.EscapeHTML([]byte(p.language)))
+}
+
+func (p *codePreWrapper) End(code bool) string {
+	if code {
+		return `</code></pre>`
+	}
+	return `</pre>`
+}
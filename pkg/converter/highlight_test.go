@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCodeBlockInfo(t *testing.T) {
+	info := ParseCodeBlockInfo(`go {2,4-6} title="main.go" linenos`)
+	assert.Equal(t, "go", info.Language)
+	assert.Equal(t, "main.go", info.Title)
+	assert.True(t, info.LineNumbers)
+	assert.Equal(t, [][2]int{{2, 2}, {4, 6}}, info.HighlightLines)
+
+	info = ParseCodeBlockInfo(`{3} filename=a.py`)
+	assert.Empty(t, info.Language)
+	assert.Equal(t, "a.py", info.Title)
+	assert.False(t, info.LineNumbers)
+	assert.Equal(t, [][2]int{{3, 3}}, info.HighlightLines)
+
+	info = ParseCodeBlockInfo("")
+	assert.Empty(t, info.Language)
+	assert.Empty(t, info.HighlightLines)
+}
+
+func TestMarkdown2HTMLCodeHighlight(t *testing.T) {
+	html := Markdown2HTML("```go {2} title=\"<main.go>\" linenos\npackage main\nfunc main() {}\n```")
+	assert.Contains(t, html, `<figcaption class="code-block-title">&lt;main.go&gt;</figcaption>`)
+	assert.Contains(t, html, `<pre class="chroma"><code class="language-go">`)
+	assert.Contains(t, html, `<span class="line hl"><span class="ln">2</span>`)
+	assert.Contains(t, html, `<span class="kn">package</span>`)
+
+	html = Markdown2HTML("```\n<script>alert(1)</script>\n```")
+	assert.Contains(t, html, "&lt;script&gt;")
+
+	html = Markdown2HTML(`<span class="kd" onclick="alert(1)">func</span>`)
+	assert.Contains(t, html, `<span class="kd">func</span>`)
+
+	// only the class names written by the highlighter are allowed
+	html = Markdown2HTML(`<span class="kd modal-backdrop">func</span> <code class="btn">x</code> <figure class="hidden"></figure>`)
+	assert.Contains(t, html, `<span>func</span>`)
+	assert.Contains(t, html, `<code>x</code>`)
+	assert.NotContains(t, html, `hidden`)
+}
+
+func TestMarkdown2InlineStyleHTML(t *testing.T) {
+	html := Markdown2InlineStyleHTML("```go {2} linenos\npackage main\nfunc main() {}\n```", "monokai")
+	assert.Contains(t, html, `<pre style="color: #f8f8f2; background-color: #272822"><code class="language-go">`)
+	assert.Contains(t, html, `<span style="color: #f92672">package</span>`)
+	assert.Contains(t, html, `<span style="display: flex; background-color: #3c3d38">`)
+	assert.NotContains(t, html, "chroma")
+
+	// the styles which are not written by the highlighter are still removed
+	html = Markdown2InlineStyleHTML(`<span style="position: fixed">x</span>`, "monokai")
+	assert.NotContains(t, html, "position")
+
+	// the unknown theme falls back to the default theme
+	assert.Equal(t, Markdown2InlineStyleHTML("```go\nfunc main() {}\n```", DefaultCodeHighlightTheme),
+		Markdown2InlineStyleHTML("```go\nfunc main() {}\n```", "not-exist"))
+}
+
+func TestCodeHighlightCSS(t *testing.T) {
+	assert.True(t, IsCodeHighlightTheme("monokai"))
+	assert.False(t, IsCodeHighlightTheme("not-exist"))
+	assert.Contains(t, CodeHighlightCSS("monokai"), ".chroma")
+	assert.Equal(t, CodeHighlightCSS(DefaultCodeHighlightTheme), CodeHighlightCSS("not-exist"))
+}
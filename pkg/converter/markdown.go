@@ -37,10 +37,38 @@ import (
 	"github.com/yuin/goldmark/util"
 )
 
+// SanitizerPriority is the priority of node renderers that sanitize the dangerous html.
+// The node renderer with lower priority overrides the others for the same node kind,
+// so the extensions must use a higher priority than it to make sure the sanitizer is always applied last.
+const SanitizerPriority = 1
+
+var markdownExtensionsFn func() []goldmark.Extender
+
+// RegisterMarkdownExtensionsFunc register a function to get the extra goldmark extensions, such as the extensions from plugins.
+func RegisterMarkdownExtensionsFunc(fn func() []goldmark.Extender) {
+	markdownExtensionsFn = fn
+}
+
 // Markdown2HTML convert markdown to html
 func Markdown2HTML(source string) string {
+	return markdown2HTML(source, &CodeHighlightExtension{})
+}
+
+// Markdown2InlineStyleHTML convert markdown to html whose code blocks are highlighted by the inline styles of the theme,
+// so that the html can be read without the css of code highlight, such as in emails and feeds
+func Markdown2InlineStyleHTML(source, theme string) string {
+	return markdown2HTML(source, &CodeHighlightExtension{InlineStyles: true, Theme: theme})
+}
+
+func markdown2HTML(source string, codeHighlight *CodeHighlightExtension) string {
+	extensions := []goldmark.Extender{extension.GFM, enclave.New(&enclave.Config{}), codeHighlight}
+	if markdownExtensionsFn != nil {
+		extensions = append(extensions, markdownExtensionsFn()...)
+	}
+	// the sanitizer must be the last one
+	extensions = append(extensions, &DangerousHTMLFilterExtension{})
 	mdConverter := goldmark.New(
-		goldmark.WithExtensions(&DangerousHTMLFilterExtension{}, extension.GFM, enclave.New(&enclave.Config{})),
+		goldmark.WithExtensions(extensions...),
 		goldmark.WithParserOptions(
 			parser.WithAutoHeadingID(),
 		),
@@ -53,7 +81,8 @@ func Markdown2HTML(source string) string {
 		log.Error(err)
 		return source
 	}
-	html := buf.String()
+	// the node renderers of extensions may write any html, so the complete output is sanitized at last
+	html := getOutputSanitizerPolicy(extensions).Sanitize(buf.String())
 	// filter := bluemonday.UGCPolicy()
 	// filter.AllowStyling()
 	// filter.RequireNoFollowOnLinks(false)
@@ -83,11 +112,97 @@ func (e *DangerousHTMLFilterExtension) Extend(m goldmark.Markdown) {
 	m.Renderer().AddOptions(renderer.WithNodeRenderers(
 		util.Prioritized(&DangerousHTMLRenderer{
 			Config: goldmarkHTML.NewConfig(),
-			Filter: bluemonday.UGCPolicy(),
-		}, 1),
+			Filter: newSanitizerPolicy(),
+		}, SanitizerPriority),
 	))
 }
 
+// SanitizerAllowList declares the html rendered by an extension which should be kept by the sanitizer
+type SanitizerAllowList struct {
+	// Elements the allowed elements, e.g. math, semantics, mrow
+	Elements []string
+	// Attrs the allowed attributes of each element, e.g. {"span": {"aria-hidden"}}
+	Attrs map[string][]string
+	// Classes the pattern of the allowed class names of each element, e.g. {"pre": regexp.MustCompile(`^mermaid$`)}
+	Classes map[string]*regexp.Regexp
+	// Styles the allowed inline style properties of each element, e.g. {"span": {"height", "vertical-align"}}
+	Styles map[string][]string
+}
+
+// SanitizerAllowListExtender is the extension which renders the html that the sanitizer removes by default
+type SanitizerAllowListExtender interface {
+	SanitizerAllowList() *SanitizerAllowList
+}
+
+func (l *SanitizerAllowList) apply(filter *bluemonday.Policy) {
+	filter.AllowElements(l.Elements...)
+	for element, attrs := range l.Attrs {
+		filter.AllowAttrs(attrs...).OnElements(element)
+	}
+	for element, classRegexp := range l.Classes {
+		filter.AllowAttrs("class").Matching(classRegexp).OnElements(element)
+	}
+	for element, styles := range l.Styles {
+		filter.AllowStyles(styles...).OnElements(element)
+	}
+}
+
+var (
+	// outputSanitizerPolicy sanitizes the complete rendered html after all the node renderers including the plugins
+	outputSanitizerPolicy = newOutputSanitizerPolicy()
+
+	enclaveClassRegexp     = regexp.MustCompile(`^enclave-[\w\- ]{1,128}$`)
+	enclaveIframeSrcRegexp = regexp.MustCompile(`^(https:)?//(www\.youtube\.com/embed/|player\.bilibili\.com/player\.html\?)`)
+)
+
+// newOutputSanitizerPolicy returns the policy to filter the complete rendered html,
+// it also allows the html rendered by the built-in extensions, such as table alignment, task list and embedded video
+func newOutputSanitizerPolicy() *bluemonday.Policy {
+	filter := newSanitizerPolicy()
+	filter.RequireNoFollowOnLinks(false)
+	filter.AllowAttrs("rel").Matching(regexp.MustCompile(`^nofollow$`)).OnElements("a")
+	filter.AllowElements("kbd")
+	filter.AllowStyles("text-align").MatchingEnum("left", "center", "right").OnElements("th", "td")
+	filter.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	filter.AllowAttrs("checked", "disabled").OnElements("input")
+	filter.AllowAttrs("class").Matching(enclaveClassRegexp).OnElements("div", "iframe")
+	filter.AllowAttrs("src").Matching(enclaveIframeSrcRegexp).OnElements("iframe")
+	filter.AllowAttrs("width", "height", "title", "frameborder", "allow", "allowfullscreen", "scrolling").
+		OnElements("iframe")
+	return filter
+}
+
+// getOutputSanitizerPolicy returns the output sanitizer policy merged with the allow lists of the extensions
+func getOutputSanitizerPolicy(extensions []goldmark.Extender) *bluemonday.Policy {
+	allowLists := make([]*SanitizerAllowList, 0)
+	for _, e := range extensions {
+		if extender, ok := e.(SanitizerAllowListExtender); ok {
+			if allowList := extender.SanitizerAllowList(); allowList != nil {
+				allowLists = append(allowLists, allowList)
+			}
+		}
+	}
+	if len(allowLists) == 0 {
+		return outputSanitizerPolicy
+	}
+	filter := newOutputSanitizerPolicy()
+	for _, allowList := range allowLists {
+		allowList.apply(filter)
+	}
+	return filter
+}
+
+// newSanitizerPolicy returns the policy to filter the dangerous html, it allows the class names of code highlight
+func newSanitizerPolicy() *bluemonday.Policy {
+	filter := bluemonday.UGCPolicy()
+	filter.AllowElements("figure", "figcaption")
+	filter.AllowAttrs("class").Matching(codeHighlightClassRegexp).OnElements("pre", "span")
+	filter.AllowAttrs("class").Matching(codeLanguageClassRegexp).OnElements("code")
+	filter.AllowAttrs("class").Matching(regexp.MustCompile(`^code-block$`)).OnElements("figure")
+	filter.AllowAttrs("class").Matching(regexp.MustCompile(`^code-block-title$`)).OnElements("figcaption")
+	return filter
+}
+
 type DangerousHTMLRenderer struct {
 	goldmarkHTML.Config
 	Filter *bluemonday.Policy
@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+type testNodeRenderer struct{}
+
+func (r *testNodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindCodeSpan, func(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			_, _ = w.WriteString(`<code class="language-test">`)
+			for c := node.FirstChild(); c != nil; c = c.NextSibling() {
+				_, _ = w.Write(c.(*ast.Text).Segment.Value(source))
+			}
+			_, _ = w.WriteString(`</code>`)
+		}
+		return ast.WalkSkipChildren, nil
+	})
+	reg.Register(ast.KindEmphasis, func(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			// write the content as is, like a careless plugin
+			_, _ = w.WriteString(`<em onclick="alert(1)">`)
+			_, _ = w.Write(node.Text(source))
+			_, _ = w.WriteString(`</em><iframe src="https://evil.com"></iframe>`)
+		}
+		return ast.WalkSkipChildren, nil
+	})
+	reg.Register(ast.KindRawHTML, func(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			segments := node.(*ast.RawHTML).Segments
+			for i := 0; i < segments.Len(); i++ {
+				segment := segments.At(i)
+				_, _ = w.Write(segment.Value(source))
+			}
+		}
+		return ast.WalkSkipChildren, nil
+	})
+}
+
+type testExtension struct{}
+
+func (e *testExtension) Extend(m goldmark.Markdown) {
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(&testNodeRenderer{}, 100)))
+}
+
+func TestMarkdown2HTMLWithExtensions(t *testing.T) {
+	RegisterMarkdownExtensionsFunc(func() []goldmark.Extender {
+		return []goldmark.Extender{&testExtension{}}
+	})
+	defer RegisterMarkdownExtensionsFunc(nil)
+
+	html := Markdown2HTML("use `go test` <script>alert(1)</script>")
+	assert.Contains(t, html, `<code class="language-test">go test</code>`)
+	assert.NotContains(t, html, "<script>")
+}
+
+func TestMarkdown2HTMLSanitizeOutput(t *testing.T) {
+	RegisterMarkdownExtensionsFunc(func() []goldmark.Extender {
+		return []goldmark.Extender{&testExtension{}}
+	})
+	defer RegisterMarkdownExtensionsFunc(nil)
+
+	html := Markdown2HTML("*emphasis*")
+	assert.NotContains(t, html, "onclick")
+	assert.NotContains(t, html, "evil.com")
+	assert.Contains(t, html, "<em>emphasis</em>")
+
+	html = Markdown2HTML(`![x" onerror="alert(1)](https://answer.test/x.png)`)
+	assert.NotContains(t, html, `onerror="`)
+	assert.Contains(t, html, `alt="x"`)
+}
+
+func TestMarkdown2HTMLKeepBuiltinOutput(t *testing.T) {
+	html := Markdown2HTML("| a | b |\n|---|:-:|\n| 1 | 2 |\n\n- [x] task\n\n<kbd>Ctrl</kbd> [link](https://answer.test)")
+	assert.Contains(t, html, `<th style="text-align: center">b</th>`)
+	assert.Contains(t, html, `<input checked="" disabled="" type="checkbox">`)
+	assert.Contains(t, html, `<kbd>Ctrl</kbd>`)
+	assert.Contains(t, html, `<a href="https://answer.test">link</a>`)
+
+	html = Markdown2HTML("![video](https://www.youtube.com/watch?v=dQw4w9WgXcQ)")
+	assert.Contains(t, html, `src="https://www.youtube.com/embed/dQw4w9WgXcQ"`)
+}
@@ -0,0 +1,235 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package diff
+
+import (
+	"regexp"
+	"strings"
+)
+
+type ChunkType string
+
+const (
+	ChunkEqual  ChunkType = "equal"
+	ChunkInsert ChunkType = "insert"
+	ChunkDelete ChunkType = "delete"
+
+	// maxTokens if the total tokens of both texts exceed this, the texts are regarded as entirely replaced
+	maxTokens = 20000
+)
+
+var wordRegexp = regexp.MustCompile(`\s+|[^\s]+`)
+
+// Chunk a piece of text that is equal, inserted or deleted
+type Chunk struct {
+	Type ChunkType `json:"type"`
+	Text string    `json:"text"`
+}
+
+// Lines diff the texts line by line
+func Lines(oldText, newText string) []*Chunk {
+	return diffTokens(splitLines(oldText), splitLines(newText))
+}
+
+// Words diff the texts word by word, the whitespace is kept as a separate token
+func Words(oldText, newText string) []*Chunk {
+	return diffTokens(wordRegexp.FindAllString(oldText, -1), wordRegexp.FindAllString(newText, -1))
+}
+
+func splitLines(text string) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffTokens diff the tokens with myers algorithm and merge the adjacent tokens with the same type
+func diffTokens(a, b []string) (chunks []*Chunk) {
+	chunks = make([]*Chunk, 0)
+	add := func(chunkType ChunkType, text string) {
+		if len(chunks) > 0 && chunks[len(chunks)-1].Type == chunkType {
+			chunks[len(chunks)-1].Text += text
+			return
+		}
+		chunks = append(chunks, &Chunk{Type: chunkType, Text: text})
+	}
+
+	if len(a)+len(b) > maxTokens {
+		add(ChunkDelete, strings.Join(a, ""))
+		add(ChunkInsert, strings.Join(b, ""))
+		return removeEmpty(chunks)
+	}
+
+	for _, op := range myers(a, b) {
+		switch op.chunkType {
+		case ChunkDelete:
+			add(ChunkDelete, a[op.index])
+		default:
+			add(op.chunkType, b[op.index])
+		}
+	}
+	return chunks
+}
+
+func removeEmpty(chunks []*Chunk) []*Chunk {
+	result := make([]*Chunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		if len(chunk.Text) > 0 {
+			result = append(result, chunk)
+		}
+	}
+	return result
+}
+
+type operation struct {
+	chunkType ChunkType
+	// index of the token in a for delete, or in b for equal and insert
+	index int
+}
+
+// myers find the shortest edit script from a to b, the linear space variant is used,
+// which finds the middle snake and divides the texts into two smaller problems recursively.
+func myers(a, b []string) []operation {
+	ops := make([]operation, 0, len(a)+len(b))
+	var compare func(aLo, aHi, bLo, bHi int)
+	compare = func(aLo, aHi, bLo, bHi int) {
+		for aLo < aHi && bLo < bHi && a[aLo] == b[bLo] {
+			ops = append(ops, operation{chunkType: ChunkEqual, index: bLo})
+			aLo, bLo = aLo+1, bLo+1
+		}
+		suffix := 0
+		for aLo < aHi && bLo < bHi && a[aHi-1] == b[bHi-1] {
+			aHi, bHi = aHi-1, bHi-1
+			suffix++
+		}
+
+		x, y, ok := middleSnake(a[aLo:aHi], b[bLo:bHi])
+		switch {
+		case aLo == aHi || bLo == bHi || !ok:
+			for i := aLo; i < aHi; i++ {
+				ops = append(ops, operation{chunkType: ChunkDelete, index: i})
+			}
+			for i := bLo; i < bHi; i++ {
+				ops = append(ops, operation{chunkType: ChunkInsert, index: i})
+			}
+		default:
+			compare(aLo, aLo+x, bLo, bLo+y)
+			compare(aLo+x, aHi, bLo+y, bHi)
+		}
+
+		for i := 0; i < suffix; i++ {
+			ops = append(ops, operation{chunkType: ChunkEqual, index: bHi + i})
+		}
+	}
+	compare(0, len(a), 0, len(b))
+	return ops
+}
+
+// middleSnake find the point where the forward and backward shortest paths overlap,
+// the point divides the texts into two parts which can be compared separately.
+// If the point is one of the corners, the texts can not be divided and ok is false.
+func middleSnake(a, b []string) (x, y int, ok bool) {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return 0, 0, false
+	}
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	vf := make([]int, 2*maxD+2)
+	vb := make([]int, 2*maxD+2)
+	for i := range vf {
+		vf[i], vb[i] = -1, -1
+	}
+	vf[offset+1], vb[offset+1] = 0, 0
+	delta := n - m
+	front := delta%2 != 0
+	// the diagonals beyond the edges of the edit graph are skipped
+	kfStart, kfEnd, kbStart, kbEnd := 0, 0, 0, 0
+
+	for d := 0; d < maxD; d++ {
+		// forward path from the top left corner
+		for k := -d + kfStart; k <= d-kfEnd; k += 2 {
+			var x1 int
+			if k == -d || (k != d && vf[offset+k-1] < vf[offset+k+1]) {
+				x1 = vf[offset+k+1]
+			} else {
+				x1 = vf[offset+k-1] + 1
+			}
+			y1 := x1 - k
+			for x1 < n && y1 < m && a[x1] == b[y1] {
+				x1, y1 = x1+1, y1+1
+			}
+			vf[offset+k] = x1
+			switch {
+			case x1 > n:
+				kfEnd += 2
+			case y1 > m:
+				kfStart += 2
+			case front:
+				kb := offset + delta - k
+				if kb >= 0 && kb < len(vb) && vb[kb] != -1 && x1 >= n-vb[kb] {
+					return splitPoint(x1, y1, n, m)
+				}
+			}
+		}
+
+		// backward path from the bottom right corner, x is counted from the end
+		for k := -d + kbStart; k <= d-kbEnd; k += 2 {
+			var x2 int
+			if k == -d || (k != d && vb[offset+k-1] < vb[offset+k+1]) {
+				x2 = vb[offset+k+1]
+			} else {
+				x2 = vb[offset+k-1] + 1
+			}
+			y2 := x2 - k
+			for x2 < n && y2 < m && a[n-x2-1] == b[m-y2-1] {
+				x2, y2 = x2+1, y2+1
+			}
+			vb[offset+k] = x2
+			switch {
+			case x2 > n:
+				kbEnd += 2
+			case y2 > m:
+				kbStart += 2
+			case !front:
+				kf := offset + delta - k
+				if kf >= 0 && kf < len(vf) && vf[kf] != -1 {
+					x1 := vf[kf]
+					y1 := offset + x1 - kf
+					if x1 >= n-x2 {
+						return splitPoint(x1, y1, n, m)
+					}
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func splitPoint(x, y, n, m int) (int, int, bool) {
+	if (x == 0 && y == 0) || (x == n && y == m) {
+		return 0, 0, false
+	}
+	return x, y, true
+}
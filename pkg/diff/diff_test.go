@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWords(t *testing.T) {
+	chunks := Words("how to use go generics", "how to use rust generics")
+	assert.Equal(t, []*Chunk{
+		{Type: ChunkEqual, Text: "how to use "},
+		{Type: ChunkDelete, Text: "go"},
+		{Type: ChunkInsert, Text: "rust"},
+		{Type: ChunkEqual, Text: " generics"},
+	}, chunks)
+}
+
+func TestLines(t *testing.T) {
+	chunks := Lines("a\nb\nc\n", "a\nc\nd\n")
+	assert.Equal(t, []*Chunk{
+		{Type: ChunkEqual, Text: "a\n"},
+		{Type: ChunkDelete, Text: "b\n"},
+		{Type: ChunkEqual, Text: "c\n"},
+		{Type: ChunkInsert, Text: "d\n"},
+	}, chunks)
+
+	assert.Empty(t, Lines("", ""))
+	assert.Equal(t, []*Chunk{{Type: ChunkInsert, Text: "a"}}, Lines("", "a"))
+	assert.Equal(t, []*Chunk{{Type: ChunkDelete, Text: "a"}}, Lines("a", ""))
+}
+
+func TestRebuild(t *testing.T) {
+	oldText := "The quick brown fox\njumps over\nthe lazy dog"
+	newText := "The quick red fox\njumps high over\nthe dog\nagain"
+	for _, chunks := range [][]*Chunk{Lines(oldText, newText), Words(oldText, newText)} {
+		var oldBuilder, newBuilder strings.Builder
+		for _, chunk := range chunks {
+			if chunk.Type != ChunkInsert {
+				oldBuilder.WriteString(chunk.Text)
+			}
+			if chunk.Type != ChunkDelete {
+				newBuilder.WriteString(chunk.Text)
+			}
+		}
+		assert.Equal(t, oldText, oldBuilder.String())
+		assert.Equal(t, newText, newBuilder.String())
+	}
+}
+
+func TestLinesLargeInput(t *testing.T) {
+	var oldBuilder, newBuilder strings.Builder
+	for i := 0; i < 3000; i++ {
+		oldBuilder.WriteString(strings.Repeat("a", i%7) + "\n")
+		newBuilder.WriteString(strings.Repeat("b", i%5) + "\n")
+	}
+	oldText, newText := oldBuilder.String(), newBuilder.String()
+	oldBuilder.Reset()
+	newBuilder.Reset()
+	for _, chunk := range Lines(oldText, newText) {
+		if chunk.Type != ChunkInsert {
+			oldBuilder.WriteString(chunk.Text)
+		}
+		if chunk.Type != ChunkDelete {
+			newBuilder.WriteString(chunk.Text)
+		}
+	}
+	assert.Equal(t, oldText, oldBuilder.String())
+	assert.Equal(t, newText, newBuilder.String())
+}
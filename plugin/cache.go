@@ -42,3 +42,68 @@ var (
 	CallCache,
 	registerCache = MakePlugin[Cache](false)
 )
+
+// NewTracedCache wraps the cache plugin, so that each cache operation is traced as a span
+func NewTracedCache(c Cache) Cache {
+	return &tracedCache{Cache: c}
+}
+
+type tracedCache struct {
+	Cache
+}
+
+func (c *tracedCache) GetString(ctx context.Context, key string) (data string, exist bool, err error) {
+	err = TraceCall[Cache](ctx, c.Cache, func(ctx context.Context) error {
+		data, exist, err = c.Cache.GetString(ctx, key)
+		return err
+	})
+	return data, exist, err
+}
+
+func (c *tracedCache) SetString(ctx context.Context, key, value string, ttl time.Duration) (err error) {
+	return TraceCall[Cache](ctx, c.Cache, func(ctx context.Context) error {
+		return c.Cache.SetString(ctx, key, value, ttl)
+	})
+}
+
+func (c *tracedCache) GetInt64(ctx context.Context, key string) (data int64, exist bool, err error) {
+	err = TraceCall[Cache](ctx, c.Cache, func(ctx context.Context) error {
+		data, exist, err = c.Cache.GetInt64(ctx, key)
+		return err
+	})
+	return data, exist, err
+}
+
+func (c *tracedCache) SetInt64(ctx context.Context, key string, value int64, ttl time.Duration) (err error) {
+	return TraceCall[Cache](ctx, c.Cache, func(ctx context.Context) error {
+		return c.Cache.SetInt64(ctx, key, value, ttl)
+	})
+}
+
+func (c *tracedCache) Increase(ctx context.Context, key string, value int64) (data int64, err error) {
+	err = TraceCall[Cache](ctx, c.Cache, func(ctx context.Context) error {
+		data, err = c.Cache.Increase(ctx, key, value)
+		return err
+	})
+	return data, err
+}
+
+func (c *tracedCache) Decrease(ctx context.Context, key string, value int64) (data int64, err error) {
+	err = TraceCall[Cache](ctx, c.Cache, func(ctx context.Context) error {
+		data, err = c.Cache.Decrease(ctx, key, value)
+		return err
+	})
+	return data, err
+}
+
+func (c *tracedCache) Del(ctx context.Context, key string) (err error) {
+	return TraceCall[Cache](ctx, c.Cache, func(ctx context.Context) error {
+		return c.Cache.Del(ctx, key)
+	})
+}
+
+func (c *tracedCache) Flush(ctx context.Context) (err error) {
+	return TraceCall[Cache](ctx, c.Cache, func(ctx context.Context) error {
+		return c.Cache.Flush(ctx)
+	})
+}
@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/segmentfault/pacman/contrib/cache/memory"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type memoryCachePlugin struct {
+	*memory.Cache
+}
+
+func (c *memoryCachePlugin) Info() Info {
+	return Info{SlugName: "memory_cache_test"}
+}
+
+func TestTracedCache(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(provider) })
+
+	ctx := context.Background()
+	c := NewTracedCache(&memoryCachePlugin{Cache: memory.NewCache()})
+	assert.NoError(t, c.SetString(ctx, "key", "value", time.Minute))
+	data, exist, err := c.GetString(ctx, "key")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "value", data)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	for _, span := range spans {
+		assert.Equal(t, "plugin.Cache", span.Name())
+		assert.Contains(t, span.Attributes(), attribute.String("plugin.slug_name", "memory_cache_test"))
+	}
+}
@@ -39,7 +39,7 @@ var (
 	registerCaptcha = MakePlugin[Captcha](false)
 )
 
-func CallCaptcha(fn func(fn Captcha) error) error {
+func CallCaptcha(fn Caller[Captcha]) error {
 	slugName := ""
 	_ = callCaptcha(func(captcha Captcha) error {
 		slugName = captcha.Info().SlugName
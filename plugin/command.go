@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"xorm.io/xorm"
+)
+
+// Env presents the environment of answer application that plugins can access
+// in their commands and lifecycle hooks.
+type Env struct {
+	// DB is the database engine of answer application
+	DB *xorm.Engine
+	// SiteURL is the site url of answer application. e.g. http://localhost:8080
+	SiteURL string
+}
+
+// EnvLoader loads the environment of answer application.
+// The database connection is created when it is called, so the commands that do not need it won't connect the database.
+type EnvLoader func() (*Env, error)
+
+// Command is a plugin that can register its own sub commands under `answer plugin <slug_name>`.
+type Command interface {
+	Base
+	// RegisterCommands registers the sub commands to the root command of the plugin.
+	RegisterCommands(root *cobra.Command, loadEnv EnvLoader)
+}
+
+// Lifecycle is a plugin that can run some logic when answer application starts or stops.
+type Lifecycle interface {
+	Base
+	// OnStart is called after answer application is initialized and before the server starts.
+	OnStart(ctx context.Context, env *Env) error
+	// OnStop is called after the server stops.
+	OnStop(ctx context.Context) error
+	// OnCheck is called by `answer check` instead of the hooks above, it should only check whether
+	// the hooks are able to run, e.g. the services they depend on are reachable, without any side effects.
+	OnCheck(ctx context.Context, env *Env) error
+}
+
+var (
+	// CallCommand is a function that calls all registered command plugins
+	CallCommand,
+	registerCommand = MakePlugin[Command](true)
+
+	// CallLifecycle is a function that calls all registered lifecycle plugins
+	CallLifecycle,
+	registerLifecycle = MakePlugin[Lifecycle](false)
+)
@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"time"
+)
+
+// EventType is the type of event, such as object.action
+type EventType string
+
+const (
+	EventUserUpdate   EventType = "user.update"
+	EventUserShare    EventType = "user.share"
+	EventUserRegister EventType = "user.register"
+	EventUserSuspend  EventType = "user.suspend"
+	EventUserActivate EventType = "user.activate"
+	EventUserDelete   EventType = "user.delete"
+
+	EventQuestionCreate EventType = "question.create"
+	EventQuestionUpdate EventType = "question.update"
+	EventQuestionDelete EventType = "question.delete"
+	EventQuestionVote   EventType = "question.vote"
+	EventQuestionAccept EventType = "question.accept"
+	EventQuestionFlag   EventType = "question.flag"
+	EventQuestionReact  EventType = "question.react"
+
+	EventAnswerCreate EventType = "answer.create"
+	EventAnswerUpdate EventType = "answer.update"
+	EventAnswerDelete EventType = "answer.delete"
+	EventAnswerVote   EventType = "answer.vote"
+	EventAnswerFlag   EventType = "answer.flag"
+	EventAnswerReact  EventType = "answer.react"
+
+	EventCommentCreate EventType = "comment.create"
+	EventCommentUpdate EventType = "comment.update"
+	EventCommentDelete EventType = "comment.delete"
+	EventCommentVote   EventType = "comment.vote"
+	EventCommentFlag   EventType = "comment.flag"
+
+	EventReviewApprove EventType = "review.approve"
+	EventReviewReject  EventType = "review.reject"
+
+	EventReportHandle EventType = "report.handle"
+
+	EventBadgeAward EventType = "badge.award"
+)
+
+// Event is the domain event that happened in answer
+type Event struct {
+	Type EventType
+	// UserID is the id of user who triggered the event
+	UserID string
+	// ObjectID is the id of object that triggered the event, such as question id, review id, badge id
+	ObjectID string
+
+	QuestionID     string
+	QuestionUserID string
+
+	AnswerID     string
+	AnswerUserID string
+
+	CommentID     string
+	CommentUserID string
+
+	// Extra contains the extra information of the event
+	Extra map[string]string
+	// CreatedAt is the time when the event happened
+	CreatedAt time.Time
+}
+
+// EventListener is a plugin that listens to the domain events.
+// The events are delivered asynchronously, and each plugin has its own queue,
+// so a slow plugin only delays its own events. If the queue of plugin is full, the new events will be dropped.
+type EventListener interface {
+	Base
+	// OnEvent is called when an event happens, the returned error will be logged.
+	OnEvent(ctx context.Context, event *Event) error
+}
+
+var (
+	// CallEventListener is a function that calls all registered event listener plugins
+	CallEventListener,
+	registerEventListener = MakePlugin[EventListener](false)
+)
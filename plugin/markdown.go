@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+import (
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// MarkdownExtension is a plugin that extends the markdown render pipeline,
+// such as math, diagrams, shortcodes and syntax highlighting.
+// All the markdown contents like question, answer, comment, bio and the preview are rendered by the pipeline.
+type MarkdownExtension interface {
+	Base
+	// MarkdownExtender returns the goldmark components that the plugin wants to add.
+	MarkdownExtender() *MarkdownExtender
+}
+
+// MarkdownExtender contains the prioritized goldmark components, the higher priority one is processed first.
+// e.g. util.Prioritized(&mathInlineParser{}, 500)
+// The node renderer with lower priority overrides the others for the same node kind,
+// but the priority of node renderer is always higher than the sanitizer, so the sanitizer can't be overridden.
+// The rendered html is sanitized at last, the elements, attributes, classes and styles that the node renderers
+// write must be declared in the AllowList, e.g. the class names of KaTeX or `<pre class="mermaid">`.
+type MarkdownExtender struct {
+	BlockParsers    []util.PrioritizedValue
+	InlineParsers   []util.PrioritizedValue
+	ASTTransformers []util.PrioritizedValue
+	NodeRenderers   []util.PrioritizedValue
+	AllowList       *converter.SanitizerAllowList
+}
+
+// SanitizerAllowList implements converter.SanitizerAllowListExtender
+func (e *MarkdownExtender) SanitizerAllowList() *converter.SanitizerAllowList {
+	return e.AllowList
+}
+
+// Extend implements goldmark.Extender
+func (e *MarkdownExtender) Extend(m goldmark.Markdown) {
+	if len(e.BlockParsers) > 0 {
+		m.Parser().AddOptions(parser.WithBlockParsers(e.BlockParsers...))
+	}
+	if len(e.InlineParsers) > 0 {
+		m.Parser().AddOptions(parser.WithInlineParsers(e.InlineParsers...))
+	}
+	if len(e.ASTTransformers) > 0 {
+		m.Parser().AddOptions(parser.WithASTTransformers(e.ASTTransformers...))
+	}
+	if len(e.NodeRenderers) > 0 {
+		nodeRenderers := make([]util.PrioritizedValue, 0, len(e.NodeRenderers))
+		for _, r := range e.NodeRenderers {
+			if r.Priority <= converter.SanitizerPriority {
+				r.Priority = converter.SanitizerPriority + 1
+			}
+			nodeRenderers = append(nodeRenderers, r)
+		}
+		m.Renderer().AddOptions(renderer.WithNodeRenderers(nodeRenderers...))
+	}
+}
+
+var (
+	// CallMarkdownExtension is a function that calls all registered markdown extension plugins
+	CallMarkdownExtension,
+	registerMarkdownExtension = MakePlugin[MarkdownExtension](false)
+)
+
+// GetMarkdownExtenders returns the goldmark extenders of all enabled markdown extension plugins
+func GetMarkdownExtenders() (extenders []goldmark.Extender) {
+	_ = CallMarkdownExtension(func(p MarkdownExtension) error {
+		if extender := p.MarkdownExtender(); extender != nil {
+			extenders = append(extenders, extender)
+		}
+		return nil
+	})
+	return extenders
+}
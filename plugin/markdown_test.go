@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/stretchr/testify/assert"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// diagramNodeRenderer renders the mermaid code blocks and the code spans as the classed markup of diagrams and math
+type diagramNodeRenderer struct{}
+
+func (r *diagramNodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, func(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			_, _ = w.WriteString(`<pre class="mermaid">`)
+			lines := node.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				line := lines.At(i)
+				_, _ = w.Write(util.EscapeHTML(line.Value(source)))
+			}
+			_, _ = w.WriteString(`</pre><div class="undeclared">diagram</div>`)
+		}
+		return ast.WalkSkipChildren, nil
+	})
+	reg.Register(ast.KindCodeSpan, func(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			_, _ = w.WriteString(`<span class="katex"><span class="mord mathnormal" style="margin-right: 0.02778em">`)
+			_, _ = w.Write(util.EscapeHTML(node.Text(source)))
+			_, _ = w.WriteString(`</span></span>`)
+		}
+		return ast.WalkSkipChildren, nil
+	})
+}
+
+type diagramPlugin struct{}
+
+func (p *diagramPlugin) Info() Info {
+	return Info{SlugName: "markdown_test_diagram"}
+}
+
+func (p *diagramPlugin) MarkdownExtender() *MarkdownExtender {
+	return &MarkdownExtender{
+		NodeRenderers: []util.PrioritizedValue{util.Prioritized(&diagramNodeRenderer{}, 100)},
+		AllowList: &converter.SanitizerAllowList{
+			Classes: map[string]*regexp.Regexp{
+				"pre":  regexp.MustCompile(`^mermaid$`),
+				"span": regexp.MustCompile(`^(katex|mord|mathnormal| )+$`),
+			},
+			Styles: map[string][]string{"span": {"margin-right"}},
+		},
+	}
+}
+
+func TestMarkdownExtenderAllowList(t *testing.T) {
+	Register(&diagramPlugin{})
+	StatusManager.Enable("markdown_test_diagram", true)
+	converter.RegisterMarkdownExtensionsFunc(GetMarkdownExtenders)
+	t.Cleanup(func() {
+		StatusManager.Enable("markdown_test_diagram", false)
+		converter.RegisterMarkdownExtensionsFunc(nil)
+	})
+
+	html := converter.Markdown2HTML("```mermaid\ngraph TD;\n```\n\nmath `x`")
+	assert.Contains(t, html, `<pre class="mermaid">graph TD;`)
+	assert.Contains(t, html, `<span class="katex"><span class="mord mathnormal" style="margin-right: 0.02778em">x</span></span>`)
+	// the markup not declared in the allow list is still removed
+	assert.Contains(t, html, `<div>diagram</div>`)
+	assert.NotContains(t, html, "undeclared")
+}
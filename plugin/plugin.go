@@ -20,14 +20,21 @@
 package plugin
 
 import (
+	"context"
 	"encoding/json"
+	"reflect"
 	"sync"
+	"time"
 
 	"github.com/segmentfault/pacman/i18n"
 
 	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/tracing"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // GinContext is a wrapper of gin.Context
@@ -110,12 +117,38 @@ func Register(p Base) {
 	if _, ok := p.(CDN); ok {
 		registerCDN(p.(CDN))
 	}
+
+	if _, ok := p.(Command); ok {
+		registerCommand(p.(Command))
+	}
+
+	if _, ok := p.(Lifecycle); ok {
+		registerLifecycle(p.(Lifecycle))
+	}
+
+	if _, ok := p.(EventListener); ok {
+		registerEventListener(p.(EventListener))
+	}
+
+	if _, ok := p.(MarkdownExtension); ok {
+		registerMarkdownExtension(p.(MarkdownExtension))
+	}
 }
 
 type Stack[T Base] struct {
 	plugins []T
 }
 
+// CallObserver observes the duration and the result of each plugin call
+type CallObserver func(pluginType, slugName string, duration time.Duration, err error)
+
+var callObserver CallObserver
+
+// SetCallObserver sets the observer of the plugin calls, it is used to collect the metrics
+func SetCallObserver(observer CallObserver) {
+	callObserver = observer
+}
+
 type RegisterFn[T Base] func(p T)
 type Caller[T Base] func(p T) error
 type CallFn[T Base] func(fn Caller[T]) error
@@ -127,6 +160,7 @@ type CallFn[T Base] func(fn Caller[T]) error
 // The caller function is used to call all registered plugins
 func MakePlugin[T Base](super bool) (CallFn[T], RegisterFn[T]) {
 	stack := Stack[T]{}
+	pluginType := reflect.TypeOf((*T)(nil)).Elem().Name()
 
 	call := func(fn Caller[T]) error {
 		for _, p := range stack.plugins {
@@ -135,7 +169,12 @@ func MakePlugin[T Base](super bool) (CallFn[T], RegisterFn[T]) {
 				continue
 			}
 
-			if err := fn(p); err != nil {
+			start := time.Now()
+			err := fn(p)
+			if callObserver != nil {
+				callObserver(pluginType, p.Info().SlugName, time.Since(start), err)
+			}
+			if err != nil {
 				return err
 			}
 		}
@@ -154,6 +193,34 @@ func MakePlugin[T Base](super bool) (CallFn[T], RegisterFn[T]) {
 	return call, register
 }
 
+// CallWithContext calls the plugins by the call function, each plugin call is traced as a span
+// which is the child of the span in the context, e.g.
+//
+//	plugin.CallWithContext(ctx, plugin.CallReviewer, func(ctx context.Context, reviewer plugin.Reviewer) error {...})
+func CallWithContext[T Base](ctx context.Context, call CallFn[T], fn func(ctx context.Context, p T) error) error {
+	return call(func(p T) error {
+		return TraceCall(ctx, p, func(ctx context.Context) error {
+			return fn(ctx, p)
+		})
+	})
+}
+
+// TraceCall traces the call of a plugin which has been picked out by the call function before, e.g.
+//
+//	plugin.TraceCall(ctx, search, func(ctx context.Context) error { return search.UpdateContent(ctx, content) })
+func TraceCall[T Base](ctx context.Context, p T, fn func(ctx context.Context) error) error {
+	pluginType := reflect.TypeOf((*T)(nil)).Elem().Name()
+	spanCtx, span := tracing.Start(ctx, "plugin."+pluginType,
+		trace.WithAttributes(attribute.String("plugin.slug_name", p.Info().SlugName)))
+	defer span.End()
+	err := fn(spanCtx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
 type statusManager struct {
 	lock   sync.Mutex
 	status map[string]bool